@@ -1,9 +1,11 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net"
 	"os"
+	"time"
 
 	"google.golang.org/grpc"
 
@@ -28,30 +30,68 @@ func main() {
 		redisAddr = "redis:6379"
 	}
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "mancala-jwt-secret-key-change-in-production"
-		log.Println("Warning: Using default JWT secret. Set JWT_SECRET environment variable in production!")
+	// Tokens are signed with keys kept in PostgreSQL, other services only get the public keys
+	signingAlgorithm := os.Getenv("JWT_SIGNING_ALGORITHM")
+	if signingAlgorithm == "" {
+		signingAlgorithm = auth.AlgorithmEdDSA
+	}
+	if signingAlgorithm != auth.AlgorithmEdDSA && signingAlgorithm != auth.AlgorithmRS256 {
+		log.Fatalf("Invalid JWT_SIGNING_ALGORITHM %q, use %s or %s", signingAlgorithm, auth.AlgorithmEdDSA, auth.AlgorithmRS256)
+	}
+
+	keyRotationInterval := auth.DefaultKeyRotationInterval
+	if rotation := os.Getenv("JWT_KEY_ROTATION_INTERVAL"); rotation != "" {
+		interval, err := time.ParseDuration(rotation)
+		if err != nil || interval <= 0 {
+			log.Fatalf("Invalid JWT_KEY_ROTATION_INTERVAL %q: %v", rotation, err)
+		}
+		keyRotationInterval = interval
+	}
+
+	// Internal services exchange these secrets for service tokens
+	serviceSecrets, err := auth.ParseServiceCredentials(os.Getenv("SERVICE_CREDENTIALS"))
+	if err != nil {
+		log.Fatalf("Invalid SERVICE_CREDENTIALS: %v", err)
+	}
+	if len(serviceSecrets) == 0 {
+		log.Println("Warning: SERVICE_CREDENTIALS is not set, internal services cannot authenticate")
 	}
 
 	// Create server
-	server, err := auth.NewServer(dbURL, redisAddr, jwtSecret)
+	server, err := auth.NewServer(dbURL, redisAddr, signingAlgorithm, keyRotationInterval, serviceSecrets)
 	if err != nil {
 		log.Fatalf("Failed to create auth server: %v", err)
 	}
 
+	// Rotate signing keys and pick up keys rotated by other instances
+	keysCtx, stopKeys := context.WithCancel(context.Background())
+	defer stopKeys()
+	go server.KeyRing().Run(keysCtx, auth.DefaultKeyRefreshInterval)
+
+	// Rate finished games as their GAME_OVER events arrive
+	if err := server.RatingUpdater().Start(); err != nil {
+		log.Printf("Warning: Failed to start rating updater: %v", err)
+	} else {
+		defer server.RatingUpdater().Stop()
+	}
+
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":"+port)
 	if err != nil {
 		log.Fatalf("Failed to listen on port %s: %v", port, err)
 	}
 
-	grpcServer := grpc.NewServer()
+	authInterceptor := server.AuthInterceptor()
+	grpcServer := grpc.NewServer(
+		grpc.UnaryInterceptor(authInterceptor.UnaryInterceptor()),
+		grpc.StreamInterceptor(authInterceptor.StreamInterceptor()),
+	)
 	authpb.RegisterAuthServer(grpcServer, server)
 
 	log.Printf("Auth service listening on port %s", port)
 	log.Printf("Connected to PostgreSQL at %s", dbURL)
 	log.Printf("Connected to Redis at %s", redisAddr)
+	log.Printf("Signing tokens with %s keys rotated every %s", signingAlgorithm, keyRotationInterval)
 	if err := grpcServer.Serve(lis); err != nil {
 		log.Fatalf("Failed to serve: %v", err)
 	}
@@ -20,17 +20,16 @@ func main() {
 		port = "50057"
 	}
 
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		redisAddr = "redis:6379"
+	}
+
 	authAddr := os.Getenv("AUTH_ADDR")
 	if authAddr == "" {
 		authAddr = "auth:50055"
 	}
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "mancala-jwt-secret-key-change-in-production"
-		log.Println("Warning: Using default JWT secret. Set JWT_SECRET environment variable in production!")
-	}
-
 	// Connect to Auth service
 	authConn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -47,7 +46,7 @@ func main() {
 	botServer := bot.NewServer()
 
 	// Create auth interceptor
-	authInterceptor := auth.NewAuthInterceptor(authClient, jwtSecret)
+	authInterceptor := auth.NewAuthInterceptor(authClient, auth.NewRedisDenylist(redisAddr))
 
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":"+port)
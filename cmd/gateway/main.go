@@ -5,6 +5,7 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
@@ -40,10 +41,19 @@ func main() {
 		config.Services.EngineAddr = engineAddr
 	}
 
-	if jwtSecret := os.Getenv("JWT_SECRET"); jwtSecret != "" {
-		config.JWTSecret = jwtSecret
-	} else {
-		log.Println("Warning: Using default JWT secret. Set JWT_SECRET environment variable in production!")
+	if redisAddr := os.Getenv("REDIS_ADDR"); redisAddr != "" {
+		config.RedisAddr = redisAddr
+	}
+
+	// Exchanged for the service token that lets the auth service trust forwarded client IPs
+	config.Services.ServiceSecret = os.Getenv("SERVICE_SECRET")
+	if config.Services.ServiceSecret == "" {
+		log.Println("Warning: SERVICE_SECRET is not set, failed logins are limited per gateway instead of per client IP")
+	}
+
+	// Comma separated addresses or CIDRs of load balancers in front of the gateway
+	if trustedProxies := os.Getenv("TRUSTED_PROXIES"); trustedProxies != "" {
+		config.TrustedProxies = strings.Split(trustedProxies, ",")
 	}
 
 	// Create and start server
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/laerson/mancala/internal/mancala"
+	"github.com/spf13/cobra"
+)
+
+var (
+	challengeAccept  string
+	challengeDecline string
+	challengeInvite  bool
+)
+
+var challengeCmd = &cobra.Command{
+	Use:   "challenge [username]",
+	Short: "Challenge a friend to a game",
+	Long: `Challenge a specific player to a game, or answer a challenge sent to you.
+
+Without arguments, lists the challenges waiting for your answer.
+With --invite, creates a private invite code that a friend can redeem
+with 'mancala join <code>'.
+
+Challenges expire after a minute and invite codes after ten minutes.
+Press Ctrl+C while waiting to withdraw your challenge or invite.
+
+Example:
+  mancala challenge alice
+  mancala challenge
+  mancala challenge --accept <challenge-id>
+  mancala challenge --decline <challenge-id>
+  mancala challenge --invite`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clientState.IsConnected() {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
+		if !clientState.IsLoggedIn() {
+			fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+			return
+		}
+
+		if apiClient == nil {
+			fmt.Println("❌ API client not initialized. Please reconnect.")
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		switch {
+		case challengeAccept != "" || challengeDecline != "":
+			respondChallenge(config)
+
+		case challengeInvite:
+			resp, err := apiClient.CreateInvite(config.UserID, config.Username)
+			if err != nil {
+				fmt.Printf("❌ Failed to create invite: %v\n", err)
+				return
+			}
+
+			if !resp.Success || resp.Invite == nil {
+				fmt.Printf("❌ Failed to create invite: %s\n", resp.Message)
+				return
+			}
+
+			fmt.Printf("🔑 Invite code: %s\n", resp.Invite.InviteCode)
+			fmt.Printf("Ask your friend to run 'mancala join %s' before %s.\n",
+				resp.Invite.InviteCode, time.Unix(resp.Invite.ExpiresAt, 0).Format("15:04"))
+			waitForChallenge(config, resp.Invite.ID)
+
+		case len(args) == 1:
+			fmt.Printf("⚔️  Challenging %s...\n", args[0])
+
+			resp, err := apiClient.Challenge(config.UserID, config.Username, args[0])
+			if err != nil {
+				fmt.Printf("❌ Failed to send challenge: %v\n", err)
+				return
+			}
+
+			if !resp.Success || resp.Challenge == nil {
+				fmt.Printf("❌ Failed to send challenge: %s\n", resp.Message)
+				return
+			}
+
+			fmt.Printf("✅ Challenge sent to %s. Waiting for an answer...\n", args[0])
+			waitForChallenge(config, resp.Challenge.ID)
+
+		default:
+			listChallenges(config)
+		}
+	},
+}
+
+// respondChallenge accepts or declines the challenge given by flag
+func respondChallenge(config mancala.Config) {
+	challengeID, accept := challengeDecline, false
+	if challengeAccept != "" {
+		challengeID, accept = challengeAccept, true
+	}
+
+	resp, err := apiClient.RespondChallenge(challengeID, config.UserID, config.Username, accept)
+	if err != nil {
+		fmt.Printf("❌ Failed to answer challenge: %v\n", err)
+		return
+	}
+
+	if !resp.Success {
+		fmt.Printf("❌ %s\n", resp.Message)
+		return
+	}
+
+	fmt.Printf("✅ %s\n", resp.Message)
+	if resp.GameID != "" {
+		startJoinedGame(resp.GameID)
+	}
+}
+
+// listChallenges prints the challenges waiting for the player's answer
+func listChallenges(config mancala.Config) {
+	resp, err := apiClient.ListChallenges(config.UserID)
+	if err != nil {
+		fmt.Printf("❌ Failed to list challenges: %v\n", err)
+		return
+	}
+
+	if len(resp.Challenges) == 0 {
+		fmt.Println("📭 No challenges waiting for you.")
+		fmt.Println("Use 'mancala challenge <username>' to challenge someone.")
+		return
+	}
+
+	fmt.Println("⚔️  Challenges waiting for you:")
+	for _, challenge := range resp.Challenges {
+		fmt.Printf("  %s  from %s, expires %s\n", challenge.ID, challenge.ChallengerName,
+			time.Unix(challenge.ExpiresAt, 0).Format("15:04:05"))
+	}
+	fmt.Println("\nAnswer with 'mancala challenge --accept <id>' or 'mancala challenge --decline <id>'.")
+}
+
+// waitForChallenge follows the player's challenge or invite until it is answered or expires,
+// and then the game it started. Ctrl+C withdraws it.
+func waitForChallenge(config mancala.Config, challengeID string) {
+	fmt.Println("Press Ctrl+C to withdraw.")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var matched atomic.Bool
+
+	// Handle Ctrl+C
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		<-sigChan
+		if !matched.Load() {
+			fmt.Println("\n🚫 Withdrawing...")
+			if _, err := apiClient.RespondChallenge(challengeID, config.UserID, config.Username, false); err != nil {
+				fmt.Printf("⚠️ Error withdrawing: %v\n", err)
+			}
+		}
+		cancel()
+	}()
+
+	notificationClient := mancala.NewNotificationClient(config.ServerURL, config.AccessToken)
+
+	err := notificationClient.Subscribe(ctx, config.UserID, func(notification mancala.Notification) {
+		switch notification.Type {
+		case "NOTIFICATION_TYPE_CHALLENGE":
+			if id, _ := notification.Data["challenge_id"].(string); id != challengeID {
+				return
+			}
+
+			mancala.DisplayChallenge(notification.Data, config.UserID)
+			if status, _ := notification.Data["status"].(string); status != "pending" {
+				cancel()
+			}
+
+		case "NOTIFICATION_TYPE_MATCH_FOUND":
+			if !matched.CompareAndSwap(false, true) {
+				return
+			}
+
+			fmt.Println("\n🎯 CHALLENGE ACCEPTED!")
+			mancala.DisplayMatchFound(notification.Data)
+			startJoinedGame(notification.GameID)
+
+		case "NOTIFICATION_TYPE_MOVE_MADE":
+			if matched.Load() {
+				mancala.DisplayMoveResult(notification.Data)
+				fmt.Print("\nWaiting for your move (use 'mancala move <pit>' in a new terminal)...")
+			}
+
+		case "NOTIFICATION_TYPE_GAME_OVER":
+			if matched.Load() {
+				mancala.DisplayGameOver(notification.Data)
+				inGame = false
+				currentGameID = ""
+				cancel()
+			}
+		}
+	})
+
+	if err != nil && err != context.Canceled {
+		fmt.Printf("❌ Notification error: %v\n", err)
+	}
+}
+
+// startJoinedGame remembers the game started from a challenge or invite
+func startJoinedGame(gameID string) {
+	currentGameID = gameID
+	inGame = true
+
+	fmt.Printf("\n📝 Game ID: %s\n", gameID)
+	fmt.Println("Use 'mancala move <pit>' to make moves (in a new terminal)")
+	fmt.Println("Use 'mancala status' to see the current game state.")
+}
+
+func init() {
+	rootCmd.AddCommand(challengeCmd)
+
+	challengeCmd.Flags().StringVar(&challengeAccept, "accept", "", "Accept the challenge with this ID")
+	challengeCmd.Flags().StringVar(&challengeDecline, "decline", "", "Decline the challenge with this ID")
+	challengeCmd.Flags().BoolVar(&challengeInvite, "invite", false, "Create an invite code for a friend to join")
+	challengeCmd.MarkFlagsMutuallyExclusive("accept", "decline", "invite")
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	drawGameID string
+)
+
+var drawCmd = &cobra.Command{
+	Use:   "draw [accept|decline]",
+	Short: "Offer a draw or answer your opponent's offer",
+	Long: `Offer your opponent a draw, or accept or decline their offer.
+
+Offering a draw when your opponent has already offered one accepts it.
+Making a move instead of answering declines the offer.
+
+Example:
+  mancala draw
+  mancala draw accept
+  mancala draw decline --game <game-id>`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: []string{"accept", "decline"},
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clientState.IsConnected() {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
+		if !clientState.IsLoggedIn() {
+			fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+			return
+		}
+
+		if apiClient == nil {
+			fmt.Println("❌ API client not initialized. Please reconnect.")
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		gameID, ok := selectGame(drawGameID, config.UserID)
+		if !ok {
+			return
+		}
+
+		if len(args) == 0 {
+			resp, err := apiClient.OfferDraw(gameID, config.UserID)
+			if err != nil {
+				fmt.Printf("❌ Failed to offer draw: %v\n", err)
+				return
+			}
+
+			if resp.Game.EndReason == "draw_agreed" {
+				fmt.Println("🤝 Your opponent had already offered a draw. The game is drawn.")
+				return
+			}
+
+			fmt.Println("🤝 Draw offered. Waiting for your opponent to answer...")
+			return
+		}
+
+		var accept bool
+		switch args[0] {
+		case "accept":
+			accept = true
+		case "decline":
+			accept = false
+		default:
+			fmt.Printf("❌ Invalid answer: %s. Use 'accept' or 'decline'.\n", args[0])
+			return
+		}
+
+		if _, err := apiClient.RespondDraw(gameID, config.UserID, accept); err != nil {
+			fmt.Printf("❌ Failed to answer draw offer: %v\n", err)
+			return
+		}
+
+		if accept {
+			fmt.Println("🤝 Draw accepted. The game is drawn.")
+		} else {
+			fmt.Println("✅ Draw declined. The game goes on.")
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(drawCmd)
+
+	drawCmd.Flags().StringVarP(&drawGameID, "game", "g", "", "Game to offer a draw in (defaults to your only game in progress)")
+}
@@ -11,7 +11,7 @@ var helpCmd = &cobra.Command{
 	Short: "Show quick start guide",
 	Long:  `Display a quick start guide for new users.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println(`
+		fmt.Print(`
 🎮 MANCALA CLI QUICK START GUIDE
 
 1️⃣  CONNECT TO SERVER
@@ -32,11 +32,24 @@ var helpCmd = &cobra.Command{
 
    Pit numbers: 0, 1, 2, 3, 4, 5
 
+⚔️  PLAY A FRIEND
+   mancala challenge <username>   (they answer with 'mancala challenge --accept <id>')
+   mancala challenge --invite     (share the code, they run 'mancala join <code>')
+
+🏆 PLAY A TOURNAMENT
+   mancala tournament list
+   mancala tournament join <id>
+   mancala tournament play <id>   (keep it open, your games appear here)
+
+👀 WATCH A GAME
+   mancala watch <game-id>
+
 📊 CHECK STATUS
    mancala status
 
 🚪 LOGOUT
    mancala logout
+   mancala logout --all    # Sign out every device
 
 💡 TIPS:
    • Use two terminals: one for 'play', one for 'move'
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/laerson/mancala/internal/mancala"
+	"github.com/spf13/cobra"
+)
+
+var (
+	historyPageToken string
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Review your finished games",
+	Long: `List your finished games, most recent first, with the result, final
+score and number of moves of each game.
+
+Use 'mancala status <game-id>' to see the final board of a game.
+
+Example:
+  mancala history
+  mancala history --page <token>`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clientState.IsConnected() {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
+		if !clientState.IsLoggedIn() {
+			fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+			return
+		}
+
+		if apiClient == nil {
+			fmt.Println("❌ API client not initialized. Please reconnect.")
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		resp, err := apiClient.ListGames(config.UserID, "finished", historyPageToken)
+		if err != nil {
+			fmt.Printf("❌ Failed to get game history: %v\n", err)
+			return
+		}
+
+		if len(resp.Games) == 0 {
+			fmt.Println("No finished games yet.")
+			return
+		}
+
+		fmt.Println("\n=== GAME HISTORY ===")
+		for _, game := range resp.Games {
+			mancala.DisplayGameResult(game, config.UserID)
+		}
+
+		if resp.NextPageToken != "" {
+			fmt.Printf("\nMore games: mancala history --page %s\n", resp.NextPageToken)
+		}
+		fmt.Println()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historyPageToken, "page", "", "Page token from a previous 'mancala history'")
+}
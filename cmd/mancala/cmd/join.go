@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var joinCmd = &cobra.Command{
+	Use:   "join <code>",
+	Short: "Join a friend's game with an invite code",
+	Long: `Join the game of a friend who created an invite code with
+'mancala challenge --invite'. Codes are not case sensitive.
+
+Example:
+  mancala join K7QX2M`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clientState.IsConnected() {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
+		if !clientState.IsLoggedIn() {
+			fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+			return
+		}
+
+		if apiClient == nil {
+			fmt.Println("❌ API client not initialized. Please reconnect.")
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		resp, err := apiClient.JoinInvite(args[0], config.UserID, config.Username)
+		if err != nil {
+			fmt.Printf("❌ Failed to join game: %v\n", err)
+			return
+		}
+
+		if !resp.Success {
+			fmt.Printf("❌ %s\n", resp.Message)
+			return
+		}
+
+		fmt.Printf("✅ %s\n", resp.Message)
+		startJoinedGame(resp.GameID)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(joinCmd)
+}
@@ -3,13 +3,19 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/laerson/mancala/internal/mancala"
 	"github.com/spf13/cobra"
 )
 
+var logoutAll bool
+
 var logoutCmd = &cobra.Command{
 	Use:   "logout",
 	Short: "Logout from the current account",
-	Long:  `Logout from the current account and clear saved authentication information.`,
+	Long: `Logout from the current account and clear saved authentication information.
+The session's tokens are revoked on the server as well.
+
+Use --all to sign out every device logged in to the account.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		if !clientState.IsLoggedIn() {
 			fmt.Println("❌ Not logged in.")
@@ -19,6 +25,24 @@ var logoutCmd = &cobra.Command{
 		config := clientState.GetConfig()
 		username := config.Username
 
+		// Revoke the tokens on the server
+		if apiClient != nil {
+			if logoutAll {
+				resp, err := apiClient.LogoutAll()
+				if err = logoutError(resp, err); err != nil {
+					fmt.Printf("❌ Failed to logout of all devices: %v\n", err)
+					return
+				}
+				fmt.Printf("🔒 Signed out %d session(s) on all devices.\n", resp.RevokedRefreshTokens)
+			} else if err := logoutError(apiClient.Logout(config.RefreshToken)); err != nil {
+				// The local logout still happens, the tokens expire on their own
+				fmt.Printf("⚠️  Could not revoke the session on the server: %v\n", err)
+			}
+		} else if logoutAll {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
 		err := clientState.ClearAuth()
 		if err != nil {
 			fmt.Printf("❌ Failed to logout: %v\n", err)
@@ -35,6 +59,18 @@ var logoutCmd = &cobra.Command{
 	},
 }
 
+// logoutError returns the request error, or the server's message when the logout did not succeed
+func logoutError(resp *mancala.LogoutResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if !resp.Success {
+		return fmt.Errorf("%s", resp.Message)
+	}
+	return nil
+}
+
 func init() {
+	logoutCmd.Flags().BoolVar(&logoutAll, "all", false, "Sign out every device logged in to the account")
 	rootCmd.AddCommand(logoutCmd)
 }
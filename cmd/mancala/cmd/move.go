@@ -7,16 +7,23 @@ import (
 	"github.com/spf13/cobra"
 )
 
+var (
+	moveGameID string
+)
+
 var moveCmd = &cobra.Command{
 	Use:   "move <pit-number>",
 	Short: "Make a move in the current game",
-	Long: `Make a move in the current game by selecting a pit number (0-5).
+	Long: `Make a move in the current game by selecting a pit number (0-5 on a standard board).
 
 Pit numbers for Player 1:
   0  1  2  3  4  5
 
+If you have several games in progress, choose one with --game.
+
 Example:
-  mancala move 3`,
+  mancala move 3
+  mancala move 3 --game <game-id>`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		if !clientState.IsConnected() {
@@ -34,25 +41,27 @@ Example:
 			return
 		}
 
-		if currentGameID == "" {
-			fmt.Println("❌ No active game. Use 'mancala play' to join a game first.")
+		config := clientState.GetConfig()
+
+		gameID, ok := selectGame(moveGameID, config.UserID)
+		if !ok {
 			return
 		}
+		currentGameID = gameID
 
 		pitStr := args[0]
 		pitIndex, err := strconv.Atoi(pitStr)
 		if err != nil {
-			fmt.Printf("❌ Invalid pit number: %s. Must be a number between 0-5.\n", pitStr)
+			fmt.Printf("❌ Invalid pit number: %s. Must be a number.\n", pitStr)
 			return
 		}
 
-		if pitIndex < 0 || pitIndex > 5 {
-			fmt.Printf("❌ Invalid pit number: %d. Must be between 0-5.\n", pitIndex)
+		// The board size depends on the game's rule set, so the games service checks the upper bound
+		if pitIndex < 0 {
+			fmt.Printf("❌ Invalid pit number: %d. Must not be negative.\n", pitIndex)
 			return
 		}
 
-		config := clientState.GetConfig()
-
 		fmt.Printf("🎲 Making move: pit %d...\n", pitIndex)
 
 		// Make the move
@@ -76,4 +85,6 @@ Example:
 
 func init() {
 	rootCmd.AddCommand(moveCmd)
+
+	moveCmd.Flags().StringVarP(&moveGameID, "game", "g", "", "Game to move in (defaults to your only game in progress)")
 }
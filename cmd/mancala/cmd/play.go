@@ -87,6 +87,14 @@ var playCmd = &cobra.Command{
 					fmt.Println("Use 'mancala move <pit>' to make moves (in a new terminal)")
 				}
 
+			case "NOTIFICATION_TYPE_CHALLENGE":
+				mancala.DisplayChallenge(notification.Data, config.UserID)
+
+			case "NOTIFICATION_TYPE_MATCH_FAILED":
+				reason, _ := notification.Data["reason"].(string)
+				fmt.Printf("\n⚠️ The game could not be started: %s\n", reason)
+				fmt.Println("⏳ You are back at the front of the queue, waiting for an opponent...")
+
 			case "NOTIFICATION_TYPE_MOVE_MADE":
 				if inGame {
 					mancala.DisplayMoveResult(notification.Data)
@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/laerson/mancala/internal/mancala"
+	"github.com/spf13/cobra"
+)
+
+var (
+	replayAll bool
+)
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <game-id>",
+	Short: "Step through every move of a game",
+	Long: `Replay a game move by move, showing the board after each move.
+
+Press Enter to step to the next move or type 'q' to stop. Use --all to
+print every position at once.
+
+Example:
+  mancala replay 3f2a9c...
+  mancala replay 3f2a9c... --all`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clientState.IsConnected() {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
+		if !clientState.IsLoggedIn() {
+			fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+			return
+		}
+
+		if apiClient == nil {
+			fmt.Println("❌ API client not initialized. Please reconnect.")
+			return
+		}
+
+		resp, err := apiClient.GetReplay(args[0])
+		if err != nil {
+			fmt.Printf("❌ Failed to get replay: %v\n", err)
+			return
+		}
+
+		replay := resp.Replay
+		fmt.Printf("\n🎬 Replay of game %s\n", replay.GameID)
+		fmt.Printf("Player 1: %s\n", replay.Player1ID)
+		fmt.Printf("Player 2: %s\n", replay.Player2ID)
+
+		reader := bufio.NewReader(os.Stdin)
+		moves := len(replay.Positions) - 1
+		for i, position := range replay.Positions {
+			if position.Move == nil {
+				fmt.Println("\nStarting position")
+			} else {
+				move := position.Move
+				fmt.Printf("\nMove %d/%d: %s played pit %d (%s)\n",
+					position.MoveNumber, moves, move.PlayerID, move.PitIndex,
+					time.Unix(move.Timestamp, 0).Format("15:04:05"))
+				if move.ExtraTurn {
+					fmt.Println("⭐ Extra turn")
+				}
+				if move.Captured {
+					fmt.Println("💥 Capture")
+				}
+			}
+
+			mancala.DisplayBoard(mancala.GameBoard{
+				Pits:          position.State.Board.Pits,
+				CurrentPlayer: position.State.CurrentPlayer,
+				GameType:      position.State.GameType,
+			})
+
+			if replayAll || i == len(replay.Positions)-1 {
+				continue
+			}
+
+			fmt.Print("[Enter] next move, [q] quit: ")
+			input, err := reader.ReadString('\n')
+			if err != nil || strings.TrimSpace(input) == "q" {
+				return
+			}
+		}
+
+		if replay.WinnerID != "" {
+			fmt.Printf("🏁 Winner: %s\n", replay.WinnerID)
+		} else if replay.IsFinished() {
+			fmt.Println("🏁 Result: Draw")
+		} else {
+			fmt.Println("⏳ Game still in progress")
+		}
+		fmt.Println()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(replayCmd)
+
+	replayCmd.Flags().BoolVar(&replayAll, "all", false, "Print every position without waiting")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	resignGameID string
+	resignAbort  bool
+)
+
+var resignCmd = &cobra.Command{
+	Use:   "resign",
+	Short: "Resign the current game",
+	Long: `Resign the current game. Your opponent wins.
+
+Use --abort to call the game off without a result instead. A game can only
+be aborted before the second move has been played.
+
+Example:
+  mancala resign
+  mancala resign --game <game-id>
+  mancala resign --abort`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clientState.IsConnected() {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
+		if !clientState.IsLoggedIn() {
+			fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+			return
+		}
+
+		if apiClient == nil {
+			fmt.Println("❌ API client not initialized. Please reconnect.")
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		gameID, ok := selectGame(resignGameID, config.UserID)
+		if !ok {
+			return
+		}
+
+		if resignAbort {
+			if _, err := apiClient.Abort(gameID, config.UserID); err != nil {
+				fmt.Printf("❌ Failed to abort game: %v\n", err)
+				return
+			}
+
+			fmt.Println("🚫 Game aborted. No result was recorded.")
+			return
+		}
+
+		resp, err := apiClient.Resign(gameID, config.UserID)
+		if err != nil {
+			fmt.Printf("❌ Failed to resign: %v\n", err)
+			return
+		}
+
+		fmt.Printf("🏳️  You resigned. %s wins.\n", resp.Game.WinnerID)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(resignCmd)
+
+	resignCmd.Flags().StringVarP(&resignGameID, "game", "g", "", "Game to resign (defaults to your only game in progress)")
+	resignCmd.Flags().BoolVar(&resignAbort, "abort", false, "Abort the game without a result (before the second move only)")
+}
@@ -1,19 +1,109 @@
 package cmd
 
 import (
+	"fmt"
+
 	"github.com/laerson/mancala/internal/mancala"
 	"github.com/spf13/cobra"
 )
 
 var statusCmd = &cobra.Command{
-	Use:   "status",
-	Short: "Show connection and login status",
-	Long:  `Display the current connection status and login information.`,
+	Use:   "status [game-id]",
+	Short: "Show connection, login and game status",
+	Long: `Display the current connection status and login information.
+
+When logged in, your in-progress games are shown with their boards and
+whose turn it is. Pass a game ID to show only that game.
+
+Example:
+  mancala status
+  mancala status 3f2a9c...`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		mancala.DisplayConnectionStatus(clientState)
+
+		if !clientState.IsConnected() || !clientState.IsLoggedIn() || apiClient == nil {
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		if len(args) > 0 {
+			resp, err := apiClient.GetGame(args[0])
+			if err != nil {
+				fmt.Printf("❌ Failed to get game: %v\n", err)
+				return
+			}
+
+			mancala.DisplayGame(resp.Game, config.UserID)
+			return
+		}
+
+		games, err := activeGames(config.UserID)
+		if err != nil {
+			fmt.Printf("❌ Failed to list games: %v\n", err)
+			return
+		}
+
+		if len(games) == 0 {
+			fmt.Println("No games in progress. Use 'mancala play' or 'mancala bot' to start one.")
+			return
+		}
+
+		fmt.Printf("=== GAMES IN PROGRESS (%d) ===\n", len(games))
+		for _, game := range games {
+			mancala.DisplayGame(game, config.UserID)
+		}
 	},
 }
 
+// activeGames fetches all of the player's in-progress games
+func activeGames(playerID string) ([]mancala.Game, error) {
+	var games []mancala.Game
+	pageToken := ""
+	for {
+		resp, err := apiClient.ListGames(playerID, "in_progress", pageToken)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, resp.Games...)
+		if resp.NextPageToken == "" {
+			return games, nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// selectGame picks the game a command acts on: the given game ID, the game this
+// process is playing, or else the player's only game in progress
+func selectGame(gameID, playerID string) (string, bool) {
+	if gameID != "" {
+		return gameID, true
+	}
+	if currentGameID != "" {
+		return currentGameID, true
+	}
+
+	// Rejoin the in-progress game when this process did not start it
+	games, err := activeGames(playerID)
+	if err != nil {
+		fmt.Printf("❌ Failed to look up active games: %v\n", err)
+		return "", false
+	}
+
+	switch len(games) {
+	case 0:
+		fmt.Println("❌ No active game. Use 'mancala play' to join a game first.")
+		return "", false
+	case 1:
+		return games[0].ID, true
+	default:
+		fmt.Println("❌ You have several games in progress. Use 'mancala status' to see them and pick one with --game.")
+		return "", false
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(statusCmd)
 }
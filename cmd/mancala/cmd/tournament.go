@@ -0,0 +1,306 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/laerson/mancala/internal/mancala"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tournamentFormat string
+	tournamentRounds int32
+	tournamentStatus string
+)
+
+var tournamentCmd = &cobra.Command{
+	Use:   "tournament",
+	Short: "Create, join and follow tournaments",
+	Long: `Play Swiss or round-robin tournaments.
+
+The organizer creates a tournament and starts it once everyone has joined.
+Every round is paired automatically. Keep 'mancala tournament play <id>' running
+to be told about your games, and use 'mancala move <pit>' in another terminal to
+play them. The next round is paired as soon as the last game of the current
+round ends.
+
+Standings are ranked by score (1 per win or bye, ½ per draw), then Buchholz
+(the sum of your opponents' scores), then Sonneborn-Berger (the scores of the
+opponents you beat plus half the scores of those you drew).
+
+Example:
+  mancala tournament create "Friday Open" --format swiss --rounds 4
+  mancala tournament list
+  mancala tournament join <tournament-id>
+  mancala tournament start <tournament-id>
+  mancala tournament play <tournament-id>
+  mancala tournament show <tournament-id>`,
+}
+
+var tournamentCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Create a tournament you organize",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tournamentReady() {
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		resp, err := apiClient.CreateTournament(config.UserID, args[0], tournamentFormat, tournamentRounds)
+		if err != nil {
+			fmt.Printf("❌ Failed to create tournament: %v\n", err)
+			return
+		}
+
+		fmt.Printf("🏆 Tournament %s created.\n", resp.Tournament.Name)
+		fmt.Printf("Players join with 'mancala tournament join %s'.\n", resp.Tournament.ID)
+		fmt.Printf("Start it with 'mancala tournament start %s' once everyone is in.\n", resp.Tournament.ID)
+	},
+}
+
+var tournamentListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List tournaments",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tournamentReady() {
+			return
+		}
+
+		resp, err := apiClient.ListTournaments(tournamentStatus)
+		if err != nil {
+			fmt.Printf("❌ Failed to list tournaments: %v\n", err)
+			return
+		}
+
+		if len(resp.Tournaments) == 0 {
+			fmt.Println("📭 No tournaments found.")
+			fmt.Println("Use 'mancala tournament create <name>' to organize one.")
+			return
+		}
+
+		fmt.Println("🏆 Tournaments:")
+		for _, tournament := range resp.Tournaments {
+			fmt.Printf("  %s  %-24s %-12s %-12s %d players, created %s\n", tournament.ID, tournament.Name,
+				tournament.Format, tournament.Status, len(tournament.Players),
+				time.Unix(tournament.CreatedAt, 0).Format("Jan 2 15:04"))
+		}
+	},
+}
+
+var tournamentJoinCmd = &cobra.Command{
+	Use:   "join <tournament-id>",
+	Short: "Register for a tournament",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tournamentReady() {
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		resp, err := apiClient.JoinTournament(args[0], config.UserID, config.Username)
+		if err != nil {
+			fmt.Printf("❌ Failed to join tournament: %v\n", err)
+			return
+		}
+
+		fmt.Printf("✅ You are registered for %s (%d players so far).\n", resp.Tournament.Name, len(resp.Tournament.Players))
+		fmt.Printf("Run 'mancala tournament play %s' to be told about your games once it starts.\n", resp.Tournament.ID)
+	},
+}
+
+var tournamentLeaveCmd = &cobra.Command{
+	Use:   "leave <tournament-id>",
+	Short: "Withdraw from a tournament before it starts",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tournamentReady() {
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		resp, err := apiClient.LeaveTournament(args[0], config.UserID)
+		if err != nil {
+			fmt.Printf("❌ Failed to leave tournament: %v\n", err)
+			return
+		}
+
+		fmt.Printf("✅ You left %s.\n", resp.Tournament.Name)
+	},
+}
+
+var tournamentStartCmd = &cobra.Command{
+	Use:   "start <tournament-id>",
+	Short: "Close registration and pair the first round",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tournamentReady() {
+			return
+		}
+
+		config := clientState.GetConfig()
+
+		resp, err := apiClient.StartTournament(args[0], config.UserID)
+		if err != nil {
+			fmt.Printf("❌ Failed to start tournament: %v\n", err)
+			return
+		}
+
+		fmt.Printf("🚀 %s has started: %d rounds.\n", resp.Tournament.Name, resp.Tournament.TotalRounds)
+		showTournament(resp.Tournament.ID, config.UserID)
+	},
+}
+
+var tournamentShowCmd = &cobra.Command{
+	Use:   "show <tournament-id>",
+	Short: "Show standings and the current round",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tournamentReady() {
+			return
+		}
+
+		showTournament(args[0], clientState.GetConfig().UserID)
+	},
+}
+
+var tournamentPlayCmd = &cobra.Command{
+	Use:   "play <tournament-id>",
+	Short: "Follow your tournament games until the tournament ends",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !tournamentReady() {
+			return
+		}
+
+		config := clientState.GetConfig()
+		tournamentID := args[0]
+
+		resp, err := apiClient.GetTournament(tournamentID)
+		if err != nil {
+			fmt.Printf("❌ Failed to get tournament: %v\n", err)
+			return
+		}
+		if resp.Tournament.Status == "finished" {
+			mancala.DisplayTournament(*resp.Tournament, resp.Standings, config.UserID)
+			return
+		}
+
+		fmt.Printf("🏆 Following %s. Your games will appear here as rounds are paired.\n", resp.Tournament.Name)
+		fmt.Println("Press Ctrl+C to stop following (your games keep running).")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Handle Ctrl+C
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+		go func() {
+			<-sigChan
+			cancel()
+		}()
+
+		notificationClient := mancala.NewNotificationClient(config.ServerURL, config.AccessToken)
+
+		err = notificationClient.Subscribe(ctx, config.UserID, func(notification mancala.Notification) {
+			switch notification.Type {
+			case "NOTIFICATION_TYPE_MATCH_FOUND":
+				mancala.DisplayMatchFound(notification.Data)
+				startJoinedGame(notification.GameID)
+
+			case "NOTIFICATION_TYPE_MOVE_MADE":
+				if inGame {
+					mancala.DisplayMoveResult(notification.Data)
+					fmt.Print("\nWaiting for your move (use 'mancala move <pit>' in a new terminal)...")
+				}
+
+			case "NOTIFICATION_TYPE_GAME_OVER":
+				if !inGame || notification.GameID != currentGameID {
+					return
+				}
+
+				mancala.DisplayGameOver(notification.Data)
+				inGame = false
+				currentGameID = ""
+
+				// The result takes a moment to reach the tournament
+				time.Sleep(time.Second)
+				resp, err := apiClient.GetTournament(tournamentID)
+				if err != nil {
+					fmt.Printf("⚠️ Failed to get standings: %v\n", err)
+					return
+				}
+
+				mancala.DisplayTournament(*resp.Tournament, resp.Standings, config.UserID)
+				if resp.Tournament.Status == "finished" {
+					fmt.Println("\n🏁 The tournament is over!")
+					cancel()
+				} else {
+					fmt.Println("\n⏳ Waiting for your next game...")
+				}
+			}
+		})
+
+		if err != nil && err != context.Canceled {
+			fmt.Printf("❌ Notification error: %v\n", err)
+		}
+	},
+}
+
+// showTournament prints a tournament's standings and current pairings
+func showTournament(tournamentID, playerID string) {
+	resp, err := apiClient.GetTournament(tournamentID)
+	if err != nil {
+		fmt.Printf("❌ Failed to get tournament: %v\n", err)
+		return
+	}
+
+	mancala.DisplayTournament(*resp.Tournament, resp.Standings, playerID)
+	fmt.Println()
+}
+
+// tournamentReady reports whether the client is connected and logged in, explaining what is missing
+func tournamentReady() bool {
+	if !clientState.IsConnected() {
+		fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+		return false
+	}
+
+	if !clientState.IsLoggedIn() {
+		fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+		return false
+	}
+
+	if apiClient == nil {
+		fmt.Println("❌ API client not initialized. Please reconnect.")
+		return false
+	}
+
+	return true
+}
+
+func init() {
+	rootCmd.AddCommand(tournamentCmd)
+
+	tournamentCmd.AddCommand(tournamentCreateCmd)
+	tournamentCmd.AddCommand(tournamentListCmd)
+	tournamentCmd.AddCommand(tournamentJoinCmd)
+	tournamentCmd.AddCommand(tournamentLeaveCmd)
+	tournamentCmd.AddCommand(tournamentStartCmd)
+	tournamentCmd.AddCommand(tournamentPlayCmd)
+	tournamentCmd.AddCommand(tournamentShowCmd)
+
+	tournamentCreateCmd.Flags().StringVar(&tournamentFormat, "format", "swiss", "Tournament format: swiss or round_robin")
+	tournamentCreateCmd.Flags().Int32Var(&tournamentRounds, "rounds", 0, "Number of Swiss rounds (0 picks enough to find a winner)")
+	tournamentListCmd.Flags().StringVar(&tournamentStatus, "status", "", "Only list tournaments that are registering, in_progress or finished")
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/laerson/mancala/internal/mancala"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch <game-id>",
+	Short: "Follow a live game as a spectator",
+	Long: `Watch a game someone else is playing. The board is redrawn after every
+move until the game ends. Spectators cannot make moves.
+
+Example:
+  mancala watch 3f2a9c...`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if !clientState.IsConnected() {
+			fmt.Println("❌ Not connected to a server. Use 'mancala connect <server-ip>' first.")
+			return
+		}
+
+		if !clientState.IsLoggedIn() {
+			fmt.Println("❌ Not logged in. Use 'mancala login' or 'mancala register' first.")
+			return
+		}
+
+		if apiClient == nil {
+			fmt.Println("❌ API client not initialized. Please reconnect.")
+			return
+		}
+
+		config := clientState.GetConfig()
+		gameID := args[0]
+
+		// Only games in progress can be watched
+		resp, err := apiClient.SpectateGame(gameID)
+		if err != nil {
+			fmt.Printf("❌ Failed to load game: %v\n", err)
+			return
+		}
+		mancala.DisplayGame(resp.Game, config.UserID)
+
+		fmt.Printf("\n👀 Watching game %s. The board is shown after every move.\n", gameID)
+		fmt.Println("Press Ctrl+C to stop watching.")
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		// Handle Ctrl+C
+		sigChan := make(chan os.Signal, 1)
+		signal.Notify(sigChan, os.Interrupt, syscall.SIGTERM)
+
+		go func() {
+			<-sigChan
+			cancel()
+		}()
+
+		notificationClient := mancala.NewNotificationClient(config.ServerURL, config.AccessToken)
+
+		err = notificationClient.Watch(ctx, gameID, func(notification mancala.Notification) {
+			switch notification.Type {
+			case "NOTIFICATION_TYPE_MOVE_MADE":
+				mancala.DisplayMoveResult(notification.Data)
+
+			case "NOTIFICATION_TYPE_GAME_OVER":
+				mancala.DisplayGameOver(notification.Data)
+				cancel()
+			}
+		})
+
+		if err != nil && err != context.Canceled {
+			fmt.Printf("❌ Notification error: %v\n", err)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(watchCmd)
+}
@@ -9,11 +9,15 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/laerson/mancala/internal/auth"
+	"github.com/laerson/mancala/internal/bot"
+	"github.com/laerson/mancala/internal/events"
 	"github.com/laerson/mancala/internal/matchmaking"
+	"github.com/laerson/mancala/internal/tournaments"
 	authpb "github.com/laerson/mancala/proto/auth"
 	botpb "github.com/laerson/mancala/proto/bot"
 	gamespb "github.com/laerson/mancala/proto/games"
 	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
 )
 
 func main() {
@@ -43,21 +47,30 @@ func main() {
 		botAddr = "bot:50057"
 	}
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "mancala-jwt-secret-key-change-in-production"
-		log.Println("Warning: Using default JWT secret. Set JWT_SECRET environment variable in production!")
+	// Calls to other services are authenticated with this service's tokens
+	serviceID := os.Getenv("SERVICE_ID")
+	if serviceID == "" {
+		serviceID = "matchmaking"
 	}
+	serviceSecret := os.Getenv("SERVICE_SECRET")
+	if serviceSecret == "" {
+		log.Println("Warning: SERVICE_SECRET is not set, calls to other services will be rejected")
+	}
+	tokenSource, err := auth.NewServiceTokenSource(authAddr, serviceID, serviceSecret)
+	if err != nil {
+		log.Fatalf("Failed to create service token source: %v", err)
+	}
+	serviceCredentials := grpc.WithPerRPCCredentials(tokenSource)
 
 	// Connect to Games service
-	gamesConn, err := grpc.NewClient(gamesAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	gamesConn, err := grpc.NewClient(gamesAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), serviceCredentials)
 	if err != nil {
 		log.Fatalf("Failed to connect to games service: %v", err)
 	}
 	defer gamesConn.Close()
 
 	// Connect to Auth service
-	authConn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	authConn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), serviceCredentials)
 	if err != nil {
 		log.Printf("Warning: Failed to connect to auth service: %v", err)
 		log.Println("Auth service validation will be skipped")
@@ -69,7 +82,7 @@ func main() {
 	}
 
 	// Connect to Bot service
-	botConn, err := grpc.NewClient(botAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	botConn, err := grpc.NewClient(botAddr, grpc.WithTransportCredentials(insecure.NewCredentials()), serviceCredentials)
 	if err != nil {
 		log.Printf("Warning: Failed to connect to bot service: %v", err)
 		log.Println("Bot matches will not be available")
@@ -80,15 +93,43 @@ func main() {
 		defer botConn.Close()
 	}
 
+	gamesClient := gamespb.NewGamesClient(gamesConn)
+
 	// Create server
+	// The queue lives in Redis so players survive restarts and replicas share it
 	server := matchmaking.NewServer(
-		gamespb.NewGamesClient(gamesConn),
+		matchmaking.NewRedisQueue(redisAddr),
+		matchmaking.NewRedisChallengeStore(redisAddr),
+		gamesClient,
 		botClient,
-		redisAddr,
+		authClient,
+		bot.NewRedisGameRegistry(redisAddr),
+		events.NewEventPublisher(redisAddr),
 	)
+	server.Start()
+	defer server.Stop()
+
+	// Tournaments are served alongside matchmaking and advance on GAME_OVER events
+	tournamentServer := tournaments.NewServer(tournaments.NewRedisStorage(redisAddr), gamesClient, redisAddr)
+	if err := tournamentServer.Start(); err != nil {
+		log.Printf("Warning: Failed to start tournament results consumer: %v", err)
+	} else {
+		defer tournamentServer.Stop()
+	}
+
+	// Start the bot driver so bot games progress without a second human. It
+	// plays the bot's moves with this service's tokens.
+	if botClient != nil {
+		botDriver := bot.NewDriver(redisAddr, bot.NewRedisGameRegistry(redisAddr), gamesClient, botClient)
+		if err := botDriver.Start(); err != nil {
+			log.Printf("Warning: Failed to start bot driver: %v", err)
+		} else {
+			defer botDriver.Stop()
+		}
+	}
 
 	// Create auth interceptor
-	authInterceptor := auth.NewAuthInterceptor(authClient, jwtSecret)
+	authInterceptor := auth.NewAuthInterceptor(authClient, auth.NewRedisDenylist(redisAddr))
 
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":"+port)
@@ -101,6 +142,7 @@ func main() {
 		grpc.StreamInterceptor(authInterceptor.StreamInterceptor()),
 	)
 	matchmakingpb.RegisterMatchmakingServer(grpcServer, server)
+	tournamentspb.RegisterTournamentsServer(grpcServer, tournamentServer)
 
 	log.Printf("Matchmaking service listening on port %s", port)
 	log.Printf("Connected to Games service at %s", gamesAddr)
@@ -13,6 +13,7 @@ import (
 	"github.com/laerson/mancala/internal/auth"
 	"github.com/laerson/mancala/internal/notifications"
 	authpb "github.com/laerson/mancala/proto/auth"
+	gamespb "github.com/laerson/mancala/proto/games"
 	notificationspb "github.com/laerson/mancala/proto/notifications"
 )
 
@@ -32,12 +33,14 @@ func main() {
 		authAddr = "auth:50055"
 	}
 
-	jwtSecret := os.Getenv("JWT_SECRET")
-	if jwtSecret == "" {
-		jwtSecret = "mancala-jwt-secret-key-change-in-production"
-		log.Println("Warning: Using default JWT secret. Set JWT_SECRET environment variable in production!")
+	gamesAddr := os.Getenv("GAMES_ADDR")
+	if gamesAddr == "" {
+		gamesAddr = "games:50052"
 	}
 
+	// Names the instance's consumer group, it must survive restarts. Defaults to the hostname.
+	instanceID := os.Getenv("INSTANCE_ID")
+
 	// Connect to Auth service
 	authConn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -50,11 +53,22 @@ func main() {
 		defer authConn.Close()
 	}
 
+	// Connect to Games service, to check the games spectators watch
+	gamesConn, err := grpc.NewClient(gamesAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		log.Printf("Warning: Failed to connect to games service: %v", err)
+	}
+	var gamesClient notifications.GamesClient
+	if gamesConn != nil {
+		gamesClient = gamespb.NewGamesClient(gamesConn)
+		defer gamesConn.Close()
+	}
+
 	// Create notification server
-	notificationServer := notifications.NewServer(redisAddr)
+	notificationServer := notifications.NewServer(redisAddr, instanceID, gamesClient)
 
 	// Create auth interceptor
-	authInterceptor := auth.NewAuthInterceptor(authClient, jwtSecret)
+	authInterceptor := auth.NewAuthInterceptor(authClient, auth.NewRedisDenylist(redisAddr))
 
 	// Start gRPC server
 	lis, err := net.Listen("tcp", ":"+port)
@@ -82,6 +96,7 @@ func main() {
 	log.Printf("Notification service listening on port %s", port)
 	log.Printf("Connected to Redis at %s", redisAddr)
 	log.Printf("Connected to Auth service at %s", authAddr)
+	log.Printf("Connected to Games service at %s", gamesAddr)
 	log.Printf("Ready to serve notifications")
 
 	if err := grpcServer.Serve(lis); err != nil {
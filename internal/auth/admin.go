@@ -0,0 +1,161 @@
+package auth
+
+import (
+	"context"
+	"log"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	authpb "github.com/laerson/mancala/proto/auth"
+)
+
+const (
+	// defaultUsersPageSize is the number of users listed when no limit is given
+	defaultUsersPageSize = 50
+
+	// maxUsersPageSize is the most users listed at once
+	maxUsersPageSize = 200
+)
+
+// ListUsers lists the users whose username contains the query. The
+// interceptor only lets moderators and admins through.
+func (s *Server) ListUsers(ctx context.Context, req *authpb.ListUsersRequest) (*authpb.ListUsersResponse, error) {
+	if req.Offset < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultUsersPageSize
+	} else if limit > maxUsersPageSize {
+		limit = maxUsersPageSize
+	}
+
+	users, total, err := s.storage.ListUsers(ctx, req.Query, limit, int(req.Offset))
+	if err != nil {
+		log.Printf("Failed to list users: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to list users")
+	}
+
+	response := &authpb.ListUsersResponse{Total: int32(total)}
+	for _, user := range users {
+		response.Users = append(response.Users, s.userToProto(user))
+	}
+
+	return response, nil
+}
+
+// BanUser bans a user and signs them out everywhere
+func (s *Server) BanUser(ctx context.Context, req *authpb.BanUserRequest) (*authpb.UserActionResponse, error) {
+	user, err := s.getManagedUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	staffID, _ := GetUserIDFromContext(ctx)
+
+	if err := s.storage.SetUserBanned(ctx, user.UserID, true, req.Reason); err != nil {
+		log.Printf("Failed to ban user %s: %v", user.UserID, err)
+		return nil, status.Errorf(codes.Internal, "Failed to ban user")
+	}
+	user.Banned, user.BanReason = true, req.Reason
+
+	// Tokens are validated locally, so they have to be revoked for the ban to take effect
+	if _, _, err := s.revokeUserTokens(ctx, user.UserID); err != nil {
+		log.Printf("Failed to revoke tokens of banned user %s: %v", user.UserID, err)
+		return nil, status.Errorf(codes.Internal, "Failed to revoke tokens")
+	}
+
+	log.Printf("User %s (%s) banned by %s: %s", user.Username, user.UserID, staffID, req.Reason)
+
+	return &authpb.UserActionResponse{
+		Success: true,
+		Message: "User banned",
+		User:    s.userToProto(user),
+	}, nil
+}
+
+// UnbanUser lifts a user's ban
+func (s *Server) UnbanUser(ctx context.Context, req *authpb.UnbanUserRequest) (*authpb.UserActionResponse, error) {
+	user, err := s.getManagedUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	staffID, _ := GetUserIDFromContext(ctx)
+
+	if err := s.storage.SetUserBanned(ctx, user.UserID, false, ""); err != nil {
+		log.Printf("Failed to unban user %s: %v", user.UserID, err)
+		return nil, status.Errorf(codes.Internal, "Failed to unban user")
+	}
+	user.Banned, user.BanReason = false, ""
+
+	log.Printf("User %s (%s) unbanned by %s", user.Username, user.UserID, staffID)
+
+	return &authpb.UserActionResponse{
+		Success: true,
+		Message: "User unbanned",
+		User:    s.userToProto(user),
+	}, nil
+}
+
+// SetUserRole changes a user's role. The interceptor only lets admins through.
+func (s *Server) SetUserRole(ctx context.Context, req *authpb.SetUserRoleRequest) (*authpb.UserActionResponse, error) {
+	if !IsValidRole(req.Role) {
+		return nil, status.Errorf(codes.InvalidArgument, "role must be %s, %s or %s", RolePlayer, RoleModerator, RoleAdmin)
+	}
+
+	user, err := s.getManagedUser(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	staffID, _ := GetUserIDFromContext(ctx)
+
+	if err := s.storage.SetUserRole(ctx, user.UserID, req.Role); err != nil {
+		log.Printf("Failed to set role of user %s: %v", user.UserID, err)
+		return nil, status.Errorf(codes.Internal, "Failed to set role")
+	}
+	user.Role = req.Role
+
+	// Access tokens carry the old role, refreshing them picks up the new one
+	if s.denylist != nil {
+		if _, err := s.denylist.RevokeUserAccessTokens(ctx, user.UserID); err != nil {
+			log.Printf("Failed to revoke access tokens of user %s: %v", user.UserID, err)
+			return nil, status.Errorf(codes.Internal, "Failed to revoke tokens")
+		}
+	}
+
+	log.Printf("User %s (%s) made %s by %s", user.Username, user.UserID, req.Role, staffID)
+
+	return &authpb.UserActionResponse{
+		Success: true,
+		Message: "Role changed to " + req.Role,
+		User:    s.userToProto(user),
+	}, nil
+}
+
+// getManagedUser looks up the user a staff member acts on. Staff may only act
+// on users of a lower role, which also keeps them from acting on themselves.
+func (s *Server) getManagedUser(ctx context.Context, userID string) (*User, error) {
+	if _, err := GetUserIDFromContext(ctx); err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	user, err := s.storage.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+
+	if roleRanks[GetRoleFromContext(ctx)] <= roleRanks[user.Role] {
+		return nil, status.Errorf(codes.PermissionDenied, "only users of a lower role can be managed")
+	}
+
+	return user, nil
+}
+
+// bannedMessage returns the in-message error for a banned user
+func bannedMessage(user *User) string {
+	if user.BanReason == "" {
+		return "Account is banned"
+	}
+	return "Account is banned: " + user.BanReason
+}
@@ -0,0 +1,120 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Denylist tracks revoked access tokens by their ID (the jti claim). Access
+// tokens are validated locally by every service, so a token stays usable
+// until it expires unless it is denied here.
+type Denylist interface {
+	// TrackAccessToken remembers an issued access token, so it can be revoked with all others of its user
+	TrackAccessToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error
+	// RevokeAccessToken denies an access token until it expires
+	RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// RevokeUserAccessTokens denies every unexpired access token of a user and returns how many there were
+	RevokeUserAccessTokens(ctx context.Context, userID string) (int, error)
+	// IsRevoked reports whether an access token was revoked
+	IsRevoked(ctx context.Context, tokenID string) (bool, error)
+}
+
+// RedisDenylist keeps revoked access tokens in Redis, shared by the auth
+// service, the gateway and every service validating tokens
+type RedisDenylist struct {
+	client *redis.Client
+}
+
+// NewRedisDenylist creates a denylist backed by Redis
+func NewRedisDenylist(redisAddr string) *RedisDenylist {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: redisAddr,
+	})
+
+	// Test connection
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		log.Printf("Warning: Failed to connect to Redis for the token denylist: %v", err)
+	}
+
+	return &RedisDenylist{client: rdb}
+}
+
+// revokedTokenKey returns the key marking an access token as revoked
+func revokedTokenKey(tokenID string) string {
+	return fmt.Sprintf("revoked_token:%s", tokenID)
+}
+
+// userAccessTokensKey returns the key of a user's issued access tokens, scored by expiry
+func userAccessTokensKey(userID string) string {
+	return fmt.Sprintf("user_access_tokens:%s", userID)
+}
+
+// TrackAccessToken remembers an issued access token until it expires
+func (d *RedisDenylist) TrackAccessToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error {
+	key := userAccessTokensKey(userID)
+
+	pipe := d.client.TxPipeline()
+	pipe.ZAdd(ctx, key, redis.Z{Score: float64(expiresAt.Unix()), Member: tokenID})
+	// Expired tokens need no revoking
+	pipe.ZRemRangeByScore(ctx, key, "-inf", strconv.FormatInt(time.Now().Unix(), 10))
+	pipe.ExpireAt(ctx, key, expiresAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to track access token: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAccessToken denies an access token until it expires
+func (d *RedisDenylist) RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil // Already unusable
+	}
+
+	if err := d.client.Set(ctx, revokedTokenKey(tokenID), "1", ttl).Err(); err != nil {
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeUserAccessTokens denies every unexpired access token of a user
+func (d *RedisDenylist) RevokeUserAccessTokens(ctx context.Context, userID string) (int, error) {
+	key := userAccessTokensKey(userID)
+
+	tokens, err := d.client.ZRangeByScoreWithScores(ctx, key, &redis.ZRangeBy{
+		Min: strconv.FormatInt(time.Now().Unix(), 10),
+		Max: "+inf",
+	}).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get access tokens: %w", err)
+	}
+
+	pipe := d.client.TxPipeline()
+	for _, token := range tokens {
+		expiresAt := time.Unix(int64(token.Score), 0)
+		pipe.Set(ctx, revokedTokenKey(token.Member.(string)), "1", time.Until(expiresAt)+time.Second)
+	}
+	pipe.Del(ctx, key)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to revoke access tokens: %w", err)
+	}
+
+	return len(tokens), nil
+}
+
+// IsRevoked reports whether an access token was revoked
+func (d *RedisDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	count, err := d.client.Exists(ctx, revokedTokenKey(tokenID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to check revoked token: %w", err)
+	}
+
+	return count > 0, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -17,13 +18,22 @@ import (
 type Interceptor struct {
 	authClient authpb.AuthClient
 	jwtManager *JWTManager
+	denylist   Denylist
 }
 
-// NewAuthInterceptor creates a new auth interceptor
-func NewAuthInterceptor(authClient authpb.AuthClient, jwtSecret string) *Interceptor {
+// NewAuthInterceptor creates a new auth interceptor. Tokens are verified with
+// the public keys fetched from the auth service, and tokens revoked on logout
+// are rejected when a denylist is given.
+func NewAuthInterceptor(authClient authpb.AuthClient, denylist Denylist) *Interceptor {
+	var jwksClient JWKSClient
+	if authClient != nil {
+		jwksClient = authClient
+	}
+
 	return &Interceptor{
 		authClient: authClient,
-		jwtManager: NewJWTManager(jwtSecret, 24*3600, 7*24*3600), // Same config as auth service
+		jwtManager: NewJWTManager(NewJWKSCache(jwksClient), 24*time.Hour, 7*24*time.Hour), // Same config as auth service
+		denylist:   denylist,
 	}
 }
 
@@ -35,19 +45,15 @@ func (interceptor *Interceptor) UnaryInterceptor() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// Skip authentication for health checks and internal service calls
+		// Skip authentication for health checks and login
 		if interceptor.isExemptMethod(info.FullMethod) {
 			return handler(ctx, req)
 		}
 
-		// Extract and validate JWT token
-		userID, err := interceptor.validateTokenFromContext(ctx)
+		ctx, err := interceptor.authenticate(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
-
-		// Add user ID to context for use in handlers
-		ctx = context.WithValue(ctx, "user_id", userID)
 		return handler(ctx, req)
 	}
 }
@@ -60,19 +66,15 @@ func (interceptor *Interceptor) StreamInterceptor() grpc.StreamServerInterceptor
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
-		// Skip authentication for internal service calls
+		// Skip authentication for health checks
 		if interceptor.isExemptMethod(info.FullMethod) {
 			return handler(srv, stream)
 		}
 
-		// Extract and validate JWT token
-		userID, err := interceptor.validateTokenFromContext(stream.Context())
+		ctx, err := interceptor.authenticate(stream.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
-
-		// Create a new context with user ID
-		ctx := context.WithValue(stream.Context(), "user_id", userID)
 		wrappedStream := &wrappedServerStream{
 			ServerStream: stream,
 			ctx:          ctx,
@@ -82,54 +84,101 @@ func (interceptor *Interceptor) StreamInterceptor() grpc.StreamServerInterceptor
 	}
 }
 
+// authenticate validates the caller's token and returns a context carrying the
+// user ID and role, or the service and its scopes for service tokens. Service
+// tokens only reach the methods their scopes allow, and users only the methods
+// their role allows.
+func (interceptor *Interceptor) authenticate(ctx context.Context, method string) (context.Context, error) {
+	claims, err := interceptor.validateTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.Service != "" {
+		scope, allowed := serviceMethodScopes[method]
+		if !allowed || !hasScope(claims.Scopes, scope) {
+			return nil, status.Errorf(codes.PermissionDenied, "service %s may not call %s", claims.Service, method)
+		}
+
+		ctx = context.WithValue(ctx, "service", claims.Service)
+		return context.WithValue(ctx, "scopes", claims.Scopes), nil
+	}
+
+	// Tokens issued before roles existed belong to players
+	role := claims.Role
+	if role == "" {
+		role = RolePlayer
+	}
+	if required, restricted := methodRoles[method]; restricted && !RoleAtLeast(role, required) {
+		return nil, status.Errorf(codes.PermissionDenied, "%s role required", required)
+	}
+
+	// Add user ID and role to context for use in handlers
+	ctx = context.WithValue(ctx, "user_id", claims.UserID)
+	return context.WithValue(ctx, "role", role), nil
+}
+
 // validateTokenFromContext extracts and validates JWT token from gRPC metadata
-func (interceptor *Interceptor) validateTokenFromContext(ctx context.Context) (string, error) {
+func (interceptor *Interceptor) validateTokenFromContext(ctx context.Context) (*JWTClaims, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return "", status.Errorf(codes.Unauthenticated, "missing metadata")
+		return nil, status.Errorf(codes.Unauthenticated, "missing metadata")
 	}
 
 	// Extract authorization header
 	authHeader := md["authorization"]
 	if len(authHeader) == 0 {
-		return "", status.Errorf(codes.Unauthenticated, "missing authorization header")
+		return nil, status.Errorf(codes.Unauthenticated, "missing authorization header")
 	}
 
 	// Extract token from "Bearer <token>" format
 	token := authHeader[0]
 	if !strings.HasPrefix(token, "Bearer ") {
-		return "", status.Errorf(codes.Unauthenticated, "invalid authorization header format")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid authorization header format")
 	}
 	token = strings.TrimPrefix(token, "Bearer ")
 
 	// Validate token locally first (faster)
 	claims, err := interceptor.jwtManager.ValidateAccessToken(token)
 	if err != nil {
-		// If local validation fails, try auth service (handles revoked tokens, etc.)
+		// If local validation fails, try auth service
 		return interceptor.validateWithAuthService(ctx, token)
 	}
 
-	return claims.UserID, nil
+	// A valid signature says nothing about a logout since the token was issued
+	if interceptor.denylist != nil {
+		revoked, err := interceptor.denylist.IsRevoked(ctx, claims.ID)
+		if err != nil {
+			// The auth service checks the denylist too
+			return interceptor.validateWithAuthService(ctx, token)
+		}
+		if revoked {
+			return nil, status.Errorf(codes.Unauthenticated, "token has been revoked")
+		}
+	}
+
+	return claims, nil
 }
 
 // validateWithAuthService validates token via auth service
-func (interceptor *Interceptor) validateWithAuthService(ctx context.Context, token string) (string, error) {
+func (interceptor *Interceptor) validateWithAuthService(ctx context.Context, token string) (*JWTClaims, error) {
 	if interceptor.authClient == nil {
-		return "", status.Errorf(codes.Unauthenticated, "invalid token")
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token")
 	}
 
 	resp, err := interceptor.authClient.ValidateToken(ctx, &authpb.ValidateTokenRequest{
 		AccessToken: token,
 	})
 	if err != nil {
-		return "", status.Errorf(codes.Unauthenticated, "token validation failed: %v", err)
+		return nil, status.Errorf(codes.Unauthenticated, "token validation failed: %v", err)
 	}
 
 	if !resp.Valid {
-		return "", status.Errorf(codes.Unauthenticated, "invalid token: %s", resp.Message)
+		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %s", resp.Message)
 	}
 
-	return resp.User.UserId, nil
+	// The auth service only vouches for user tokens
+	return &JWTClaims{UserID: resp.User.UserId, Username: resp.User.Username, Role: resp.User.Role}, nil
 }
 
 // isExemptMethod checks if a method should skip authentication
@@ -139,14 +188,13 @@ func (interceptor *Interceptor) isExemptMethod(method string) bool {
 		"/grpc.health.v1.Health/Check",
 		"/grpc.health.v1.Health/Watch",
 
-		// Games service - Create is called by matchmaking service
-		"/proto.games.Games/Create",
-
-		// Auth service methods (obviously don't need auth)
-		"/proto.auth.Auth/Register",
-		"/proto.auth.Auth/Login",
-		"/proto.auth.Auth/ValidateToken",
-		"/proto.auth.Auth/RefreshToken",
+		// Auth service methods obtaining or checking tokens
+		"/auth.Auth/Register",
+		"/auth.Auth/Login",
+		"/auth.Auth/RefreshToken",
+		"/auth.Auth/IssueServiceToken",
+		"/auth.Auth/ValidateToken",
+		"/auth.Auth/GetJWKS",
 	}
 
 	for _, exempt := range exemptMethods {
@@ -178,6 +226,11 @@ func GetUserIDFromContext(ctx context.Context) (string, error) {
 
 // ValidatePlayerOwnership checks if the authenticated user owns the specified player ID
 func ValidatePlayerOwnership(ctx context.Context, playerID string) error {
+	// Services allowed to play bots move for any bot player
+	if IsBotID(playerID) && HasScope(ctx, ScopePlayBots) {
+		return nil
+	}
+
 	userID, err := GetUserIDFromContext(ctx)
 	if err != nil {
 		return status.Errorf(codes.Unauthenticated, "authentication required")
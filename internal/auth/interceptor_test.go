@@ -0,0 +1,168 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptor_RejectsRevokedTokens(t *testing.T) {
+	keyRing := newTestKeyRing(t, AlgorithmEdDSA)
+	denylist := newMockDenylist()
+	interceptor := NewAuthInterceptor(nil, denylist)
+	interceptor.jwtManager = NewJWTManager(NewJWKSCache(&fakeJWKSClient{keyRing: keyRing}), time.Hour, 24*time.Hour)
+
+	jwtManager := NewJWTManager(keyRing, time.Hour, 24*time.Hour)
+	token, err := jwtManager.GenerateAccessToken("alice-id", "alice", RolePlayer)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+
+	if claims, err := interceptor.validateTokenFromContext(ctx); err != nil || claims.UserID != "alice-id" {
+		t.Fatalf("validateTokenFromContext() = %v, %v, want alice-id", claims, err)
+	}
+
+	claims, _ := jwtManager.ValidateAccessToken(token)
+	denylist.RevokeAccessToken(context.Background(), claims.ID, claims.ExpiresAt.Time)
+
+	if _, err := interceptor.validateTokenFromContext(ctx); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("validateTokenFromContext() of a revoked token error = %v, want Unauthenticated", err)
+	}
+}
+
+func TestInterceptor_ServiceTokens(t *testing.T) {
+	keyRing := newTestKeyRing(t, AlgorithmEdDSA)
+	interceptor := NewAuthInterceptor(nil, nil)
+	interceptor.jwtManager = NewJWTManager(NewJWKSCache(&fakeJWKSClient{keyRing: keyRing}), time.Hour, 24*time.Hour)
+
+	jwtManager := NewJWTManager(keyRing, time.Hour, 24*time.Hour)
+	serviceToken, err := jwtManager.GenerateServiceToken("matchmaking", []string{ScopeCreateGames}, time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to generate service token: %v", err)
+	}
+	userToken, err := jwtManager.GenerateAccessToken("alice-id", "alice", RolePlayer)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	var handled context.Context
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = ctx
+		return nil, nil
+	}
+	call := func(token, method string) error {
+		ctx := context.Background()
+		if token != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", "Bearer "+token))
+		}
+		_, err := interceptor.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	if err := call(serviceToken, "/proto.games.Games/Create"); err != nil {
+		t.Fatalf("Service call within its scopes error = %v", err)
+	}
+	if service, _ := GetServiceFromContext(handled); service != "matchmaking" || !HasScope(handled, ScopeCreateGames) {
+		t.Errorf("Service call context has service %q, want matchmaking with %s", service, ScopeCreateGames)
+	}
+	if _, err := GetUserIDFromContext(handled); err == nil {
+		t.Error("Service call context has a user ID")
+	}
+
+	// Scopes limit service tokens to the methods internal callers need
+	if err := call(serviceToken, "/proto.games.Games/Move"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Service call outside its scopes error = %v, want PermissionDenied", err)
+	}
+	if err := call(serviceToken, "/proto.games.Games/Resign"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("Service call of a user method error = %v, want PermissionDenied", err)
+	}
+
+	// Only health checks and login skip authentication
+	if err := call("", "/proto.games.Games/Create"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("Unauthenticated Create error = %v, want Unauthenticated", err)
+	}
+	if err := call("", "/auth.Auth/Login"); err != nil {
+		t.Errorf("Unauthenticated Login error = %v", err)
+	}
+
+	if err := call(userToken, "/proto.games.Games/Resign"); err != nil {
+		t.Fatalf("User call error = %v", err)
+	}
+	if userID, _ := GetUserIDFromContext(handled); userID != "alice-id" {
+		t.Errorf("User call context has user %q, want alice-id", userID)
+	}
+}
+
+func TestInterceptor_Roles(t *testing.T) {
+	keyRing := newTestKeyRing(t, AlgorithmEdDSA)
+	interceptor := NewAuthInterceptor(nil, nil)
+	interceptor.jwtManager = NewJWTManager(NewJWKSCache(&fakeJWKSClient{keyRing: keyRing}), time.Hour, 24*time.Hour)
+	jwtManager := NewJWTManager(keyRing, time.Hour, 24*time.Hour)
+
+	var handled context.Context
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		handled = ctx
+		return nil, nil
+	}
+	call := func(role, method string) error {
+		token, err := jwtManager.GenerateAccessToken("alice-id", "alice", role)
+		if err != nil {
+			t.Fatalf("Failed to generate token: %v", err)
+		}
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+		_, err = interceptor.UnaryInterceptor()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, handler)
+		return err
+	}
+
+	tests := []struct {
+		role    string
+		method  string
+		allowed bool
+	}{
+		{RolePlayer, "/proto.games.Games/Move", true},
+		{RolePlayer, "/auth.Auth/ListUsers", false},
+		{"", "/proto.games.Games/ForceEnd", false},
+		{RoleModerator, "/auth.Auth/ListUsers", true},
+		{RoleModerator, "/proto.matchmaking.Matchmaking/GetQueueLength", true},
+		{RoleModerator, "/auth.Auth/SetUserRole", false},
+		{RoleAdmin, "/auth.Auth/SetUserRole", true},
+	}
+
+	for _, tt := range tests {
+		err := call(tt.role, tt.method)
+		if tt.allowed && err != nil {
+			t.Errorf("%q calling %s error = %v, want allowed", tt.role, tt.method, err)
+		}
+		if !tt.allowed && status.Code(err) != codes.PermissionDenied {
+			t.Errorf("%q calling %s error = %v, want PermissionDenied", tt.role, tt.method, err)
+		}
+	}
+
+	if err := call(RoleModerator, "/auth.Auth/BanUser"); err != nil {
+		t.Fatalf("Moderator call error = %v", err)
+	}
+	if role := GetRoleFromContext(handled); role != RoleModerator {
+		t.Errorf("Moderator call context has role %q, want %s", role, RoleModerator)
+	}
+}
+
+func TestValidatePlayerOwnership_Bots(t *testing.T) {
+	service := context.WithValue(context.WithValue(context.Background(), "service", "matchmaking"), "scopes", []string{ScopePlayBots})
+	if err := ValidatePlayerOwnership(service, "bot-1a2b3c4d"); err != nil {
+		t.Errorf("ValidatePlayerOwnership() of a bot by a service = %v, want nil", err)
+	}
+	if err := ValidatePlayerOwnership(service, "alice-id"); status.Code(err) != codes.Unauthenticated {
+		t.Errorf("ValidatePlayerOwnership() of a user by a service = %v, want Unauthenticated", err)
+	}
+
+	user := context.WithValue(context.Background(), "user_id", "alice-id")
+	if err := ValidatePlayerOwnership(user, "bot-1a2b3c4d"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("ValidatePlayerOwnership() of a bot by a user = %v, want PermissionDenied", err)
+	}
+}
@@ -0,0 +1,165 @@
+package auth
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	authpb "github.com/laerson/mancala/proto/auth"
+)
+
+const (
+	// jwksRefreshInterval is how long fetched keys are used before they are fetched again
+	jwksRefreshInterval = 5 * time.Minute
+
+	// jwksRetryInterval is the least time between fetches, so tokens with
+	// unknown key IDs cannot flood the auth service
+	jwksRetryInterval = 30 * time.Second
+
+	// jwksFetchTimeout bounds a fetch of the keys
+	jwksFetchTimeout = 5 * time.Second
+)
+
+// EncodeJWK converts a verification key to a JSON Web Key
+func EncodeJWK(key *VerificationKey) (*authpb.JSONWebKey, error) {
+	jwk := &authpb.JSONWebKey{
+		Kid: key.ID,
+		Alg: key.Algorithm,
+		Use: "sig",
+	}
+
+	switch publicKey := key.PublicKey.(type) {
+	case ed25519.PublicKey:
+		jwk.Kty = "OKP"
+		jwk.Crv = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(publicKey)
+	case *rsa.PublicKey:
+		jwk.Kty = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(publicKey.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(publicKey.E)).Bytes())
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", key.PublicKey)
+	}
+
+	return jwk, nil
+}
+
+// DecodeJWK converts a JSON Web Key to a verification key
+func DecodeJWK(jwk *authpb.JSONWebKey) (*VerificationKey, error) {
+	key := &VerificationKey{ID: jwk.Kid, Algorithm: jwk.Alg}
+
+	switch {
+	case jwk.Kty == "OKP" && jwk.Crv == "Ed25519" && jwk.Alg == AlgorithmEdDSA:
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 key %q", jwk.Kid)
+		}
+		key.PublicKey = ed25519.PublicKey(x)
+	case jwk.Kty == "RSA" && jwk.Alg == AlgorithmRS256:
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA modulus of key %q", jwk.Kid)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, fmt.Errorf("invalid RSA exponent of key %q", jwk.Kid)
+		}
+		key.PublicKey = &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key %q of type %s for %s", jwk.Kid, jwk.Kty, jwk.Alg)
+	}
+
+	return key, nil
+}
+
+// JWKSClient is the subset of the Auth service used to fetch verification keys
+type JWKSClient interface {
+	GetJWKS(ctx context.Context, in *authpb.GetJWKSRequest, opts ...grpc.CallOption) (*authpb.GetJWKSResponse, error)
+}
+
+// JWKSCache verifies tokens with the public keys of the auth service. Keys are
+// fetched again when they are stale or a token names an unknown key, which is
+// how verifiers learn about rotated keys.
+type JWKSCache struct {
+	client JWKSClient
+
+	mu          sync.Mutex
+	keys        map[string]*VerificationKey
+	fetchedAt   time.Time
+	attemptedAt time.Time
+}
+
+// NewJWKSCache creates a cache of the keys served by the auth service
+func NewJWKSCache(client JWKSClient) *JWKSCache {
+	return &JWKSCache{
+		client: client,
+		keys:   make(map[string]*VerificationKey),
+	}
+}
+
+// SigningKey always fails, verifiers only know public keys
+func (c *JWKSCache) SigningKey() (*SigningKey, error) {
+	return nil, fmt.Errorf("only the auth service signs tokens")
+}
+
+// VerificationKey returns the key with the given ID, fetching the keys when
+// the key is unknown or the cached keys are stale
+func (c *JWKSCache) VerificationKey(keyID string) (*VerificationKey, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key, ok := c.keys[keyID]
+	due := !ok || time.Since(c.fetchedAt) >= jwksRefreshInterval
+	if due && time.Since(c.attemptedAt) >= jwksRetryInterval {
+		// Cached keys stay in use while the auth service is unreachable
+		if err := c.fetch(); err == nil {
+			key, ok = c.keys[keyID]
+		} else if !ok {
+			return nil, err
+		}
+	}
+
+	if !ok {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+	return key, nil
+}
+
+// fetch replaces the cached keys with the auth service's, the caller must hold the lock
+func (c *JWKSCache) fetch() error {
+	c.attemptedAt = time.Now()
+	if c.client == nil {
+		return fmt.Errorf("auth service unavailable")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), jwksFetchTimeout)
+	defer cancel()
+
+	resp, err := c.client.GetJWKS(ctx, &authpb.GetJWKSRequest{})
+	if err != nil {
+		return fmt.Errorf("failed to fetch signing keys: %w", err)
+	}
+
+	keys := make(map[string]*VerificationKey, len(resp.Keys))
+	for _, jwk := range resp.Keys {
+		key, err := DecodeJWK(jwk)
+		if err != nil {
+			return err
+		}
+		keys[key.ID] = key
+	}
+
+	c.keys = keys
+	c.fetchedAt = c.attemptedAt
+	return nil
+}
@@ -10,32 +10,38 @@ import (
 
 // JWTClaims represents the JWT claims
 type JWTClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
+	UserID   string   `json:"user_id"`
+	Username string   `json:"username"`
+	Role     string   `json:"role,omitempty"`
+	Service  string   `json:"service,omitempty"` // Set in service tokens instead of a user
+	Scopes   []string `json:"scopes,omitempty"`  // What a service token may do
 	jwt.RegisteredClaims
 }
 
-// JWTManager handles JWT token operations
+// JWTManager handles JWT token operations. Tokens are signed with the
+// asymmetric keys of a KeySource and name their key in the kid header, so
+// services that only hold public keys can verify tokens but not mint them.
 type JWTManager struct {
-	secretKey            []byte
+	keys                 KeySource
 	accessTokenDuration  time.Duration
 	refreshTokenDuration time.Duration
 }
 
 // NewJWTManager creates a new JWT manager
-func NewJWTManager(secretKey string, accessTokenDuration, refreshTokenDuration time.Duration) *JWTManager {
+func NewJWTManager(keys KeySource, accessTokenDuration, refreshTokenDuration time.Duration) *JWTManager {
 	return &JWTManager{
-		secretKey:            []byte(secretKey),
+		keys:                 keys,
 		accessTokenDuration:  accessTokenDuration,
 		refreshTokenDuration: refreshTokenDuration,
 	}
 }
 
-// GenerateAccessToken generates a new access token
-func (manager *JWTManager) GenerateAccessToken(userID, username string) (string, error) {
+// GenerateAccessToken generates a new access token carrying the user's role
+func (manager *JWTManager) GenerateAccessToken(userID, username, role string) (string, error) {
 	claims := JWTClaims{
 		UserID:   userID,
 		Username: username,
+		Role:     role,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(manager.accessTokenDuration)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -46,8 +52,26 @@ func (manager *JWTManager) GenerateAccessToken(userID, username string) (string,
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(manager.secretKey)
+	return manager.sign(claims)
+}
+
+// GenerateServiceToken generates an access token identifying an internal
+// service, valid for the given duration and limited to the given scopes
+func (manager *JWTManager) GenerateServiceToken(serviceID string, scopes []string, duration time.Duration) (string, error) {
+	claims := JWTClaims{
+		Service: serviceID,
+		Scopes:  scopes,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(duration)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			NotBefore: jwt.NewNumericDate(time.Now()),
+			Issuer:    "mancala-auth",
+			Subject:   "service:" + serviceID,
+			ID:        uuid.New().String(),
+		},
+	}
+
+	return manager.sign(claims)
 }
 
 // GenerateRefreshToken generates a new refresh token
@@ -61,19 +85,12 @@ func (manager *JWTManager) GenerateRefreshToken(userID string) (string, error) {
 		ID:        uuid.New().String(),
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(manager.secretKey)
+	return manager.sign(claims)
 }
 
 // ValidateAccessToken validates and parses an access token
 func (manager *JWTManager) ValidateAccessToken(accessToken string) (*JWTClaims, error) {
-	token, err := jwt.ParseWithClaims(accessToken, &JWTClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return manager.secretKey, nil
-	})
-
+	token, err := jwt.ParseWithClaims(accessToken, &JWTClaims{}, manager.verificationKey, validMethods)
 	if err != nil {
 		return nil, err
 	}
@@ -88,13 +105,7 @@ func (manager *JWTManager) ValidateAccessToken(accessToken string) (*JWTClaims,
 
 // ValidateRefreshToken validates a refresh token
 func (manager *JWTManager) ValidateRefreshToken(refreshToken string) (*jwt.RegisteredClaims, error) {
-	token, err := jwt.ParseWithClaims(refreshToken, &jwt.RegisteredClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-		return manager.secretKey, nil
-	})
-
+	token, err := jwt.ParseWithClaims(refreshToken, &jwt.RegisteredClaims{}, manager.verificationKey, validMethods)
 	if err != nil {
 		return nil, err
 	}
@@ -106,3 +117,36 @@ func (manager *JWTManager) ValidateRefreshToken(refreshToken string) (*jwt.Regis
 
 	return claims, nil
 }
+
+// validMethods rejects tokens signed with anything but the supported algorithms
+var validMethods = jwt.WithValidMethods([]string{AlgorithmEdDSA, AlgorithmRS256})
+
+// sign signs claims with the current signing key
+func (manager *JWTManager) sign(claims jwt.Claims) (string, error) {
+	key, err := manager.keys.SigningKey()
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.PrivateKey)
+}
+
+// verificationKey returns the public key of the key a token names
+func (manager *JWTManager) verificationKey(token *jwt.Token) (interface{}, error) {
+	keyID, ok := token.Header["kid"].(string)
+	if !ok || keyID == "" {
+		return nil, fmt.Errorf("token has no key ID")
+	}
+
+	key, err := manager.keys.VerificationKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if token.Method.Alg() != key.Algorithm {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+	return key.PublicKey, nil
+}
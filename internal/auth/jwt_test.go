@@ -1,17 +1,34 @@
 package auth
 
 import (
+	"context"
 	"testing"
 	"time"
+
+	"github.com/golang-jwt/jwt/v5"
 )
 
+// newTestJWTManager returns a JWT manager signing with a new Ed25519 key
+func newTestJWTManager(t *testing.T, accessTokenDuration, refreshTokenDuration time.Duration) *JWTManager {
+	return NewJWTManager(newTestKeyRing(t, AlgorithmEdDSA), accessTokenDuration, refreshTokenDuration)
+}
+
+// newTestKeyRing returns a key ring with a single key for the given algorithm
+func newTestKeyRing(t *testing.T, algorithm string) *KeyRing {
+	keyRing := NewKeyRing(NewMemoryKeyStore(), algorithm, DefaultKeyRotationInterval, 24*time.Hour)
+	if err := keyRing.Refresh(context.Background()); err != nil {
+		t.Fatalf("Failed to create signing key: %v", err)
+	}
+	return keyRing
+}
+
 func TestJWTManager_GenerateAndValidateAccessToken(t *testing.T) {
-	manager := NewJWTManager("test-secret", time.Hour, 24*time.Hour)
+	manager := newTestJWTManager(t, time.Hour, 24*time.Hour)
 	userID := "test-user-id"
 	username := "testuser"
 
 	// Generate token
-	token, err := manager.GenerateAccessToken(userID, username)
+	token, err := manager.GenerateAccessToken(userID, username, RolePlayer)
 	if err != nil {
 		t.Fatalf("Failed to generate access token: %v", err)
 	}
@@ -40,7 +57,7 @@ func TestJWTManager_GenerateAndValidateAccessToken(t *testing.T) {
 }
 
 func TestJWTManager_GenerateAndValidateRefreshToken(t *testing.T) {
-	manager := NewJWTManager("test-secret", time.Hour, 24*time.Hour)
+	manager := newTestJWTManager(t, time.Hour, 24*time.Hour)
 	userID := "test-user-id"
 
 	// Generate token
@@ -70,12 +87,12 @@ func TestJWTManager_GenerateAndValidateRefreshToken(t *testing.T) {
 
 func TestJWTManager_ExpiredToken(t *testing.T) {
 	// Create manager with very short expiration
-	manager := NewJWTManager("test-secret", -time.Hour, -time.Hour)
+	manager := newTestJWTManager(t, -time.Hour, -time.Hour)
 	userID := "test-user-id"
 	username := "testuser"
 
 	// Generate expired token
-	token, err := manager.GenerateAccessToken(userID, username)
+	token, err := manager.GenerateAccessToken(userID, username, RolePlayer)
 	if err != nil {
 		t.Fatalf("Failed to generate access token: %v", err)
 	}
@@ -88,7 +105,7 @@ func TestJWTManager_ExpiredToken(t *testing.T) {
 }
 
 func TestJWTManager_InvalidToken(t *testing.T) {
-	manager := NewJWTManager("test-secret", time.Hour, 24*time.Hour)
+	manager := newTestJWTManager(t, time.Hour, 24*time.Hour)
 
 	// Test invalid token
 	_, err := manager.ValidateAccessToken("invalid-token")
@@ -96,12 +113,46 @@ func TestJWTManager_InvalidToken(t *testing.T) {
 		t.Error("Expected error for invalid token")
 	}
 
-	// Test token with wrong secret
-	wrongManager := NewJWTManager("wrong-secret", time.Hour, 24*time.Hour)
-	token, _ := manager.GenerateAccessToken("user", "username")
+	// Test token signed with another key
+	wrongManager := newTestJWTManager(t, time.Hour, 24*time.Hour)
+	token, _ := manager.GenerateAccessToken("user", "username", RolePlayer)
 
 	_, err = wrongManager.ValidateAccessToken(token)
 	if err == nil {
-		t.Error("Expected error for token with wrong secret")
+		t.Error("Expected error for token signed with another key")
+	}
+}
+
+func TestJWTManager_RS256(t *testing.T) {
+	manager := NewJWTManager(newTestKeyRing(t, AlgorithmRS256), time.Hour, 24*time.Hour)
+
+	token, err := manager.GenerateAccessToken("test-user-id", "testuser", RolePlayer)
+	if err != nil {
+		t.Fatalf("Failed to generate access token: %v", err)
+	}
+
+	claims, err := manager.ValidateAccessToken(token)
+	if err != nil {
+		t.Fatalf("Failed to validate access token: %v", err)
+	}
+	if claims.UserID != "test-user-id" {
+		t.Errorf("Expected user ID test-user-id, got %s", claims.UserID)
+	}
+}
+
+func TestJWTManager_RejectsSharedSecretTokens(t *testing.T) {
+	manager := newTestJWTManager(t, time.Hour, 24*time.Hour)
+	key, _ := manager.keys.SigningKey()
+
+	// A token signed with HMAC under the key's ID, e.g. with the public key as the secret
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, JWTClaims{UserID: "attacker"})
+	token.Header["kid"] = key.ID
+	forged, err := token.SignedString([]byte("mancala-jwt-secret-key-change-in-production"))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	if _, err := manager.ValidateAccessToken(forged); err == nil {
+		t.Error("Expected error for an HS256 token")
 	}
 }
@@ -0,0 +1,304 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Token signing algorithms
+const (
+	AlgorithmEdDSA = "EdDSA" // Ed25519
+	AlgorithmRS256 = "RS256" // RSA with SHA-256
+)
+
+const (
+	// rsaKeyBits is the size of generated RSA keys
+	rsaKeyBits = 2048
+
+	// keyPublishDelay is how long a new key is published before it signs
+	// tokens, so every auth instance has loaded it by the time its tokens arrive
+	keyPublishDelay = 2 * time.Minute
+
+	// DefaultKeyRotationInterval is how often a new signing key is generated
+	DefaultKeyRotationInterval = 7 * 24 * time.Hour
+
+	// DefaultKeyRefreshInterval is how often the key ring reloads and rotates its keys
+	DefaultKeyRefreshInterval = time.Minute
+)
+
+// SigningKey is a private key tokens are signed with, identified in tokens by
+// its ID (the kid header)
+type SigningKey struct {
+	ID         string
+	Algorithm  string
+	PrivateKey crypto.Signer
+	CreatedAt  time.Time
+}
+
+// VerificationKey is the public half of a signing key
+type VerificationKey struct {
+	ID        string
+	Algorithm string
+	PublicKey crypto.PublicKey
+}
+
+// KeySource provides the keys tokens are signed and verified with
+type KeySource interface {
+	// SigningKey returns the key new tokens are signed with
+	SigningKey() (*SigningKey, error)
+	// VerificationKey returns the key with the given ID
+	VerificationKey(keyID string) (*VerificationKey, error)
+}
+
+// GenerateSigningKey creates a signing key for the given algorithm
+func GenerateSigningKey(algorithm string) (*SigningKey, error) {
+	var privateKey crypto.Signer
+	switch algorithm {
+	case AlgorithmEdDSA:
+		_, edKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate Ed25519 key: %w", err)
+		}
+		privateKey = edKey
+	case AlgorithmRS256:
+		rsaKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate RSA key: %w", err)
+		}
+		privateKey = rsaKey
+	default:
+		return nil, fmt.Errorf("unsupported signing algorithm: %s", algorithm)
+	}
+
+	return &SigningKey{
+		ID:         uuid.New().String(),
+		Algorithm:  algorithm,
+		PrivateKey: privateKey,
+		CreatedAt:  time.Now(),
+	}, nil
+}
+
+// VerificationKey returns the public half of the key
+func (k *SigningKey) VerificationKey() *VerificationKey {
+	return &VerificationKey{
+		ID:        k.ID,
+		Algorithm: k.Algorithm,
+		PublicKey: k.PrivateKey.Public(),
+	}
+}
+
+// MarshalPrivateKey encodes a private key as PKCS #8 PEM for storage
+func MarshalPrivateKey(privateKey crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})), nil
+}
+
+// ParsePrivateKey decodes a private key encoded by MarshalPrivateKey
+func ParsePrivateKey(encoded string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(encoded))
+	if block == nil {
+		return nil, fmt.Errorf("invalid private key PEM")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	return signer, nil
+}
+
+// KeyStore persists signing keys, shared by every auth service instance
+type KeyStore interface {
+	// SaveSigningKey stores a new signing key
+	SaveSigningKey(ctx context.Context, key *SigningKey) error
+	// GetSigningKeys returns every stored signing key, oldest first
+	GetSigningKeys(ctx context.Context) ([]*SigningKey, error)
+	// DeleteSigningKey removes a signing key
+	DeleteSigningKey(ctx context.Context, keyID string) error
+}
+
+// KeyRing holds the auth service's signing keys. A new key is generated every
+// rotation interval, and older keys stay published until every token they
+// signed has expired.
+type KeyRing struct {
+	store            KeyStore
+	algorithm        string
+	rotationInterval time.Duration
+	tokenLifetime    time.Duration // Longest lifetime of a signed token
+
+	mu   sync.RWMutex
+	keys []*SigningKey // Oldest first
+}
+
+// NewKeyRing creates a key ring generating keys for the given algorithm
+func NewKeyRing(store KeyStore, algorithm string, rotationInterval, tokenLifetime time.Duration) *KeyRing {
+	return &KeyRing{
+		store:            store,
+		algorithm:        algorithm,
+		rotationInterval: rotationInterval,
+		tokenLifetime:    tokenLifetime,
+	}
+}
+
+// Refresh reloads the stored keys, generates a key when the newest one is due
+// for rotation and deletes keys whose tokens have all expired
+func (r *KeyRing) Refresh(ctx context.Context) error {
+	keys, err := r.store.GetSigningKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
+	now := time.Now()
+	if len(keys) == 0 || now.Sub(keys[len(keys)-1].CreatedAt) >= r.rotationInterval {
+		key, err := GenerateSigningKey(r.algorithm)
+		if err != nil {
+			return err
+		}
+		if err := r.store.SaveSigningKey(ctx, key); err != nil {
+			return fmt.Errorf("failed to save signing key: %w", err)
+		}
+		log.Printf("Generated %s signing key %s", key.Algorithm, key.ID)
+		keys = append(keys, key)
+	}
+
+	// A key stops signing once the next one is published
+	live := make([]*SigningKey, 0, len(keys))
+	for i, key := range keys {
+		if i+1 < len(keys) && now.After(keys[i+1].CreatedAt.Add(keyPublishDelay+r.tokenLifetime)) {
+			if err := r.store.DeleteSigningKey(ctx, key.ID); err != nil {
+				log.Printf("Failed to delete expired signing key %s: %v", key.ID, err)
+			} else {
+				log.Printf("Deleted expired signing key %s", key.ID)
+				continue
+			}
+		}
+		live = append(live, key)
+	}
+
+	r.mu.Lock()
+	r.keys = live
+	r.mu.Unlock()
+
+	return nil
+}
+
+// Run refreshes the keys at the given interval until the context is done
+func (r *KeyRing) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Refresh(ctx); err != nil {
+				log.Printf("Failed to refresh signing keys: %v", err)
+			}
+		}
+	}
+}
+
+// SigningKey returns the newest published key, or the first key while it is the only one
+func (r *KeyRing) SigningKey() (*SigningKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if len(r.keys) == 0 {
+		return nil, fmt.Errorf("no signing keys loaded")
+	}
+
+	publishedBefore := time.Now().Add(-keyPublishDelay)
+	for i := len(r.keys) - 1; i > 0; i-- {
+		if !r.keys[i].CreatedAt.After(publishedBefore) {
+			return r.keys[i], nil
+		}
+	}
+	return r.keys[0], nil
+}
+
+// VerificationKey returns the key with the given ID
+func (r *KeyRing) VerificationKey(keyID string) (*VerificationKey, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, key := range r.keys {
+		if key.ID == keyID {
+			return key.VerificationKey(), nil
+		}
+	}
+	return nil, fmt.Errorf("unknown signing key %q", keyID)
+}
+
+// VerificationKeys returns the public keys of every key in the ring
+func (r *KeyRing) VerificationKeys() []*VerificationKey {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	keys := make([]*VerificationKey, len(r.keys))
+	for i, key := range r.keys {
+		keys[i] = key.VerificationKey()
+	}
+	return keys
+}
+
+// MemoryKeyStore keeps signing keys in memory, for tests and single instance setups
+type MemoryKeyStore struct {
+	mu   sync.Mutex
+	keys []*SigningKey
+}
+
+// NewMemoryKeyStore creates an in-memory key store
+func NewMemoryKeyStore() *MemoryKeyStore {
+	return &MemoryKeyStore{}
+}
+
+// SaveSigningKey stores a new signing key
+func (m *MemoryKeyStore) SaveSigningKey(ctx context.Context, key *SigningKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.keys = append(m.keys, key)
+	return nil
+}
+
+// GetSigningKeys returns every stored signing key, oldest first
+func (m *MemoryKeyStore) GetSigningKeys(ctx context.Context) ([]*SigningKey, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return append([]*SigningKey(nil), m.keys...), nil
+}
+
+// DeleteSigningKey removes a signing key
+func (m *MemoryKeyStore) DeleteSigningKey(ctx context.Context, keyID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i, key := range m.keys {
+		if key.ID == keyID {
+			m.keys = append(m.keys[:i], m.keys[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	authpb "github.com/laerson/mancala/proto/auth"
+)
+
+// fakeJWKSClient serves the keys of a key ring like the auth service does
+type fakeJWKSClient struct {
+	keyRing *KeyRing
+	fetches int
+}
+
+func (f *fakeJWKSClient) GetJWKS(ctx context.Context, req *authpb.GetJWKSRequest, opts ...grpc.CallOption) (*authpb.GetJWKSResponse, error) {
+	f.fetches++
+	server := &Server{keyRing: f.keyRing}
+	return server.GetJWKS(ctx, req)
+}
+
+// age moves the creation of a stored key back in time
+func age(t *testing.T, store *MemoryKeyStore, index int, by time.Duration) *SigningKey {
+	keys, _ := store.GetSigningKeys(context.Background())
+	if index >= len(keys) {
+		t.Fatalf("Key store has %d keys, want at least %d", len(keys), index+1)
+	}
+	keys[index].CreatedAt = keys[index].CreatedAt.Add(-by)
+	return keys[index]
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemoryKeyStore()
+	tokenLifetime := 24 * time.Hour
+	keyRing := NewKeyRing(store, AlgorithmEdDSA, 7*24*time.Hour, tokenLifetime)
+	manager := NewJWTManager(keyRing, time.Hour, tokenLifetime)
+
+	if err := keyRing.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	oldToken, _ := manager.GenerateAccessToken("alice-id", "alice", RolePlayer)
+
+	// The first key is due for rotation
+	oldKey := age(t, store, 0, 7*24*time.Hour)
+	if err := keyRing.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if got := len(keyRing.VerificationKeys()); got != 2 {
+		t.Fatalf("VerificationKeys() after rotation has %d keys, want 2", got)
+	}
+
+	// The new key is published before it signs
+	if key, _ := keyRing.SigningKey(); key.ID != oldKey.ID {
+		t.Errorf("SigningKey() right after rotation = %s, want the old key %s", key.ID, oldKey.ID)
+	}
+	newKey := age(t, store, 1, keyPublishDelay)
+	if key, _ := keyRing.SigningKey(); key.ID != newKey.ID {
+		t.Errorf("SigningKey() once published = %s, want the new key %s", key.ID, newKey.ID)
+	}
+
+	// Tokens of the old key stay valid until they expire
+	if _, err := manager.ValidateAccessToken(oldToken); err != nil {
+		t.Errorf("ValidateAccessToken() of a token signed before the rotation error = %v", err)
+	}
+
+	// Every token of the old key has expired
+	age(t, store, 1, tokenLifetime)
+	if err := keyRing.Refresh(ctx); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	if _, err := keyRing.VerificationKey(oldKey.ID); err == nil {
+		t.Error("VerificationKey() of an expired key succeeded")
+	}
+}
+
+func TestJWKSCache(t *testing.T) {
+	for _, algorithm := range []string{AlgorithmEdDSA, AlgorithmRS256} {
+		t.Run(algorithm, func(t *testing.T) {
+			keyRing := newTestKeyRing(t, algorithm)
+			client := &fakeJWKSClient{keyRing: keyRing}
+			signer := NewJWTManager(keyRing, time.Hour, 24*time.Hour)
+			verifier := NewJWTManager(NewJWKSCache(client), time.Hour, 24*time.Hour)
+
+			token, _ := signer.GenerateAccessToken("alice-id", "alice", RolePlayer)
+			claims, err := verifier.ValidateAccessToken(token)
+			if err != nil || claims.UserID != "alice-id" {
+				t.Fatalf("ValidateAccessToken() = %v, %v, want alice-id", claims, err)
+			}
+
+			if _, err := verifier.GenerateAccessToken("alice-id", "alice", RolePlayer); err == nil {
+				t.Error("GenerateAccessToken() with public keys only succeeded")
+			}
+
+			// Unknown keys are not fetched again right away
+			if _, err := verifier.ValidateAccessToken(mustToken(t, newTestJWTManager(t, time.Hour, time.Hour))); err == nil {
+				t.Error("ValidateAccessToken() of a token signed with an unknown key succeeded")
+			}
+			if _, err := verifier.ValidateAccessToken(token); err != nil {
+				t.Errorf("ValidateAccessToken() error = %v", err)
+			}
+			if client.fetches != 1 {
+				t.Errorf("JWKS fetched %d times, want 1", client.fetches)
+			}
+		})
+	}
+}
+
+// mustToken returns an access token of the given manager
+func mustToken(t *testing.T, manager *JWTManager) string {
+	token, err := manager.GenerateAccessToken("alice-id", "alice", RolePlayer)
+	if err != nil {
+		t.Fatalf("Failed to generate access token: %v", err)
+	}
+	return token
+}
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"strings"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
@@ -14,6 +15,9 @@ type User struct {
 	PasswordHash string    `json:"password_hash" redis:"password_hash"`
 	CreatedAt    time.Time `json:"created_at" redis:"created_at"`
 	LastLogin    time.Time `json:"last_login" redis:"last_login"`
+	Role         string    `json:"role" redis:"role"`
+	Banned       bool      `json:"banned" redis:"banned"`
+	BanReason    string    `json:"ban_reason" redis:"ban_reason"`
 }
 
 // RefreshToken represents a refresh token in the database
@@ -60,3 +64,18 @@ func IsValidUsername(username string) bool {
 func IsValidPassword(password string) bool {
 	return len(password) >= 8
 }
+
+// IsBotID checks if an ID has the form of the player IDs of bots, "bot-" and 8 hex digits
+func IsBotID(id string) bool {
+	if len(id) != 12 || !strings.HasPrefix(id, "bot-") {
+		return false
+	}
+
+	for _, char := range id[4:] {
+		if !((char >= '0' && char <= '9') || (char >= 'a' && char <= 'f')) {
+			return false
+		}
+	}
+
+	return true
+}
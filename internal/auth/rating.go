@@ -0,0 +1,48 @@
+package auth
+
+import "math"
+
+const (
+	// DefaultRating is the Elo rating of a user who has not played a rated game
+	DefaultRating = 1500
+
+	// provisionalGames is how many rated games a rating moves faster for
+	provisionalGames = 20
+
+	provisionalKFactor = 40
+	establishedKFactor = 20
+)
+
+// Rating represents the Elo rating of a user
+type Rating struct {
+	UserID      string  `json:"user_id"`
+	Rating      float64 `json:"rating"`
+	GamesPlayed int     `json:"games_played"`
+}
+
+// ExpectedScore returns the score a player is expected to make against the opponent,
+// from 0 for a certain loss to 1 for a certain win
+func ExpectedScore(rating, opponentRating float64) float64 {
+	return 1 / (1 + math.Pow(10, (opponentRating-rating)/400))
+}
+
+// UpdateRatings applies the result of a game to both players' ratings. The score is
+// player one's: 1 for a win, 0.5 for a draw and 0 for a loss.
+func UpdateRatings(player1, player2 Rating, score float64) (Rating, Rating) {
+	expected := ExpectedScore(player1.Rating, player2.Rating)
+
+	player1.Rating += kFactor(player1.GamesPlayed) * (score - expected)
+	player2.Rating += kFactor(player2.GamesPlayed) * (expected - score)
+	player1.GamesPlayed++
+	player2.GamesPlayed++
+
+	return player1, player2
+}
+
+// kFactor returns how far a single game moves the rating. New players converge faster.
+func kFactor(gamesPlayed int) float64 {
+	if gamesPlayed < provisionalGames {
+		return provisionalKFactor
+	}
+	return establishedKFactor
+}
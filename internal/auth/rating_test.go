@@ -0,0 +1,121 @@
+package auth
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/laerson/mancala/internal/events"
+)
+
+func TestUpdateRatings(t *testing.T) {
+	tests := []struct {
+		name        string
+		player1     Rating
+		player2     Rating
+		score       float64
+		wantPlayer1 float64
+		wantPlayer2 float64
+	}{
+		{
+			name:        "new players, player one wins",
+			player1:     Rating{Rating: 1500},
+			player2:     Rating{Rating: 1500},
+			score:       1,
+			wantPlayer1: 1520,
+			wantPlayer2: 1480,
+		},
+		{
+			name:        "equal players draw",
+			player1:     Rating{Rating: 1500},
+			player2:     Rating{Rating: 1500},
+			score:       0.5,
+			wantPlayer1: 1500,
+			wantPlayer2: 1500,
+		},
+		{
+			name:        "established players move slower",
+			player1:     Rating{Rating: 1500, GamesPlayed: 50},
+			player2:     Rating{Rating: 1500, GamesPlayed: 50},
+			score:       0,
+			wantPlayer1: 1490,
+			wantPlayer2: 1510,
+		},
+		{
+			name:        "upset win gains more",
+			player1:     Rating{Rating: 1300, GamesPlayed: 50},
+			player2:     Rating{Rating: 1700, GamesPlayed: 50},
+			score:       1,
+			wantPlayer1: 1318.18,
+			wantPlayer2: 1681.82,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			player1, player2 := UpdateRatings(tt.player1, tt.player2, tt.score)
+			if math.Abs(player1.Rating-tt.wantPlayer1) > 0.01 {
+				t.Errorf("UpdateRatings() player1 = %.2f, want %.2f", player1.Rating, tt.wantPlayer1)
+			}
+			if math.Abs(player2.Rating-tt.wantPlayer2) > 0.01 {
+				t.Errorf("UpdateRatings() player2 = %.2f, want %.2f", player2.Rating, tt.wantPlayer2)
+			}
+			if player1.GamesPlayed != tt.player1.GamesPlayed+1 || player2.GamesPlayed != tt.player2.GamesPlayed+1 {
+				t.Errorf("UpdateRatings() games played = %d, %d, want one more each", player1.GamesPlayed, player2.GamesPlayed)
+			}
+		})
+	}
+}
+
+func TestRatingUpdater_HandleGameOver(t *testing.T) {
+	storage := newMockStorage()
+	storage.users["alice"] = &User{UserID: "alice"}
+	storage.users["bob"] = &User{UserID: "bob"}
+	updater := &RatingUpdater{storage: storage}
+
+	gameOver := func(gameID, winnerID string, isDraw bool, reason string) events.Event {
+		return events.Event{
+			Type:   events.EventTypeGameOver,
+			GameID: gameID,
+			Data: map[string]interface{}{
+				"player1_id": "alice",
+				"player2_id": "bob",
+				"winner_id":  winnerID,
+				"is_draw":    isDraw,
+				"reason":     reason,
+			},
+		}
+	}
+
+	ctx := context.Background()
+	for _, event := range []events.Event{
+		gameOver("game1", "alice", false, events.GameOverReasonCompleted),
+		gameOver("game1", "alice", false, events.GameOverReasonCompleted), // redelivered
+		gameOver("game2", "", false, events.GameOverReasonAborted),
+		gameOver("game3", "", true, events.GameOverReasonDrawAgreed),
+	} {
+		if err := updater.handleGameOver(ctx, event); err != nil {
+			t.Fatalf("handleGameOver() error = %v", err)
+		}
+	}
+
+	alice := storage.rating("alice")
+	if alice.GamesPlayed != 2 {
+		t.Errorf("handleGameOver() rated %d games, want 2", alice.GamesPlayed)
+	}
+	if alice.Rating <= DefaultRating || storage.rating("bob").Rating >= DefaultRating {
+		t.Errorf("handleGameOver() ratings = %.2f, %.2f, want the winner above the loser", alice.Rating, storage.rating("bob").Rating)
+	}
+
+	// Games against bots are skipped by the storage
+	if err := updater.handleGameOver(ctx, events.Event{
+		Type:   events.EventTypeGameOver,
+		GameID: "game4",
+		Data:   map[string]interface{}{"player1_id": "alice", "player2_id": "bot-1", "winner_id": "alice"},
+	}); err != nil {
+		t.Fatalf("handleGameOver() error = %v", err)
+	}
+	if storage.rating("alice").GamesPlayed != 2 {
+		t.Error("handleGameOver() rated a game against a bot")
+	}
+}
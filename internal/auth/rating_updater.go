@@ -0,0 +1,84 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/laerson/mancala/internal/events"
+)
+
+// RatingUpdater consumes GAME_OVER events and updates the players' ratings
+type RatingUpdater struct {
+	consumer *events.Consumer
+	storage  StorageInterface
+}
+
+// NewRatingUpdater creates a new rating updater
+func NewRatingUpdater(redisAddr string, storage StorageInterface) *RatingUpdater {
+	u := &RatingUpdater{storage: storage}
+	u.consumer = events.NewConsumer(redisAddr, "ratings", u.handleEvent)
+	return u
+}
+
+// Start begins consuming game events from Redis streams
+func (u *RatingUpdater) Start() error {
+	return u.consumer.Start()
+}
+
+// Stop stops the rating updater
+func (u *RatingUpdater) Stop() {
+	u.consumer.Stop()
+}
+
+// handleEvent rates the game of a GAME_OVER event
+func (u *RatingUpdater) handleEvent(ctx context.Context, event events.Event) error {
+	if event.Type != events.EventTypeGameOver {
+		return nil
+	}
+	return u.handleGameOver(ctx, event)
+}
+
+// handleGameOver rates a finished game
+func (u *RatingUpdater) handleGameOver(ctx context.Context, event events.Event) error {
+	var data events.GameOverData
+	bytes, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return fmt.Errorf("failed to parse game over data: %w", err)
+	}
+
+	score, ok := gameScore(data)
+	if !ok {
+		return nil
+	}
+
+	rated, err := u.storage.RecordGameResult(ctx, event.GameID, data.Player1ID, data.Player2ID, score)
+	if err != nil {
+		return err
+	}
+	if rated {
+		log.Printf("Rated game %s between %s and %s", event.GameID, data.Player1ID, data.Player2ID)
+	}
+
+	return nil
+}
+
+// gameScore returns player one's score in a finished game. Aborted games are not rated.
+func gameScore(data events.GameOverData) (float64, bool) {
+	switch {
+	case data.Reason == events.GameOverReasonAborted:
+		return 0, false
+	case data.IsDraw:
+		return 0.5, true
+	case data.WinnerID == data.Player1ID && data.WinnerID != "":
+		return 1, true
+	case data.WinnerID == data.Player2ID && data.WinnerID != "":
+		return 0, true
+	default:
+		return 0, false
+	}
+}
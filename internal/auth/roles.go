@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"context"
+)
+
+// User roles. Each role may do everything the roles before it may.
+const (
+	RolePlayer    = "player"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// roleRanks orders the roles by privilege
+var roleRanks = map[string]int{
+	RolePlayer:    1,
+	RoleModerator: 2,
+	RoleAdmin:     3,
+}
+
+// methodRoles lists the methods only staff may call, with the least role each
+// needs. Every other method is open to any authenticated user.
+var methodRoles = map[string]string{
+	"/auth.Auth/ListUsers":   RoleModerator,
+	"/auth.Auth/BanUser":     RoleModerator,
+	"/auth.Auth/UnbanUser":   RoleModerator,
+	"/auth.Auth/SetUserRole": RoleAdmin,
+
+	"/proto.games.Games/ForceEnd": RoleModerator,
+
+	"/proto.matchmaking.Matchmaking/RemoveFromQueue": RoleModerator,
+	"/proto.matchmaking.Matchmaking/GetQueueLength":  RoleModerator,
+}
+
+// IsValidRole checks if role is one of the user roles
+func IsValidRole(role string) bool {
+	_, ok := roleRanks[role]
+	return ok
+}
+
+// RoleAtLeast reports whether role is the required role or a more privileged one
+func RoleAtLeast(role, required string) bool {
+	return roleRanks[role] >= roleRanks[required] && IsValidRole(role)
+}
+
+// GetRoleFromContext extracts the role of the authenticated user from context
+func GetRoleFromContext(ctx context.Context) string {
+	role, ok := ctx.Value("role").(string)
+	if !ok || role == "" {
+		return RolePlayer
+	}
+	return role
+}
@@ -2,37 +2,80 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"log"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/laerson/mancala/internal/events"
 	authpb "github.com/laerson/mancala/proto/auth"
 )
 
 // Server implements the Auth service
 type Server struct {
 	authpb.UnimplementedAuthServer
-	storage    StorageInterface
-	jwtManager *JWTManager
+	storage        StorageInterface
+	jwtManager     *JWTManager
+	keyRing        *KeyRing
+	denylist       Denylist
+	ratingUpdater  *RatingUpdater
+	serviceSecrets map[string]string // Service ID to secret, see ParseServiceCredentials
+	loginThrottle  LoginThrottle
+	securityEvents SecurityEventPublisher
 }
 
-// NewServer creates a new auth server
-func NewServer(dbURL, redisAddr, jwtSecret string) (*Server, error) {
+// NewServer creates a new auth server signing tokens with keys for the given
+// algorithm, rotated at the given interval. Service tokens are issued to the
+// internal services whose secrets are given.
+func NewServer(dbURL, redisAddr, signingAlgorithm string, keyRotationInterval time.Duration, serviceSecrets map[string]string) (*Server, error) {
 	storage, err := NewStorage(dbURL, redisAddr)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create storage: %w", err)
 	}
 
+	// Keys verify tokens until the longest lived ones, refresh tokens, expire
+	refreshTokenDuration := 7 * 24 * time.Hour
+	keyRing := NewKeyRing(storage, signingAlgorithm, keyRotationInterval, refreshTokenDuration)
+	if err := keyRing.Refresh(context.Background()); err != nil {
+		return nil, fmt.Errorf("failed to load signing keys: %w", err)
+	}
+
 	return &Server{
-		storage:    storage,
-		jwtManager: NewJWTManager(jwtSecret, 24*time.Hour, 7*24*time.Hour), // 1 day access, 7 days refresh
+		storage:        storage,
+		jwtManager:     NewJWTManager(keyRing, 24*time.Hour, refreshTokenDuration), // 1 day access, 7 days refresh
+		keyRing:        keyRing,
+		denylist:       NewRedisDenylist(redisAddr),
+		ratingUpdater:  NewRatingUpdater(redisAddr, storage),
+		serviceSecrets: serviceSecrets,
+		loginThrottle:  NewRedisLoginThrottle(redisAddr),
+		securityEvents: events.NewEventPublisher(redisAddr),
 	}, nil
 }
 
+// AuthInterceptor returns the interceptor authenticating calls to the auth
+// service itself, verifying tokens with its own keys
+func (s *Server) AuthInterceptor() *Interceptor {
+	return &Interceptor{
+		jwtManager: s.jwtManager,
+		denylist:   s.denylist,
+	}
+}
+
+// KeyRing returns the keys tokens are signed with, to be refreshed with KeyRing().Run
+func (s *Server) KeyRing() *KeyRing {
+	return s.keyRing
+}
+
+// RatingUpdater returns the consumer that rates finished games
+func (s *Server) RatingUpdater() *RatingUpdater {
+	return s.ratingUpdater
+}
+
 // Register creates a new user account
 func (s *Server) Register(ctx context.Context, req *authpb.RegisterRequest) (*authpb.RegisterResponse, error) {
 	// Validate input
@@ -71,6 +114,7 @@ func (s *Server) Register(ctx context.Context, req *authpb.RegisterRequest) (*au
 		PasswordHash: passwordHash,
 		CreatedAt:    time.Now(),
 		LastLogin:    time.Now(),
+		Role:         RolePlayer,
 	}
 
 	// Store user
@@ -87,11 +131,12 @@ func (s *Server) Register(ctx context.Context, req *authpb.RegisterRequest) (*au
 	}
 
 	// Generate tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.UserID, user.Username)
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.UserID, user.Username, user.Role)
 	if err != nil {
 		log.Printf("Failed to generate access token: %v", err)
 		return nil, status.Errorf(codes.Internal, "Failed to generate tokens")
 	}
+	s.trackAccessToken(ctx, accessToken)
 
 	refreshTokenString, err := s.jwtManager.GenerateRefreshToken(user.UserID)
 	if err != nil {
@@ -127,20 +172,45 @@ func (s *Server) Register(ctx context.Context, req *authpb.RegisterRequest) (*au
 
 // Login authenticates a user and returns JWT tokens
 func (s *Server) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.LoginResponse, error) {
+	ip := s.clientIP(ctx)
+
+	// Refuse throttled attempts before spending a bcrypt check on them
+	if s.loginThrottle != nil {
+		retryAfter, err := s.loginThrottle.RetryAfter(ctx, req.Username, ip)
+		if err != nil {
+			log.Printf("Failed to check login throttle: %v", err)
+			// Continue anyway
+		} else if retryAfter > 0 {
+			return &authpb.LoginResponse{
+				Success:           false,
+				Message:           fmt.Sprintf("Too many failed login attempts, try again in %s", retryAfter.Round(time.Second)),
+				RetryAfterSeconds: retryAfterSeconds(retryAfter),
+			}, nil
+		}
+	}
+
 	// Get user by username
 	user, err := s.storage.GetUserByUsername(ctx, req.Username)
 	if err != nil {
-		return &authpb.LoginResponse{
-			Success: false,
-			Message: "Invalid credentials",
-		}, nil
+		return s.loginFailed(ctx, req.Username, ip, nil), nil
 	}
 
 	// Check password
 	if !CheckPassword(req.Password, user.PasswordHash) {
+		return s.loginFailed(ctx, req.Username, ip, user), nil
+	}
+
+	if s.loginThrottle != nil {
+		if err := s.loginThrottle.Reset(ctx, req.Username); err != nil {
+			log.Printf("Failed to reset failed logins of %s: %v", user.Username, err)
+		}
+	}
+
+	// Only tell banned users once they proved who they are
+	if user.Banned {
 		return &authpb.LoginResponse{
 			Success: false,
-			Message: "Invalid credentials",
+			Message: bannedMessage(user),
 		}, nil
 	}
 
@@ -152,11 +222,12 @@ func (s *Server) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.L
 	}
 
 	// Generate tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.UserID, user.Username)
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.UserID, user.Username, user.Role)
 	if err != nil {
 		log.Printf("Failed to generate access token: %v", err)
 		return nil, status.Errorf(codes.Internal, "Failed to generate tokens")
 	}
+	s.trackAccessToken(ctx, accessToken)
 
 	refreshTokenString, err := s.jwtManager.GenerateRefreshToken(user.UserID)
 	if err != nil {
@@ -190,6 +261,53 @@ func (s *Server) Login(ctx context.Context, req *authpb.LoginRequest) (*authpb.L
 	}, nil
 }
 
+// loginFailed counts a failed login and returns its response. Unknown
+// usernames count too, so lockouts don't reveal which accounts exist.
+func (s *Server) loginFailed(ctx context.Context, username, ip string, user *User) *authpb.LoginResponse {
+	response := &authpb.LoginResponse{
+		Success: false,
+		Message: "Invalid credentials",
+	}
+	if s.loginThrottle == nil {
+		return response
+	}
+
+	failure, err := s.loginThrottle.RecordFailure(ctx, username, ip)
+	if err != nil {
+		log.Printf("Failed to record failed login: %v", err)
+		return response
+	}
+	response.RetryAfterSeconds = retryAfterSeconds(failure.RetryAfter)
+
+	for _, lockout := range failure.Lockouts {
+		data := events.AccountLockedData{
+			Scope:       lockout.Scope,
+			Username:    username,
+			IP:          ip,
+			Failures:    lockout.Failures,
+			LockedUntil: lockout.LockedUntil.Unix(),
+		}
+		if user != nil {
+			data.UserID = user.UserID
+		}
+
+		log.Printf("Security: logins locked by %s until %s after %d failures (username %q, ip %s)",
+			lockout.Scope, lockout.LockedUntil.Format(time.RFC3339), lockout.Failures, username, ip)
+		if s.securityEvents != nil {
+			if err := s.securityEvents.PublishAccountLocked(ctx, data); err != nil {
+				log.Printf("Failed to publish account locked event: %v", err)
+			}
+		}
+	}
+
+	return response
+}
+
+// retryAfterSeconds rounds a wait up to whole seconds
+func retryAfterSeconds(wait time.Duration) int64 {
+	return int64((wait + time.Second - 1) / time.Second)
+}
+
 // ValidateToken validates a JWT access token
 func (s *Server) ValidateToken(ctx context.Context, req *authpb.ValidateTokenRequest) (*authpb.ValidateTokenResponse, error) {
 	claims, err := s.jwtManager.ValidateAccessToken(req.AccessToken)
@@ -200,6 +318,13 @@ func (s *Server) ValidateToken(ctx context.Context, req *authpb.ValidateTokenReq
 		}, nil
 	}
 
+	if s.isRevoked(ctx, claims) {
+		return &authpb.ValidateTokenResponse{
+			Valid:   false,
+			Message: "Token has been revoked",
+		}, nil
+	}
+
 	// Get user info
 	user, err := s.storage.GetUserByID(ctx, claims.UserID)
 	if err != nil {
@@ -209,6 +334,13 @@ func (s *Server) ValidateToken(ctx context.Context, req *authpb.ValidateTokenReq
 		}, nil
 	}
 
+	if user.Banned {
+		return &authpb.ValidateTokenResponse{
+			Valid:   false,
+			Message: bannedMessage(user),
+		}, nil
+	}
+
 	return &authpb.ValidateTokenResponse{
 		Valid:     true,
 		Message:   "Token is valid",
@@ -246,12 +378,20 @@ func (s *Server) RefreshToken(ctx context.Context, req *authpb.RefreshTokenReque
 		}, nil
 	}
 
+	if user.Banned {
+		return &authpb.RefreshTokenResponse{
+			Success: false,
+			Message: bannedMessage(user),
+		}, nil
+	}
+
 	// Generate new tokens
-	accessToken, err := s.jwtManager.GenerateAccessToken(user.UserID, user.Username)
+	accessToken, err := s.jwtManager.GenerateAccessToken(user.UserID, user.Username, user.Role)
 	if err != nil {
 		log.Printf("Failed to generate access token: %v", err)
 		return nil, status.Errorf(codes.Internal, "Failed to generate tokens")
 	}
+	s.trackAccessToken(ctx, accessToken)
 
 	newRefreshTokenString, err := s.jwtManager.GenerateRefreshToken(user.UserID)
 	if err != nil {
@@ -289,9 +429,82 @@ func (s *Server) RefreshToken(ctx context.Context, req *authpb.RefreshTokenReque
 	}, nil
 }
 
-// GetProfile retrieves user profile information
+// Logout revokes an access token until it expires and deletes the refresh token issued with it
+func (s *Server) Logout(ctx context.Context, req *authpb.LogoutRequest) (*authpb.LogoutResponse, error) {
+	claims, err := s.jwtManager.ValidateAccessToken(req.AccessToken)
+	if err != nil {
+		return &authpb.LogoutResponse{
+			Success: false,
+			Message: "Invalid or expired token",
+		}, nil
+	}
+
+	if err := s.revokeAccessToken(ctx, claims); err != nil {
+		log.Printf("Failed to revoke access token: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to revoke token")
+	}
+
+	// Only the user's own refresh token can be deleted
+	if req.RefreshToken != "" {
+		refreshToken, err := s.storage.GetRefreshToken(ctx, req.RefreshToken)
+		if err == nil && refreshToken.UserID == claims.UserID {
+			if err := s.storage.DeleteRefreshToken(ctx, req.RefreshToken); err != nil {
+				log.Printf("Failed to delete refresh token: %v", err)
+				return nil, status.Errorf(codes.Internal, "Failed to revoke token")
+			}
+		}
+	}
+
+	log.Printf("User logged out: %s (%s)", claims.Username, claims.UserID)
+
+	return &authpb.LogoutResponse{
+		Success: true,
+		Message: "Logged out successfully",
+	}, nil
+}
+
+// LogoutAll revokes every access token and deletes every refresh token of the token's user
+func (s *Server) LogoutAll(ctx context.Context, req *authpb.LogoutAllRequest) (*authpb.LogoutAllResponse, error) {
+	claims, err := s.jwtManager.ValidateAccessToken(req.AccessToken)
+	if err != nil || s.isRevoked(ctx, claims) {
+		return &authpb.LogoutAllResponse{
+			Success: false,
+			Message: "Invalid or expired token",
+		}, nil
+	}
+
+	revokedAccessTokens, revokedRefreshTokens, err := s.revokeUserTokens(ctx, claims.UserID)
+	if err != nil {
+		log.Printf("Failed to revoke tokens: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to revoke tokens")
+	}
+
+	// The token used for the request is revoked even when it was never tracked
+	if err := s.revokeAccessToken(ctx, claims); err != nil {
+		log.Printf("Failed to revoke access token: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to revoke tokens")
+	}
+
+	log.Printf("User logged out everywhere: %s (%s), %d access and %d refresh tokens revoked",
+		claims.Username, claims.UserID, revokedAccessTokens, revokedRefreshTokens)
+
+	return &authpb.LogoutAllResponse{
+		Success:              true,
+		Message:              "Logged out of all sessions",
+		RevokedAccessTokens:  int32(revokedAccessTokens),
+		RevokedRefreshTokens: int32(revokedRefreshTokens),
+	}, nil
+}
+
+// GetProfile retrieves user profile information by user ID, or by username when no ID is given
 func (s *Server) GetProfile(ctx context.Context, req *authpb.GetProfileRequest) (*authpb.GetProfileResponse, error) {
-	user, err := s.storage.GetUserByID(ctx, req.UserId)
+	var user *User
+	var err error
+	if req.UserId == "" && req.Username != "" {
+		user, err = s.storage.GetUserByUsername(ctx, req.Username)
+	} else {
+		user, err = s.storage.GetUserByID(ctx, req.UserId)
+	}
 	if err != nil {
 		return &authpb.GetProfileResponse{
 			Success: false,
@@ -306,6 +519,129 @@ func (s *Server) GetProfile(ctx context.Context, req *authpb.GetProfileRequest)
 	}, nil
 }
 
+// GetRatings retrieves the ratings of several users. Users without a rated game have the default rating.
+func (s *Server) GetRatings(ctx context.Context, req *authpb.GetRatingsRequest) (*authpb.GetRatingsResponse, error) {
+	ratings, err := s.storage.GetRatings(ctx, req.UserIds)
+	if err != nil {
+		log.Printf("Failed to get ratings: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to get ratings")
+	}
+
+	response := &authpb.GetRatingsResponse{}
+	for _, rating := range ratings {
+		response.Ratings = append(response.Ratings, &authpb.PlayerRating{
+			UserId:      rating.UserID,
+			Rating:      int32(math.Round(rating.Rating)),
+			GamesPlayed: int32(rating.GamesPlayed),
+		})
+	}
+
+	return response, nil
+}
+
+// GetJWKS returns the public keys of every signing key whose tokens may still be valid
+func (s *Server) GetJWKS(ctx context.Context, req *authpb.GetJWKSRequest) (*authpb.GetJWKSResponse, error) {
+	response := &authpb.GetJWKSResponse{}
+	for _, key := range s.keyRing.VerificationKeys() {
+		jwk, err := EncodeJWK(key)
+		if err != nil {
+			log.Printf("Failed to encode signing key %s: %v", key.ID, err)
+			return nil, status.Errorf(codes.Internal, "Failed to get signing keys")
+		}
+		response.Keys = append(response.Keys, jwk)
+	}
+
+	return response, nil
+}
+
+// IssueServiceToken exchanges an internal service's credentials for a
+// short-lived token carrying the service's scopes
+func (s *Server) IssueServiceToken(ctx context.Context, req *authpb.IssueServiceTokenRequest) (*authpb.IssueServiceTokenResponse, error) {
+	secret, known := s.serviceSecrets[req.ServiceId]
+	scopes := servicePermissions[req.ServiceId]
+	if !known || len(scopes) == 0 || subtle.ConstantTimeCompare([]byte(secret), []byte(req.ServiceSecret)) != 1 {
+		log.Printf("Refused service token for %q", req.ServiceId)
+		return &authpb.IssueServiceTokenResponse{
+			Success: false,
+			Message: "Invalid service credentials",
+		}, nil
+	}
+
+	accessToken, err := s.jwtManager.GenerateServiceToken(req.ServiceId, scopes, serviceTokenDuration)
+	if err != nil {
+		log.Printf("Failed to generate service token: %v", err)
+		return nil, status.Errorf(codes.Internal, "Failed to generate tokens")
+	}
+
+	return &authpb.IssueServiceTokenResponse{
+		Success:     true,
+		Message:     "Service token issued",
+		AccessToken: accessToken,
+		ExpiresAt:   time.Now().Add(serviceTokenDuration).Unix(),
+		Scopes:      scopes,
+	}, nil
+}
+
+// trackAccessToken records an issued access token so LogoutAll can revoke it
+func (s *Server) trackAccessToken(ctx context.Context, accessToken string) {
+	if s.denylist == nil {
+		return
+	}
+
+	claims, err := s.jwtManager.ValidateAccessToken(accessToken)
+	if err != nil {
+		log.Printf("Failed to parse issued access token: %v", err)
+		return
+	}
+
+	if err := s.denylist.TrackAccessToken(ctx, claims.UserID, claims.ID, claims.ExpiresAt.Time); err != nil {
+		log.Printf("Failed to track access token: %v", err)
+		// Continue anyway, the token can still be revoked by Logout
+	}
+}
+
+// revokeAccessToken denies an access token until it expires
+func (s *Server) revokeAccessToken(ctx context.Context, claims *JWTClaims) error {
+	if s.denylist == nil {
+		return nil
+	}
+	return s.denylist.RevokeAccessToken(ctx, claims.ID, claims.ExpiresAt.Time)
+}
+
+// revokeUserTokens revokes every access token and deletes every refresh token
+// of a user, returning how many of each there were
+func (s *Server) revokeUserTokens(ctx context.Context, userID string) (int, int, error) {
+	revokedRefreshTokens, err := s.storage.DeleteUserRefreshTokens(ctx, userID)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	revokedAccessTokens := 0
+	if s.denylist != nil {
+		revokedAccessTokens, err = s.denylist.RevokeUserAccessTokens(ctx, userID)
+		if err != nil {
+			return 0, 0, err
+		}
+	}
+
+	return revokedAccessTokens, revokedRefreshTokens, nil
+}
+
+// isRevoked reports whether an access token was revoked. A token is treated
+// as revoked when the denylist cannot be checked.
+func (s *Server) isRevoked(ctx context.Context, claims *JWTClaims) bool {
+	if s.denylist == nil {
+		return false
+	}
+
+	revoked, err := s.denylist.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		log.Printf("Failed to check token denylist: %v", err)
+		return true
+	}
+	return revoked
+}
+
 // userToProto converts internal User to protobuf User
 func (s *Server) userToProto(user *User) *authpb.User {
 	return &authpb.User{
@@ -314,5 +650,8 @@ func (s *Server) userToProto(user *User) *authpb.User {
 		DisplayName: user.DisplayName,
 		CreatedAt:   user.CreatedAt.Unix(),
 		LastLogin:   user.LastLogin.Unix(),
+		Role:        user.Role,
+		Banned:      user.Banned,
+		BanReason:   user.BanReason,
 	}
 }
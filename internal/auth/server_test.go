@@ -3,9 +3,14 @@ package auth
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	authpb "github.com/laerson/mancala/proto/auth"
 )
 
@@ -14,6 +19,8 @@ type mockStorage struct {
 	users         map[string]*User
 	usersByName   map[string]string
 	refreshTokens map[string]*RefreshToken
+	ratings       map[string]Rating
+	ratedGames    map[string]bool
 }
 
 func newMockStorage() *mockStorage {
@@ -21,6 +28,8 @@ func newMockStorage() *mockStorage {
 		users:         make(map[string]*User),
 		usersByName:   make(map[string]string),
 		refreshTokens: make(map[string]*RefreshToken),
+		ratings:       make(map[string]Rating),
+		ratedGames:    make(map[string]bool),
 	}
 }
 
@@ -51,6 +60,40 @@ func (m *mockStorage) UpdateLastLogin(ctx context.Context, userID string) error
 	return nil // Mock implementation
 }
 
+func (m *mockStorage) ListUsers(ctx context.Context, query string, limit, offset int) ([]*User, int, error) {
+	var matching []*User
+	for _, user := range m.users {
+		if strings.Contains(strings.ToLower(user.Username), strings.ToLower(query)) {
+			matching = append(matching, user)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool { return matching[i].Username < matching[j].Username })
+
+	page := matching[min(offset, len(matching)):]
+	return page[:min(limit, len(page))], len(matching), nil
+}
+
+func (m *mockStorage) SetUserRole(ctx context.Context, userID, role string) error {
+	user, exists := m.users[userID]
+	if !exists {
+		return fmt.Errorf("user not found")
+	}
+	user.Role = role
+	return nil
+}
+
+func (m *mockStorage) SetUserBanned(ctx context.Context, userID string, banned bool, reason string) error {
+	user, exists := m.users[userID]
+	if !exists {
+		return fmt.Errorf("user not found")
+	}
+	if !banned {
+		reason = ""
+	}
+	user.Banned, user.BanReason = banned, reason
+	return nil
+}
+
 func (m *mockStorage) StoreRefreshToken(ctx context.Context, token *RefreshToken) error {
 	m.refreshTokens[token.Token] = token
 	return nil
@@ -68,14 +111,94 @@ func (m *mockStorage) DeleteRefreshToken(ctx context.Context, token string) erro
 	return nil
 }
 
+func (m *mockStorage) DeleteUserRefreshTokens(ctx context.Context, userID string) (int, error) {
+	deleted := 0
+	for token, refreshToken := range m.refreshTokens {
+		if refreshToken.UserID == userID {
+			delete(m.refreshTokens, token)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// rating returns the user's rating, starting users at the default rating like the users table does
+func (m *mockStorage) rating(userID string) Rating {
+	if rating, exists := m.ratings[userID]; exists {
+		return rating
+	}
+	return Rating{UserID: userID, Rating: DefaultRating}
+}
+
+func (m *mockStorage) GetRatings(ctx context.Context, userIDs []string) ([]*Rating, error) {
+	var ratings []*Rating
+	for _, userID := range userIDs {
+		if _, exists := m.users[userID]; exists {
+			rating := m.rating(userID)
+			ratings = append(ratings, &rating)
+		}
+	}
+	return ratings, nil
+}
+
+func (m *mockStorage) RecordGameResult(ctx context.Context, gameID, player1ID, player2ID string, score float64) (bool, error) {
+	_, exists1 := m.users[player1ID]
+	_, exists2 := m.users[player2ID]
+	if m.ratedGames[gameID] || !exists1 || !exists2 {
+		return false, nil
+	}
+
+	m.ratedGames[gameID] = true
+	player1, player2 := UpdateRatings(m.rating(player1ID), m.rating(player2ID), score)
+	m.ratings[player1ID] = player1
+	m.ratings[player2ID] = player2
+	return true, nil
+}
+
 func (m *mockStorage) Close() error {
 	return nil
 }
 
+// Mock denylist for testing
+type mockDenylist struct {
+	issued  map[string][]string // userID -> token IDs
+	revoked map[string]bool
+}
+
+func newMockDenylist() *mockDenylist {
+	return &mockDenylist{
+		issued:  make(map[string][]string),
+		revoked: make(map[string]bool),
+	}
+}
+
+func (m *mockDenylist) TrackAccessToken(ctx context.Context, userID, tokenID string, expiresAt time.Time) error {
+	m.issued[userID] = append(m.issued[userID], tokenID)
+	return nil
+}
+
+func (m *mockDenylist) RevokeAccessToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	m.revoked[tokenID] = true
+	return nil
+}
+
+func (m *mockDenylist) RevokeUserAccessTokens(ctx context.Context, userID string) (int, error) {
+	revoked := len(m.issued[userID])
+	for _, tokenID := range m.issued[userID] {
+		m.revoked[tokenID] = true
+	}
+	delete(m.issued, userID)
+	return revoked, nil
+}
+
+func (m *mockDenylist) IsRevoked(ctx context.Context, tokenID string) (bool, error) {
+	return m.revoked[tokenID], nil
+}
+
 func TestServer_Register(t *testing.T) {
 	server := &Server{
 		storage:    nil, // We'll override methods
-		jwtManager: NewJWTManager("test-secret", time.Hour, 24*time.Hour),
+		jwtManager: newTestJWTManager(t, time.Hour, 24*time.Hour),
 	}
 
 	tests := []struct {
@@ -143,7 +266,7 @@ func TestServer_Register(t *testing.T) {
 func TestServer_Login(t *testing.T) {
 	server := &Server{
 		storage:    newMockStorage(),
-		jwtManager: NewJWTManager("test-secret", time.Hour, 24*time.Hour),
+		jwtManager: newTestJWTManager(t, time.Hour, 24*time.Hour),
 	}
 
 	// Create a test user first
@@ -213,7 +336,7 @@ func TestServer_Login(t *testing.T) {
 func TestServer_ValidateToken(t *testing.T) {
 	server := &Server{
 		storage:    newMockStorage(),
-		jwtManager: NewJWTManager("test-secret", time.Hour, 24*time.Hour),
+		jwtManager: newTestJWTManager(t, time.Hour, 24*time.Hour),
 	}
 
 	// Create a test user
@@ -227,7 +350,7 @@ func TestServer_ValidateToken(t *testing.T) {
 	server.storage.(*mockStorage).users[testUser.UserID] = testUser
 
 	// Generate a valid token
-	validToken, err := server.jwtManager.GenerateAccessToken(testUser.UserID, testUser.Username)
+	validToken, err := server.jwtManager.GenerateAccessToken(testUser.UserID, testUser.Username, RolePlayer)
 	if err != nil {
 		t.Fatalf("Failed to generate token: %v", err)
 	}
@@ -277,3 +400,323 @@ func TestServer_ValidateToken(t *testing.T) {
 		})
 	}
 }
+
+func TestServer_GetRatings(t *testing.T) {
+	storage := newMockStorage()
+	storage.users["alice"] = &User{UserID: "alice"}
+	storage.users["bob"] = &User{UserID: "bob"}
+	storage.ratings["bob"] = Rating{UserID: "bob", Rating: 1612.6, GamesPlayed: 7}
+
+	server := &Server{
+		storage:    storage,
+		jwtManager: newTestJWTManager(t, time.Hour, 24*time.Hour),
+	}
+
+	resp, err := server.GetRatings(context.Background(), &authpb.GetRatingsRequest{UserIds: []string{"alice", "bob", "unknown"}})
+	if err != nil {
+		t.Fatalf("GetRatings() error = %v", err)
+	}
+
+	if len(resp.Ratings) != 2 {
+		t.Fatalf("GetRatings() returned %d ratings, want 2", len(resp.Ratings))
+	}
+	if resp.Ratings[0].Rating != DefaultRating || resp.Ratings[0].GamesPlayed != 0 {
+		t.Errorf("GetRatings() alice = %v, want the default rating", resp.Ratings[0])
+	}
+	if resp.Ratings[1].Rating != 1613 || resp.Ratings[1].GamesPlayed != 7 {
+		t.Errorf("GetRatings() bob = %v, want 1613 after 7 games", resp.Ratings[1])
+	}
+}
+
+func TestServer_GetProfile(t *testing.T) {
+	storage := newMockStorage()
+	storage.CreateUser(context.Background(), &User{UserID: "alice-id", Username: "alice", DisplayName: "Alice"})
+
+	server := &Server{
+		storage:    storage,
+		jwtManager: newTestJWTManager(t, time.Hour, 24*time.Hour),
+	}
+
+	tests := []struct {
+		name        string
+		req         *authpb.GetProfileRequest
+		wantSuccess bool
+	}{
+		{
+			name:        "By user ID",
+			req:         &authpb.GetProfileRequest{UserId: "alice-id"},
+			wantSuccess: true,
+		},
+		{
+			name:        "By username",
+			req:         &authpb.GetProfileRequest{Username: "alice"},
+			wantSuccess: true,
+		},
+		{
+			name:        "Unknown username",
+			req:         &authpb.GetProfileRequest{Username: "bob"},
+			wantSuccess: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.GetProfile(context.Background(), tt.req)
+			if err != nil {
+				t.Fatalf("GetProfile() error = %v", err)
+			}
+
+			if resp.Success != tt.wantSuccess {
+				t.Errorf("GetProfile() success = %v, want %v", resp.Success, tt.wantSuccess)
+			}
+			if tt.wantSuccess && resp.User.UserId != "alice-id" {
+				t.Errorf("GetProfile() user ID = %s, want alice-id", resp.User.UserId)
+			}
+		})
+	}
+}
+
+// newLogoutTestServer returns a server with a registered user
+func newLogoutTestServer(t *testing.T) *Server {
+	server := &Server{
+		storage:    newMockStorage(),
+		jwtManager: newTestJWTManager(t, time.Hour, 24*time.Hour),
+		denylist:   newMockDenylist(),
+	}
+
+	hashedPassword, _ := HashPassword("password123")
+	server.storage.CreateUser(context.Background(), &User{UserID: "alice-id", Username: "alice", PasswordHash: hashedPassword})
+	server.storage.CreateUser(context.Background(), &User{UserID: "bob-id", Username: "bob", PasswordHash: hashedPassword})
+	return server
+}
+
+// login logs a user in and returns its tokens
+func login(t *testing.T, server *Server, username string) *authpb.LoginResponse {
+	resp, err := server.Login(context.Background(), &authpb.LoginRequest{Username: username, Password: "password123"})
+	if err != nil || !resp.Success {
+		t.Fatalf("Login() = %v, %v", resp, err)
+	}
+	return resp
+}
+
+// isValid reports whether the auth service accepts an access token
+func isValid(t *testing.T, server *Server, accessToken string) bool {
+	resp, err := server.ValidateToken(context.Background(), &authpb.ValidateTokenRequest{AccessToken: accessToken})
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	return resp.Valid
+}
+
+func TestServer_Logout(t *testing.T) {
+	server := newLogoutTestServer(t)
+	ctx := context.Background()
+
+	phone := login(t, server, "alice")
+	laptop := login(t, server, "alice")
+	bob := login(t, server, "bob")
+
+	// Someone else's refresh token is left alone
+	resp, err := server.Logout(ctx, &authpb.LogoutRequest{AccessToken: phone.AccessToken, RefreshToken: bob.RefreshToken})
+	if err != nil || !resp.Success {
+		t.Fatalf("Logout() = %v, %v", resp, err)
+	}
+	if _, err := server.storage.GetRefreshToken(ctx, bob.RefreshToken); err != nil {
+		t.Error("Logout() deleted another user's refresh token")
+	}
+
+	if _, err := server.Logout(ctx, &authpb.LogoutRequest{AccessToken: laptop.AccessToken, RefreshToken: laptop.RefreshToken}); err != nil {
+		t.Fatalf("Logout() error = %v", err)
+	}
+	if _, err := server.storage.GetRefreshToken(ctx, laptop.RefreshToken); err == nil {
+		t.Error("Logout() kept the refresh token")
+	}
+
+	if isValid(t, server, phone.AccessToken) || isValid(t, server, laptop.AccessToken) {
+		t.Error("ValidateToken() accepted a token after logout")
+	}
+	if !isValid(t, server, bob.AccessToken) {
+		t.Error("ValidateToken() rejected the token of a user who did not log out")
+	}
+
+	resp, err = server.Logout(ctx, &authpb.LogoutRequest{AccessToken: "invalid-token"})
+	if err != nil || resp.Success {
+		t.Errorf("Logout() with an invalid token = %v, %v, want no success", resp, err)
+	}
+}
+
+func TestServer_LogoutAll(t *testing.T) {
+	server := newLogoutTestServer(t)
+	ctx := context.Background()
+
+	phone := login(t, server, "alice")
+	laptop := login(t, server, "alice")
+	bob := login(t, server, "bob")
+
+	resp, err := server.LogoutAll(ctx, &authpb.LogoutAllRequest{AccessToken: laptop.AccessToken})
+	if err != nil || !resp.Success {
+		t.Fatalf("LogoutAll() = %v, %v", resp, err)
+	}
+	if resp.RevokedAccessTokens != 2 || resp.RevokedRefreshTokens != 2 {
+		t.Errorf("LogoutAll() revoked %d access and %d refresh tokens, want 2 and 2", resp.RevokedAccessTokens, resp.RevokedRefreshTokens)
+	}
+
+	if isValid(t, server, phone.AccessToken) || isValid(t, server, laptop.AccessToken) {
+		t.Error("ValidateToken() accepted a token after LogoutAll")
+	}
+	refreshed, err := server.RefreshToken(ctx, &authpb.RefreshTokenRequest{RefreshToken: phone.RefreshToken})
+	if err != nil || refreshed.Success {
+		t.Errorf("RefreshToken() after LogoutAll = %v, %v, want no success", refreshed, err)
+	}
+	if !isValid(t, server, bob.AccessToken) {
+		t.Error("ValidateToken() rejected the token of another user")
+	}
+
+	// A revoked token cannot be used again
+	resp, err = server.LogoutAll(ctx, &authpb.LogoutAllRequest{AccessToken: laptop.AccessToken})
+	if err != nil || resp.Success {
+		t.Errorf("LogoutAll() with a revoked token = %v, %v, want no success", resp, err)
+	}
+}
+
+func TestServer_IssueServiceToken(t *testing.T) {
+	server := &Server{
+		storage:        newMockStorage(),
+		jwtManager:     newTestJWTManager(t, time.Hour, 24*time.Hour),
+		serviceSecrets: map[string]string{"matchmaking": "s3cr3t"},
+	}
+
+	resp, err := server.IssueServiceToken(context.Background(), &authpb.IssueServiceTokenRequest{ServiceId: "matchmaking", ServiceSecret: "s3cr3t"})
+	if err != nil || !resp.Success {
+		t.Fatalf("IssueServiceToken() = %v, %v", resp, err)
+	}
+
+	claims, err := server.jwtManager.ValidateAccessToken(resp.AccessToken)
+	if err != nil {
+		t.Fatalf("Service token is invalid: %v", err)
+	}
+	if claims.Service != "matchmaking" || claims.UserID != "" || !hasScope(claims.Scopes, ScopeCreateGames) {
+		t.Errorf("Service token claims = %+v, want matchmaking with %s and no user", claims, ScopeCreateGames)
+	}
+	if time.Until(claims.ExpiresAt.Time) > serviceTokenDuration {
+		t.Errorf("Service token expires at %v, want within %v", claims.ExpiresAt, serviceTokenDuration)
+	}
+
+	// Service tokens never identify a user
+	validated, err := server.ValidateToken(context.Background(), &authpb.ValidateTokenRequest{AccessToken: resp.AccessToken})
+	if err != nil || validated.Valid {
+		t.Errorf("ValidateToken() of a service token = %v, %v, want invalid", validated, err)
+	}
+
+	for _, req := range []*authpb.IssueServiceTokenRequest{
+		{ServiceId: "matchmaking", ServiceSecret: "wrong"},
+		{ServiceId: "matchmaking"},
+		{ServiceId: "gateway", ServiceSecret: "s3cr3t"},
+	} {
+		resp, err := server.IssueServiceToken(context.Background(), req)
+		if err != nil || resp.Success {
+			t.Errorf("IssueServiceToken(%v) = %v, %v, want no success", req, resp, err)
+		}
+	}
+}
+
+// staffContext returns the context of an authenticated staff member
+func staffContext(userID, role string) context.Context {
+	return context.WithValue(context.WithValue(context.Background(), "user_id", userID), "role", role)
+}
+
+func TestServer_BanUser(t *testing.T) {
+	server := newLogoutTestServer(t)
+	moderator := staffContext("mod-id", RoleModerator)
+
+	session := login(t, server, "alice")
+
+	resp, err := server.BanUser(moderator, &authpb.BanUserRequest{UserId: "alice-id", Reason: "cheating"})
+	if err != nil || !resp.Success || !resp.User.Banned {
+		t.Fatalf("BanUser() = %v, %v", resp, err)
+	}
+
+	// Banned users are signed out and cannot sign in again
+	if isValid(t, server, session.AccessToken) {
+		t.Error("ValidateToken() accepted the token of a banned user")
+	}
+	refreshed, err := server.RefreshToken(context.Background(), &authpb.RefreshTokenRequest{RefreshToken: session.RefreshToken})
+	if err != nil || refreshed.Success {
+		t.Errorf("RefreshToken() of a banned user = %v, %v, want no success", refreshed, err)
+	}
+	loginResp, err := server.Login(context.Background(), &authpb.LoginRequest{Username: "alice", Password: "password123"})
+	if err != nil || loginResp.Success || loginResp.Message != "Account is banned: cheating" {
+		t.Errorf("Login() of a banned user = %v, %v, want the ban reason", loginResp, err)
+	}
+
+	unbanned, err := server.UnbanUser(moderator, &authpb.UnbanUserRequest{UserId: "alice-id"})
+	if err != nil || !unbanned.Success || unbanned.User.Banned || unbanned.User.BanReason != "" {
+		t.Fatalf("UnbanUser() = %v, %v", unbanned, err)
+	}
+	login(t, server, "alice")
+
+	if _, err := server.BanUser(moderator, &authpb.BanUserRequest{UserId: "missing-id"}); status.Code(err) != codes.NotFound {
+		t.Errorf("BanUser() of a missing user error = %v, want NotFound", err)
+	}
+}
+
+func TestServer_SetUserRole(t *testing.T) {
+	server := newLogoutTestServer(t)
+	admin := staffContext("admin-id", RoleAdmin)
+
+	session := login(t, server, "alice")
+
+	resp, err := server.SetUserRole(admin, &authpb.SetUserRoleRequest{UserId: "alice-id", Role: RoleModerator})
+	if err != nil || !resp.Success || resp.User.Role != RoleModerator {
+		t.Fatalf("SetUserRole() = %v, %v", resp, err)
+	}
+
+	// Access tokens carrying the old role are revoked, refreshed ones carry the new role
+	if isValid(t, server, session.AccessToken) {
+		t.Error("ValidateToken() accepted a token issued before the role change")
+	}
+	refreshed, err := server.RefreshToken(context.Background(), &authpb.RefreshTokenRequest{RefreshToken: session.RefreshToken})
+	if err != nil || !refreshed.Success {
+		t.Fatalf("RefreshToken() = %v, %v", refreshed, err)
+	}
+	if claims, _ := server.jwtManager.ValidateAccessToken(refreshed.AccessToken); claims.Role != RoleModerator {
+		t.Errorf("Refreshed token role = %q, want %s", claims.Role, RoleModerator)
+	}
+
+	// Staff only manage users of a lower role
+	moderator := staffContext("alice-id", RoleModerator)
+	if _, err := server.BanUser(moderator, &authpb.BanUserRequest{UserId: "alice-id"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("BanUser() of oneself error = %v, want PermissionDenied", err)
+	}
+	if _, err := server.BanUser(staffContext("mod-id", RoleModerator), &authpb.BanUserRequest{UserId: "alice-id"}); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("BanUser() of another moderator error = %v, want PermissionDenied", err)
+	}
+
+	if _, err := server.SetUserRole(admin, &authpb.SetUserRoleRequest{UserId: "bob-id", Role: "owner"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("SetUserRole() to an unknown role error = %v, want InvalidArgument", err)
+	}
+}
+
+func TestServer_ListUsers(t *testing.T) {
+	server := newLogoutTestServer(t)
+	hashedPassword, _ := HashPassword("password123")
+	server.storage.CreateUser(context.Background(), &User{UserID: "alicia-id", Username: "alicia", PasswordHash: hashedPassword})
+	moderator := staffContext("mod-id", RoleModerator)
+
+	resp, err := server.ListUsers(moderator, &authpb.ListUsersRequest{Query: "ali"})
+	if err != nil {
+		t.Fatalf("ListUsers() error = %v", err)
+	}
+	if resp.Total != 2 || len(resp.Users) != 2 || resp.Users[0].Username != "alice" || resp.Users[1].Username != "alicia" {
+		t.Errorf("ListUsers() = %v, want alice and alicia", resp)
+	}
+
+	resp, err = server.ListUsers(moderator, &authpb.ListUsersRequest{Limit: 1, Offset: 1})
+	if err != nil || resp.Total != 3 || len(resp.Users) != 1 || resp.Users[0].Username != "alicia" {
+		t.Errorf("ListUsers() second page = %v, %v, want alicia of 3", resp, err)
+	}
+
+	if _, err := server.ListUsers(moderator, &authpb.ListUsersRequest{Offset: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("ListUsers() with a negative offset error = %v, want InvalidArgument", err)
+	}
+}
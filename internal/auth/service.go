@@ -0,0 +1,183 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	authpb "github.com/laerson/mancala/proto/auth"
+)
+
+// Scopes granted to service tokens
+const (
+	ScopeCreateGames = "games:create"      // Create games between any players
+	ScopePlayBots    = "games:play-bots"   // Move for bot players
+	ScopeBots        = "bots"              // Create bots and ask them for moves
+	ScopeReadRatings = "auth:ratings:read" // Read the ratings of any user
+	ScopeClientIP    = "auth:client-ip"    // Forward the IP address of the client a login is made for
+)
+
+const (
+	// serviceTokenDuration is the lifetime of service tokens
+	serviceTokenDuration = 15 * time.Minute
+
+	// serviceTokenRenewBefore is how long before expiry a service token is replaced
+	serviceTokenRenewBefore = time.Minute
+
+	// serviceTokenFetchTimeout bounds a request for a service token
+	serviceTokenFetchTimeout = 5 * time.Second
+)
+
+// servicePermissions lists the scopes of every internal service allowed to
+// obtain service tokens. Tournaments run inside the matchmaking service.
+var servicePermissions = map[string][]string{
+	"matchmaking": {ScopeCreateGames, ScopePlayBots, ScopeBots, ScopeReadRatings},
+	"gateway":     {ScopeClientIP},
+}
+
+// serviceMethodScopes lists the only methods service tokens may call, with
+// the scope each needs
+var serviceMethodScopes = map[string]string{
+	"/proto.games.Games/Create": ScopeCreateGames,
+	"/proto.games.Games/Move":   ScopePlayBots,
+	"/proto.games.Games/Get":    ScopePlayBots,
+	"/proto.bot.Bot/CreateBot":  ScopeBots,
+	"/proto.bot.Bot/GetMove":    ScopeBots,
+	"/auth.Auth/GetRatings":     ScopeReadRatings,
+}
+
+// ParseServiceCredentials parses the secrets of internal services from a
+// comma separated list of service:secret pairs, e.g. "matchmaking:s3cr3t"
+func ParseServiceCredentials(value string) (map[string]string, error) {
+	credentials := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+
+		serviceID, secret, ok := strings.Cut(pair, ":")
+		if !ok || serviceID == "" || secret == "" {
+			return nil, fmt.Errorf("invalid service credentials %q, want service:secret", pair)
+		}
+		if _, known := servicePermissions[serviceID]; !known {
+			return nil, fmt.Errorf("unknown service %q", serviceID)
+		}
+		credentials[serviceID] = secret
+	}
+
+	return credentials, nil
+}
+
+// ServiceTokenClient is the subset of the Auth service used to obtain service tokens
+type ServiceTokenClient interface {
+	IssueServiceToken(ctx context.Context, in *authpb.IssueServiceTokenRequest, opts ...grpc.CallOption) (*authpb.IssueServiceTokenResponse, error)
+}
+
+// ServiceTokenSource authenticates the outgoing calls of an internal service
+// with service tokens, renewing them before they expire. Pass it to
+// grpc.WithPerRPCCredentials on the connections to other services.
+type ServiceTokenSource struct {
+	client    ServiceTokenClient
+	serviceID string
+	secret    string
+
+	mu        sync.Mutex
+	token     string
+	expiresAt time.Time
+}
+
+// NewServiceTokenSource creates a token source for the given service,
+// obtaining its tokens from the auth service
+func NewServiceTokenSource(authAddr, serviceID, secret string) (*ServiceTokenSource, error) {
+	conn, err := grpc.NewClient(authAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to auth service: %w", err)
+	}
+
+	return &ServiceTokenSource{
+		client:    authpb.NewAuthClient(conn),
+		serviceID: serviceID,
+		secret:    secret,
+	}, nil
+}
+
+// Token returns a valid service token, requesting a new one when the current
+// one is about to expire
+func (s *ServiceTokenSource) Token(ctx context.Context) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.token != "" && time.Until(s.expiresAt) > serviceTokenRenewBefore {
+		return s.token, nil
+	}
+	if s.secret == "" {
+		return "", fmt.Errorf("no secret configured for service %s", s.serviceID)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, serviceTokenFetchTimeout)
+	defer cancel()
+
+	resp, err := s.client.IssueServiceToken(ctx, &authpb.IssueServiceTokenRequest{
+		ServiceId:     s.serviceID,
+		ServiceSecret: s.secret,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get service token: %w", err)
+	}
+	if !resp.Success {
+		return "", fmt.Errorf("service token refused: %s", resp.Message)
+	}
+
+	s.token = resp.AccessToken
+	s.expiresAt = time.Unix(resp.ExpiresAt, 0)
+	return s.token, nil
+}
+
+// GetRequestMetadata adds the service token to an outgoing call
+func (s *ServiceTokenSource) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	token, err := s.Token(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	return map[string]string{"authorization": "Bearer " + token}, nil
+}
+
+// RequireTransportSecurity reports false, services talk over the cluster network
+func (s *ServiceTokenSource) RequireTransportSecurity() bool {
+	return false
+}
+
+// GetServiceFromContext extracts the authenticated service from context, set
+// instead of a user ID for calls made with service tokens
+func GetServiceFromContext(ctx context.Context) (string, error) {
+	service, ok := ctx.Value("service").(string)
+	if !ok || service == "" {
+		return "", fmt.Errorf("service not found in context")
+	}
+	return service, nil
+}
+
+// HasScope reports whether the call was made by a service granted the scope
+func HasScope(ctx context.Context, scope string) bool {
+	scopes, _ := ctx.Value("scopes").([]string)
+	return hasScope(scopes, scope)
+}
+
+// hasScope reports whether scopes contains scope
+func hasScope(scopes []string, scope string) bool {
+	for _, granted := range scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,62 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+
+	authpb "github.com/laerson/mancala/proto/auth"
+)
+
+// fakeServiceTokenClient issues service tokens from an auth server
+type fakeServiceTokenClient struct {
+	server *Server
+	issued int
+}
+
+func (f *fakeServiceTokenClient) IssueServiceToken(ctx context.Context, in *authpb.IssueServiceTokenRequest, opts ...grpc.CallOption) (*authpb.IssueServiceTokenResponse, error) {
+	f.issued++
+	return f.server.IssueServiceToken(ctx, in)
+}
+
+func TestServiceTokenSource(t *testing.T) {
+	client := &fakeServiceTokenClient{server: &Server{
+		jwtManager:     newTestJWTManager(t, time.Hour, 24*time.Hour),
+		serviceSecrets: map[string]string{"matchmaking": "s3cr3t"},
+	}}
+	source := &ServiceTokenSource{client: client, serviceID: "matchmaking", secret: "s3cr3t"}
+
+	md, err := source.GetRequestMetadata(context.Background())
+	if err != nil {
+		t.Fatalf("GetRequestMetadata() error = %v", err)
+	}
+	if again, _ := source.GetRequestMetadata(context.Background()); again["authorization"] != md["authorization"] || client.issued != 1 {
+		t.Errorf("GetRequestMetadata() requested %d tokens, want the first one reused", client.issued)
+	}
+
+	// Tokens about to expire are replaced
+	source.expiresAt = time.Now().Add(serviceTokenRenewBefore / 2)
+	if _, err := source.Token(context.Background()); err != nil || client.issued != 2 {
+		t.Errorf("Token() near expiry = %v after %d requests, want a new token", err, client.issued)
+	}
+
+	refused := &ServiceTokenSource{client: client, serviceID: "matchmaking", secret: "wrong"}
+	if _, err := refused.GetRequestMetadata(context.Background()); err == nil {
+		t.Error("GetRequestMetadata() with a wrong secret error = nil, want an error")
+	}
+}
+
+func TestParseServiceCredentials(t *testing.T) {
+	secrets, err := ParseServiceCredentials(" matchmaking:a:b ,")
+	if err != nil || len(secrets) != 1 || secrets["matchmaking"] != "a:b" {
+		t.Errorf("ParseServiceCredentials() = %v, %v, want the matchmaking secret a:b", secrets, err)
+	}
+
+	for _, value := range []string{"matchmaking", "matchmaking:", "unknown:secret"} {
+		if _, err := ParseServiceCredentials(value); err == nil {
+			t.Errorf("ParseServiceCredentials(%q) error = nil, want an error", value)
+		}
+	}
+}
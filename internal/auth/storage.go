@@ -5,9 +5,11 @@ import (
 	"database/sql"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -17,9 +19,21 @@ type StorageInterface interface {
 	GetUserByID(ctx context.Context, userID string) (*User, error)
 	GetUserByUsername(ctx context.Context, username string) (*User, error)
 	UpdateLastLogin(ctx context.Context, userID string) error
+	// ListUsers returns a page of the users whose username contains the query, and how many match it
+	ListUsers(ctx context.Context, query string, limit, offset int) ([]*User, int, error)
+	SetUserRole(ctx context.Context, userID, role string) error
+	// SetUserBanned bans or unbans a user, the reason is cleared on unban
+	SetUserBanned(ctx context.Context, userID string, banned bool, reason string) error
 	StoreRefreshToken(ctx context.Context, refreshToken *RefreshToken) error
 	GetRefreshToken(ctx context.Context, token string) (*RefreshToken, error)
 	DeleteRefreshToken(ctx context.Context, token string) error
+	// DeleteUserRefreshTokens removes every refresh token of a user and returns how many there were
+	DeleteUserRefreshTokens(ctx context.Context, userID string) (int, error)
+	// GetRatings returns the ratings of the given users. Unknown users are left out.
+	GetRatings(ctx context.Context, userIDs []string) ([]*Rating, error)
+	// RecordGameResult updates both players' ratings with the result of a game, scored for
+	// player one. It reports false when the game was already rated or a player is not a user.
+	RecordGameResult(ctx context.Context, gameID, player1ID, player2ID string, score float64) (bool, error)
 	Close() error
 }
 
@@ -76,6 +90,21 @@ func (s *Storage) initSchema() error {
 		);`,
 		`CREATE INDEX IF NOT EXISTS idx_users_username ON users(username);`,
 		`CREATE INDEX IF NOT EXISTS idx_users_created_at ON users(created_at);`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS rating DOUBLE PRECISION NOT NULL DEFAULT 1500;`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS games_rated INTEGER NOT NULL DEFAULT 0;`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS role VARCHAR(20) NOT NULL DEFAULT 'player';`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS banned BOOLEAN NOT NULL DEFAULT FALSE;`,
+		`ALTER TABLE users ADD COLUMN IF NOT EXISTS ban_reason TEXT NOT NULL DEFAULT '';`,
+		`CREATE TABLE IF NOT EXISTS rated_games (
+			game_id VARCHAR(64) PRIMARY KEY,
+			rated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+		);`,
+		`CREATE TABLE IF NOT EXISTS signing_keys (
+			key_id VARCHAR(64) PRIMARY KEY,
+			algorithm VARCHAR(16) NOT NULL,
+			private_key TEXT NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+		);`,
 	}
 
 	for _, query := range queries {
@@ -90,8 +119,8 @@ func (s *Storage) initSchema() error {
 // CreateUser stores a new user in PostgreSQL
 func (s *Storage) CreateUser(ctx context.Context, user *User) error {
 	query := `
-		INSERT INTO users (user_id, username, display_name, password_hash, created_at, last_login)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO users (user_id, username, display_name, password_hash, created_at, last_login, role)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
 	`
 	_, err := s.db.ExecContext(ctx, query,
 		user.UserID,
@@ -100,6 +129,7 @@ func (s *Storage) CreateUser(ctx context.Context, user *User) error {
 		user.PasswordHash,
 		user.CreatedAt,
 		user.LastLogin,
+		user.Role,
 	)
 
 	if err != nil {
@@ -113,24 +143,39 @@ func (s *Storage) CreateUser(ctx context.Context, user *User) error {
 	return nil
 }
 
-// GetUserByID retrieves a user by their UUID from PostgreSQL
-func (s *Storage) GetUserByID(ctx context.Context, userID string) (*User, error) {
-	query := `
-		SELECT user_id, username, display_name, password_hash, created_at, last_login
-		FROM users
-		WHERE user_id = $1
-	`
+// userColumns are the columns scanned by scanUser
+const userColumns = `user_id, username, display_name, password_hash, created_at, last_login, role, banned, ban_reason`
+
+// rowScanner is a *sql.Row or *sql.Rows
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
 
+// scanUser reads a row of userColumns
+func scanUser(row rowScanner) (*User, error) {
 	var user User
-	err := s.db.QueryRowContext(ctx, query, userID).Scan(
+	err := row.Scan(
 		&user.UserID,
 		&user.Username,
 		&user.DisplayName,
 		&user.PasswordHash,
 		&user.CreatedAt,
 		&user.LastLogin,
+		&user.Role,
+		&user.Banned,
+		&user.BanReason,
 	)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// GetUserByID retrieves a user by their UUID from PostgreSQL
+func (s *Storage) GetUserByID(ctx context.Context, userID string) (*User, error) {
+	query := `SELECT ` + userColumns + ` FROM users WHERE user_id = $1`
 
+	user, err := scanUser(s.db.QueryRowContext(ctx, query, userID))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("user not found")
@@ -138,27 +183,14 @@ func (s *Storage) GetUserByID(ctx context.Context, userID string) (*User, error)
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 // GetUserByUsername retrieves a user by their username from PostgreSQL
 func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*User, error) {
-	query := `
-		SELECT user_id, username, display_name, password_hash, created_at, last_login
-		FROM users
-		WHERE username = $1
-	`
-
-	var user User
-	err := s.db.QueryRowContext(ctx, query, username).Scan(
-		&user.UserID,
-		&user.Username,
-		&user.DisplayName,
-		&user.PasswordHash,
-		&user.CreatedAt,
-		&user.LastLogin,
-	)
+	query := `SELECT ` + userColumns + ` FROM users WHERE username = $1`
 
+	user, err := scanUser(s.db.QueryRowContext(ctx, query, username))
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, fmt.Errorf("user not found")
@@ -166,7 +198,7 @@ func (s *Storage) GetUserByUsername(ctx context.Context, username string) (*User
 		return nil, fmt.Errorf("failed to get user: %w", err)
 	}
 
-	return &user, nil
+	return user, nil
 }
 
 // UpdateLastLogin updates the user's last login timestamp in PostgreSQL
@@ -194,6 +226,82 @@ func (s *Storage) UpdateLastLogin(ctx context.Context, userID string) error {
 	return nil
 }
 
+// ListUsers searches users by username in PostgreSQL, ordered by username
+func (s *Storage) ListUsers(ctx context.Context, query string, limit, offset int) ([]*User, int, error) {
+	// Wildcards in the query match themselves
+	pattern := "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(query) + "%"
+
+	var total int
+	if err := s.db.QueryRowContext(ctx, `SELECT COUNT(*) FROM users WHERE username ILIKE $1`, pattern).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count users: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT `+userColumns+`
+		FROM users
+		WHERE username ILIKE $1
+		ORDER BY username
+		LIMIT $2 OFFSET $3
+	`, pattern, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+	defer rows.Close()
+
+	var users []*User
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan user: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return users, total, nil
+}
+
+// SetUserRole changes a user's role in PostgreSQL
+func (s *Storage) SetUserRole(ctx context.Context, userID, role string) error {
+	result, err := s.db.ExecContext(ctx, `UPDATE users SET role = $2 WHERE user_id = $1`, userID, role)
+	if err != nil {
+		return fmt.Errorf("failed to set role: %w", err)
+	}
+
+	return requireUpdated(result)
+}
+
+// SetUserBanned bans or unbans a user in PostgreSQL
+func (s *Storage) SetUserBanned(ctx context.Context, userID string, banned bool, reason string) error {
+	if !banned {
+		reason = ""
+	}
+
+	result, err := s.db.ExecContext(ctx, `UPDATE users SET banned = $2, ban_reason = $3 WHERE user_id = $1`, userID, banned, reason)
+	if err != nil {
+		return fmt.Errorf("failed to set ban: %w", err)
+	}
+
+	return requireUpdated(result)
+}
+
+// requireUpdated returns "user not found" when an update matched no user
+func requireUpdated(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user not found")
+	}
+
+	return nil
+}
+
 // StoreRefreshToken stores a refresh token in Redis with expiration
 func (s *Storage) StoreRefreshToken(ctx context.Context, refreshToken *RefreshToken) error {
 	tokenData, err := json.Marshal(refreshToken)
@@ -281,6 +389,211 @@ func (s *Storage) DeleteRefreshToken(ctx context.Context, token string) error {
 	return nil
 }
 
+// DeleteUserRefreshTokens removes every refresh token of a user from Redis
+func (s *Storage) DeleteUserRefreshTokens(ctx context.Context, userID string) (int, error) {
+	userTokensKey := fmt.Sprintf("user_refresh_tokens:%s", userID)
+
+	tokenIDs, err := s.redisClient.SMembers(ctx, userTokensKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get refresh tokens: %w", err)
+	}
+
+	pipe := s.redisClient.TxPipeline()
+	deleted := 0
+	for _, tokenID := range tokenIDs {
+		data, err := s.redisClient.Get(ctx, fmt.Sprintf("refresh_token:%s", tokenID)).Result()
+		if err == redis.Nil {
+			continue // Expired
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to get refresh token: %w", err)
+		}
+
+		var refreshToken RefreshToken
+		if err := json.Unmarshal([]byte(data), &refreshToken); err != nil {
+			return 0, fmt.Errorf("failed to unmarshal refresh token: %w", err)
+		}
+
+		pipe.Del(ctx, fmt.Sprintf("refresh_token:%s", tokenID))
+		pipe.Del(ctx, fmt.Sprintf("refresh_token_lookup:%s", refreshToken.Token))
+		deleted++
+	}
+	pipe.Del(ctx, userTokensKey)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, fmt.Errorf("failed to delete refresh tokens: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// GetRatings retrieves the ratings of the given users from PostgreSQL
+func (s *Storage) GetRatings(ctx context.Context, userIDs []string) ([]*Rating, error) {
+	query := `
+		SELECT user_id, rating, games_rated
+		FROM users
+		WHERE user_id = ANY($1::uuid[])
+	`
+
+	rows, err := s.db.QueryContext(ctx, query, pq.Array(validUserIDs(userIDs)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ratings: %w", err)
+	}
+	defer rows.Close()
+
+	var ratings []*Rating
+	for rows.Next() {
+		var rating Rating
+		if err := rows.Scan(&rating.UserID, &rating.Rating, &rating.GamesPlayed); err != nil {
+			return nil, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		ratings = append(ratings, &rating)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get ratings: %w", err)
+	}
+
+	return ratings, nil
+}
+
+// RecordGameResult updates both players' ratings in one transaction. The game ID is
+// recorded as well, so a redelivered GAME_OVER event does not rate the game twice.
+func (s *Storage) RecordGameResult(ctx context.Context, gameID, player1ID, player2ID string, score float64) (bool, error) {
+	// Bots are not users, so games against them are not rated
+	if player1ID == player2ID || len(validUserIDs([]string{player1ID, player2ID})) != 2 {
+		return false, nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO rated_games (game_id) VALUES ($1) ON CONFLICT DO NOTHING`, gameID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record rated game: %w", err)
+	}
+	if inserted, err := result.RowsAffected(); err != nil || inserted == 0 {
+		return false, err
+	}
+
+	// Lock both rows so concurrent games of the same player are applied one after the other
+	rows, err := tx.QueryContext(ctx, `
+		SELECT user_id, rating, games_rated
+		FROM users
+		WHERE user_id IN ($1, $2)
+		FOR UPDATE
+	`, player1ID, player2ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get ratings: %w", err)
+	}
+
+	ratings := make(map[string]Rating)
+	for rows.Next() {
+		var rating Rating
+		if err := rows.Scan(&rating.UserID, &rating.Rating, &rating.GamesPlayed); err != nil {
+			rows.Close()
+			return false, fmt.Errorf("failed to scan rating: %w", err)
+		}
+		ratings[rating.UserID] = rating
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return false, fmt.Errorf("failed to get ratings: %w", err)
+	}
+
+	player1, ok1 := ratings[player1ID]
+	player2, ok2 := ratings[player2ID]
+	if !ok1 || !ok2 {
+		return false, nil
+	}
+
+	player1, player2 = UpdateRatings(player1, player2, score)
+	for _, rating := range []Rating{player1, player2} {
+		_, err := tx.ExecContext(ctx, `UPDATE users SET rating = $2, games_rated = $3 WHERE user_id = $1`,
+			rating.UserID, rating.Rating, rating.GamesPlayed)
+		if err != nil {
+			return false, fmt.Errorf("failed to update rating: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit ratings: %w", err)
+	}
+
+	return true, nil
+}
+
+// SaveSigningKey stores a new token signing key in PostgreSQL
+func (s *Storage) SaveSigningKey(ctx context.Context, key *SigningKey) error {
+	privateKey, err := MarshalPrivateKey(key.PrivateKey)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO signing_keys (key_id, algorithm, private_key, created_at)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := s.db.ExecContext(ctx, query, key.ID, key.Algorithm, privateKey, key.CreatedAt); err != nil {
+		return fmt.Errorf("failed to save signing key: %w", err)
+	}
+
+	return nil
+}
+
+// GetSigningKeys retrieves every token signing key from PostgreSQL, oldest first
+func (s *Storage) GetSigningKeys(ctx context.Context) ([]*SigningKey, error) {
+	query := `
+		SELECT key_id, algorithm, private_key, created_at
+		FROM signing_keys
+		ORDER BY created_at, key_id
+	`
+	rows, err := s.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get signing keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []*SigningKey
+	for rows.Next() {
+		var key SigningKey
+		var privateKey string
+		if err := rows.Scan(&key.ID, &key.Algorithm, &privateKey, &key.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan signing key: %w", err)
+		}
+
+		key.PrivateKey, err = ParsePrivateKey(privateKey)
+		if err != nil {
+			return nil, fmt.Errorf("signing key %s: %w", key.ID, err)
+		}
+		keys = append(keys, &key)
+	}
+
+	return keys, rows.Err()
+}
+
+// DeleteSigningKey removes a token signing key from PostgreSQL
+func (s *Storage) DeleteSigningKey(ctx context.Context, keyID string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM signing_keys WHERE key_id = $1`, keyID); err != nil {
+		return fmt.Errorf("failed to delete signing key: %w", err)
+	}
+	return nil
+}
+
+// validUserIDs filters out IDs that cannot belong to a user, such as bot IDs
+func validUserIDs(userIDs []string) []string {
+	var valid []string
+	for _, userID := range userIDs {
+		if _, err := uuid.Parse(userID); err == nil {
+			valid = append(valid, userID)
+		}
+	}
+	return valid
+}
+
 // Close closes both PostgreSQL and Redis connections
 func (s *Storage) Close() error {
 	var dbErr, redisErr error
@@ -0,0 +1,255 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/laerson/mancala/internal/events"
+)
+
+// Scopes of failed login counters
+const (
+	ThrottleScopeUsername = "username"
+	ThrottleScopeIP       = "ip"
+)
+
+// throttlePolicy sets how failed logins are slowed down. The first
+// freeAttempts failures are free, each one after doubles the wait starting at
+// baseDelay, and maxFailures failures lock logins out.
+type throttlePolicy struct {
+	freeAttempts int
+	maxFailures  int
+	baseDelay    time.Duration
+	maxDelay     time.Duration
+	lockout      time.Duration
+}
+
+var (
+	// usernamePolicy protects a single account from password guessing
+	usernamePolicy = throttlePolicy{
+		freeAttempts: 3,
+		maxFailures:  10,
+		baseDelay:    time.Second,
+		maxDelay:     time.Minute,
+		lockout:      15 * time.Minute,
+	}
+
+	// ipPolicy protects every account from a single client, allowing for
+	// several players behind the same address
+	ipPolicy = throttlePolicy{
+		freeAttempts: 20,
+		maxFailures:  100,
+		baseDelay:    time.Second,
+		maxDelay:     time.Minute,
+		lockout:      15 * time.Minute,
+	}
+)
+
+// failedLoginWindow is how long failed logins are counted after the last one
+const failedLoginWindow = time.Hour
+
+// wait returns how long logins must wait after the given number of failures,
+// and whether that is a lockout
+func (p throttlePolicy) wait(failures int) (time.Duration, bool) {
+	if failures >= p.maxFailures {
+		return p.lockout, true
+	}
+	if failures <= p.freeAttempts {
+		return 0, false
+	}
+
+	delay := p.baseDelay
+	for i := p.freeAttempts + 1; i < failures && delay < p.maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, p.maxDelay), false
+}
+
+// Lockout describes logins locked out by failed attempts
+type Lockout struct {
+	Scope       string // ThrottleScopeUsername or ThrottleScopeIP
+	Failures    int
+	LockedUntil time.Time
+}
+
+// LoginFailure is the outcome of a failed login
+type LoginFailure struct {
+	RetryAfter time.Duration // How long the next attempt must wait
+	Lockouts   []Lockout     // Lockouts the failure started
+}
+
+// LoginThrottle counts failed logins per username and per client IP address
+// and slows further attempts down, exponentially and then with a lockout
+type LoginThrottle interface {
+	// RetryAfter returns how long logins for the username or from the IP must wait, zero when they may go ahead
+	RetryAfter(ctx context.Context, username, ip string) (time.Duration, error)
+	// RecordFailure counts a failed login for the username and the IP
+	RecordFailure(ctx context.Context, username, ip string) (LoginFailure, error)
+	// Reset forgets the failed logins of a username after a successful one
+	Reset(ctx context.Context, username string) error
+}
+
+// SecurityEventPublisher publishes security events, see events.EventPublisher
+type SecurityEventPublisher interface {
+	PublishAccountLocked(ctx context.Context, data events.AccountLockedData) error
+}
+
+// RedisLoginThrottle keeps failed login counters in Redis, shared by every
+// auth service instance
+type RedisLoginThrottle struct {
+	client *redis.Client
+}
+
+// NewRedisLoginThrottle creates a login throttle backed by Redis
+func NewRedisLoginThrottle(redisAddr string) *RedisLoginThrottle {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: redisAddr,
+	})
+
+	// Test connection
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		log.Printf("Warning: Failed to connect to Redis for the login throttle: %v", err)
+	}
+
+	return &RedisLoginThrottle{client: rdb}
+}
+
+// failedLoginsKey returns the key counting the failed logins of a username or IP
+func failedLoginsKey(scope, value string) string {
+	return fmt.Sprintf("login_failures:%s:%s", scope, value)
+}
+
+// loginBlockedKey returns the key blocking logins of a username or IP until it expires
+func loginBlockedKey(scope, value string) string {
+	return fmt.Sprintf("login_blocked:%s:%s", scope, value)
+}
+
+// throttleTargets returns the counters a login attempt counts against.
+// Usernames are compared case-insensitively so case changes don't get more attempts.
+func throttleTargets(username, ip string) map[string]string {
+	targets := map[string]string{ThrottleScopeUsername: strings.ToLower(username)}
+	if ip != "" {
+		targets[ThrottleScopeIP] = ip
+	}
+	return targets
+}
+
+// RetryAfter returns how long logins for the username or from the IP must wait
+func (t *RedisLoginThrottle) RetryAfter(ctx context.Context, username, ip string) (time.Duration, error) {
+	var retryAfter time.Duration
+	for scope, value := range throttleTargets(username, ip) {
+		ttl, err := t.client.PTTL(ctx, loginBlockedKey(scope, value)).Result()
+		if err != nil {
+			return 0, fmt.Errorf("failed to check login block: %w", err)
+		}
+		retryAfter = max(retryAfter, ttl) // Negative when not blocked
+	}
+
+	return retryAfter, nil
+}
+
+// RecordFailure counts a failed login and blocks further attempts as the policies require
+func (t *RedisLoginThrottle) RecordFailure(ctx context.Context, username, ip string) (LoginFailure, error) {
+	var failure LoginFailure
+	for scope, value := range throttleTargets(username, ip) {
+		policy := usernamePolicy
+		if scope == ThrottleScopeIP {
+			policy = ipPolicy
+		}
+
+		key := failedLoginsKey(scope, value)
+		pipe := t.client.TxPipeline()
+		incr := pipe.Incr(ctx, key)
+		pipe.Expire(ctx, key, failedLoginWindow)
+		if _, err := pipe.Exec(ctx); err != nil {
+			return failure, fmt.Errorf("failed to count failed login: %w", err)
+		}
+
+		failures := int(incr.Val())
+		wait, locked := policy.wait(failures)
+		if wait <= 0 {
+			continue
+		}
+
+		if err := t.client.Set(ctx, loginBlockedKey(scope, value), "1", wait).Err(); err != nil {
+			return failure, fmt.Errorf("failed to block logins: %w", err)
+		}
+
+		failure.RetryAfter = max(failure.RetryAfter, wait)
+		if locked {
+			failure.Lockouts = append(failure.Lockouts, Lockout{
+				Scope:       scope,
+				Failures:    failures,
+				LockedUntil: time.Now().Add(wait),
+			})
+		}
+	}
+
+	return failure, nil
+}
+
+// Reset forgets the failed logins of a username. Failures from the IP still
+// count, a valid account must not clear a guessing client's record.
+func (t *RedisLoginThrottle) Reset(ctx context.Context, username string) error {
+	username = strings.ToLower(username)
+	err := t.client.Del(ctx,
+		failedLoginsKey(ThrottleScopeUsername, username),
+		loginBlockedKey(ThrottleScopeUsername, username),
+	).Err()
+	if err != nil {
+		return fmt.Errorf("failed to reset failed logins: %w", err)
+	}
+
+	return nil
+}
+
+// clientIP returns the IP address of the client a login is made for. Logins
+// need no token, so anyone reaching the auth service could forward made-up
+// addresses: only the one forwarded in x-forwarded-for by a caller with a
+// ScopeClientIP service token, i.e. the gateway, is trusted. Any other call
+// counts against the address of the caller itself.
+func (s *Server) clientIP(ctx context.Context) string {
+	if claims, err := s.AuthInterceptor().validateTokenFromContext(ctx); err == nil && hasScope(claims.Scopes, ScopeClientIP) {
+		if ip := forwardedIP(ctx); ip != "" {
+			return ip
+		}
+	}
+
+	return peerIP(ctx)
+}
+
+// forwardedIP returns the first address of the x-forwarded-for metadata, the original client
+func forwardedIP(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	forwarded := md.Get("x-forwarded-for")
+	if len(forwarded) == 0 {
+		return ""
+	}
+	ip, _, _ := strings.Cut(forwarded[0], ",")
+	return strings.TrimSpace(ip)
+}
+
+// peerIP returns the IP address of the caller
+func peerIP(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			return p.Addr.String()
+		}
+		return host
+	}
+
+	return ""
+}
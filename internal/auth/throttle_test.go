@@ -0,0 +1,233 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+
+	"github.com/laerson/mancala/internal/events"
+	authpb "github.com/laerson/mancala/proto/auth"
+)
+
+// mockLoginThrottle applies the throttle policies in memory
+type mockLoginThrottle struct {
+	failures     map[string]int
+	blockedUntil map[string]time.Time
+}
+
+func newMockLoginThrottle() *mockLoginThrottle {
+	return &mockLoginThrottle{
+		failures:     make(map[string]int),
+		blockedUntil: make(map[string]time.Time),
+	}
+}
+
+func (m *mockLoginThrottle) RetryAfter(ctx context.Context, username, ip string) (time.Duration, error) {
+	var retryAfter time.Duration
+	for scope, value := range throttleTargets(username, ip) {
+		retryAfter = max(retryAfter, time.Until(m.blockedUntil[scope+":"+value]))
+	}
+	return retryAfter, nil
+}
+
+func (m *mockLoginThrottle) RecordFailure(ctx context.Context, username, ip string) (LoginFailure, error) {
+	var failure LoginFailure
+	for scope, value := range throttleTargets(username, ip) {
+		policy := usernamePolicy
+		if scope == ThrottleScopeIP {
+			policy = ipPolicy
+		}
+
+		key := scope + ":" + value
+		m.failures[key]++
+		wait, locked := policy.wait(m.failures[key])
+		if wait <= 0 {
+			continue
+		}
+
+		m.blockedUntil[key] = time.Now().Add(wait)
+		failure.RetryAfter = max(failure.RetryAfter, wait)
+		if locked {
+			failure.Lockouts = append(failure.Lockouts, Lockout{Scope: scope, Failures: m.failures[key], LockedUntil: m.blockedUntil[key]})
+		}
+	}
+	return failure, nil
+}
+
+func (m *mockLoginThrottle) Reset(ctx context.Context, username string) error {
+	key := ThrottleScopeUsername + ":" + strings.ToLower(username)
+	delete(m.failures, key)
+	delete(m.blockedUntil, key)
+	return nil
+}
+
+// unblock lets the next attempt through without forgetting the failures
+func (m *mockLoginThrottle) unblock() {
+	m.blockedUntil = make(map[string]time.Time)
+}
+
+// mockSecurityEvents records published security events
+type mockSecurityEvents struct {
+	locked []events.AccountLockedData
+}
+
+func (m *mockSecurityEvents) PublishAccountLocked(ctx context.Context, data events.AccountLockedData) error {
+	m.locked = append(m.locked, data)
+	return nil
+}
+
+func TestThrottlePolicy_Wait(t *testing.T) {
+	tests := []struct {
+		failures   int
+		wantWait   time.Duration
+		wantLocked bool
+	}{
+		{1, 0, false},
+		{3, 0, false},
+		{4, time.Second, false},
+		{5, 2 * time.Second, false},
+		{7, 8 * time.Second, false},
+		{9, 32 * time.Second, false},
+		{10, 15 * time.Minute, true},
+		{12, 15 * time.Minute, true},
+	}
+
+	for _, tt := range tests {
+		wait, locked := usernamePolicy.wait(tt.failures)
+		if wait != tt.wantWait || locked != tt.wantLocked {
+			t.Errorf("wait(%d) = %v, %v, want %v, %v", tt.failures, wait, locked, tt.wantWait, tt.wantLocked)
+		}
+	}
+
+	// Delays stop growing at the maximum
+	if wait, locked := ipPolicy.wait(60); wait != ipPolicy.maxDelay || locked {
+		t.Errorf("wait(60) = %v, %v, want %v", wait, locked, ipPolicy.maxDelay)
+	}
+}
+
+// gatewayContext returns the context of a login forwarded by the gateway for
+// the given client address
+func gatewayContext(t *testing.T, server *Server, clientIP string) context.Context {
+	token, err := server.jwtManager.GenerateServiceToken("gateway", servicePermissions["gateway"], time.Minute)
+	if err != nil {
+		t.Fatalf("Failed to generate service token: %v", err)
+	}
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token, "x-forwarded-for", clientIP))
+}
+
+func TestServer_ClientIP(t *testing.T) {
+	server := newLogoutTestServer(t)
+	caller := peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 41234}})
+
+	if ip := server.clientIP(caller); ip != "10.0.0.7" {
+		t.Errorf("clientIP() of a direct call = %q, want 10.0.0.7", ip)
+	}
+
+	gateway := peer.NewContext(gatewayContext(t, server, "203.0.113.9, 10.0.0.1"), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 41234}})
+	if ip := server.clientIP(gateway); ip != "203.0.113.9" {
+		t.Errorf("clientIP() of a call forwarded by the gateway = %q, want 203.0.113.9", ip)
+	}
+
+	// Callers without the gateway's scope cannot pick the address they are throttled by
+	matchmakingToken, _ := server.jwtManager.GenerateServiceToken("matchmaking", servicePermissions["matchmaking"], time.Minute)
+	userToken, _ := server.jwtManager.GenerateAccessToken("alice-id", "alice", RolePlayer)
+	for name, md := range map[string]metadata.MD{
+		"no token":         metadata.Pairs("x-forwarded-for", "198.51.100.1"),
+		"a user token":     metadata.Pairs("authorization", "Bearer "+userToken, "x-forwarded-for", "198.51.100.1"),
+		"another service":  metadata.Pairs("authorization", "Bearer "+matchmakingToken, "x-forwarded-for", "198.51.100.1"),
+		"an invalid token": metadata.Pairs("authorization", "Bearer forged", "x-forwarded-for", "198.51.100.1"),
+	} {
+		spoofed := metadata.NewIncomingContext(caller, md)
+		if ip := server.clientIP(spoofed); ip != "10.0.0.7" {
+			t.Errorf("clientIP() of a spoofed call with %s = %q, want the caller's 10.0.0.7", name, ip)
+		}
+	}
+}
+
+func TestServer_LoginThrottling_SpoofedIP(t *testing.T) {
+	server := newLogoutTestServer(t)
+	throttle := newMockLoginThrottle()
+	server.loginThrottle = throttle
+
+	// A direct caller making up a new address for each attempt is still counted by its own
+	for i := 0; i < ipPolicy.freeAttempts+1; i++ {
+		md := metadata.Pairs("x-forwarded-for", fmt.Sprintf("198.51.100.%d", i))
+		ctx := peer.NewContext(metadata.NewIncomingContext(context.Background(), md), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.7"), Port: 41234}})
+		server.Login(ctx, &authpb.LoginRequest{Username: fmt.Sprintf("guess%d", i), Password: "wrong"})
+	}
+
+	if got := throttle.failures[ThrottleScopeIP+":10.0.0.7"]; got != ipPolicy.freeAttempts+1 {
+		t.Errorf("Failed logins counted for the caller = %d, want %d", got, ipPolicy.freeAttempts+1)
+	}
+	for key := range throttle.failures {
+		if strings.HasPrefix(key, ThrottleScopeIP+":198.51.100.") {
+			t.Errorf("Failed login counted for the spoofed address %s", key)
+		}
+	}
+}
+
+func TestServer_LoginThrottling(t *testing.T) {
+	server := newLogoutTestServer(t)
+	throttle := newMockLoginThrottle()
+	securityEvents := &mockSecurityEvents{}
+	server.loginThrottle = throttle
+	server.securityEvents = securityEvents
+	ctx := gatewayContext(t, server, "203.0.113.9")
+
+	attempt := func(password string) *authpb.LoginResponse {
+		resp, err := server.Login(ctx, &authpb.LoginRequest{Username: "alice", Password: password})
+		if err != nil {
+			t.Fatalf("Login() error = %v", err)
+		}
+		return resp
+	}
+
+	for i := 1; i <= usernamePolicy.freeAttempts; i++ {
+		if resp := attempt("wrong"); resp.Success || resp.RetryAfterSeconds != 0 {
+			t.Fatalf("Failed login %d = %v, want no wait", i, resp)
+		}
+	}
+	if resp := attempt("wrong"); resp.RetryAfterSeconds != 1 {
+		t.Fatalf("Failed login after the free attempts = %v, want a 1s wait", resp)
+	}
+
+	// Even the right password waits, without a bcrypt check
+	if resp := attempt("password123"); resp.Success || resp.RetryAfterSeconds != 1 {
+		t.Errorf("Throttled login = %v, want refused with a 1s wait", resp)
+	}
+
+	for i := usernamePolicy.freeAttempts + 2; i <= usernamePolicy.maxFailures; i++ {
+		throttle.unblock()
+		attempt("wrong")
+	}
+	if len(securityEvents.locked) != 1 {
+		t.Fatalf("Published %d account locked events, want 1", len(securityEvents.locked))
+	}
+	locked := securityEvents.locked[0]
+	if locked.Scope != ThrottleScopeUsername || locked.UserID != "alice-id" || locked.IP != "203.0.113.9" || locked.Failures != usernamePolicy.maxFailures {
+		t.Errorf("Account locked event = %+v, want alice locked from 203.0.113.9", locked)
+	}
+	if resp := attempt("password123"); resp.Success || resp.RetryAfterSeconds < int64(time.Minute/time.Second) {
+		t.Errorf("Login of a locked account = %v, want refused until the lockout ends", resp)
+	}
+
+	// Another account from the same address is not locked
+	if resp, err := server.Login(ctx, &authpb.LoginRequest{Username: "bob", Password: "password123"}); err != nil || !resp.Success {
+		t.Errorf("Login() of another user = %v, %v", resp, err)
+	}
+
+	// A successful login forgets the failures of the username
+	throttle.unblock()
+	if resp := attempt("password123"); !resp.Success {
+		t.Fatalf("Login() after the lockout = %v", resp)
+	}
+	if resp := attempt("wrong"); resp.RetryAfterSeconds != 0 {
+		t.Errorf("Failed login after a successful one = %v, want no wait", resp)
+	}
+}
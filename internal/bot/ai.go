@@ -7,10 +7,22 @@ import (
 	"sort"
 	"time"
 
+	"github.com/laerson/mancala/internal/rules"
 	botpb "github.com/laerson/mancala/proto/bot"
 	enginepb "github.com/laerson/mancala/proto/engine"
 )
 
+const (
+	// defaultSearchTime is used when a move request does not set a time limit
+	defaultSearchTime = 1 * time.Second
+
+	// maxSearchDepth bounds iterative deepening once the time limit is generous
+	maxSearchDepth = 32
+
+	// winScore is added to the final store difference of a finished game
+	winScore = 1000
+)
+
 // AIEngine handles bot move calculations
 type AIEngine struct {
 	rand *rand.Rand
@@ -23,8 +35,9 @@ func NewAIEngine() *AIEngine {
 	}
 }
 
-// CalculateMove determines the best move for a bot given the game state
-func (ai *AIEngine) CalculateMove(gameState *enginepb.GameState, difficulty botpb.BotDifficulty, botPlayerID string) (uint32, string, int32, error) {
+// CalculateMove determines the best move for a bot given the game state.
+// A zero time limit lets the hard bot search for defaultSearchTime.
+func (ai *AIEngine) CalculateMove(gameState *enginepb.GameState, difficulty botpb.BotDifficulty, botPlayerID string, timeLimit time.Duration) (uint32, string, int32, error) {
 	// Determine which player the bot is (Player 1 or Player 2)
 	botPlayer := ai.getBotPlayer(gameState, botPlayerID)
 	if botPlayer != enginepb.Player_PLAYER_ONE && botPlayer != enginepb.Player_PLAYER_TWO {
@@ -43,7 +56,7 @@ func (ai *AIEngine) CalculateMove(gameState *enginepb.GameState, difficulty botp
 	case botpb.BotDifficulty_BOT_DIFFICULTY_MEDIUM:
 		return ai.calculateMediumMove(gameState, validMoves, botPlayer)
 	case botpb.BotDifficulty_BOT_DIFFICULTY_HARD:
-		return ai.calculateHardMove(gameState, validMoves, botPlayer)
+		return ai.calculateHardMove(gameState, validMoves, botPlayer, timeLimit)
 	default:
 		return ai.calculateEasyMove(validMoves)
 	}
@@ -59,24 +72,7 @@ func (ai *AIEngine) getBotPlayer(gameState *enginepb.GameState, botPlayerID stri
 
 // getValidMoves returns all valid moves for a player
 func (ai *AIEngine) getValidMoves(gameState *enginepb.GameState, player enginepb.Player) []uint32 {
-	var validMoves []uint32
-
-	// Determine the pit range for this player
-	var startPit, endPit uint32
-	if player == enginepb.Player_PLAYER_ONE {
-		startPit, endPit = 0, 5 // Player 1 controls pits 0-5
-	} else {
-		startPit, endPit = 7, 12 // Player 2 controls pits 7-12
-	}
-
-	// Check each pit for valid moves (must have stones)
-	for pit := startPit; pit <= endPit; pit++ {
-		if pit < uint32(len(gameState.Board.Pits)) && gameState.Board.Pits[pit] > 0 {
-			validMoves = append(validMoves, pit)
-		}
-	}
-
-	return validMoves
+	return rules.ForState(gameState).LegalMoves(gameState.Board.Pits, player)
 }
 
 // calculateEasyMove - Random valid move
@@ -115,23 +111,168 @@ func (ai *AIEngine) calculateMediumMove(gameState *enginepb.GameState, validMove
 	return best.pit, best.reason, best.score, nil
 }
 
-// calculateHardMove - Advanced AI with minimax
-func (ai *AIEngine) calculateHardMove(gameState *enginepb.GameState, validMoves []uint32, botPlayer enginepb.Player) (uint32, string, int32, error) {
-	const maxDepth = 6 // Look ahead 6 moves
+// calculateHardMove - Alpha-beta search with iterative deepening
+func (ai *AIEngine) calculateHardMove(gameState *enginepb.GameState, validMoves []uint32, botPlayer enginepb.Player, timeLimit time.Duration) (uint32, string, int32, error) {
+	if timeLimit <= 0 {
+		timeLimit = defaultSearchTime
+	}
+
+	search := &alphaBetaSearch{
+		game:      rules.ForState(gameState),
+		botPlayer: botPlayer,
+		deadline:  time.Now().Add(timeLimit),
+	}
+
+	moves := append([]uint32(nil), validMoves...)
+	bestMove := moves[0]
+	bestScore := int32(math.MinInt32)
+	completedDepth := 0
+
+	for depth := 1; depth <= maxSearchDepth; depth++ {
+		move, score, ok := search.searchRoot(gameState.Board.Pits, moves, depth, completedDepth > 0)
+		if !ok {
+			break // Out of time: keep the result of the last completed depth
+		}
+		bestMove, bestScore, completedDepth = move, score, depth
+
+		// A proven win or loss cannot change with a deeper search
+		if score >= winScore || score <= -winScore {
+			break
+		}
+
+		// Search the best move first next time to get more cutoffs
+		moves = moveToFront(moves, bestMove)
+	}
+
+	reasoning := fmt.Sprintf("Alpha-beta search to depth %d (%d positions), score: %d", completedDepth, search.nodes, bestScore)
+	return bestMove, reasoning, bestScore, nil
+}
+
+// alphaBetaSearch holds the state of a single time-limited search
+type alphaBetaSearch struct {
+	game      rules.Game
+	botPlayer enginepb.Player
+	deadline  time.Time
+	timed     bool
+	timedOut  bool
+	nodes     int
+}
+
+// searchRoot searches every root move to the given depth. It reports false when the
+// deadline was hit before the depth was completed. A timed search can be interrupted.
+func (s *alphaBetaSearch) searchRoot(pits []uint32, moves []uint32, depth int, timed bool) (uint32, int32, bool) {
+	s.timed = timed
+	alpha, beta := int32(math.MinInt32), int32(math.MaxInt32)
+	bestMove := moves[0]
+	bestScore := int32(math.MinInt32)
+
+	for _, move := range moves {
+		result, err := s.game.ApplyMove(pits, s.botPlayer, move)
+		if err != nil {
+			continue
+		}
 
-	bestMove := validMoves[0]
-	bestScore := int32(math.Inf(-1))
+		score := s.alphaBeta(result, depth-1, alpha, beta)
+		if s.timedOut {
+			return 0, 0, false
+		}
 
-	for _, move := range validMoves {
-		score := ai.minimax(gameState, move, maxDepth-1, int32(math.Inf(-1)), int32(math.Inf(1)), false, botPlayer)
 		if score > bestScore {
 			bestScore = score
 			bestMove = move
 		}
+		if score > alpha {
+			alpha = score
+		}
 	}
 
-	reasoning := fmt.Sprintf("Minimax evaluation with depth %d, score: %d", maxDepth, bestScore)
-	return bestMove, reasoning, bestScore, nil
+	return bestMove, bestScore, true
+}
+
+// alphaBeta scores the position reached by a move. The bot maximizes and its opponent
+// minimizes; an extra turn keeps the same side to move, so it can be maximized twice in a row.
+func (s *alphaBetaSearch) alphaBeta(position *rules.MoveResult, depth int, alpha, beta int32) int32 {
+	s.nodes++
+	if s.timed && s.nodes%1024 == 0 && time.Now().After(s.deadline) {
+		s.timedOut = true
+	}
+	if s.timedOut {
+		return 0
+	}
+
+	if position.IsFinished {
+		return s.terminalScore(position.Pits)
+	}
+	if depth <= 0 {
+		return s.evaluate(position.Pits)
+	}
+
+	player := position.NextPlayer
+	maximizing := player == s.botPlayer
+
+	best := int32(math.MaxInt32)
+	if maximizing {
+		best = math.MinInt32
+	}
+
+	for _, move := range s.game.LegalMoves(position.Pits, player) {
+		next, err := s.game.ApplyMove(position.Pits, player, move)
+		if err != nil {
+			continue
+		}
+
+		score := s.alphaBeta(next, depth-1, alpha, beta)
+		if maximizing {
+			if score > best {
+				best = score
+			}
+			if best > alpha {
+				alpha = best
+			}
+		} else {
+			if score < best {
+				best = score
+			}
+			if best < beta {
+				beta = best
+			}
+		}
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	return best
+}
+
+// evaluate scores an unfinished position from the bot's point of view
+func (s *alphaBetaSearch) evaluate(pits []uint32) int32 {
+	return int32(pits[s.game.StoreIndex(s.botPlayer)]) - int32(pits[s.game.StoreIndex(rules.Opponent(s.botPlayer))])
+}
+
+// terminalScore scores a finished game so that any win beats any heuristic value
+func (s *alphaBetaSearch) terminalScore(pits []uint32) int32 {
+	diff := s.evaluate(pits)
+	switch {
+	case diff > 0:
+		return winScore + diff
+	case diff < 0:
+		return -winScore + diff
+	default:
+		return 0
+	}
+}
+
+// moveToFront returns the moves with the given move searched first
+func moveToFront(moves []uint32, first uint32) []uint32 {
+	ordered := []uint32{first}
+	for _, move := range moves {
+		if move != first {
+			ordered = append(ordered, move)
+		}
+	}
+	return ordered
 }
 
 // evaluateBasicMove - Heuristic evaluation for medium difficulty
@@ -168,93 +309,28 @@ func (ai *AIEngine) evaluateBasicMove(gameState *enginepb.GameState, pit uint32,
 
 // wouldCapture checks if a move would capture opponent stones
 func (ai *AIEngine) wouldCapture(gameState *enginepb.GameState, pit uint32, botPlayer enginepb.Player) bool {
-	stones := gameState.Board.Pits[pit]
-	finalPit := (pit + stones) % 14
-
-	// Check if the final pit is on our side and empty
-	var ourSideStart, ourSideEnd uint32
-	if botPlayer == enginepb.Player_PLAYER_ONE {
-		ourSideStart, ourSideEnd = 0, 5
-	} else {
-		ourSideStart, ourSideEnd = 7, 12
-	}
-
-	if finalPit >= ourSideStart && finalPit <= ourSideEnd && gameState.Board.Pits[finalPit] == 0 {
-		// Check if opposite pit has stones to capture
-		oppositePit := 12 - finalPit
-		return gameState.Board.Pits[oppositePit] > 0
-	}
-
-	return false
+	result, err := rules.ForState(gameState).ApplyMove(gameState.Board.Pits, botPlayer, pit)
+	return err == nil && result.Captured
 }
 
 // wouldGetExtraTurn checks if a move would land in the bot's mancala
 func (ai *AIEngine) wouldGetExtraTurn(gameState *enginepb.GameState, pit uint32, botPlayer enginepb.Player) bool {
-	stones := gameState.Board.Pits[pit]
-	finalPit := (pit + stones) % 14
-
-	// Check if final pit is our mancala
-	var ourMancala uint32
-	if botPlayer == enginepb.Player_PLAYER_ONE {
-		ourMancala = 6
-	} else {
-		ourMancala = 13
-	}
-
-	return finalPit == ourMancala
+	result, err := rules.ForState(gameState).ApplyMove(gameState.Board.Pits, botPlayer, pit)
+	return err == nil && result.ExtraTurn
 }
 
 // wouldLeaveVulnerable checks if a move would leave us vulnerable to captures
 func (ai *AIEngine) wouldLeaveVulnerable(gameState *enginepb.GameState, pit uint32, botPlayer enginepb.Player) bool {
+	// Oware captures from the opponent's side instead of across the board
+	if gameState.GameType != enginepb.GameType_GAME_TYPE_KALAH {
+		return false
+	}
+
 	// Simplified vulnerability check - if the pit would become empty
 	// and the opposite pit has stones, we might be vulnerable
 	if gameState.Board.Pits[pit] == 1 {
-		oppositePit := 12 - pit
+		oppositePit := rules.OppositePit(gameState.Rules, pit)
 		return gameState.Board.Pits[oppositePit] > 0
 	}
 	return false
 }
-
-// minimax algorithm for hard difficulty
-func (ai *AIEngine) minimax(gameState *enginepb.GameState, move uint32, depth int32, alpha, beta int32, isMaximizing bool, botPlayer enginepb.Player) int32 {
-	// Base case: reached depth limit or game over
-	if depth == 0 || ai.isGameOver(gameState) {
-		return ai.evaluatePosition(gameState, botPlayer)
-	}
-
-	// Simulate the move (simplified - in real implementation, we'd need the engine)
-	// For now, return a heuristic evaluation
-	return ai.evaluatePosition(gameState, botPlayer)
-}
-
-// evaluatePosition evaluates the current position for the bot
-func (ai *AIEngine) evaluatePosition(gameState *enginepb.GameState, botPlayer enginepb.Player) int32 {
-	var botMancala, opponentMancala uint32
-
-	if botPlayer == enginepb.Player_PLAYER_ONE {
-		botMancala, opponentMancala = 6, 13
-	} else {
-		botMancala, opponentMancala = 13, 6
-	}
-
-	// Simple evaluation: difference in mancala stones
-	botStones := int32(gameState.Board.Pits[botMancala])
-	opponentStones := int32(gameState.Board.Pits[opponentMancala])
-
-	return botStones - opponentStones
-}
-
-// isGameOver checks if the game is finished
-func (ai *AIEngine) isGameOver(gameState *enginepb.GameState) bool {
-	// Check if either player has no moves left
-	player1HasMoves := ai.playerHasMoves(gameState, enginepb.Player_PLAYER_ONE)
-	player2HasMoves := ai.playerHasMoves(gameState, enginepb.Player_PLAYER_TWO)
-
-	return !player1HasMoves || !player2HasMoves
-}
-
-// playerHasMoves checks if a player has any valid moves
-func (ai *AIEngine) playerHasMoves(gameState *enginepb.GameState, player enginepb.Player) bool {
-	validMoves := ai.getValidMoves(gameState, player)
-	return len(validMoves) > 0
-}
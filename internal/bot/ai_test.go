@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/laerson/mancala/internal/rules"
+	botpb "github.com/laerson/mancala/proto/bot"
+	enginepb "github.com/laerson/mancala/proto/engine"
+)
+
+// exactValue plays out every line of a small endgame to score it without pruning
+func exactValue(s *alphaBetaSearch, position *rules.MoveResult) int32 {
+	if position.IsFinished {
+		return s.terminalScore(position.Pits)
+	}
+
+	maximizing := position.NextPlayer == s.botPlayer
+	var best int32
+	for i, move := range rules.LegalMoves(nil, position.Pits, position.NextPlayer) {
+		next, _ := rules.ApplyMove(nil, position.Pits, position.NextPlayer, move)
+		score := exactValue(s, next)
+		if i == 0 || (maximizing && score > best) || (!maximizing && score < best) {
+			best = score
+		}
+	}
+	return best
+}
+
+func TestCalculateHardMove_SolvesEndgames(t *testing.T) {
+	tests := []struct {
+		name   string
+		pits   []uint32
+		player enginepb.Player
+	}{
+		{
+			name:   "extra turn before capture",
+			pits:   []uint32{0, 0, 0, 0, 1, 1, 20, 2, 1, 0, 0, 0, 1, 22},
+			player: enginepb.Player_PLAYER_ONE,
+		},
+		{
+			name:   "player two to move",
+			pits:   []uint32{2, 0, 1, 0, 0, 1, 18, 0, 1, 0, 2, 0, 1, 22},
+			player: enginepb.Player_PLAYER_TWO,
+		},
+		{
+			name:   "avoid giving away a capture",
+			pits:   []uint32{0, 3, 0, 0, 2, 0, 20, 0, 0, 4, 0, 1, 0, 18},
+			player: enginepb.Player_PLAYER_ONE,
+		},
+	}
+
+	ai := NewAIEngine()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gameState := &enginepb.GameState{
+				Board:         &enginepb.Board{Pits: tt.pits},
+				CurrentPlayer: tt.player,
+			}
+
+			move, _, score, err := ai.CalculateMove(gameState, botpb.BotDifficulty_BOT_DIFFICULTY_HARD, "bot-1234", 5*time.Second)
+			if err != nil {
+				t.Fatalf("CalculateMove() error = %v", err)
+			}
+
+			reference := &alphaBetaSearch{game: rules.ForState(gameState), botPlayer: tt.player}
+			var want int32
+			for i, candidate := range rules.LegalMoves(nil, tt.pits, tt.player) {
+				next, _ := rules.ApplyMove(nil, tt.pits, tt.player, candidate)
+				if value := exactValue(reference, next); i == 0 || value > want {
+					want = value
+				}
+			}
+
+			if score != want {
+				t.Errorf("CalculateMove() score = %d, want %d", score, want)
+			}
+
+			next, err := rules.ApplyMove(nil, tt.pits, tt.player, move)
+			if err != nil {
+				t.Fatalf("CalculateMove() chose illegal pit %d: %v", move, err)
+			}
+			if got := exactValue(reference, next); got != want {
+				t.Errorf("CalculateMove() pit %d is worth %d, want best value %d", move, got, want)
+			}
+		})
+	}
+}
+
+func TestCalculateHardMove_HonorsTimeLimit(t *testing.T) {
+	gameState := &enginepb.GameState{
+		Board:         &enginepb.Board{Pits: []uint32{4, 4, 4, 4, 4, 4, 0, 4, 4, 4, 4, 4, 4, 0}},
+		CurrentPlayer: enginepb.Player_PLAYER_ONE,
+	}
+
+	ai := NewAIEngine()
+	start := time.Now()
+	move, reasoning, _, err := ai.CalculateMove(gameState, botpb.BotDifficulty_BOT_DIFFICULTY_HARD, "bot-1234", 50*time.Millisecond)
+	elapsed := time.Since(start)
+
+	if err != nil {
+		t.Fatalf("CalculateMove() error = %v", err)
+	}
+	if !rules.IsPlayablePit(nil, move, enginepb.Player_PLAYER_ONE) || gameState.Board.Pits[move] == 0 {
+		t.Errorf("CalculateMove() pit = %d, want a legal move", move)
+	}
+	if elapsed > 500*time.Millisecond {
+		t.Errorf("CalculateMove() took %v with a 50ms limit", elapsed)
+	}
+	if reasoning == "" {
+		t.Error("CalculateMove() returned no reasoning")
+	}
+}
+
+func TestCalculateMove_OwareMustFeed(t *testing.T) {
+	// Player two has no seeds, so only pit 5 reaches their side
+	gameState := &enginepb.GameState{
+		Board:         &enginepb.Board{Pits: []uint32{1, 0, 0, 0, 0, 1, 10, 0, 0, 0, 0, 0, 0, 12}},
+		CurrentPlayer: enginepb.Player_PLAYER_ONE,
+		GameType:      enginepb.GameType_GAME_TYPE_OWARE,
+	}
+
+	difficulties := []botpb.BotDifficulty{
+		botpb.BotDifficulty_BOT_DIFFICULTY_EASY,
+		botpb.BotDifficulty_BOT_DIFFICULTY_MEDIUM,
+		botpb.BotDifficulty_BOT_DIFFICULTY_HARD,
+	}
+
+	ai := NewAIEngine()
+	for _, difficulty := range difficulties {
+		t.Run(difficulty.String(), func(t *testing.T) {
+			move, _, _, err := ai.CalculateMove(gameState, difficulty, "bot-1234", 100*time.Millisecond)
+			if err != nil {
+				t.Fatalf("CalculateMove() error = %v", err)
+			}
+			if move != 5 {
+				t.Errorf("CalculateMove() pit = %d, want 5", move)
+			}
+		})
+	}
+}
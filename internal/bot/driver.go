@@ -0,0 +1,241 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/laerson/mancala/internal/events"
+	botpb "github.com/laerson/mancala/proto/bot"
+	enginepb "github.com/laerson/mancala/proto/engine"
+	gamespb "github.com/laerson/mancala/proto/games"
+)
+
+const (
+	// moveTimeLimit is the thinking time granted to the bot service per move
+	moveTimeLimit = 2 * time.Second
+
+	// maxMoveAttempts is how often a bot move is tried before its event is left pending for a later retry
+	maxMoveAttempts = 3
+)
+
+// GamesClient is the subset of the Games service used to play bot moves
+type GamesClient interface {
+	Move(ctx context.Context, req *gamespb.MakeGameMoveRequest, opts ...grpc.CallOption) (*gamespb.MakeGameMoveResponse, error)
+	Get(ctx context.Context, req *gamespb.GetGameRequest, opts ...grpc.CallOption) (*gamespb.GetGameResponse, error)
+}
+
+// errMoveObsolete reports a bot move that no retry would get accepted: the game is over or
+// gone, or it is no longer the bot's turn because the event was stale or already handled
+var errMoveObsolete = errors.New("bot move no longer needed")
+
+// MoveClient is the subset of the Bot service used to choose bot moves
+type MoveClient interface {
+	GetMove(ctx context.Context, req *botpb.GetMoveRequest, opts ...grpc.CallOption) (*botpb.GetMoveResponse, error)
+}
+
+// Driver consumes game events and plays the bot's side of bot games
+type Driver struct {
+	consumer    *events.Consumer
+	registry    GameRegistry
+	gamesClient GamesClient
+	botClient   MoveClient
+}
+
+// moveMadeState is the part of a MOVE_MADE event the driver needs
+type moveMadeState struct {
+	PlayerID  string `json:"player_id"`
+	GameState struct {
+		Board         []uint32          `json:"board"`
+		CurrentPlayer int32             `json:"current_player"`
+		Rules         *enginepb.RuleSet `json:"rules"`
+		GameType      enginepb.GameType `json:"game_type"`
+	} `json:"game_state"`
+	MoveResult struct {
+		IsFinished bool `json:"is_finished"`
+	} `json:"move_result"`
+}
+
+// NewDriver creates a new bot driver
+func NewDriver(redisAddr string, registry GameRegistry, gamesClient GamesClient, botClient MoveClient) *Driver {
+	d := &Driver{
+		registry:    registry,
+		gamesClient: gamesClient,
+		botClient:   botClient,
+	}
+
+	// Only new events matter: replaying history would replay stale moves
+	d.consumer = events.NewConsumer(redisAddr, "bot-driver", d.handleEvent)
+	return d
+}
+
+// Start begins consuming game events from Redis streams
+func (d *Driver) Start() error {
+	return d.consumer.Start()
+}
+
+// Stop stops the bot driver
+func (d *Driver) Stop() {
+	d.consumer.Stop()
+}
+
+// handleEvent handles a game event. A move that could not be played leaves the
+// event pending, so it is retried later, by this or another replica.
+func (d *Driver) handleEvent(ctx context.Context, event events.Event) error {
+	switch event.Type {
+	case events.EventTypeMoveMade:
+		return d.handleMoveMade(ctx, event)
+	case events.EventTypeGameOver:
+		return d.registry.RemoveGame(ctx, event.GameID)
+	}
+	return nil
+}
+
+// handleMoveMade plays a bot move when the event hands the turn to a bot.
+// Extra turns arrive as MOVE_MADE events of the bot's own moves, so chains are handled naturally.
+func (d *Driver) handleMoveMade(ctx context.Context, event events.Event) error {
+	var data moveMadeState
+	if err := decodeEventData(event.Data, &data); err != nil {
+		return fmt.Errorf("failed to parse move made data: %w", err)
+	}
+
+	if data.MoveResult.IsFinished {
+		return nil
+	}
+
+	game, err := d.registry.GetGame(ctx, event.GameID)
+	if err != nil {
+		return err
+	}
+	if game == nil || enginepb.Player(data.GameState.CurrentPlayer) != game.Seat {
+		return nil
+	}
+
+	gameState := &enginepb.GameState{
+		Board:         &enginepb.Board{Pits: data.GameState.Board},
+		CurrentPlayer: game.Seat,
+		Rules:         data.GameState.Rules,
+		GameType:      data.GameState.GameType,
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= maxMoveAttempts; attempt++ {
+		lastErr = d.playMove(ctx, game, gameState)
+		if lastErr == nil {
+			return nil
+		}
+		if errors.Is(lastErr, errMoveObsolete) {
+			log.Printf("Dropping bot %s move in game %s: %v", game.BotID, game.GameID, lastErr)
+			return nil
+		}
+		log.Printf("Bot %s move attempt %d in game %s failed: %v", game.BotID, attempt, game.GameID, lastErr)
+		if attempt == maxMoveAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(time.Duration(attempt) * 500 * time.Millisecond):
+		}
+
+		// Another request may have changed the game, so the retry starts from its current state
+		gameState, lastErr = d.currentState(ctx, game)
+		if errors.Is(lastErr, errMoveObsolete) {
+			log.Printf("Dropping bot %s move in game %s: %v", game.BotID, game.GameID, lastErr)
+			return nil
+		}
+		if lastErr != nil {
+			return lastErr
+		}
+	}
+
+	return lastErr
+}
+
+// currentState reads the game from the games service and returns its state
+// when it is still the bot's turn
+func (d *Driver) currentState(ctx context.Context, game *BotGame) (*enginepb.GameState, error) {
+	resp, err := d.gamesClient.Get(ctx, &gamespb.GetGameRequest{GameId: game.GameID})
+	if err != nil {
+		return nil, fmt.Errorf("games service error: %w", err)
+	}
+	if gameErr := resp.GetError(); gameErr != nil {
+		return nil, gameError(gameErr)
+	}
+
+	current := resp.GetGame()
+	if current.GetStatus() != gamespb.GameStatus_GAME_STATUS_IN_PROGRESS {
+		return nil, fmt.Errorf("%w: game is finished", errMoveObsolete)
+	}
+	if current.GetState().GetCurrentPlayer() != game.Seat {
+		return nil, fmt.Errorf("%w: not the bot's turn", errMoveObsolete)
+	}
+	return current.State, nil
+}
+
+// gameError converts an in-message games service error. Refusals that a retry
+// can't change make the move obsolete; version conflicts and failures of the
+// service itself are worth retrying.
+func gameError(gameErr *gamespb.Error) error {
+	switch gameErr.Code {
+	case gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT, gamespb.ErrorCode_ERROR_CODE_INTERNAL:
+		return fmt.Errorf("games service error: %s", gameErr.Message)
+	default:
+		return fmt.Errorf("%w: %s", errMoveObsolete, gameErr.Message)
+	}
+}
+
+// playMove asks the bot service for a move and submits it to the games service as the bot
+func (d *Driver) playMove(ctx context.Context, game *BotGame, gameState *enginepb.GameState) error {
+	moveResp, err := d.botClient.GetMove(ctx, &botpb.GetMoveRequest{
+		GameState:   gameState,
+		Difficulty:  game.Difficulty,
+		BotId:       game.BotID,
+		TimeLimitMs: int32(moveTimeLimit / time.Millisecond),
+	})
+	if err != nil {
+		return fmt.Errorf("bot service error: %w", err)
+	}
+
+	var pitIndex uint32
+	switch result := moveResp.Result.(type) {
+	case *botpb.GetMoveResponse_Move:
+		pitIndex = result.Move.PitIndex
+	case *botpb.GetMoveResponse_Error:
+		return fmt.Errorf("bot service error: %s", result.Error.Message)
+	default:
+		return fmt.Errorf("unexpected bot service response")
+	}
+
+	// The games client carries a service token allowed to move for bot players
+	gameResp, err := d.gamesClient.Move(ctx, &gamespb.MakeGameMoveRequest{
+		PlayerId: game.BotID,
+		GameId:   game.GameID,
+		PitIndex: pitIndex,
+	})
+	if err != nil {
+		return fmt.Errorf("games service error: %w", err)
+	}
+
+	if gameErr := gameResp.GetError(); gameErr != nil {
+		return gameError(gameErr)
+	}
+
+	log.Printf("Bot %s played pit %d in game %s", game.BotID, pitIndex, game.GameID)
+	return nil
+}
+
+// decodeEventData converts generic event data into a typed struct
+func decodeEventData(input map[string]interface{}, output interface{}) error {
+	bytes, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(bytes, output)
+}
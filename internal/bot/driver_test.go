@@ -0,0 +1,319 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+
+	"github.com/laerson/mancala/internal/events"
+	botpb "github.com/laerson/mancala/proto/bot"
+	enginepb "github.com/laerson/mancala/proto/engine"
+	gamespb "github.com/laerson/mancala/proto/games"
+)
+
+// Mock registry for testing
+type mockRegistry struct {
+	games map[string]*BotGame
+}
+
+func (m *mockRegistry) RegisterGame(ctx context.Context, game *BotGame) error {
+	m.games[game.GameID] = game
+	return nil
+}
+
+func (m *mockRegistry) GetGame(ctx context.Context, gameID string) (*BotGame, error) {
+	return m.games[gameID], nil
+}
+
+func (m *mockRegistry) RemoveGame(ctx context.Context, gameID string) error {
+	delete(m.games, gameID)
+	return nil
+}
+
+// Mock Games client recording the moves it receives, failing them while err is set and
+// refusing them with the queued in-message errors. Get returns game.
+type mockGamesClient struct {
+	moves      []*gamespb.MakeGameMoveRequest
+	err        error
+	moveErrors []*gamespb.Error
+	game       *gamespb.Game
+	gets       int
+}
+
+func (m *mockGamesClient) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest, opts ...grpc.CallOption) (*gamespb.MakeGameMoveResponse, error) {
+	m.moves = append(m.moves, req)
+	if m.err != nil {
+		return nil, m.err
+	}
+	if len(m.moveErrors) > 0 {
+		gameErr := m.moveErrors[0]
+		m.moveErrors = m.moveErrors[1:]
+		return &gamespb.MakeGameMoveResponse{Result: &gamespb.MakeGameMoveResponse_Error{Error: gameErr}}, nil
+	}
+	return &gamespb.MakeGameMoveResponse{
+		Result: &gamespb.MakeGameMoveResponse_MoveResult{
+			MoveResult: &enginepb.MoveResult{},
+		},
+	}, nil
+}
+
+func (m *mockGamesClient) Get(ctx context.Context, req *gamespb.GetGameRequest, opts ...grpc.CallOption) (*gamespb.GetGameResponse, error) {
+	m.gets++
+	if m.game == nil {
+		return &gamespb.GetGameResponse{Result: &gamespb.GetGameResponse_Error{
+			Error: &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_NOT_FOUND, Message: "game not found"},
+		}}, nil
+	}
+	return &gamespb.GetGameResponse{Result: &gamespb.GetGameResponse_Game{Game: m.game}}, nil
+}
+
+// Mock Bot client always choosing the same pit
+type mockMoveClient struct {
+	requests []*botpb.GetMoveRequest
+}
+
+func (m *mockMoveClient) GetMove(ctx context.Context, req *botpb.GetMoveRequest, opts ...grpc.CallOption) (*botpb.GetMoveResponse, error) {
+	m.requests = append(m.requests, req)
+	return &botpb.GetMoveResponse{
+		Result: &botpb.GetMoveResponse_Move{
+			Move: &botpb.MoveResult{PitIndex: 9},
+		},
+	}, nil
+}
+
+func newTestDriver() (*Driver, *mockRegistry, *mockGamesClient, *mockMoveClient) {
+	registry := &mockRegistry{games: map[string]*BotGame{
+		"game1": {
+			GameID:     "game1",
+			BotID:      "bot-1234",
+			Seat:       enginepb.Player_PLAYER_TWO,
+			Difficulty: botpb.BotDifficulty_BOT_DIFFICULTY_HARD,
+		},
+	}}
+	gamesClient := &mockGamesClient{game: &gamespb.Game{
+		Id:     "game1",
+		Status: gamespb.GameStatus_GAME_STATUS_IN_PROGRESS,
+		State: &enginepb.GameState{
+			Board:         &enginepb.Board{Pits: []uint32{0, 0, 6, 6, 5, 5, 1, 4, 4, 4, 4, 4, 4, 1}},
+			CurrentPlayer: enginepb.Player_PLAYER_TWO,
+		},
+	}}
+	moveClient := &mockMoveClient{}
+	driver := NewDriver("localhost:6379", registry, gamesClient, moveClient)
+	return driver, registry, gamesClient, moveClient
+}
+
+func moveMadeEvent(gameID, playerID string, currentPlayer enginepb.Player, isFinished bool) events.Event {
+	return events.Event{
+		ID:     "event1",
+		Type:   events.EventTypeMoveMade,
+		GameID: gameID,
+		Data: map[string]interface{}{
+			"player_id": playerID,
+			"pit_index": float64(0),
+			"game_state": map[string]interface{}{
+				"board":          []interface{}{float64(0), float64(5), float64(5), float64(5), float64(5), float64(4), float64(0), float64(4), float64(4), float64(4), float64(4), float64(4), float64(4), float64(0)},
+				"current_player": float64(currentPlayer),
+			},
+			"move_result": map[string]interface{}{
+				"is_finished": isFinished,
+			},
+		},
+	}
+}
+
+func TestDriver_PlaysBotTurn(t *testing.T) {
+	driver, _, gamesClient, moveClient := newTestDriver()
+
+	err := driver.handleMoveMade(context.Background(), moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false))
+	if err != nil {
+		t.Fatalf("handleMoveMade() error = %v, want nil", err)
+	}
+
+	if len(moveClient.requests) != 1 {
+		t.Fatalf("Expected 1 GetMove request, got %d", len(moveClient.requests))
+	}
+	if moveClient.requests[0].Difficulty != botpb.BotDifficulty_BOT_DIFFICULTY_HARD {
+		t.Errorf("GetMove difficulty = %v, want %v", moveClient.requests[0].Difficulty, botpb.BotDifficulty_BOT_DIFFICULTY_HARD)
+	}
+	if got := moveClient.requests[0].GameState.Board.Pits[1]; got != 5 {
+		t.Errorf("GetMove board pit 1 = %d, want 5", got)
+	}
+
+	if len(gamesClient.moves) != 1 {
+		t.Fatalf("Expected 1 Move request, got %d", len(gamesClient.moves))
+	}
+	move := gamesClient.moves[0]
+	if move.PlayerId != "bot-1234" || move.GameId != "game1" || move.PitIndex != 9 {
+		t.Errorf("Move() request = %v, want bot-1234 playing pit 9 in game1", move)
+	}
+}
+
+func TestDriver_PlaysExtraTurn(t *testing.T) {
+	driver, _, gamesClient, _ := newTestDriver()
+
+	// The bot's own move landed in its store, so it is still the bot's turn
+	err := driver.handleMoveMade(context.Background(), moveMadeEvent("game1", "bot-1234", enginepb.Player_PLAYER_TWO, false))
+	if err != nil {
+		t.Fatalf("handleMoveMade() error = %v, want nil", err)
+	}
+
+	if len(gamesClient.moves) != 1 {
+		t.Errorf("Expected bot to move again on extra turn, got %d moves", len(gamesClient.moves))
+	}
+}
+
+func TestDriver_PassesRuleSet(t *testing.T) {
+	driver, _, _, moveClient := newTestDriver()
+
+	event := moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false)
+	event.Data["game_state"].(map[string]interface{})["rules"] = map[string]interface{}{
+		"pits_per_side":   float64(4),
+		"disable_capture": true,
+		"sweep_policy":    float64(enginepb.SweepPolicy_SWEEP_TO_MOVER),
+	}
+
+	if err := driver.handleMoveMade(context.Background(), event); err != nil {
+		t.Fatalf("handleMoveMade() error = %v, want nil", err)
+	}
+
+	if len(moveClient.requests) != 1 {
+		t.Fatalf("Expected 1 GetMove request, got %d", len(moveClient.requests))
+	}
+	got := moveClient.requests[0].GameState.Rules
+	if got.GetPitsPerSide() != 4 || !got.GetDisableCapture() || got.GetSweepPolicy() != enginepb.SweepPolicy_SWEEP_TO_MOVER {
+		t.Errorf("GetMove rules = %v, want 4 pits per side without captures, sweeping to mover", got)
+	}
+}
+
+func TestDriver_PassesGameType(t *testing.T) {
+	driver, _, _, moveClient := newTestDriver()
+
+	event := moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false)
+	event.Data["game_state"].(map[string]interface{})["game_type"] = float64(enginepb.GameType_GAME_TYPE_OWARE)
+
+	if err := driver.handleMoveMade(context.Background(), event); err != nil {
+		t.Fatalf("handleMoveMade() error = %v, want nil", err)
+	}
+
+	if len(moveClient.requests) != 1 {
+		t.Fatalf("Expected 1 GetMove request, got %d", len(moveClient.requests))
+	}
+	if got := moveClient.requests[0].GameState.GameType; got != enginepb.GameType_GAME_TYPE_OWARE {
+		t.Errorf("GetMove game type = %v, want %v", got, enginepb.GameType_GAME_TYPE_OWARE)
+	}
+}
+
+func TestDriver_IgnoresOtherTurns(t *testing.T) {
+	tests := []struct {
+		name  string
+		event events.Event
+	}{
+		{
+			name:  "human's turn",
+			event: moveMadeEvent("game1", "bot-1234", enginepb.Player_PLAYER_ONE, false),
+		},
+		{
+			name:  "game finished",
+			event: moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, true),
+		},
+		{
+			name:  "not a bot game",
+			event: moveMadeEvent("game2", "human", enginepb.Player_PLAYER_TWO, false),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, _, gamesClient, moveClient := newTestDriver()
+
+			if err := driver.handleMoveMade(context.Background(), tt.event); err != nil {
+				t.Fatalf("handleMoveMade() error = %v, want nil", err)
+			}
+
+			if len(moveClient.requests) != 0 || len(gamesClient.moves) != 0 {
+				t.Errorf("Expected no bot move, got %d GetMove and %d Move requests", len(moveClient.requests), len(gamesClient.moves))
+			}
+		})
+	}
+}
+
+func TestDriver_LeavesFailedMovePending(t *testing.T) {
+	driver, _, gamesClient, _ := newTestDriver()
+	gamesClient.err = errors.New("games service unavailable")
+
+	// The error keeps the event pending, so the consumer delivers it again later
+	err := driver.handleEvent(context.Background(), moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false))
+	if err == nil {
+		t.Fatal("handleEvent() error = nil, want the move failure")
+	}
+	if len(gamesClient.moves) != maxMoveAttempts {
+		t.Errorf("Expected %d Move attempts, got %d", maxMoveAttempts, len(gamesClient.moves))
+	}
+
+	gamesClient.err = nil
+	if err := driver.handleEvent(context.Background(), moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false)); err != nil {
+		t.Errorf("handleEvent() of the redelivered event error = %v, want nil", err)
+	}
+}
+
+func TestDriver_DropsObsoleteMoves(t *testing.T) {
+	tests := []struct {
+		name string
+		code gamespb.ErrorCode
+	}{
+		{name: "game finished", code: gamespb.ErrorCode_ERROR_CODE_GAME_FINISHED},
+		{name: "game archived", code: gamespb.ErrorCode_ERROR_CODE_NOT_FOUND},
+		{name: "not the bot's game", code: gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED},
+		{name: "stale event", code: gamespb.ErrorCode_ERROR_CODE_NOT_YOUR_TURN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			driver, _, gamesClient, moveClient := newTestDriver()
+			gamesClient.moveErrors = []*gamespb.Error{{Code: tt.code, Message: "refused"}}
+
+			// Acknowledged right away, as no retry would get the move accepted
+			err := driver.handleEvent(context.Background(), moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false))
+			if err != nil {
+				t.Fatalf("handleEvent() error = %v, want nil", err)
+			}
+			if len(moveClient.requests) != 1 || len(gamesClient.moves) != 1 {
+				t.Errorf("Expected a single attempt, got %d GetMove and %d Move requests", len(moveClient.requests), len(gamesClient.moves))
+			}
+		})
+	}
+}
+
+func TestDriver_RetriesVersionConflictFromCurrentGame(t *testing.T) {
+	driver, _, gamesClient, moveClient := newTestDriver()
+	gamesClient.moveErrors = []*gamespb.Error{{Code: gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT, Message: "conflict"}}
+
+	err := driver.handleEvent(context.Background(), moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false))
+	if err != nil {
+		t.Fatalf("handleEvent() error = %v, want nil", err)
+	}
+
+	if gamesClient.gets != 1 || len(gamesClient.moves) != 2 {
+		t.Fatalf("Expected the game re-read once and 2 Move attempts, got %d reads and %d moves", gamesClient.gets, len(gamesClient.moves))
+	}
+	if got := moveClient.requests[1].GameState.Board.Pits[2]; got != 6 {
+		t.Errorf("Retried GetMove board pit 2 = %d, want 6 from the current game", got)
+	}
+}
+
+func TestDriver_StopsRetryingWhenTurnPassed(t *testing.T) {
+	driver, _, gamesClient, _ := newTestDriver()
+	gamesClient.moveErrors = []*gamespb.Error{{Code: gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT, Message: "conflict"}}
+	gamesClient.game.State.CurrentPlayer = enginepb.Player_PLAYER_ONE
+
+	err := driver.handleEvent(context.Background(), moveMadeEvent("game1", "human", enginepb.Player_PLAYER_TWO, false))
+	if err != nil {
+		t.Fatalf("handleEvent() error = %v, want nil", err)
+	}
+	if len(gamesClient.moves) != 1 {
+		t.Errorf("Expected no retry once the turn passed, got %d Move attempts", len(gamesClient.moves))
+	}
+}
@@ -0,0 +1,107 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	botpb "github.com/laerson/mancala/proto/bot"
+	enginepb "github.com/laerson/mancala/proto/engine"
+)
+
+// botGameTTL bounds how long an abandoned bot game is remembered
+const botGameTTL = 24 * time.Hour
+
+// BotGame describes a game in which a bot occupies one of the seats
+type BotGame struct {
+	GameID     string
+	BotID      string
+	Seat       enginepb.Player
+	Difficulty botpb.BotDifficulty
+}
+
+// GameRegistry records which games are played by bots and how
+type GameRegistry interface {
+	RegisterGame(ctx context.Context, game *BotGame) error
+	// GetGame returns nil without error when the game has no bot seat
+	GetGame(ctx context.Context, gameID string) (*BotGame, error)
+	RemoveGame(ctx context.Context, gameID string) error
+}
+
+// RedisGameRegistry stores bot games in Redis so any service replica can drive them
+type RedisGameRegistry struct {
+	redisClient *redis.Client
+}
+
+// NewRedisGameRegistry creates a new Redis backed bot game registry
+func NewRedisGameRegistry(redisAddr string) *RedisGameRegistry {
+	return &RedisGameRegistry{
+		redisClient: redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		}),
+	}
+}
+
+// RegisterGame records the bot seat and difficulty of a newly created game
+func (r *RedisGameRegistry) RegisterGame(ctx context.Context, game *BotGame) error {
+	key := botGameKey(game.GameID)
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.HSet(ctx, key, map[string]interface{}{
+		"bot_id":     game.BotID,
+		"seat":       int(game.Seat),
+		"difficulty": int(game.Difficulty),
+	})
+	pipe.Expire(ctx, key, botGameTTL)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return fmt.Errorf("failed to register bot game: %w", err)
+	}
+
+	return nil
+}
+
+// GetGame looks up the bot seat of a game
+func (r *RedisGameRegistry) GetGame(ctx context.Context, gameID string) (*BotGame, error) {
+	values, err := r.redisClient.HGetAll(ctx, botGameKey(gameID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bot game: %w", err)
+	}
+
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	seat, err := strconv.Atoi(values["seat"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bot seat for game %s: %w", gameID, err)
+	}
+
+	difficulty, err := strconv.Atoi(values["difficulty"])
+	if err != nil {
+		return nil, fmt.Errorf("invalid bot difficulty for game %s: %w", gameID, err)
+	}
+
+	return &BotGame{
+		GameID:     gameID,
+		BotID:      values["bot_id"],
+		Seat:       enginepb.Player(seat),
+		Difficulty: botpb.BotDifficulty(difficulty),
+	}, nil
+}
+
+// RemoveGame forgets a finished bot game
+func (r *RedisGameRegistry) RemoveGame(ctx context.Context, gameID string) error {
+	if err := r.redisClient.Del(ctx, botGameKey(gameID)).Err(); err != nil {
+		return fmt.Errorf("failed to remove bot game: %w", err)
+	}
+
+	return nil
+}
+
+func botGameKey(gameID string) string {
+	return fmt.Sprintf("bot_game:%s", gameID)
+}
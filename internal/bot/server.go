@@ -3,6 +3,7 @@ package bot
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -51,6 +52,7 @@ func (s *Server) GetMove(ctx context.Context, req *botpb.GetMoveRequest) (*botpb
 		req.GameState,
 		req.Difficulty,
 		req.BotId,
+		time.Duration(req.TimeLimitMs)*time.Millisecond,
 	)
 
 	if err != nil {
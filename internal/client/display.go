@@ -65,7 +65,7 @@ func DisplayBoard(board GameBoard) {
 
 // DisplayWelcome displays a welcome message
 func DisplayWelcome() {
-	fmt.Println(`
+	fmt.Print(`
 ╔═══════════════════════════════════════════════════════════════╗
 ║                      MANCALA GAME CLIENT                     ║
 ║                                                               ║
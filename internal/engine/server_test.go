@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+	"github.com/laerson/mancala/internal/rules"
 	enginepb "github.com/laerson/mancala/proto/engine"
 	"google.golang.org/protobuf/testing/protocmp"
 )
@@ -61,6 +62,7 @@ func TestMove(t *testing.T) {
 						CurrentPlayer: enginepb.Player_PLAYER_TWO,
 						IsFinished:    false,
 						Winner:        enginepb.Winner_NO_WINNER,
+						Captured:      true,
 					},
 				},
 			},
@@ -85,6 +87,7 @@ func TestMove(t *testing.T) {
 						CurrentPlayer: enginepb.Player_PLAYER_ONE,
 						IsFinished:    false,
 						Winner:        enginepb.Winner_NO_WINNER,
+						ExtraTurn:     true,
 					},
 				},
 			},
@@ -108,6 +111,97 @@ func TestMove(t *testing.T) {
 						},
 						IsFinished: true,
 						Winner:     enginepb.Winner_WINNER_PLAYER_TWO,
+						ExtraTurn:  true,
+					},
+				},
+			},
+		},
+		{
+			name: "house rules without captures on a four pit board",
+			req: &enginepb.MoveRequest{
+				GameState: &enginepb.GameState{
+					Board: &enginepb.Board{
+						Pits: []uint32{1, 0, 1, 1, 0, 1, 1, 1, 1, 0},
+					},
+					CurrentPlayer: enginepb.Player_PLAYER_ONE,
+					Rules:         &enginepb.RuleSet{PitsPerSide: 4, DisableCapture: true},
+				},
+				PitIndex: 0,
+			},
+			wantResponse: &enginepb.MoveResponse{
+				Result: &enginepb.MoveResponse_MoveResult{
+					MoveResult: &enginepb.MoveResult{
+						Board: &enginepb.Board{
+							Pits: []uint32{0, 1, 1, 1, 0, 1, 1, 1, 1, 0},
+						},
+						CurrentPlayer: enginepb.Player_PLAYER_TWO,
+						Winner:        enginepb.Winner_NO_WINNER,
+					},
+				},
+			},
+		},
+		{
+			name: "Oware capture",
+			req: &enginepb.MoveRequest{
+				GameState: &enginepb.GameState{
+					Board: &enginepb.Board{
+						Pits: []uint32{0, 0, 0, 0, 3, 0, 0, 1, 2, 5, 0, 0, 0, 0},
+					},
+					CurrentPlayer: enginepb.Player_PLAYER_ONE,
+					GameType:      enginepb.GameType_GAME_TYPE_OWARE,
+				},
+				PitIndex: 4,
+			},
+			wantResponse: &enginepb.MoveResponse{
+				Result: &enginepb.MoveResponse_MoveResult{
+					MoveResult: &enginepb.MoveResult{
+						Board: &enginepb.Board{
+							Pits: []uint32{0, 0, 0, 0, 0, 1, 5, 0, 0, 5, 0, 0, 0, 0},
+						},
+						CurrentPlayer: enginepb.Player_PLAYER_TWO,
+						IsFinished:    false,
+						Winner:        enginepb.Winner_NO_WINNER,
+						Captured:      true,
+					},
+				},
+			},
+		},
+		{
+			name: "Oware Must Feed",
+			req: &enginepb.MoveRequest{
+				GameState: &enginepb.GameState{
+					Board: &enginepb.Board{
+						Pits: []uint32{1, 0, 0, 0, 0, 1, 10, 0, 0, 0, 0, 0, 0, 12},
+					},
+					CurrentPlayer: enginepb.Player_PLAYER_ONE,
+					GameType:      enginepb.GameType_GAME_TYPE_OWARE,
+				},
+				PitIndex: 0,
+			},
+			wantResponse: &enginepb.MoveResponse{
+				Result: &enginepb.MoveResponse_Error{
+					Error: &enginepb.Error{
+						Message: "move must give the opponent seeds",
+					},
+				},
+			},
+		},
+		{
+			name: "Invalid Rule Set",
+			req: &enginepb.MoveRequest{
+				GameState: &enginepb.GameState{
+					Board: &enginepb.Board{
+						Pits: []uint32{4, 4, 4, 4, 4, 4, 0, 4, 4, 4, 4, 4, 4, 0},
+					},
+					CurrentPlayer: enginepb.Player_PLAYER_ONE,
+					Rules:         &enginepb.RuleSet{PitsPerSide: 40},
+				},
+				PitIndex: 0,
+			},
+			wantResponse: &enginepb.MoveResponse{
+				Result: &enginepb.MoveResponse_Error{
+					Error: &enginepb.Error{
+						Message: "invalid rule set: at most 12 pits per side",
 					},
 				},
 			},
@@ -126,7 +220,7 @@ func TestMove(t *testing.T) {
 			wantResponse: &enginepb.MoveResponse{
 				Result: &enginepb.MoveResponse_Error{
 					Error: &enginepb.Error{
-						Message: errInvalidBoard.Error(),
+						Message: rules.ErrInvalidBoard.Error(),
 					},
 				},
 			},
@@ -145,7 +239,7 @@ func TestMove(t *testing.T) {
 			wantResponse: &enginepb.MoveResponse{
 				Result: &enginepb.MoveResponse_Error{
 					Error: &enginepb.Error{
-						Message: errInvalidPitIndex.Error(),
+						Message: rules.ErrInvalidPitIndex.Error(),
 					},
 				},
 			},
@@ -164,7 +258,7 @@ func TestMove(t *testing.T) {
 			wantResponse: &enginepb.MoveResponse{
 				Result: &enginepb.MoveResponse_Error{
 					Error: &enginepb.Error{
-						Message: errEmptyPit.Error(),
+						Message: rules.ErrEmptyPit.Error(),
 					},
 				},
 			},
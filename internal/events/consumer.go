@@ -0,0 +1,239 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+)
+
+const (
+	// claimMinIdle is how long a delivered event may stay unacknowledged before
+	// another consumer of the group takes it over
+	claimMinIdle = 30 * time.Second
+	// claimInterval is how often pending events are reclaimed
+	claimInterval = 15 * time.Second
+	// maxDeliveries is how often an event is delivered before it is given up on
+	maxDeliveries = 5
+)
+
+// Handler handles an event read by a Consumer. Returning an error leaves the
+// event pending, so it is delivered again later.
+type Handler func(ctx context.Context, event Event) error
+
+// Consumer reads the events stream as a member of a consumer group. Every
+// replica of a service joins the same group, so each event is handled by one
+// of them. The group remembers its position, so events published while the
+// service was down are still handled.
+//
+// An event is acknowledged once its handler succeeds. Events whose handler
+// failed, or whose consumer crashed before acknowledging them, stay pending
+// and are reclaimed by any member of the group once idle for claimMinIdle,
+// until they were delivered maxDeliveries times.
+type Consumer struct {
+	redisClient   *redis.Client
+	group         string
+	name          string
+	handler       Handler
+	claimMinIdle  time.Duration
+	claimInterval time.Duration
+	mu            sync.Mutex
+	running       bool
+	ctx           context.Context
+	cancel        context.CancelFunc
+}
+
+// NewConsumer creates a consumer of the group, named after the host
+func NewConsumer(redisAddr, group string, handler Handler) *Consumer {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: redisAddr,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		name = uuid.New().String()
+	}
+
+	return &Consumer{
+		redisClient:   rdb,
+		group:         group,
+		name:          name,
+		handler:       handler,
+		claimMinIdle:  claimMinIdle,
+		claimInterval: claimInterval,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+// Start creates the group if needed, starting at new events, and begins consuming
+func (c *Consumer) Start() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.running {
+		return nil
+	}
+
+	err := c.redisClient.XGroupCreateMkStream(c.ctx, EventsStreamKey, c.group, "$").Err()
+	if err != nil && !strings.HasPrefix(err.Error(), "BUSYGROUP") {
+		return fmt.Errorf("failed to create consumer group %s: %w", c.group, err)
+	}
+
+	c.running = true
+	go c.consume()
+
+	log.Printf("Consuming events as %s in group %s", c.name, c.group)
+	return nil
+}
+
+// Stop stops consuming. Events being handled stay pending for another consumer.
+func (c *Consumer) Stop() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.running {
+		return
+	}
+
+	c.cancel()
+	c.running = false
+	log.Printf("Stopped consuming events in group %s", c.group)
+}
+
+// consume continuously reads new events, reclaiming pending ones every claimInterval
+func (c *Consumer) consume() {
+	nextClaim := time.Now()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		default:
+			if time.Now().After(nextClaim) {
+				c.claimPending()
+				nextClaim = time.Now().Add(c.claimInterval)
+			}
+
+			streams, err := c.redisClient.XReadGroup(c.ctx, &redis.XReadGroupArgs{
+				Group:    c.group,
+				Consumer: c.name,
+				Streams:  []string{EventsStreamKey, ">"},
+				Count:    10,
+				Block:    1 * time.Second,
+			}).Result()
+
+			if err != nil {
+				if err != redis.Nil && c.ctx.Err() == nil {
+					log.Printf("Error reading from stream in group %s: %v", c.group, err)
+				}
+				continue
+			}
+
+			for _, stream := range streams {
+				for _, message := range stream.Messages {
+					c.processMessage(message)
+				}
+			}
+		}
+	}
+}
+
+// claimPending takes over the events of the group left unacknowledged for
+// claimMinIdle and handles them again. XPENDING and XCLAIM are used rather
+// than XAUTOCLAIM, whose Redis 7 reply the v8 client can't read, and give the
+// delivery count for free.
+func (c *Consumer) claimPending() {
+	pending, err := c.redisClient.XPendingExt(c.ctx, &redis.XPendingExtArgs{
+		Stream: EventsStreamKey,
+		Group:  c.group,
+		Idle:   c.claimMinIdle,
+		Start:  "-",
+		End:    "+",
+		Count:  100,
+	}).Result()
+
+	if err != nil {
+		if c.ctx.Err() == nil {
+			log.Printf("Failed to list pending events in group %s: %v", c.group, err)
+		}
+		return
+	}
+
+	var ids []string
+	for _, entry := range pending {
+		if entry.RetryCount >= maxDeliveries {
+			log.Printf("Giving up on event %s in group %s after %d deliveries", entry.ID, c.group, entry.RetryCount)
+			c.redisClient.XAck(c.ctx, EventsStreamKey, c.group, entry.ID)
+			continue
+		}
+		ids = append(ids, entry.ID)
+	}
+	if len(ids) == 0 {
+		return
+	}
+
+	// Claiming only entries still idle makes sure two consumers don't both take one
+	messages, err := c.redisClient.XClaim(c.ctx, &redis.XClaimArgs{
+		Stream:   EventsStreamKey,
+		Group:    c.group,
+		Consumer: c.name,
+		MinIdle:  c.claimMinIdle,
+		Messages: ids,
+	}).Result()
+
+	if err != nil {
+		if c.ctx.Err() == nil {
+			log.Printf("Failed to reclaim pending events in group %s: %v", c.group, err)
+		}
+		return
+	}
+
+	for _, message := range messages {
+		log.Printf("Reclaimed pending event %s in group %s", message.ID, c.group)
+		c.processMessage(message)
+	}
+}
+
+// processMessage handles a stream message and acknowledges it unless the
+// handler failed. Messages that can't be parsed are acknowledged right away,
+// no retry would fix them.
+func (c *Consumer) processMessage(message redis.XMessage) {
+	event, err := ParseMessage(message)
+	if err != nil {
+		log.Printf("Dropping message %s: %v", message.ID, err)
+		c.redisClient.XAck(c.ctx, EventsStreamKey, c.group, message.ID)
+		return
+	}
+
+	if err := c.handler(c.ctx, event); err != nil {
+		log.Printf("Failed to handle event %s (%s) for game %s in group %s, leaving it pending: %v", message.ID, event.Type, event.GameID, c.group, err)
+		return
+	}
+
+	c.redisClient.XAck(c.ctx, EventsStreamKey, c.group, message.ID)
+}
+
+// ParseMessage returns the event published in a stream message
+func ParseMessage(message redis.XMessage) (Event, error) {
+	var event Event
+
+	eventDataStr, ok := message.Values["data"].(string)
+	if !ok {
+		return event, fmt.Errorf("invalid event data format")
+	}
+
+	if err := json.Unmarshal([]byte(eventDataStr), &event); err != nil {
+		return event, fmt.Errorf("failed to unmarshal event: %w", err)
+	}
+
+	return event, nil
+}
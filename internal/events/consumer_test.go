@@ -0,0 +1,184 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/testcontainers/testcontainers-go"
+	tcredis "github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+// startRedis starts a Redis container for the test and returns its address
+func startRedis(t *testing.T) string {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	redisContainer, err := tcredis.Run(ctx, "redis:7-alpine")
+	if err != nil {
+		t.Fatalf("failed to start redis container: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+			t.Logf("failed to terminate redis container: %v", err)
+		}
+	})
+
+	host, err := redisContainer.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get redis host: %v", err)
+	}
+
+	port, err := redisContainer.MappedPort(ctx, "6379")
+	if err != nil {
+		t.Fatalf("failed to get redis port: %v", err)
+	}
+
+	return host + ":" + port.Port()
+}
+
+// recordingHandler counts the deliveries of each game's events, failing the first failures of them
+type recordingHandler struct {
+	mu         sync.Mutex
+	deliveries map[string]int
+	failures   int
+}
+
+func (h *recordingHandler) handle(ctx context.Context, event Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.deliveries[event.GameID]++
+	if h.deliveries[event.GameID] <= h.failures {
+		return errors.New("handler failed")
+	}
+	return nil
+}
+
+func (h *recordingHandler) count(gameID string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.deliveries[gameID]
+}
+
+// newTestConsumer creates a consumer reclaiming events quickly
+func newTestConsumer(t *testing.T, redisAddr, name string, handler Handler) *Consumer {
+	consumer := NewConsumer(redisAddr, "test", handler)
+	consumer.name = name
+	consumer.claimMinIdle = 200 * time.Millisecond
+	consumer.claimInterval = 100 * time.Millisecond
+	return consumer
+}
+
+// waitFor polls the condition for a few seconds
+func waitFor(condition func() bool) bool {
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		if condition() {
+			return true
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	return false
+}
+
+// pendingCount returns how many events of the group are not acknowledged
+func pendingCount(t *testing.T, consumer *Consumer) int64 {
+	pending, err := consumer.redisClient.XPending(context.Background(), EventsStreamKey, consumer.group).Result()
+	if err != nil {
+		t.Fatalf("XPending() error = %v", err)
+	}
+	return pending.Count
+}
+
+func TestConsumer_RetriesFailedEvents(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	handler := &recordingHandler{deliveries: make(map[string]int), failures: 2}
+	consumer := newTestConsumer(t, redisAddr, "consumer-a", handler.handle)
+	if err := consumer.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(consumer.Stop)
+
+	publisher := NewEventPublisher(redisAddr)
+	if err := publisher.PublishGameCreated(ctx, "game1", "p1", "p2"); err != nil {
+		t.Fatalf("PublishGameCreated() error = %v", err)
+	}
+
+	if !waitFor(func() bool { return handler.count("game1") == 3 }) {
+		t.Fatalf("Event delivered %d times, want 3: two failures and a success", handler.count("game1"))
+	}
+	if !waitFor(func() bool { return pendingCount(t, consumer) == 0 }) {
+		t.Errorf("%d events pending after the handler succeeded, want 0", pendingCount(t, consumer))
+	}
+}
+
+func TestConsumer_ReclaimsEventsOfCrashedConsumer(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	handler := &recordingHandler{deliveries: make(map[string]int)}
+	consumer := newTestConsumer(t, redisAddr, "consumer-a", handler.handle)
+	if err := consumer.redisClient.XGroupCreateMkStream(ctx, EventsStreamKey, consumer.group, "$").Err(); err != nil {
+		t.Fatalf("XGroupCreateMkStream() error = %v", err)
+	}
+
+	publisher := NewEventPublisher(redisAddr)
+	if err := publisher.PublishGameCreated(ctx, "game1", "p1", "p2"); err != nil {
+		t.Fatalf("PublishGameCreated() error = %v", err)
+	}
+
+	// Another replica reads the event and crashes before acknowledging it
+	streams, err := consumer.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    consumer.group,
+		Consumer: "crashed",
+		Streams:  []string{EventsStreamKey, ">"},
+		Count:    10,
+	}).Result()
+	if err != nil || len(streams) != 1 || len(streams[0].Messages) != 1 {
+		t.Fatalf("XReadGroup() = %v, %v, want the event", streams, err)
+	}
+
+	if err := consumer.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(consumer.Stop)
+
+	if !waitFor(func() bool { return handler.count("game1") == 1 }) {
+		t.Fatalf("Event of the crashed consumer delivered %d times, want 1", handler.count("game1"))
+	}
+	if !waitFor(func() bool { return pendingCount(t, consumer) == 0 }) {
+		t.Errorf("%d events pending after the event was reclaimed, want 0", pendingCount(t, consumer))
+	}
+}
+
+func TestConsumer_GivesUpAfterMaxDeliveries(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	handler := &recordingHandler{deliveries: make(map[string]int), failures: maxDeliveries + 10}
+	consumer := newTestConsumer(t, redisAddr, "consumer-a", handler.handle)
+	if err := consumer.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(consumer.Stop)
+
+	publisher := NewEventPublisher(redisAddr)
+	if err := publisher.PublishGameCreated(ctx, "game1", "p1", "p2"); err != nil {
+		t.Fatalf("PublishGameCreated() error = %v", err)
+	}
+
+	if !waitFor(func() bool { return pendingCount(t, consumer) == 0 && handler.count("game1") > 0 }) {
+		t.Fatalf("Event still pending after %d deliveries", handler.count("game1"))
+	}
+	if got := handler.count("game1"); got != maxDeliveries {
+		t.Errorf("Event delivered %d times, want %d", got, maxDeliveries)
+	}
+}
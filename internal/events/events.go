@@ -18,9 +18,25 @@ const (
 type EventType string
 
 const (
-	EventTypeMoveMade   EventType = "MOVE_MADE"
-	EventTypeGameOver   EventType = "GAME_OVER"
-	EventTypeMatchFound EventType = "MATCH_FOUND"
+	EventTypeGameCreated EventType = "GAME_CREATED"
+	EventTypeMoveMade    EventType = "MOVE_MADE"
+	EventTypeGameOver    EventType = "GAME_OVER"
+	EventTypeMatchFound  EventType = "MATCH_FOUND"
+	EventTypeMatchFailed EventType = "MATCH_FAILED"
+	EventTypeChallenge   EventType = "CHALLENGE"
+
+	// Security events
+	EventTypeAccountLocked EventType = "ACCOUNT_LOCKED"
+)
+
+// Reasons given by GAME_OVER events, the games service's end reasons without their prefix
+const (
+	GameOverReasonCompleted        = "COMPLETED"
+	GameOverReasonResignation      = "RESIGNATION"
+	GameOverReasonDrawAgreed       = "DRAW_AGREED"
+	GameOverReasonAborted          = "ABORTED" // Ended without a result
+	GameOverReasonTimeout          = "TIMEOUT"
+	GameOverReasonEndedByModerator = "ENDED_BY_MODERATOR"
 )
 
 // Base event structure
@@ -32,19 +48,32 @@ type Event struct {
 	Data      map[string]interface{} `json:"data"`
 }
 
-// Move made event data
+// Game created event data
+type GameCreatedData struct {
+	Player1ID string `json:"player1_id"`
+	Player2ID string `json:"player2_id"`
+}
+
+// Move made event data. Both players are named so the event can be routed on
+// its own, even long after the game is over.
 type MoveMadeData struct {
 	PlayerID   string                 `json:"player_id"`
+	Player1ID  string                 `json:"player1_id"`
+	Player2ID  string                 `json:"player2_id"`
 	PitIndex   uint32                 `json:"pit_index"`
 	GameState  map[string]interface{} `json:"game_state"`
 	MoveResult map[string]interface{} `json:"move_result"`
+	Clock      map[string]interface{} `json:"clock,omitempty"`
 }
 
 // Game over event data
 type GameOverData struct {
+	Player1ID  string                 `json:"player1_id"`
+	Player2ID  string                 `json:"player2_id"`
 	FinalState map[string]interface{} `json:"final_state"`
 	WinnerID   string                 `json:"winner_id,omitempty"`
 	IsDraw     bool                   `json:"is_draw"`
+	Reason     string                 `json:"reason,omitempty"`
 }
 
 // Match found event data
@@ -56,6 +85,34 @@ type MatchFoundData struct {
 	Player2Name string `json:"player2_name"`
 }
 
+// Match failed event data
+type MatchFailedData struct {
+	Player1ID string `json:"player1_id"`
+	Player2ID string `json:"player2_id"`
+	Reason    string `json:"reason"`
+}
+
+// Challenge event data. The status is "pending", "declined", "withdrawn" or "expired".
+type ChallengeData struct {
+	ChallengeID    string `json:"challenge_id"`
+	ChallengerID   string `json:"challenger_id"`
+	ChallengerName string `json:"challenger_name"`
+	TargetID       string `json:"target_id,omitempty"`
+	Status         string `json:"status"`
+	ExpiresAt      int64  `json:"expires_at"`
+}
+
+// Account locked event data, published when failed logins lock out a username
+// or an IP address. The scope is "username" or "ip".
+type AccountLockedData struct {
+	Scope       string `json:"scope"`
+	Username    string `json:"username"`
+	UserID      string `json:"user_id,omitempty"` // Unset when no user has the username
+	IP          string `json:"ip,omitempty"`
+	Failures    int    `json:"failures"`
+	LockedUntil int64  `json:"locked_until"`
+}
+
 // EventPublisher handles publishing events to Redis Streams
 type EventPublisher struct {
 	redisClient *redis.Client
@@ -78,13 +135,34 @@ func NewEventPublisher(redisAddr string) *EventPublisher {
 	}
 }
 
-// PublishMoveMade publishes a move made event
-func (ep *EventPublisher) PublishMoveMade(ctx context.Context, gameID, playerID string, pitIndex uint32, gameState, moveResult map[string]interface{}) error {
+// PublishGameCreated publishes a game created event with both players of the game
+func (ep *EventPublisher) PublishGameCreated(ctx context.Context, gameID, player1ID, player2ID string) error {
+	data := GameCreatedData{
+		Player1ID: player1ID,
+		Player2ID: player2ID,
+	}
+
+	event := Event{
+		ID:        uuid.New().String(),
+		Type:      EventTypeGameCreated,
+		GameID:    gameID,
+		Timestamp: time.Now().Unix(),
+		Data:      structToMap(data),
+	}
+
+	return ep.publishEvent(ctx, event)
+}
+
+// PublishMoveMade publishes a move made event. The clock is nil for games without a time control.
+func (ep *EventPublisher) PublishMoveMade(ctx context.Context, gameID, player1ID, player2ID, playerID string, pitIndex uint32, gameState, moveResult, clock map[string]interface{}) error {
 	data := MoveMadeData{
 		PlayerID:   playerID,
+		Player1ID:  player1ID,
+		Player2ID:  player2ID,
 		PitIndex:   pitIndex,
 		GameState:  gameState,
 		MoveResult: moveResult,
+		Clock:      clock,
 	}
 
 	event := Event{
@@ -98,12 +176,15 @@ func (ep *EventPublisher) PublishMoveMade(ctx context.Context, gameID, playerID
 	return ep.publishEvent(ctx, event)
 }
 
-// PublishGameOver publishes a game over event
-func (ep *EventPublisher) PublishGameOver(ctx context.Context, gameID, winnerID string, isDraw bool, finalState map[string]interface{}) error {
+// PublishGameOver publishes a game over event. The reason tells how the game ended, e.g. GameOverReasonTimeout.
+func (ep *EventPublisher) PublishGameOver(ctx context.Context, gameID, player1ID, player2ID, winnerID string, isDraw bool, reason string, finalState map[string]interface{}) error {
 	data := GameOverData{
+		Player1ID:  player1ID,
+		Player2ID:  player2ID,
 		FinalState: finalState,
 		WinnerID:   winnerID,
 		IsDraw:     isDraw,
+		Reason:     reason,
 	}
 
 	event := Event{
@@ -138,6 +219,48 @@ func (ep *EventPublisher) PublishMatchFound(ctx context.Context, gameID, matchID
 	return ep.publishEvent(ctx, event)
 }
 
+// PublishMatchFailed publishes a match failed event. Matches without a game have no game ID.
+func (ep *EventPublisher) PublishMatchFailed(ctx context.Context, player1ID, player2ID, reason string) error {
+	data := MatchFailedData{
+		Player1ID: player1ID,
+		Player2ID: player2ID,
+		Reason:    reason,
+	}
+
+	event := Event{
+		ID:        uuid.New().String(),
+		Type:      EventTypeMatchFailed,
+		Timestamp: time.Now().Unix(),
+		Data:      structToMap(data),
+	}
+
+	return ep.publishEvent(ctx, event)
+}
+
+// PublishChallenge publishes a change of a challenge or invite. Invites have no target.
+func (ep *EventPublisher) PublishChallenge(ctx context.Context, data ChallengeData) error {
+	event := Event{
+		ID:        uuid.New().String(),
+		Type:      EventTypeChallenge,
+		Timestamp: time.Now().Unix(),
+		Data:      structToMap(data),
+	}
+
+	return ep.publishEvent(ctx, event)
+}
+
+// PublishAccountLocked publishes a lockout after repeated failed logins
+func (ep *EventPublisher) PublishAccountLocked(ctx context.Context, data AccountLockedData) error {
+	event := Event{
+		ID:        uuid.New().String(),
+		Type:      EventTypeAccountLocked,
+		Timestamp: time.Now().Unix(),
+		Data:      structToMap(data),
+	}
+
+	return ep.publishEvent(ctx, event)
+}
+
 // publishEvent publishes an event to Redis Stream
 func (ep *EventPublisher) publishEvent(ctx context.Context, event Event) error {
 	eventJSON, err := json.Marshal(event)
@@ -0,0 +1,178 @@
+package games
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	gamespb "github.com/laerson/mancala/proto/games"
+	_ "github.com/lib/pq"
+)
+
+// Archive durably stores finished games so players can review past results
+type Archive interface {
+	ArchiveGame(ctx context.Context, game *gamespb.Game) error
+	GetArchivedGame(ctx context.Context, gameID string) (*gamespb.Game, error)
+	// ListArchivedGames returns the player's finished games, most recent first
+	ListArchivedGames(ctx context.Context, playerID string, offset, limit int) ([]*gamespb.Game, error)
+}
+
+// PostgresArchive stores finished games in PostgreSQL
+type PostgresArchive struct {
+	db *sql.DB
+}
+
+// NewPostgresArchive connects to PostgreSQL and prepares the archive tables
+func NewPostgresArchive(dbURL string) (*PostgresArchive, error) {
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to PostgreSQL: %w", err)
+	}
+
+	// Test the connection
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping PostgreSQL: %w", err)
+	}
+
+	archive := &PostgresArchive{db: db}
+
+	if err := archive.initSchema(); err != nil {
+		return nil, fmt.Errorf("failed to initialize archive schema: %w", err)
+	}
+
+	return archive, nil
+}
+
+// initSchema creates the archive tables
+func (a *PostgresArchive) initSchema() error {
+	queries := []string{
+		`CREATE TABLE IF NOT EXISTS archived_games (
+			game_id VARCHAR(64) PRIMARY KEY,
+			player1_id VARCHAR(64) NOT NULL,
+			player2_id VARCHAR(64) NOT NULL,
+			winner SMALLINT NOT NULL,
+			winner_id VARCHAR(64) NOT NULL DEFAULT '',
+			final_board JSONB NOT NULL,
+			moves JSONB NOT NULL,
+			game JSONB NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			finished_at TIMESTAMP WITH TIME ZONE NOT NULL
+		);`,
+		`CREATE INDEX IF NOT EXISTS idx_archived_games_player1 ON archived_games(player1_id, finished_at DESC);`,
+		`CREATE INDEX IF NOT EXISTS idx_archived_games_player2 ON archived_games(player2_id, finished_at DESC);`,
+	}
+
+	for _, query := range queries {
+		if _, err := a.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute schema query: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ArchiveGame writes a finished game. Archiving the same game twice keeps the first copy.
+func (a *PostgresArchive) ArchiveGame(ctx context.Context, game *gamespb.Game) error {
+	gameJSON, err := json.Marshal(game)
+	if err != nil {
+		return fmt.Errorf("failed to marshal game: %w", err)
+	}
+
+	finalBoard, err := json.Marshal(game.GetState().GetBoard().GetPits())
+	if err != nil {
+		return fmt.Errorf("failed to marshal final board: %w", err)
+	}
+
+	moves, err := json.Marshal(game.Moves)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moves: %w", err)
+	}
+
+	query := `
+		INSERT INTO archived_games (game_id, player1_id, player2_id, winner, winner_id, final_board, moves, game, created_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		ON CONFLICT (game_id) DO NOTHING
+	`
+	_, err = a.db.ExecContext(ctx, query,
+		game.Id,
+		game.Player1Id,
+		game.Player2Id,
+		int(game.Winner),
+		game.WinnerId,
+		finalBoard,
+		moves,
+		gameJSON,
+		time.Unix(game.CreatedAt, 0),
+		time.Unix(game.FinishedAt, 0),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to archive game: %w", err)
+	}
+
+	return nil
+}
+
+// GetArchivedGame retrieves a finished game from the archive
+func (a *PostgresArchive) GetArchivedGame(ctx context.Context, gameID string) (*gamespb.Game, error) {
+	var gameJSON []byte
+	err := a.db.QueryRowContext(ctx, `SELECT game FROM archived_games WHERE game_id = $1`, gameID).Scan(&gameJSON)
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("game not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get archived game: %w", err)
+	}
+
+	return unmarshalArchivedGame(gameJSON)
+}
+
+// ListArchivedGames retrieves a page of the player's finished games
+func (a *PostgresArchive) ListArchivedGames(ctx context.Context, playerID string, offset, limit int) ([]*gamespb.Game, error) {
+	query := `
+		SELECT game
+		FROM archived_games
+		WHERE player1_id = $1 OR player2_id = $1
+		ORDER BY finished_at DESC, game_id
+		OFFSET $2 LIMIT $3
+	`
+	rows, err := a.db.QueryContext(ctx, query, playerID, offset, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived games: %w", err)
+	}
+	defer rows.Close()
+
+	var games []*gamespb.Game
+	for rows.Next() {
+		var gameJSON []byte
+		if err := rows.Scan(&gameJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan archived game: %w", err)
+		}
+
+		game, err := unmarshalArchivedGame(gameJSON)
+		if err != nil {
+			return nil, err
+		}
+		games = append(games, game)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list archived games: %w", err)
+	}
+
+	return games, nil
+}
+
+// Close closes the database connection
+func (a *PostgresArchive) Close() error {
+	return a.db.Close()
+}
+
+func unmarshalArchivedGame(gameJSON []byte) (*gamespb.Game, error) {
+	var game gamespb.Game
+	if err := json.Unmarshal(gameJSON, &game); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archived game: %w", err)
+	}
+	return &game, nil
+}
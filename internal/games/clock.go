@@ -0,0 +1,174 @@
+package games
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	enginepb "github.com/laerson/mancala/proto/engine"
+	gamespb "github.com/laerson/mancala/proto/games"
+)
+
+// DefaultSweepInterval is how often the sweeper looks for players who ran out of time and
+// for finished games left to archive
+const DefaultSweepInterval = time.Second
+
+// validateTimeControl checks that a time control uses exactly one of its two modes
+func validateTimeControl(tc *gamespb.TimeControl) error {
+	if tc.GetBaseMs() < 0 || tc.GetIncrementMs() < 0 || tc.GetPerMoveMs() < 0 {
+		return fmt.Errorf("time control values cannot be negative")
+	}
+	if tc.GetPerMoveMs() > 0 && (tc.GetBaseMs() > 0 || tc.GetIncrementMs() > 0) {
+		return fmt.Errorf("use either a base time with increment or a fixed time per move")
+	}
+	if tc.GetIncrementMs() > 0 && tc.GetBaseMs() == 0 {
+		return fmt.Errorf("an increment requires a base time")
+	}
+	return nil
+}
+
+// newClock starts the clock of a new game. Player one's time runs from creation.
+// Games without a time control have no clock.
+func newClock(tc *gamespb.TimeControl, now time.Time) *gamespb.Clock {
+	start := tc.GetBaseMs()
+	if tc.GetPerMoveMs() > 0 {
+		start = tc.GetPerMoveMs()
+	}
+	if start == 0 {
+		return nil
+	}
+
+	return &gamespb.Clock{
+		Player1RemainingMs: start,
+		Player2RemainingMs: start,
+		TurnStartedAtMs:    now.UnixMilli(),
+	}
+}
+
+// remainingMs returns the time the player has left at the given moment,
+// charging the current turn to the player to move
+func remainingMs(game *gamespb.Game, player enginepb.Player, now time.Time) int64 {
+	clock := game.Clock
+	remaining := clock.Player1RemainingMs
+	if player == enginepb.Player_PLAYER_TWO {
+		remaining = clock.Player2RemainingMs
+	}
+
+	if game.State.CurrentPlayer == player {
+		remaining -= now.UnixMilli() - clock.TurnStartedAtMs
+	}
+	return remaining
+}
+
+// setRemainingMs sets the player's remaining time
+func setRemainingMs(clock *gamespb.Clock, player enginepb.Player, remaining int64) {
+	if player == enginepb.Player_PLAYER_TWO {
+		clock.Player2RemainingMs = remaining
+	} else {
+		clock.Player1RemainingMs = remaining
+	}
+}
+
+// isOutOfTime reports whether the player to move has used up their time
+func isOutOfTime(game *gamespb.Game, now time.Time) bool {
+	return game.Clock != nil && remainingMs(game, game.State.CurrentPlayer, now) <= 0
+}
+
+// clockDeadline returns when the player to move runs out of time
+func clockDeadline(game *gamespb.Game) (time.Time, bool) {
+	if game.Clock == nil || !isInProgress(game) {
+		return time.Time{}, false
+	}
+
+	remaining := remainingMs(game, game.State.CurrentPlayer, time.UnixMilli(game.Clock.TurnStartedAtMs))
+	return time.UnixMilli(game.Clock.TurnStartedAtMs + remaining), true
+}
+
+// chargeMove charges the mover for the time spent on a move and starts the next turn
+func chargeMove(game *gamespb.Game, mover enginepb.Player, now time.Time) {
+	clock := game.Clock
+	if clock == nil {
+		return
+	}
+
+	remaining := remainingMs(game, mover, now)
+	if perMove := game.TimeControl.GetPerMoveMs(); perMove > 0 {
+		remaining = perMove
+	} else {
+		remaining += game.TimeControl.GetIncrementMs()
+	}
+
+	setRemainingMs(clock, mover, remaining)
+	clock.TurnStartedAtMs = now.UnixMilli()
+}
+
+// SweepClocks forfeits every game whose player to move has run out of time
+func (s *Server) SweepClocks(ctx context.Context, now time.Time) error {
+	gameIDs, err := s.storage.ListExpiredGames(ctx, now)
+	if err != nil {
+		return err
+	}
+
+	for _, gameID := range gameIDs {
+		game, err := s.storage.GetGame(ctx, gameID)
+		if err != nil {
+			// The game finished after its deadline was listed
+			continue
+		}
+
+		// The player may have moved since the deadline was listed
+		if !isInProgress(game) || !isOutOfTime(game, now) {
+			continue
+		}
+
+		// A conflict means another request changed the game first, e.g. a move played just before the deadline
+		if err := s.forfeitOnTime(ctx, game); err != nil && !errors.Is(err, ErrVersionConflict) {
+			log.Printf("Failed to forfeit game %s on time: %v", gameID, err)
+		}
+	}
+
+	return nil
+}
+
+// RunSweeper calls SweepClocks and SweepFinishedGames every interval until the context is cancelled
+func (s *Server) RunSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := s.SweepClocks(ctx, now); err != nil {
+				log.Printf("Failed to sweep game clocks: %v", err)
+			}
+			if err := s.SweepFinishedGames(ctx, now); err != nil {
+				log.Printf("Failed to sweep finished games: %v", err)
+			}
+		}
+	}
+}
+
+// forfeitOnTime ends the game in favour of the opponent of the player to move
+func (s *Server) forfeitOnTime(ctx context.Context, game *gamespb.Game) error {
+	flagged := game.State.CurrentPlayer
+	setRemainingMs(game.Clock, flagged, 0)
+
+	return s.finishGame(ctx, game, winnerAgainst(flagged), gamespb.GameEndReason_GAME_END_REASON_TIMEOUT)
+}
+
+// clockToMap converts a clock to a map for event publishing. Untimed games have no clock.
+func clockToMap(clock *gamespb.Clock) map[string]interface{} {
+	if clock == nil {
+		return nil
+	}
+
+	return map[string]interface{}{
+		"player1_remaining_ms": clock.Player1RemainingMs,
+		"player2_remaining_ms": clock.Player2RemainingMs,
+		"turn_started_at_ms":   clock.TurnStartedAtMs,
+	}
+}
@@ -0,0 +1,122 @@
+package games
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	enginepb "github.com/laerson/mancala/proto/engine"
+	gamespb "github.com/laerson/mancala/proto/games"
+)
+
+func TestValidateTimeControl(t *testing.T) {
+	tests := []struct {
+		name    string
+		tc      *gamespb.TimeControl
+		wantErr bool
+	}{
+		{name: "No time control", tc: nil},
+		{name: "Base time with increment", tc: &gamespb.TimeControl{BaseMs: 300000, IncrementMs: 5000}},
+		{name: "Fixed time per move", tc: &gamespb.TimeControl{PerMoveMs: 30000}},
+		{name: "Negative base time", tc: &gamespb.TimeControl{BaseMs: -1}, wantErr: true},
+		{name: "Both modes", tc: &gamespb.TimeControl{BaseMs: 300000, PerMoveMs: 30000}, wantErr: true},
+		{name: "Increment without base time", tc: &gamespb.TimeControl{IncrementMs: 5000}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTimeControl(tt.tc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateTimeControl() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChargeMove(t *testing.T) {
+	start := time.UnixMilli(1_000_000)
+
+	tests := []struct {
+		name          string
+		tc            *gamespb.TimeControl
+		thinking      time.Duration
+		wantRemaining int64
+	}{
+		{
+			name:          "Increment is added after the move",
+			tc:            &gamespb.TimeControl{BaseMs: 60000, IncrementMs: 2000},
+			thinking:      10 * time.Second,
+			wantRemaining: 52000,
+		},
+		{
+			name:          "Fixed time per move is reset",
+			tc:            &gamespb.TimeControl{PerMoveMs: 30000},
+			thinking:      10 * time.Second,
+			wantRemaining: 30000,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+			game.TimeControl = tt.tc
+			game.Clock = newClock(tt.tc, start)
+
+			now := start.Add(tt.thinking)
+			chargeMove(game, enginepb.Player_PLAYER_ONE, now)
+
+			if game.Clock.Player1RemainingMs != tt.wantRemaining {
+				t.Errorf("chargeMove() Player1RemainingMs = %d, want %d", game.Clock.Player1RemainingMs, tt.wantRemaining)
+			}
+			if game.Clock.TurnStartedAtMs != now.UnixMilli() {
+				t.Errorf("chargeMove() TurnStartedAtMs = %d, want %d", game.Clock.TurnStartedAtMs, now.UnixMilli())
+			}
+		})
+	}
+}
+
+func TestServer_SweepClocks(t *testing.T) {
+	storage := NewMockStorage()
+	archive := NewMockArchive()
+	server := NewServer(storage, archive, NewMockEngineClient(), "localhost:6379")
+
+	now := time.Now()
+	tc := &gamespb.TimeControl{BaseMs: 60000}
+
+	expired := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	expired.TimeControl = tc
+	expired.Clock = newClock(tc, now.Add(-2*time.Minute))
+	storage.SaveGame(context.Background(), expired)
+
+	running := NewGame("player3", "player4", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	running.TimeControl = tc
+	running.Clock = newClock(tc, now.Add(-30*time.Second))
+	storage.SaveGame(context.Background(), running)
+
+	untimed := NewGame("player5", "player6", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), untimed)
+
+	if err := server.SweepClocks(context.Background(), now); err != nil {
+		t.Fatalf("SweepClocks() error = %v, want nil", err)
+	}
+
+	archived, err := archive.GetArchivedGame(context.Background(), expired.Id)
+	if err != nil {
+		t.Fatalf("Game out of time should be archived: %v", err)
+	}
+	if archived.Winner != enginepb.Winner_WINNER_PLAYER_TWO || archived.WinnerId != "player2" {
+		t.Errorf("SweepClocks() winner = %v (%q), want player2", archived.Winner, archived.WinnerId)
+	}
+	if archived.EndReason != gamespb.GameEndReason_GAME_END_REASON_TIMEOUT {
+		t.Errorf("SweepClocks() EndReason = %v, want %v", archived.EndReason, gamespb.GameEndReason_GAME_END_REASON_TIMEOUT)
+	}
+	if archived.Clock.Player1RemainingMs != 0 {
+		t.Errorf("SweepClocks() Player1RemainingMs = %d, want 0", archived.Clock.Player1RemainingMs)
+	}
+
+	for _, game := range []*gamespb.Game{running, untimed} {
+		if _, err := storage.GetGame(context.Background(), game.Id); err != nil {
+			t.Errorf("SweepClocks() removed game %s with time left", game.Id)
+		}
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"net"
 	"testing"
 
+	"github.com/laerson/mancala/internal/auth"
 	enginepb "github.com/laerson/mancala/proto/engine"
 	gamespb "github.com/laerson/mancala/proto/games"
 	"github.com/testcontainers/testcontainers-go"
@@ -17,6 +18,8 @@ import (
 const bufSize = 1024 * 1024
 
 func setupIntegrationTest(t *testing.T) (gamespb.GamesClient, func()) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
 	ctx := context.Background()
 
 	redisContainer, err := redis.Run(ctx, "redis:7-alpine")
@@ -51,10 +54,14 @@ func setupIntegrationTest(t *testing.T) (gamespb.GamesClient, func()) {
 		},
 	})
 
-	server := NewServer(storage, mockEngineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), mockEngineClient, "localhost:6379")
 
 	lis := bufconn.Listen(bufSize)
-	grpcServer := grpc.NewServer()
+	// Calls are made as a service allowed to create games for any players
+	grpcServer := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx = context.WithValue(ctx, "service", "matchmaking")
+		return handler(context.WithValue(ctx, "scopes", []string{auth.ScopeCreateGames}), req)
+	}))
 	gamespb.RegisterGamesServer(grpcServer, server)
 
 	go func() {
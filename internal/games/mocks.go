@@ -2,14 +2,17 @@ package games
 
 import (
 	"context"
+	"time"
 
 	enginepb "github.com/laerson/mancala/proto/engine"
 	gamespb "github.com/laerson/mancala/proto/games"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 )
 
 type MockStorage struct {
 	games map[string]*gamespb.Game
+	order []string
 }
 
 func NewMockStorage() *MockStorage {
@@ -18,8 +21,18 @@ func NewMockStorage() *MockStorage {
 	}
 }
 
+// SaveGame stores a copy of the game, so callers holding a stale copy conflict like they do against Redis
 func (m *MockStorage) SaveGame(ctx context.Context, game *gamespb.Game) error {
-	m.games[game.Id] = game
+	stored, exists := m.games[game.Id]
+	if stored.GetVersion() != game.Version {
+		return ErrVersionConflict
+	}
+
+	if !exists {
+		m.order = append(m.order, game.Id)
+	}
+	game.Version++
+	m.games[game.Id] = proto.Clone(game).(*gamespb.Game)
 	return nil
 }
 
@@ -28,7 +41,7 @@ func (m *MockStorage) GetGame(ctx context.Context, gameID string) (*gamespb.Game
 	if !exists {
 		return nil, &gameNotFoundError{}
 	}
-	return game, nil
+	return proto.Clone(game).(*gamespb.Game), nil
 }
 
 func (m *MockStorage) DeleteGame(ctx context.Context, gameID string) error {
@@ -36,6 +49,90 @@ func (m *MockStorage) DeleteGame(ctx context.Context, gameID string) error {
 	return nil
 }
 
+func (m *MockStorage) ListPlayerGames(ctx context.Context, playerID string) ([]*gamespb.Game, error) {
+	var games []*gamespb.Game
+	for _, gameID := range m.order {
+		if game, exists := m.games[gameID]; exists && IsPlayerInGame(game, playerID) {
+			games = append(games, game)
+		}
+	}
+	return games, nil
+}
+
+func (m *MockStorage) ListExpiredGames(ctx context.Context, now time.Time) ([]string, error) {
+	var gameIDs []string
+	for _, gameID := range m.order {
+		game, exists := m.games[gameID]
+		if !exists {
+			continue
+		}
+		if deadline, ok := clockDeadline(game); ok && !deadline.After(now) {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	return gameIDs, nil
+}
+
+func (m *MockStorage) ListUnarchivedGames(ctx context.Context, finishedBefore time.Time) ([]string, error) {
+	var gameIDs []string
+	for _, gameID := range m.order {
+		game, exists := m.games[gameID]
+		if exists && game.Status == gamespb.GameStatus_GAME_STATUS_FINISHED && game.FinishedAt < finishedBefore.Unix() {
+			gameIDs = append(gameIDs, gameID)
+		}
+	}
+	return gameIDs, nil
+}
+
+type MockArchive struct {
+	games []*gamespb.Game
+	err   error
+}
+
+func NewMockArchive() *MockArchive {
+	return &MockArchive{}
+}
+
+func (m *MockArchive) SetError(err error) {
+	m.err = err
+}
+
+func (m *MockArchive) ArchiveGame(ctx context.Context, game *gamespb.Game) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.games = append(m.games, game)
+	return nil
+}
+
+func (m *MockArchive) GetArchivedGame(ctx context.Context, gameID string) (*gamespb.Game, error) {
+	for _, game := range m.games {
+		if game.Id == gameID {
+			return game, nil
+		}
+	}
+	return nil, &gameNotFoundError{}
+}
+
+func (m *MockArchive) ListArchivedGames(ctx context.Context, playerID string, offset, limit int) ([]*gamespb.Game, error) {
+	var games []*gamespb.Game
+	// Most recently archived first
+	for i := len(m.games) - 1; i >= 0; i-- {
+		if IsPlayerInGame(m.games[i], playerID) {
+			games = append(games, m.games[i])
+		}
+	}
+
+	if offset > len(games) {
+		offset = len(games)
+	}
+	end := offset + limit
+	if end > len(games) {
+		end = len(games)
+	}
+	return games[offset:end], nil
+}
+
 type gameNotFoundError struct{}
 
 func (e *gameNotFoundError) Error() string {
@@ -2,22 +2,24 @@ package games
 
 import (
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
+	"time"
 
+	"github.com/laerson/mancala/internal/rules"
 	enginepb "github.com/laerson/mancala/proto/engine"
 	gamespb "github.com/laerson/mancala/proto/games"
 )
 
-func NewGame(player1ID, player2ID string) *gamespb.Game {
+// NewGame creates a game between two players. A nil rule set plays the standard rules of the game type.
+func NewGame(player1ID, player2ID string, gameType enginepb.GameType, ruleSet *enginepb.RuleSet) *gamespb.Game {
 	gameID := generateGameID()
 
-	initialBoard := &enginepb.Board{
-		Pits: []uint32{4, 4, 4, 4, 4, 4, 0, 4, 4, 4, 4, 4, 4, 0},
-	}
-
 	gameState := &enginepb.GameState{
-		Board:         initialBoard,
+		Board:         newInitialBoard(gameType, ruleSet),
 		CurrentPlayer: enginepb.Player_PLAYER_ONE,
+		Rules:         ruleSet,
+		GameType:      gameType,
 	}
 
 	return &gamespb.Game{
@@ -25,6 +27,63 @@ func NewGame(player1ID, player2ID string) *gamespb.Game {
 		State:     gameState,
 		Player1Id: player1ID,
 		Player2Id: player2ID,
+		Status:    gamespb.GameStatus_GAME_STATUS_IN_PROGRESS,
+		CreatedAt: time.Now().Unix(),
+		// Kept apart from the state so the game can be replayed from the start
+		InitialBoard: newInitialBoard(gameType, ruleSet),
+	}
+}
+
+func newInitialBoard(gameType enginepb.GameType, ruleSet *enginepb.RuleSet) *enginepb.Board {
+	return &enginepb.Board{
+		Pits: rules.For(gameType, ruleSet).InitialBoard(),
+	}
+}
+
+// BuildReplay lists every position of a game, starting with the initial position
+func BuildReplay(game *gamespb.Game) *gamespb.Replay {
+	initialBoard := game.InitialBoard
+	if initialBoard == nil {
+		// Games created before initial boards were recorded used the standard board
+		initialBoard = newInitialBoard(enginepb.GameType_GAME_TYPE_KALAH, nil)
+	}
+
+	ruleSet := game.GetState().GetRules()
+	gameType := game.GetState().GetGameType()
+	positions := []*gamespb.ReplayPosition{
+		{
+			MoveNumber: 0,
+			State: &enginepb.GameState{
+				Board:         initialBoard,
+				CurrentPlayer: enginepb.Player_PLAYER_ONE,
+				Rules:         ruleSet,
+				GameType:      gameType,
+			},
+		},
+	}
+
+	for i, move := range game.Moves {
+		positions = append(positions, &gamespb.ReplayPosition{
+			MoveNumber: int32(i + 1),
+			Move:       move,
+			State: &enginepb.GameState{
+				Board:         move.Board,
+				CurrentPlayer: move.NextPlayer,
+				Rules:         ruleSet,
+				GameType:      gameType,
+			},
+		})
+	}
+
+	return &gamespb.Replay{
+		GameId:    game.Id,
+		Player1Id: game.Player1Id,
+		Player2Id: game.Player2Id,
+		Positions: positions,
+		Status:    game.Status,
+		Winner:    game.Winner,
+		WinnerId:  game.WinnerId,
+		EndReason: game.EndReason,
 	}
 }
 
@@ -34,6 +93,12 @@ func generateGameID() string {
 	return hex.EncodeToString(bytes)
 }
 
+// requestGameID derives the ID of the game created for a request ID, in the same format as generated IDs
+func requestGameID(requestID string) string {
+	sum := sha256.Sum256([]byte("game-request:" + requestID))
+	return hex.EncodeToString(sum[:16])
+}
+
 func IsPlayerInGame(game *gamespb.Game, playerID string) bool {
 	return game.Player1Id == playerID || game.Player2Id == playerID
 }
@@ -4,13 +4,14 @@ import (
 	"testing"
 
 	enginepb "github.com/laerson/mancala/proto/engine"
+	gamespb "github.com/laerson/mancala/proto/games"
 )
 
 func TestNewGame(t *testing.T) {
 	player1ID := "player1"
 	player2ID := "player2"
 
-	game := NewGame(player1ID, player2ID)
+	game := NewGame(player1ID, player2ID, enginepb.GameType_GAME_TYPE_KALAH, nil)
 
 	if game.Id == "" {
 		t.Error("Game ID should not be empty")
@@ -43,6 +44,28 @@ func TestNewGame(t *testing.T) {
 	}
 }
 
+func TestNewGame_RuleSet(t *testing.T) {
+	ruleSet := &enginepb.RuleSet{PitsPerSide: 4, InitialSeeds: 5}
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, ruleSet)
+
+	expectedPits := []uint32{5, 5, 5, 5, 0, 5, 5, 5, 5, 0}
+	if len(game.State.Board.Pits) != len(expectedPits) {
+		t.Fatalf("Board should have %d pits, got %d", len(expectedPits), len(game.State.Board.Pits))
+	}
+	for i, expected := range expectedPits {
+		if game.State.Board.Pits[i] != expected {
+			t.Errorf("Pit %d should have %d seeds, got %d", i, expected, game.State.Board.Pits[i])
+		}
+	}
+	if game.State.Rules != ruleSet {
+		t.Errorf("Game rules should be %v, got %v", ruleSet, game.State.Rules)
+	}
+	if len(game.InitialBoard.Pits) != len(expectedPits) {
+		t.Errorf("Initial board should have %d pits, got %d", len(expectedPits), len(game.InitialBoard.Pits))
+	}
+}
+
 func TestGenerateGameID(t *testing.T) {
 	id1 := generateGameID()
 	id2 := generateGameID()
@@ -62,7 +85,7 @@ func TestGenerateGameID(t *testing.T) {
 }
 
 func TestIsPlayerInGame(t *testing.T) {
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 
 	tests := []struct {
 		name     string
@@ -102,7 +125,7 @@ func TestIsPlayerInGame(t *testing.T) {
 }
 
 func TestGetPlayerFromID(t *testing.T) {
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 
 	tests := []struct {
 		name     string
@@ -140,3 +163,51 @@ func TestGetPlayerFromID(t *testing.T) {
 		})
 	}
 }
+
+func TestBuildReplay(t *testing.T) {
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	game.Moves = []*gamespb.GameMove{
+		{
+			PlayerId:   "player1",
+			PitIndex:   2,
+			Board:      &enginepb.Board{Pits: []uint32{4, 4, 0, 5, 5, 5, 1, 4, 4, 4, 4, 4, 4, 0}},
+			ExtraTurn:  true,
+			NextPlayer: enginepb.Player_PLAYER_ONE,
+		},
+		{
+			PlayerId:   "player1",
+			PitIndex:   5,
+			Board:      &enginepb.Board{Pits: []uint32{4, 4, 0, 5, 5, 0, 2, 5, 5, 5, 5, 4, 4, 0}},
+			NextPlayer: enginepb.Player_PLAYER_TWO,
+		},
+	}
+
+	replay := BuildReplay(game)
+
+	if replay.GameId != game.Id {
+		t.Errorf("BuildReplay() GameId = %v, want %v", replay.GameId, game.Id)
+	}
+	if len(replay.Positions) != 3 {
+		t.Fatalf("BuildReplay() returned %d positions, want 3", len(replay.Positions))
+	}
+
+	initial := replay.Positions[0]
+	if initial.MoveNumber != 0 || initial.Move != nil {
+		t.Errorf("Initial position = move %d (%v), want move 0 without a move", initial.MoveNumber, initial.Move)
+	}
+	if initial.State.Board.Pits[0] != 4 || initial.State.CurrentPlayer != enginepb.Player_PLAYER_ONE {
+		t.Errorf("Initial position state = %v, want the starting board with PLAYER_ONE to move", initial.State)
+	}
+
+	for i, position := range replay.Positions[1:] {
+		if position.MoveNumber != int32(i+1) {
+			t.Errorf("Position %d MoveNumber = %d, want %d", i+1, position.MoveNumber, i+1)
+		}
+		if position.Move != game.Moves[i] {
+			t.Errorf("Position %d Move = %v, want %v", i+1, position.Move, game.Moves[i])
+		}
+		if position.State.Board != game.Moves[i].Board || position.State.CurrentPlayer != game.Moves[i].NextPlayer {
+			t.Errorf("Position %d State = %v, want board and turn after move %d", i+1, position.State, i+1)
+		}
+	}
+}
@@ -2,13 +2,30 @@ package games
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/laerson/mancala/internal/auth"
 	"github.com/laerson/mancala/internal/events"
+	"github.com/laerson/mancala/internal/rules"
 	enginepb "github.com/laerson/mancala/proto/engine"
 	gamespb "github.com/laerson/mancala/proto/games"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+
+	// archiveGracePeriod is how long a finished game is left to the request that
+	// finished it before the sweeper archives it
+	archiveGracePeriod = time.Minute
 )
 
 type EngineClient interface {
@@ -18,13 +35,15 @@ type EngineClient interface {
 type Server struct {
 	gamespb.UnimplementedGamesServer
 	storage        Storage
+	archive        Archive
 	engineClient   EngineClient
 	eventPublisher *events.EventPublisher
 }
 
-func NewServer(storage Storage, engineClient EngineClient, redisAddr string) *Server {
+func NewServer(storage Storage, archive Archive, engineClient EngineClient, redisAddr string) *Server {
 	return &Server{
 		storage:        storage,
+		archive:        archive,
 		engineClient:   engineClient,
 		eventPublisher: events.NewEventPublisher(redisAddr),
 	}
@@ -35,18 +54,104 @@ func (s *Server) Create(ctx context.Context, req *gamespb.CreateGameRequest) (*g
 		return nil, fmt.Errorf("both player IDs are required")
 	}
 
-	game := NewGame(req.Player1Id, req.Player2Id)
+	if err := authorizeCreate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	if err := rules.Validate(req.GameType, req.Rules); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	if err := validateTimeControl(req.TimeControl); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	game := NewGame(req.Player1Id, req.Player2Id, req.GameType, req.Rules)
+	game.TimeControl = req.TimeControl
+	game.Clock = newClock(req.TimeControl, time.Unix(game.CreatedAt, 0))
+
+	// A retried request gets the game its first attempt created
+	if req.RequestId != "" {
+		game.Id = requestGameID(req.RequestId)
+		existing, err := s.requestedGame(ctx, req, game.Id)
+		if err != nil {
+			return nil, err
+		}
+		if existing != nil {
+			return &gamespb.CreateGameResponse{Game: existing}, nil
+		}
+	}
 
 	err := s.storage.SaveGame(ctx, game)
+	if errors.Is(err, ErrVersionConflict) && req.RequestId != "" {
+		// Another attempt with the same request ID saved the game first
+		existing, err := s.requestedGame(ctx, req, game.Id)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, status.Errorf(codes.Aborted, "game is being created, please retry")
+		}
+		return &gamespb.CreateGameResponse{Game: existing}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to save game: %w", err)
 	}
 
+	// Publish GAME_CREATED event
+	err = s.eventPublisher.PublishGameCreated(ctx, game.Id, game.Player1Id, game.Player2Id)
+	if err != nil {
+		// Log error but don't fail the game operation
+		log.Printf("Failed to publish game created event: %v", err)
+	}
+
 	return &gamespb.CreateGameResponse{
 		Game: game,
 	}, nil
 }
 
+// requestedGame returns the game already created for the request, from storage or the
+// archive, or nil if there is none yet
+func (s *Server) requestedGame(ctx context.Context, req *gamespb.CreateGameRequest, gameID string) (*gamespb.Game, error) {
+	game, err := s.storage.GetGame(ctx, gameID)
+	if err != nil {
+		// The game may have finished already
+		game, err = s.archive.GetArchivedGame(ctx, gameID)
+	}
+	if err != nil {
+		return nil, nil
+	}
+
+	if game.Player1Id != req.Player1Id || game.Player2Id != req.Player2Id {
+		return nil, status.Errorf(codes.AlreadyExists, "request ID was used for another game")
+	}
+	return game, nil
+}
+
+// authorizeCreate checks the caller may create the game. Players only create
+// games they play in, while services creating games for others (matchmaking,
+// challenges, tournaments) and admins may pair any players.
+func authorizeCreate(ctx context.Context, req *gamespb.CreateGameRequest) error {
+	if auth.HasScope(ctx, auth.ScopeCreateGames) {
+		return nil
+	}
+
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	if auth.RoleAtLeast(auth.GetRoleFromContext(ctx), auth.RoleAdmin) {
+		return nil
+	}
+
+	if userID != req.Player1Id && userID != req.Player2Id {
+		return status.Errorf(codes.PermissionDenied, "you can only create games you play in")
+	}
+
+	return nil
+}
+
 func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*gamespb.MakeGameMoveResponse, error) {
 	if req.PlayerId == "" || req.GameId == "" {
 		return &gamespb.MakeGameMoveResponse{
@@ -60,7 +165,7 @@ func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*g
 	if err := auth.ValidatePlayerOwnership(ctx, req.PlayerId); err != nil {
 		return &gamespb.MakeGameMoveResponse{
 			Result: &gamespb.MakeGameMoveResponse_Error{
-				Error: &gamespb.Error{Message: "unauthorized: player ID does not match authenticated user"},
+				Error: errNotOwner(),
 			},
 		}, nil
 	}
@@ -69,7 +174,7 @@ func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*g
 	if err != nil {
 		return &gamespb.MakeGameMoveResponse{
 			Result: &gamespb.MakeGameMoveResponse_Error{
-				Error: &gamespb.Error{Message: "game not found"},
+				Error: errGameNotFound(),
 			},
 		}, nil
 	}
@@ -77,7 +182,15 @@ func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*g
 	if !IsPlayerInGame(game, req.PlayerId) {
 		return &gamespb.MakeGameMoveResponse{
 			Result: &gamespb.MakeGameMoveResponse_Error{
-				Error: &gamespb.Error{Message: "player is not part of this game"},
+				Error: errNotParticipant(),
+			},
+		}, nil
+	}
+
+	if !isInProgress(game) {
+		return &gamespb.MakeGameMoveResponse{
+			Result: &gamespb.MakeGameMoveResponse_Error{
+				Error: errGameFinished(),
 			},
 		}, nil
 	}
@@ -86,7 +199,20 @@ func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*g
 	if game.State.CurrentPlayer != currentPlayer {
 		return &gamespb.MakeGameMoveResponse{
 			Result: &gamespb.MakeGameMoveResponse_Error{
-				Error: &gamespb.Error{Message: "it's not your turn"},
+				Error: &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_NOT_YOUR_TURN, Message: "it's not your turn"},
+			},
+		}, nil
+	}
+
+	// A move that arrives after the flag fell loses the game instead of being played
+	now := time.Now()
+	if isOutOfTime(game, now) {
+		if err := s.forfeitOnTime(ctx, game); err != nil {
+			log.Printf("Failed to forfeit game %s on time: %v", req.GameId, err)
+		}
+		return &gamespb.MakeGameMoveResponse{
+			Result: &gamespb.MakeGameMoveResponse_Error{
+				Error: &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_GAME_FINISHED, Message: "you ran out of time"},
 			},
 		}, nil
 	}
@@ -100,7 +226,7 @@ func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*g
 	if err != nil {
 		return &gamespb.MakeGameMoveResponse{
 			Result: &gamespb.MakeGameMoveResponse_Error{
-				Error: &gamespb.Error{Message: fmt.Sprintf("engine error: %v", err)},
+				Error: errInternal(fmt.Sprintf("engine error: %v", err)),
 			},
 		}, nil
 	}
@@ -113,45 +239,50 @@ func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*g
 			},
 		}, nil
 	case *enginepb.MoveResponse_MoveResult:
+		chargeMove(game, currentPlayer, now)
 		game.State.Board = result.MoveResult.Board
 		game.State.CurrentPlayer = result.MoveResult.CurrentPlayer
+		game.Moves = append(game.Moves, &gamespb.GameMove{
+			PlayerId:   req.PlayerId,
+			PitIndex:   req.PitIndex,
+			Timestamp:  now.Unix(),
+			Board:      result.MoveResult.Board,
+			ExtraTurn:  result.MoveResult.ExtraTurn,
+			Captured:   result.MoveResult.Captured,
+			NextPlayer: result.MoveResult.CurrentPlayer,
+		})
+
+		// Moving instead of answering declines the opponent's draw offer
+		if game.DrawOfferedBy != "" && game.DrawOfferedBy != req.PlayerId {
+			game.DrawOfferedBy = ""
+		}
+
+		if result.MoveResult.IsFinished {
+			markFinished(game, result.MoveResult.Winner, gamespb.GameEndReason_GAME_END_REASON_COMPLETED)
+		}
+
+		// The save fails if another request changed the game since it was read
+		err = s.storage.SaveGame(ctx, game)
+		if err != nil {
+			return &gamespb.MakeGameMoveResponse{
+				Result: &gamespb.MakeGameMoveResponse_Error{
+					Error: saveError(err, "failed to save game state"),
+				},
+			}, nil
+		}
 
 		// Publish MOVE_MADE event
 		gameStateMap := gameStateToMap(game.State)
 		moveResultMap := moveResultToMap(result.MoveResult)
-		err = s.eventPublisher.PublishMoveMade(ctx, req.GameId, req.PlayerId, req.PitIndex, gameStateMap, moveResultMap)
+		err = s.eventPublisher.PublishMoveMade(ctx, req.GameId, game.Player1Id, game.Player2Id, req.PlayerId, req.PitIndex, gameStateMap, moveResultMap, clockToMap(game.Clock))
 		if err != nil {
 			// Log error but don't fail the game operation
-			fmt.Printf("Failed to publish move made event: %v", err)
+			log.Printf("Failed to publish move made event: %v", err)
 		}
 
+		// The move is played once saved, whether or not the game could be archived yet
 		if result.MoveResult.IsFinished {
-			// Publish GAME_OVER event
-			winnerID := determineWinner(result.MoveResult, game)
-			isDraw := winnerID == ""
-			err = s.eventPublisher.PublishGameOver(ctx, req.GameId, winnerID, isDraw, gameStateMap)
-			if err != nil {
-				// Log error but don't fail the game operation
-				fmt.Printf("Failed to publish game over event: %v", err)
-			}
-
-			err = s.storage.DeleteGame(ctx, req.GameId)
-			if err != nil {
-				return &gamespb.MakeGameMoveResponse{
-					Result: &gamespb.MakeGameMoveResponse_Error{
-						Error: &gamespb.Error{Message: "failed to clean up finished game"},
-					},
-				}, nil
-			}
-		} else {
-			err = s.storage.SaveGame(ctx, game)
-			if err != nil {
-				return &gamespb.MakeGameMoveResponse{
-					Result: &gamespb.MakeGameMoveResponse_Error{
-						Error: &gamespb.Error{Message: "failed to save game state"},
-					},
-				}, nil
-			}
+			s.closeFinishedGame(ctx, game)
 		}
 
 		return &gamespb.MakeGameMoveResponse{
@@ -162,12 +293,486 @@ func (s *Server) Move(ctx context.Context, req *gamespb.MakeGameMoveRequest) (*g
 	default:
 		return &gamespb.MakeGameMoveResponse{
 			Result: &gamespb.MakeGameMoveResponse_Error{
-				Error: &gamespb.Error{Message: "unexpected engine response"},
+				Error: errInternal("unexpected engine response"),
 			},
 		}, nil
 	}
 }
 
+func (s *Server) Get(ctx context.Context, req *gamespb.GetGameRequest) (*gamespb.GetGameResponse, error) {
+	if req.GameId == "" {
+		return &gamespb.GetGameResponse{
+			Result: &gamespb.GetGameResponse_Error{
+				Error: &gamespb.Error{Message: "game ID is required"},
+			},
+		}, nil
+	}
+
+	game, gameErr := s.getParticipantGame(ctx, req.GameId)
+	if gameErr != nil {
+		return &gamespb.GetGameResponse{
+			Result: &gamespb.GetGameResponse_Error{
+				Error: gameErr,
+			},
+		}, nil
+	}
+
+	return &gamespb.GetGameResponse{
+		Result: &gamespb.GetGameResponse_Game{
+			Game: game,
+		},
+	}, nil
+}
+
+// Spectate returns a game in progress to any authenticated user. The view is
+// read-only, moves and actions are still limited to the game's players, and
+// finished games stay private to them.
+func (s *Server) Spectate(ctx context.Context, req *gamespb.SpectateRequest) (*gamespb.GetGameResponse, error) {
+	if req.GameId == "" {
+		return &gamespb.GetGameResponse{
+			Result: &gamespb.GetGameResponse_Error{
+				Error: &gamespb.Error{Message: "game ID is required"},
+			},
+		}, nil
+	}
+
+	if _, err := auth.GetUserIDFromContext(ctx); err != nil {
+		return &gamespb.GetGameResponse{
+			Result: &gamespb.GetGameResponse_Error{
+				Error: errUnauthenticated(),
+			},
+		}, nil
+	}
+
+	game, err := s.storage.GetGame(ctx, req.GameId)
+	if err != nil {
+		return &gamespb.GetGameResponse{
+			Result: &gamespb.GetGameResponse_Error{
+				Error: errGameNotFound(),
+			},
+		}, nil
+	}
+
+	if !isInProgress(game) {
+		return &gamespb.GetGameResponse{
+			Result: &gamespb.GetGameResponse_Error{
+				Error: errGameFinished(),
+			},
+		}, nil
+	}
+
+	return &gamespb.GetGameResponse{
+		Result: &gamespb.GetGameResponse_Game{
+			Game: game,
+		},
+	}, nil
+}
+
+func (s *Server) GetReplay(ctx context.Context, req *gamespb.GetReplayRequest) (*gamespb.GetReplayResponse, error) {
+	if req.GameId == "" {
+		return &gamespb.GetReplayResponse{
+			Result: &gamespb.GetReplayResponse_Error{
+				Error: &gamespb.Error{Message: "game ID is required"},
+			},
+		}, nil
+	}
+
+	game, gameErr := s.getParticipantGame(ctx, req.GameId)
+	if gameErr != nil {
+		return &gamespb.GetReplayResponse{
+			Result: &gamespb.GetReplayResponse_Error{
+				Error: gameErr,
+			},
+		}, nil
+	}
+
+	return &gamespb.GetReplayResponse{
+		Result: &gamespb.GetReplayResponse_Replay{
+			Replay: BuildReplay(game),
+		},
+	}, nil
+}
+
+func (s *Server) Resign(ctx context.Context, req *gamespb.ResignRequest) (*gamespb.GameActionResponse, error) {
+	game, gameErr := s.getActiveGame(ctx, req.GameId, req.PlayerId)
+	if gameErr != nil {
+		return actionErrResp(gameErr), nil
+	}
+
+	// The opponent of the resigning player wins
+	winner := winnerAgainst(GetPlayerFromID(req.PlayerId, game))
+	if err := s.finishGame(ctx, game, winner, gamespb.GameEndReason_GAME_END_REASON_RESIGNATION); err != nil {
+		return actionErrResp(saveError(err, "failed to save game")), nil
+	}
+
+	return actionResp(game), nil
+}
+
+func (s *Server) OfferDraw(ctx context.Context, req *gamespb.OfferDrawRequest) (*gamespb.GameActionResponse, error) {
+	game, gameErr := s.getActiveGame(ctx, req.GameId, req.PlayerId)
+	if gameErr != nil {
+		return actionErrResp(gameErr), nil
+	}
+
+	switch game.DrawOfferedBy {
+	case req.PlayerId:
+		return actionErrResp(&gamespb.Error{Message: "draw already offered"}), nil
+	case "":
+	default:
+		// Offering a draw back accepts the opponent's offer
+		if err := s.finishGame(ctx, game, enginepb.Winner_DRAW, gamespb.GameEndReason_GAME_END_REASON_DRAW_AGREED); err != nil {
+			return actionErrResp(saveError(err, "failed to save game")), nil
+		}
+		return actionResp(game), nil
+	}
+
+	game.DrawOfferedBy = req.PlayerId
+	if err := s.storage.SaveGame(ctx, game); err != nil {
+		return actionErrResp(saveError(err, "failed to save game state")), nil
+	}
+
+	return actionResp(game), nil
+}
+
+func (s *Server) RespondDraw(ctx context.Context, req *gamespb.RespondDrawRequest) (*gamespb.GameActionResponse, error) {
+	game, gameErr := s.getActiveGame(ctx, req.GameId, req.PlayerId)
+	if gameErr != nil {
+		return actionErrResp(gameErr), nil
+	}
+
+	if game.DrawOfferedBy == "" || game.DrawOfferedBy == req.PlayerId {
+		return actionErrResp(&gamespb.Error{Message: "no draw offer to respond to"}), nil
+	}
+
+	if req.Accept {
+		if err := s.finishGame(ctx, game, enginepb.Winner_DRAW, gamespb.GameEndReason_GAME_END_REASON_DRAW_AGREED); err != nil {
+			return actionErrResp(saveError(err, "failed to save game")), nil
+		}
+		return actionResp(game), nil
+	}
+
+	game.DrawOfferedBy = ""
+	if err := s.storage.SaveGame(ctx, game); err != nil {
+		return actionErrResp(saveError(err, "failed to save game state")), nil
+	}
+
+	return actionResp(game), nil
+}
+
+func (s *Server) Abort(ctx context.Context, req *gamespb.AbortRequest) (*gamespb.GameActionResponse, error) {
+	game, gameErr := s.getActiveGame(ctx, req.GameId, req.PlayerId)
+	if gameErr != nil {
+		return actionErrResp(gameErr), nil
+	}
+
+	// Either player may call the game off until the second move has been played
+	if len(game.Moves) >= 2 {
+		return actionErrResp(&gamespb.Error{Message: "game can only be aborted before the second move"}), nil
+	}
+
+	if err := s.finishGame(ctx, game, enginepb.Winner_NO_WINNER, gamespb.GameEndReason_GAME_END_REASON_ABORTED); err != nil {
+		return actionErrResp(saveError(err, "failed to save game")), nil
+	}
+
+	return actionResp(game), nil
+}
+
+// ForceEnd ends a game in progress on a moderator's behalf, the interceptor only
+// lets moderators and admins through. The game ends without a result unless a
+// winner is named.
+func (s *Server) ForceEnd(ctx context.Context, req *gamespb.ForceEndRequest) (*gamespb.GameActionResponse, error) {
+	if req.GameId == "" {
+		return actionErrResp(&gamespb.Error{Message: "game ID is required"}), nil
+	}
+
+	moderatorID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return actionErrResp(errUnauthenticated()), nil
+	}
+
+	game, err := s.storage.GetGame(ctx, req.GameId)
+	if err != nil {
+		return actionErrResp(errGameNotFound()), nil
+	}
+
+	if !isInProgress(game) {
+		return actionErrResp(errGameFinished()), nil
+	}
+
+	winner := enginepb.Winner_NO_WINNER
+	if req.WinnerId != "" {
+		if !IsPlayerInGame(game, req.WinnerId) {
+			return actionErrResp(&gamespb.Error{Message: "winner is not part of this game"}), nil
+		}
+		winner = enginepb.Winner_WINNER_PLAYER_ONE
+		if GetPlayerFromID(req.WinnerId, game) == enginepb.Player_PLAYER_TWO {
+			winner = enginepb.Winner_WINNER_PLAYER_TWO
+		}
+	}
+
+	if err := s.finishGame(ctx, game, winner, gamespb.GameEndReason_GAME_END_REASON_ENDED_BY_MODERATOR); err != nil {
+		return actionErrResp(saveError(err, "failed to save game")), nil
+	}
+
+	log.Printf("Game %s ended by moderator %s: %s", game.Id, moderatorID, req.Reason)
+	return actionResp(game), nil
+}
+
+// getActiveGame looks up a game in progress on behalf of the given player.
+// It returns an in-message error when the player may not act on the game.
+func (s *Server) getActiveGame(ctx context.Context, gameID, playerID string) (*gamespb.Game, *gamespb.Error) {
+	if playerID == "" || gameID == "" {
+		return nil, &gamespb.Error{Message: "player ID and game ID are required"}
+	}
+
+	if err := auth.ValidatePlayerOwnership(ctx, playerID); err != nil {
+		return nil, errNotOwner()
+	}
+
+	game, err := s.storage.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, errGameNotFound()
+	}
+
+	if !IsPlayerInGame(game, playerID) {
+		return nil, errNotParticipant()
+	}
+
+	if !isInProgress(game) {
+		return nil, errGameFinished()
+	}
+
+	return game, nil
+}
+
+// finishGame records the result of a game, publishes GAME_OVER and moves the game to the archive.
+// Saving the result first means only one of several concurrent requests can finish the game.
+func (s *Server) finishGame(ctx context.Context, game *gamespb.Game, winner enginepb.Winner, reason gamespb.GameEndReason) error {
+	markFinished(game, winner, reason)
+	if err := s.storage.SaveGame(ctx, game); err != nil {
+		return err
+	}
+
+	s.closeFinishedGame(ctx, game)
+	return nil
+}
+
+// markFinished records the result of a game without saving it
+func markFinished(game *gamespb.Game, winner enginepb.Winner, reason gamespb.GameEndReason) {
+	game.Status = gamespb.GameStatus_GAME_STATUS_FINISHED
+	game.FinishedAt = time.Now().Unix()
+	game.Winner = winner
+	game.WinnerId = determineWinner(winner, game)
+	game.EndReason = reason
+	game.DrawOfferedBy = ""
+}
+
+// closeFinishedGame publishes GAME_OVER for a saved finished game and moves it to the archive.
+// A game that can't be archived or removed now stays in storage, and SweepFinishedGames
+// archives it later.
+func (s *Server) closeFinishedGame(ctx context.Context, game *gamespb.Game) {
+	// Publish GAME_OVER event
+	isDraw := game.Winner == enginepb.Winner_DRAW
+	err := s.eventPublisher.PublishGameOver(ctx, game.Id, game.Player1Id, game.Player2Id, game.WinnerId, isDraw, endReasonName(game.EndReason), gameStateToMap(game.State))
+	if err != nil {
+		// Log error but don't fail the game operation
+		log.Printf("Failed to publish game over event: %v", err)
+	}
+
+	if err := s.archiveGame(ctx, game); err != nil {
+		log.Printf("Failed to archive finished game %s, leaving it for the sweeper: %v", game.Id, err)
+	}
+}
+
+// archiveGame moves a finished game from storage to the archive. Archiving a
+// game twice keeps the first copy, so a failed removal can simply be retried.
+func (s *Server) archiveGame(ctx context.Context, game *gamespb.Game) error {
+	if err := s.archive.ArchiveGame(ctx, game); err != nil {
+		return err
+	}
+	return s.storage.DeleteGame(ctx, game.Id)
+}
+
+// SweepFinishedGames archives the games that finished more than archiveGracePeriod
+// ago but are still stored, because archiving them failed when they finished
+func (s *Server) SweepFinishedGames(ctx context.Context, now time.Time) error {
+	gameIDs, err := s.storage.ListUnarchivedGames(ctx, now.Add(-archiveGracePeriod))
+	if err != nil {
+		return err
+	}
+
+	for _, gameID := range gameIDs {
+		game, err := s.storage.GetGame(ctx, gameID)
+		if err != nil {
+			// Archived since it was listed
+			continue
+		}
+
+		if err := s.archiveGame(ctx, game); err != nil {
+			log.Printf("Failed to archive finished game %s: %v", gameID, err)
+			continue
+		}
+		log.Printf("Archived finished game %s", gameID)
+	}
+
+	return nil
+}
+
+// saveError returns the in-message error for a failed save. Version conflicts
+// have their own code so the gateway can tell the client to retry; any other
+// failure is the service's own.
+func saveError(err error, message string) *gamespb.Error {
+	if errors.Is(err, ErrVersionConflict) {
+		return &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT, Message: err.Error()}
+	}
+	return errInternal(message)
+}
+
+// In-message errors callers tell apart by their code
+func errGameNotFound() *gamespb.Error {
+	return &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_NOT_FOUND, Message: "game not found"}
+}
+
+func errGameFinished() *gamespb.Error {
+	return &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_GAME_FINISHED, Message: "game is already finished"}
+}
+
+func errNotParticipant() *gamespb.Error {
+	return &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED, Message: "player is not part of this game"}
+}
+
+func errNotOwner() *gamespb.Error {
+	return &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED, Message: "unauthorized: player ID does not match authenticated user"}
+}
+
+func errInternal(message string) *gamespb.Error {
+	return &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_INTERNAL, Message: message}
+}
+
+func errUnauthenticated() *gamespb.Error {
+	return &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED, Message: "unauthorized: user not authenticated"}
+}
+
+// getParticipantGame looks a game up in storage or the archive on behalf of one of its players,
+// or of a service playing its bot. It returns an in-message error when the game cannot be shown
+// to the caller.
+func (s *Server) getParticipantGame(ctx context.Context, gameID string) (*gamespb.Game, *gamespb.Error) {
+	botService := auth.HasScope(ctx, auth.ScopePlayBots)
+	userID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil && !botService {
+		return nil, errUnauthenticated()
+	}
+
+	game, err := s.storage.GetGame(ctx, gameID)
+	if err != nil {
+		// Finished games live in the archive
+		game, err = s.archive.GetArchivedGame(ctx, gameID)
+	}
+	if err != nil {
+		return nil, errGameNotFound()
+	}
+
+	if botService && (auth.IsBotID(game.Player1Id) || auth.IsBotID(game.Player2Id)) {
+		return game, nil
+	}
+	if !IsPlayerInGame(game, userID) {
+		return nil, errNotParticipant()
+	}
+
+	return game, nil
+}
+
+func (s *Server) ListGames(ctx context.Context, req *gamespb.ListGamesRequest) (*gamespb.ListGamesResponse, error) {
+	if req.PlayerId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player ID is required")
+	}
+
+	// Players may only list their own games
+	if err := auth.ValidatePlayerOwnership(ctx, req.PlayerId); err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "player ID does not match authenticated user")
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	offset := 0
+	if req.PageToken != "" {
+		var err error
+		offset, err = strconv.Atoi(req.PageToken)
+		if err != nil || offset < 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid page token")
+		}
+	}
+
+	includeActive := req.Status != gamespb.GameStatus_GAME_STATUS_FINISHED
+	includeArchived := req.Status != gamespb.GameStatus_GAME_STATUS_IN_PROGRESS
+
+	// Games in progress come first, followed by the archive, most recent first
+	var active []*gamespb.Game
+	if includeActive {
+		games, err := s.storage.ListPlayerGames(ctx, req.PlayerId)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list games: %v", err)
+		}
+
+		for _, game := range games {
+			if isInProgress(game) {
+				active = append(active, game)
+			}
+		}
+	}
+
+	var page []*gamespb.Game
+	if offset < len(active) {
+		end := offset + pageSize
+		if end > len(active) {
+			end = len(active)
+		}
+		page = active[offset:end]
+	}
+	hasMore := offset+pageSize < len(active)
+
+	if includeArchived && !hasMore {
+		archiveOffset := offset - len(active)
+		if archiveOffset < 0 {
+			archiveOffset = 0
+		}
+
+		// Ask for one extra game to find out whether another page follows
+		remaining := pageSize - len(page)
+		archived, err := s.archive.ListArchivedGames(ctx, req.PlayerId, archiveOffset, remaining+1)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list archived games: %v", err)
+		}
+
+		if len(archived) > remaining {
+			hasMore = true
+			archived = archived[:remaining]
+		}
+		page = append(page, archived...)
+	}
+
+	resp := &gamespb.ListGamesResponse{
+		Games: page,
+	}
+	if hasMore {
+		resp.NextPageToken = strconv.Itoa(offset + pageSize)
+	}
+
+	return resp, nil
+}
+
+// isInProgress reports whether a stored game is still being played.
+// Games stored before statuses were recorded are in progress.
+func isInProgress(game *gamespb.Game) bool {
+	return game.Status != gamespb.GameStatus_GAME_STATUS_FINISHED
+}
+
 // Helper function to convert GameState to map for event publishing
 func gameStateToMap(state *enginepb.GameState) map[string]interface{} {
 	if state == nil {
@@ -179,9 +784,28 @@ func gameStateToMap(state *enginepb.GameState) map[string]interface{} {
 		boardSlice[i] = v
 	}
 
-	return map[string]interface{}{
+	stateMap := map[string]interface{}{
 		"board":          boardSlice,
 		"current_player": int(state.CurrentPlayer),
+		"game_type":      int(state.GameType),
+	}
+
+	// House rules travel with the state so bots play the same variant
+	if state.Rules != nil {
+		stateMap["rules"] = ruleSetToMap(state.Rules)
+	}
+
+	return stateMap
+}
+
+// Helper function to convert a RuleSet to map for event publishing
+func ruleSetToMap(ruleSet *enginepb.RuleSet) map[string]interface{} {
+	return map[string]interface{}{
+		"pits_per_side":   ruleSet.PitsPerSide,
+		"initial_seeds":   ruleSet.InitialSeeds,
+		"disable_capture": ruleSet.DisableCapture,
+		"empty_capture":   ruleSet.EmptyCapture,
+		"sweep_policy":    int(ruleSet.SweepPolicy),
 	}
 }
 
@@ -205,12 +829,8 @@ func moveResultToMap(moveResult *enginepb.MoveResult) map[string]interface{} {
 }
 
 // Helper function to determine winner from MoveResult and game context
-func determineWinner(moveResult *enginepb.MoveResult, game *gamespb.Game) string {
-	if !moveResult.IsFinished {
-		return ""
-	}
-
-	switch moveResult.Winner {
+func determineWinner(winner enginepb.Winner, game *gamespb.Game) string {
+	switch winner {
 	case enginepb.Winner_WINNER_PLAYER_ONE:
 		return game.Player1Id
 	case enginepb.Winner_WINNER_PLAYER_TWO:
@@ -220,3 +840,32 @@ func determineWinner(moveResult *enginepb.MoveResult, game *gamespb.Game) string
 		return ""
 	}
 }
+
+// winnerAgainst returns the result of a game the given player lost
+func winnerAgainst(loser enginepb.Player) enginepb.Winner {
+	if loser == enginepb.Player_PLAYER_ONE {
+		return enginepb.Winner_WINNER_PLAYER_TWO
+	}
+	return enginepb.Winner_WINNER_PLAYER_ONE
+}
+
+// endReasonName returns the reason published with GAME_OVER, e.g. events.GameOverReasonTimeout
+func endReasonName(reason gamespb.GameEndReason) string {
+	return strings.TrimPrefix(reason.String(), "GAME_END_REASON_")
+}
+
+func actionResp(game *gamespb.Game) *gamespb.GameActionResponse {
+	return &gamespb.GameActionResponse{
+		Result: &gamespb.GameActionResponse_Game{
+			Game: game,
+		},
+	}
+}
+
+func actionErrResp(gameErr *gamespb.Error) *gamespb.GameActionResponse {
+	return &gamespb.GameActionResponse{
+		Result: &gamespb.GameActionResponse_Error{
+			Error: gameErr,
+		},
+	}
+}
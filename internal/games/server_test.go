@@ -3,16 +3,33 @@ package games
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
+	"time"
 
+	"github.com/laerson/mancala/internal/auth"
+	"github.com/laerson/mancala/internal/events"
 	enginepb "github.com/laerson/mancala/proto/engine"
 	gamespb "github.com/laerson/mancala/proto/games"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// authContext returns a context carrying the authenticated user ID, as set by the auth interceptor
+func authContext(userID string) context.Context {
+	return context.WithValue(context.Background(), "user_id", userID)
+}
+
+// serviceContext returns the context of a call made with a service token granted the scopes
+func serviceContext(service string, scopes ...string) context.Context {
+	ctx := context.WithValue(context.Background(), "service", service)
+	return context.WithValue(ctx, "scopes", scopes)
+}
+
 func TestServer_Create(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
 	tests := []struct {
 		name    string
@@ -27,6 +44,61 @@ func TestServer_Create(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "House rules",
+			request: &gamespb.CreateGameRequest{
+				Player1Id: "player1",
+				Player2Id: "player2",
+				Rules:     &enginepb.RuleSet{PitsPerSide: 5, InitialSeeds: 3},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid rules",
+			request: &gamespb.CreateGameRequest{
+				Player1Id: "player1",
+				Player2Id: "player2",
+				Rules:     &enginepb.RuleSet{PitsPerSide: 20},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Oware",
+			request: &gamespb.CreateGameRequest{
+				Player1Id: "player1",
+				Player2Id: "player2",
+				GameType:  enginepb.GameType_GAME_TYPE_OWARE,
+			},
+			wantErr: false,
+		},
+		{
+			name: "Oware with house rules",
+			request: &gamespb.CreateGameRequest{
+				Player1Id: "player1",
+				Player2Id: "player2",
+				GameType:  enginepb.GameType_GAME_TYPE_OWARE,
+				Rules:     &enginepb.RuleSet{PitsPerSide: 4},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Time control",
+			request: &gamespb.CreateGameRequest{
+				Player1Id:   "player1",
+				Player2Id:   "player2",
+				TimeControl: &gamespb.TimeControl{BaseMs: 300000, IncrementMs: 5000},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Invalid time control",
+			request: &gamespb.CreateGameRequest{
+				Player1Id:   "player1",
+				Player2Id:   "player2",
+				TimeControl: &gamespb.TimeControl{BaseMs: 300000, PerMoveMs: 30000},
+			},
+			wantErr: true,
+		},
 		{
 			name: "Empty player1 ID",
 			request: &gamespb.CreateGameRequest{
@@ -47,7 +119,7 @@ func TestServer_Create(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			response, err := server.Create(context.Background(), tt.request)
+			response, err := server.Create(authContext("player1"), tt.request)
 
 			if tt.wantErr {
 				if err == nil {
@@ -72,16 +144,103 @@ func TestServer_Create(t *testing.T) {
 			if response.Game.Player2Id != tt.request.Player2Id {
 				t.Errorf("Create() Player2Id = %v, want %v", response.Game.Player2Id, tt.request.Player2Id)
 			}
+			if response.Game.State.Rules != tt.request.Rules {
+				t.Errorf("Create() Rules = %v, want %v", response.Game.State.Rules, tt.request.Rules)
+			}
+			if got := response.Game.Clock.GetPlayer2RemainingMs(); got != tt.request.TimeControl.GetBaseMs() {
+				t.Errorf("Create() Player2RemainingMs = %v, want %v", got, tt.request.TimeControl.GetBaseMs())
+			}
+			if response.Game.State.GameType != tt.request.GameType {
+				t.Errorf("Create() GameType = %v, want %v", response.Game.State.GameType, tt.request.GameType)
+			}
 		})
 	}
 }
 
+func TestServer_Create_Authorization(t *testing.T) {
+	storage := NewMockStorage()
+	server := NewServer(storage, NewMockArchive(), NewMockEngineClient(), "localhost:6379")
+	request := &gamespb.CreateGameRequest{Player1Id: "alice", Player2Id: "bob"}
+
+	admin := context.WithValue(authContext("admin1"), "role", auth.RoleAdmin)
+	moderator := context.WithValue(authContext("mod1"), "role", auth.RoleModerator)
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		wantCode codes.Code
+	}{
+		{"First player", authContext("alice"), codes.OK},
+		{"Second player", authContext("bob"), codes.OK},
+		{"Matchmaking service", serviceContext("matchmaking", auth.ScopeCreateGames), codes.OK},
+		{"Admin", admin, codes.OK},
+		{"Another user", authContext("mallory"), codes.PermissionDenied},
+		{"Moderator", moderator, codes.PermissionDenied},
+		{"Service without the scope", serviceContext("bot", auth.ScopePlayBots), codes.Unauthenticated},
+		{"Unauthenticated", context.Background(), codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.Create(tt.ctx, request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Errorf("Create() code = %v, want %v (error %v)", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestServer_Create_RequestID(t *testing.T) {
+	storage := NewMockStorage()
+	archive := NewMockArchive()
+	server := NewServer(storage, archive, NewMockEngineClient(), "localhost:6379")
+	ctx := serviceContext("tournaments", auth.ScopeCreateGames)
+	request := &gamespb.CreateGameRequest{Player1Id: "alice", Player2Id: "bob", RequestId: "t1-1-1"}
+
+	first, err := server.Create(ctx, request)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	retried, err := server.Create(ctx, request)
+	if err != nil {
+		t.Fatalf("Create() retry error = %v", err)
+	}
+	if retried.Game.Id != first.Game.Id {
+		t.Errorf("Create() retry game = %s, want %s", retried.Game.Id, first.Game.Id)
+	}
+	if len(storage.games) != 1 {
+		t.Errorf("Stored games = %d, want 1", len(storage.games))
+	}
+
+	// A retry after the game finished gets the archived game rather than a new one
+	storage.DeleteGame(context.Background(), first.Game.Id)
+	archive.ArchiveGame(context.Background(), first.Game)
+	retried, err = server.Create(ctx, request)
+	if err != nil || retried.Game.Id != first.Game.Id {
+		t.Errorf("Create() after archiving = %v, %v, want game %s", retried, err, first.Game.Id)
+	}
+	if len(storage.games) != 0 {
+		t.Errorf("Stored games = %d, want 0", len(storage.games))
+	}
+
+	other, err := server.Create(ctx, &gamespb.CreateGameRequest{Player1Id: "alice", Player2Id: "bob", RequestId: "t1-1-2"})
+	if err != nil || other.Game.Id == first.Game.Id {
+		t.Errorf("Create() with another request ID = %v, %v, want a new game", other, err)
+	}
+
+	_, err = server.Create(ctx, &gamespb.CreateGameRequest{Player1Id: "carol", Player2Id: "dave", RequestId: "t1-1-1"})
+	if code := status.Code(err); code != codes.AlreadyExists {
+		t.Errorf("Create() reusing the request ID for other players code = %v, want %v", code, codes.AlreadyExists)
+	}
+}
+
 func TestServer_Move_ValidMove(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	storage.SaveGame(context.Background(), game)
 
 	engineClient.SetMoveResponse(&enginepb.MoveResponse{
@@ -103,7 +262,7 @@ func TestServer_Move_ValidMove(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -125,7 +284,7 @@ func TestServer_Move_ValidMove(t *testing.T) {
 func TestServer_Move_GameNotFound(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
 	request := &gamespb.MakeGameMoveRequest{
 		PlayerId: "player1",
@@ -133,7 +292,7 @@ func TestServer_Move_GameNotFound(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -146,14 +305,17 @@ func TestServer_Move_GameNotFound(t *testing.T) {
 	if errorResult.Error.Message != "game not found" {
 		t.Errorf("Move() error message = %v, want 'game not found'", errorResult.Error.Message)
 	}
+	if errorResult.Error.Code != gamespb.ErrorCode_ERROR_CODE_NOT_FOUND {
+		t.Errorf("Move() error code = %v, want %v", errorResult.Error.Code, gamespb.ErrorCode_ERROR_CODE_NOT_FOUND)
+	}
 }
 
 func TestServer_Move_PlayerNotInGame(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	storage.SaveGame(context.Background(), game)
 
 	request := &gamespb.MakeGameMoveRequest{
@@ -162,7 +324,7 @@ func TestServer_Move_PlayerNotInGame(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -180,9 +342,9 @@ func TestServer_Move_PlayerNotInGame(t *testing.T) {
 func TestServer_Move_NotPlayerTurn(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	storage.SaveGame(context.Background(), game)
 
 	request := &gamespb.MakeGameMoveRequest{
@@ -191,7 +353,7 @@ func TestServer_Move_NotPlayerTurn(t *testing.T) {
 		PitIndex: 7,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -204,14 +366,17 @@ func TestServer_Move_NotPlayerTurn(t *testing.T) {
 	if errorResult.Error.Message != "it's not your turn" {
 		t.Errorf("Move() error message = %v, want 'it's not your turn'", errorResult.Error.Message)
 	}
+	if errorResult.Error.Code != gamespb.ErrorCode_ERROR_CODE_NOT_YOUR_TURN {
+		t.Errorf("Move() error code = %v, want %v", errorResult.Error.Code, gamespb.ErrorCode_ERROR_CODE_NOT_YOUR_TURN)
+	}
 }
 
 func TestServer_Move_EngineError(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	storage.SaveGame(context.Background(), game)
 
 	engineClient.SetMoveError(errors.New("engine service unavailable"))
@@ -222,7 +387,7 @@ func TestServer_Move_EngineError(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -236,14 +401,17 @@ func TestServer_Move_EngineError(t *testing.T) {
 	if errorResult.Error.Message != expectedMsg {
 		t.Errorf("Move() error message = %v, want %v", errorResult.Error.Message, expectedMsg)
 	}
+	if errorResult.Error.Code != gamespb.ErrorCode_ERROR_CODE_INTERNAL {
+		t.Errorf("Move() error code = %v, want %v", errorResult.Error.Code, gamespb.ErrorCode_ERROR_CODE_INTERNAL)
+	}
 }
 
 func TestServer_Move_EngineReturnedError(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	storage.SaveGame(context.Background(), game)
 
 	engineClient.SetMoveResponse(&enginepb.MoveResponse{
@@ -258,7 +426,7 @@ func TestServer_Move_EngineReturnedError(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -275,10 +443,11 @@ func TestServer_Move_EngineReturnedError(t *testing.T) {
 
 func TestServer_Move_GameFinished(t *testing.T) {
 	storage := NewMockStorage()
+	archive := NewMockArchive()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, archive, engineClient, "localhost:6379")
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	storage.SaveGame(context.Background(), game)
 
 	engineClient.SetMoveResponse(&enginepb.MoveResponse{
@@ -300,7 +469,7 @@ func TestServer_Move_GameFinished(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -321,12 +490,96 @@ func TestServer_Move_GameFinished(t *testing.T) {
 	if err == nil {
 		t.Error("Game should be deleted after finishing")
 	}
+
+	archived, err := archive.GetArchivedGame(context.Background(), game.Id)
+	if err != nil {
+		t.Fatalf("Finished game should be archived: %v", err)
+	}
+	if archived.Status != gamespb.GameStatus_GAME_STATUS_FINISHED {
+		t.Errorf("Archived game Status = %v, want %v", archived.Status, gamespb.GameStatus_GAME_STATUS_FINISHED)
+	}
+	if archived.Winner != enginepb.Winner_DRAW || archived.WinnerId != "" {
+		t.Errorf("Archived game winner = %v (%q), want draw", archived.Winner, archived.WinnerId)
+	}
+	if archived.FinishedAt == 0 {
+		t.Error("Archived game FinishedAt = 0, want finish time")
+	}
+	if len(archived.Moves) != 1 || archived.Moves[0].PlayerId != "player1" || archived.Moves[0].PitIndex != 0 {
+		t.Errorf("Archived game Moves = %v, want the finishing move", archived.Moves)
+	}
+	if archived.State.Board.Pits[6] != 24 || archived.State.Board.Pits[13] != 24 {
+		t.Errorf("Archived game board = %v, want final board", archived.State.Board.Pits)
+	}
+}
+
+func TestServer_Move_GameFinished_ArchiveError(t *testing.T) {
+	storage := NewMockStorage()
+	archive := NewMockArchive()
+	archive.SetError(errors.New("database unavailable"))
+	engineClient := NewMockEngineClient()
+	server := NewServer(storage, archive, engineClient, "localhost:6379")
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), game)
+
+	engineClient.SetMoveResponse(&enginepb.MoveResponse{
+		Result: &enginepb.MoveResponse_MoveResult{
+			MoveResult: &enginepb.MoveResult{
+				Board: &enginepb.Board{
+					Pits: []uint32{0, 0, 0, 0, 0, 0, 30, 0, 0, 0, 0, 0, 0, 18},
+				},
+				IsFinished: true,
+				Winner:     enginepb.Winner_WINNER_PLAYER_ONE,
+			},
+		},
+	})
+
+	request := &gamespb.MakeGameMoveRequest{
+		PlayerId: "player1",
+		GameId:   game.Id,
+		PitIndex: 0,
+	}
+
+	response, err := server.Move(authContext(request.PlayerId), request)
+	if err != nil {
+		t.Errorf("Move() error = %v, want nil", err)
+	}
+	if response.GetMoveResult() == nil {
+		t.Fatalf("Move() response = %v, want MoveResult", response)
+	}
+
+	kept, err := storage.GetGame(context.Background(), game.Id)
+	if err != nil {
+		t.Fatal("Finished game should be kept when archiving fails")
+	}
+	if kept.Status != gamespb.GameStatus_GAME_STATUS_FINISHED || kept.WinnerId != "player1" {
+		t.Errorf("Kept game status = %v, winner = %q, want finished with winner player1", kept.Status, kept.WinnerId)
+	}
+
+	// The sweeper retries once the archive is back and the grace period has passed
+	archive.SetError(nil)
+	if err := server.SweepFinishedGames(context.Background(), time.Now()); err != nil {
+		t.Fatalf("SweepFinishedGames() error = %v", err)
+	}
+	if _, err := archive.GetArchivedGame(context.Background(), game.Id); err == nil {
+		t.Error("Game should not be archived within the grace period")
+	}
+
+	if err := server.SweepFinishedGames(context.Background(), time.Now().Add(archiveGracePeriod+time.Second)); err != nil {
+		t.Fatalf("SweepFinishedGames() error = %v", err)
+	}
+	if _, err := archive.GetArchivedGame(context.Background(), game.Id); err != nil {
+		t.Errorf("Swept game should be archived: %v", err)
+	}
+	if _, err := storage.GetGame(context.Background(), game.Id); err == nil {
+		t.Error("Swept game should be deleted from storage")
+	}
 }
 
 func TestServer_Move_EmptyPlayerID(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
 	request := &gamespb.MakeGameMoveRequest{
 		PlayerId: "",
@@ -334,7 +587,7 @@ func TestServer_Move_EmptyPlayerID(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -352,7 +605,7 @@ func TestServer_Move_EmptyPlayerID(t *testing.T) {
 func TestServer_Move_EmptyGameID(t *testing.T) {
 	storage := NewMockStorage()
 	engineClient := NewMockEngineClient()
-	server := NewServer(storage, engineClient, "localhost:6379")
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
 
 	request := &gamespb.MakeGameMoveRequest{
 		PlayerId: "player1",
@@ -360,7 +613,7 @@ func TestServer_Move_EmptyGameID(t *testing.T) {
 		PitIndex: 0,
 	}
 
-	response, err := server.Move(context.Background(), request)
+	response, err := server.Move(authContext(request.PlayerId), request)
 	if err != nil {
 		t.Errorf("Move() error = %v, want nil", err)
 	}
@@ -374,3 +627,756 @@ func TestServer_Move_EmptyGameID(t *testing.T) {
 		t.Errorf("Move() error message = %v, want 'player ID and game ID are required'", errorResult.Error.Message)
 	}
 }
+
+func TestServer_Get(t *testing.T) {
+	storage := NewMockStorage()
+	server := NewServer(storage, NewMockArchive(), NewMockEngineClient(), "localhost:6379")
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), game)
+
+	tests := []struct {
+		name      string
+		userID    string
+		gameID    string
+		wantError string
+	}{
+		{
+			name:   "participant gets game",
+			userID: "player2",
+			gameID: game.Id,
+		},
+		{
+			name:      "non participant",
+			userID:    "player3",
+			gameID:    game.Id,
+			wantError: "player is not part of this game",
+		},
+		{
+			name:      "game not found",
+			userID:    "player1",
+			gameID:    "nonexistent",
+			wantError: "game not found",
+		},
+		{
+			name:      "empty game ID",
+			userID:    "player1",
+			gameID:    "",
+			wantError: "game ID is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := server.Get(authContext(tt.userID), &gamespb.GetGameRequest{GameId: tt.gameID})
+			if err != nil {
+				t.Fatalf("Get() error = %v, want nil", err)
+			}
+
+			if tt.wantError != "" {
+				if response.GetError().GetMessage() != tt.wantError {
+					t.Errorf("Get() error message = %v, want %v", response.GetError().GetMessage(), tt.wantError)
+				}
+				return
+			}
+
+			if response.GetGame().GetId() != game.Id {
+				t.Errorf("Get() game ID = %v, want %v", response.GetGame().GetId(), game.Id)
+			}
+			if response.GetGame().GetState().GetCurrentPlayer() != enginepb.Player_PLAYER_ONE {
+				t.Errorf("Get() CurrentPlayer = %v, want %v", response.GetGame().GetState().GetCurrentPlayer(), enginepb.Player_PLAYER_ONE)
+			}
+		})
+	}
+}
+
+func TestServer_Get_BotService(t *testing.T) {
+	storage := NewMockStorage()
+	server := NewServer(storage, NewMockArchive(), NewMockEngineClient(), "localhost:6379")
+
+	botGame := NewGame("player1", "bot-0a1b2c3d", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	humanGame := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), botGame)
+	storage.SaveGame(context.Background(), humanGame)
+
+	// The bot driver re-reads the games its bots play, and only those
+	ctx := serviceContext("matchmaking", auth.ScopePlayBots)
+	if response, _ := server.Get(ctx, &gamespb.GetGameRequest{GameId: botGame.Id}); response.GetGame().GetId() != botGame.Id {
+		t.Errorf("Get() of a bot game = %v, want the game", response)
+	}
+	if response, _ := server.Get(ctx, &gamespb.GetGameRequest{GameId: humanGame.Id}); response.GetError().GetCode() != gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED {
+		t.Errorf("Get() of a game between humans = %v, want permission denied", response)
+	}
+}
+
+func TestServer_Spectate(t *testing.T) {
+	storage := NewMockStorage()
+	server := NewServer(storage, NewMockArchive(), NewMockEngineClient(), "localhost:6379")
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), game)
+
+	finished := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	finished.Status = gamespb.GameStatus_GAME_STATUS_FINISHED
+	storage.SaveGame(context.Background(), finished)
+
+	tests := []struct {
+		name     string
+		gameID   string
+		wantCode gamespb.ErrorCode
+	}{
+		{
+			name:   "spectator gets game in progress",
+			gameID: game.Id,
+		},
+		{
+			name:     "finished game",
+			gameID:   finished.Id,
+			wantCode: gamespb.ErrorCode_ERROR_CODE_GAME_FINISHED,
+		},
+		{
+			name:     "game not found",
+			gameID:   "nonexistent",
+			wantCode: gamespb.ErrorCode_ERROR_CODE_NOT_FOUND,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := server.Spectate(authContext("spectator"), &gamespb.SpectateRequest{GameId: tt.gameID})
+			if err != nil {
+				t.Fatalf("Spectate() error = %v, want nil", err)
+			}
+
+			if tt.wantCode != gamespb.ErrorCode_ERROR_CODE_UNSPECIFIED {
+				if response.GetError().GetCode() != tt.wantCode {
+					t.Errorf("Spectate() error = %v, want code %v", response.GetError(), tt.wantCode)
+				}
+				return
+			}
+
+			if response.GetGame().GetId() != game.Id {
+				t.Errorf("Spectate() game ID = %v, want %v", response.GetGame().GetId(), game.Id)
+			}
+		})
+	}
+
+	// Spectating doesn't let the spectator play
+	move, _ := server.Move(authContext("spectator"), &gamespb.MakeGameMoveRequest{GameId: game.Id, PlayerId: "spectator", PitIndex: 0})
+	if move.GetError().GetCode() != gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED {
+		t.Errorf("Move() by a spectator = %v, want permission denied", move)
+	}
+}
+
+func TestServer_ListGames(t *testing.T) {
+	storage := NewMockStorage()
+	archive := NewMockArchive()
+	server := NewServer(storage, archive, NewMockEngineClient(), "localhost:6379")
+	ctx := authContext("player1")
+
+	var gameIDs []string
+	for i := 0; i < 3; i++ {
+		game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+		storage.SaveGame(context.Background(), game)
+		gameIDs = append(gameIDs, game.Id)
+	}
+	finished := NewGame("player1", "player3", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	finished.Status = gamespb.GameStatus_GAME_STATUS_FINISHED
+	archive.ArchiveGame(context.Background(), finished)
+	storage.SaveGame(context.Background(), NewGame("player2", "player3", enginepb.GameType_GAME_TYPE_KALAH, nil))
+
+	// First page
+	response, err := server.ListGames(ctx, &gamespb.ListGamesRequest{
+		PlayerId: "player1",
+		Status:   gamespb.GameStatus_GAME_STATUS_IN_PROGRESS,
+		PageSize: 2,
+	})
+	if err != nil {
+		t.Fatalf("ListGames() error = %v, want nil", err)
+	}
+	if len(response.Games) != 2 || response.Games[0].Id != gameIDs[0] || response.Games[1].Id != gameIDs[1] {
+		t.Errorf("ListGames() first page = %v, want games %v", response.Games, gameIDs[:2])
+	}
+	if response.NextPageToken == "" {
+		t.Fatal("ListGames() NextPageToken is empty, want a token for the next page")
+	}
+
+	// Second page
+	response, err = server.ListGames(ctx, &gamespb.ListGamesRequest{
+		PlayerId:  "player1",
+		Status:    gamespb.GameStatus_GAME_STATUS_IN_PROGRESS,
+		PageSize:  2,
+		PageToken: response.NextPageToken,
+	})
+	if err != nil {
+		t.Fatalf("ListGames() error = %v, want nil", err)
+	}
+	if len(response.Games) != 1 || response.Games[0].Id != gameIDs[2] {
+		t.Errorf("ListGames() second page = %v, want game %v", response.Games, gameIDs[2])
+	}
+	if response.NextPageToken != "" {
+		t.Errorf("ListGames() NextPageToken = %v, want empty on last page", response.NextPageToken)
+	}
+
+	// Any status
+	response, err = server.ListGames(ctx, &gamespb.ListGamesRequest{PlayerId: "player1"})
+	if err != nil {
+		t.Fatalf("ListGames() error = %v, want nil", err)
+	}
+	if len(response.Games) != 4 {
+		t.Errorf("ListGames() returned %d games, want 4", len(response.Games))
+	}
+}
+
+func TestServer_ListGames_InvalidRequests(t *testing.T) {
+	server := NewServer(NewMockStorage(), NewMockArchive(), NewMockEngineClient(), "localhost:6379")
+
+	tests := []struct {
+		name     string
+		userID   string
+		request  *gamespb.ListGamesRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "other player's games",
+			userID:   "player2",
+			request:  &gamespb.ListGamesRequest{PlayerId: "player1"},
+			wantCode: codes.PermissionDenied,
+		},
+		{
+			name:     "empty player ID",
+			userID:   "player1",
+			request:  &gamespb.ListGamesRequest{},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "invalid page token",
+			userID:   "player1",
+			request:  &gamespb.ListGamesRequest{PlayerId: "player1", PageToken: "abc"},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := server.ListGames(authContext(tt.userID), tt.request)
+			if status.Code(err) != tt.wantCode {
+				t.Errorf("ListGames() error code = %v, want %v", status.Code(err), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestServer_GetAndListArchivedGames(t *testing.T) {
+	storage := NewMockStorage()
+	archive := NewMockArchive()
+	server := NewServer(storage, archive, NewMockEngineClient(), "localhost:6379")
+	ctx := authContext("player1")
+
+	active := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), active)
+
+	older := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	older.Status = gamespb.GameStatus_GAME_STATUS_FINISHED
+	archive.ArchiveGame(context.Background(), older)
+	newer := NewGame("player3", "player1", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	newer.Status = gamespb.GameStatus_GAME_STATUS_FINISHED
+	archive.ArchiveGame(context.Background(), newer)
+
+	getResponse, err := server.Get(ctx, &gamespb.GetGameRequest{GameId: older.Id})
+	if err != nil {
+		t.Fatalf("Get() error = %v, want nil", err)
+	}
+	if getResponse.GetGame().GetId() != older.Id {
+		t.Errorf("Get() archived game = %v, want %v", getResponse.GetGame(), older.Id)
+	}
+
+	listResponse, err := server.ListGames(ctx, &gamespb.ListGamesRequest{
+		PlayerId: "player1",
+		Status:   gamespb.GameStatus_GAME_STATUS_FINISHED,
+	})
+	if err != nil {
+		t.Fatalf("ListGames() error = %v, want nil", err)
+	}
+	if len(listResponse.Games) != 2 || listResponse.Games[0].Id != newer.Id || listResponse.Games[1].Id != older.Id {
+		t.Errorf("ListGames() finished = %v, want %v then %v", listResponse.Games, newer.Id, older.Id)
+	}
+
+	// Any status pages through games in progress first, then the archive
+	var ids []string
+	pageToken := ""
+	for {
+		listResponse, err = server.ListGames(ctx, &gamespb.ListGamesRequest{
+			PlayerId:  "player1",
+			PageSize:  2,
+			PageToken: pageToken,
+		})
+		if err != nil {
+			t.Fatalf("ListGames() error = %v, want nil", err)
+		}
+		for _, game := range listResponse.Games {
+			ids = append(ids, game.Id)
+		}
+		if listResponse.NextPageToken == "" {
+			break
+		}
+		pageToken = listResponse.NextPageToken
+	}
+
+	want := []string{active.Id, newer.Id, older.Id}
+	if len(ids) != len(want) || ids[0] != want[0] || ids[1] != want[1] || ids[2] != want[2] {
+		t.Errorf("ListGames() all pages = %v, want %v", ids, want)
+	}
+}
+
+func TestServer_GetReplay(t *testing.T) {
+	storage := NewMockStorage()
+	engineClient := NewMockEngineClient()
+	server := NewServer(storage, NewMockArchive(), engineClient, "localhost:6379")
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), game)
+
+	// An extra turn for player one
+	engineClient.SetMoveResponse(&enginepb.MoveResponse{
+		Result: &enginepb.MoveResponse_MoveResult{
+			MoveResult: &enginepb.MoveResult{
+				Board: &enginepb.Board{
+					Pits: []uint32{4, 4, 0, 5, 5, 5, 1, 4, 4, 4, 4, 4, 4, 0},
+				},
+				CurrentPlayer: enginepb.Player_PLAYER_ONE,
+				ExtraTurn:     true,
+			},
+		},
+	})
+	server.Move(authContext("player1"), &gamespb.MakeGameMoveRequest{PlayerId: "player1", GameId: game.Id, PitIndex: 2})
+
+	engineClient.SetMoveResponse(&enginepb.MoveResponse{
+		Result: &enginepb.MoveResponse_MoveResult{
+			MoveResult: &enginepb.MoveResult{
+				Board: &enginepb.Board{
+					Pits: []uint32{4, 4, 0, 5, 5, 0, 2, 5, 5, 5, 5, 4, 4, 0},
+				},
+				CurrentPlayer: enginepb.Player_PLAYER_TWO,
+			},
+		},
+	})
+	server.Move(authContext("player1"), &gamespb.MakeGameMoveRequest{PlayerId: "player1", GameId: game.Id, PitIndex: 5})
+
+	response, err := server.GetReplay(authContext("player2"), &gamespb.GetReplayRequest{GameId: game.Id})
+	if err != nil {
+		t.Fatalf("GetReplay() error = %v, want nil", err)
+	}
+
+	replay := response.GetReplay()
+	if replay == nil {
+		t.Fatalf("GetReplay() response = %v, want replay", response)
+	}
+	if len(replay.Positions) != 3 {
+		t.Fatalf("GetReplay() returned %d positions, want 3", len(replay.Positions))
+	}
+
+	first := replay.Positions[1].Move
+	if first.PitIndex != 2 || !first.ExtraTurn || first.NextPlayer != enginepb.Player_PLAYER_ONE {
+		t.Errorf("GetReplay() first move = %v, want pit 2 with an extra turn", first)
+	}
+	if replay.Positions[1].State.Board.Pits[6] != 1 {
+		t.Errorf("GetReplay() board after first move = %v, want 1 seed in store", replay.Positions[1].State.Board.Pits)
+	}
+	if replay.Positions[2].State.CurrentPlayer != enginepb.Player_PLAYER_TWO {
+		t.Errorf("GetReplay() turn after second move = %v, want %v", replay.Positions[2].State.CurrentPlayer, enginepb.Player_PLAYER_TWO)
+	}
+
+	response, err = server.GetReplay(authContext("player3"), &gamespb.GetReplayRequest{GameId: game.Id})
+	if err != nil {
+		t.Fatalf("GetReplay() error = %v, want nil", err)
+	}
+	if response.GetError().GetMessage() != "player is not part of this game" {
+		t.Errorf("GetReplay() for non participant = %v, want error", response)
+	}
+}
+
+func TestServer_Resign(t *testing.T) {
+	tests := []struct {
+		name       string
+		playerID   string
+		authUser   string
+		wantWinner enginepb.Winner
+		wantError  string
+	}{
+		{
+			name:       "Player one resigns",
+			playerID:   "player1",
+			authUser:   "player1",
+			wantWinner: enginepb.Winner_WINNER_PLAYER_TWO,
+		},
+		{
+			name:       "Player two resigns",
+			playerID:   "player2",
+			authUser:   "player2",
+			wantWinner: enginepb.Winner_WINNER_PLAYER_ONE,
+		},
+		{
+			name:      "Player not in game",
+			playerID:  "player3",
+			authUser:  "player3",
+			wantError: "player is not part of this game",
+		},
+		{
+			name:      "Resigning for another player",
+			playerID:  "player1",
+			authUser:  "player2",
+			wantError: "unauthorized: player ID does not match authenticated user",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewMockStorage()
+			archive := NewMockArchive()
+			server := NewServer(storage, archive, NewMockEngineClient(), "localhost:6379")
+
+			game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+			storage.SaveGame(context.Background(), game)
+
+			response, err := server.Resign(authContext(tt.authUser), &gamespb.ResignRequest{GameId: game.Id, PlayerId: tt.playerID})
+			if err != nil {
+				t.Fatalf("Resign() error = %v, want nil", err)
+			}
+
+			if tt.wantError != "" {
+				if response.GetError().GetMessage() != tt.wantError {
+					t.Errorf("Resign() error = %v, want %v", response.GetError(), tt.wantError)
+				}
+				return
+			}
+
+			archived, err := archive.GetArchivedGame(context.Background(), game.Id)
+			if err != nil {
+				t.Fatalf("Resigned game should be archived: %v", err)
+			}
+			if archived.Winner != tt.wantWinner {
+				t.Errorf("Resign() Winner = %v, want %v", archived.Winner, tt.wantWinner)
+			}
+			if archived.EndReason != gamespb.GameEndReason_GAME_END_REASON_RESIGNATION {
+				t.Errorf("Resign() EndReason = %v, want %v", archived.EndReason, gamespb.GameEndReason_GAME_END_REASON_RESIGNATION)
+			}
+			if archived.WinnerId == tt.playerID || archived.WinnerId == "" {
+				t.Errorf("Resign() WinnerId = %q, want the opponent of %s", archived.WinnerId, tt.playerID)
+			}
+
+			if _, err := storage.GetGame(context.Background(), game.Id); err == nil {
+				t.Error("Game should be deleted after resigning")
+			}
+		})
+	}
+}
+
+func TestServer_DrawOffer(t *testing.T) {
+	storage := NewMockStorage()
+	archive := NewMockArchive()
+	engineClient := NewMockEngineClient()
+	server := NewServer(storage, archive, engineClient, "localhost:6379")
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.SaveGame(context.Background(), game)
+
+	offer := &gamespb.OfferDrawRequest{GameId: game.Id, PlayerId: "player1"}
+	response, _ := server.OfferDraw(authContext("player1"), offer)
+	if response.GetGame().GetDrawOfferedBy() != "player1" {
+		t.Fatalf("OfferDraw() = %v, want offer by player1", response)
+	}
+
+	response, _ = server.OfferDraw(authContext("player1"), offer)
+	if response.GetError().GetMessage() != "draw already offered" {
+		t.Errorf("OfferDraw() twice = %v, want error", response)
+	}
+
+	response, _ = server.RespondDraw(authContext("player1"), &gamespb.RespondDrawRequest{GameId: game.Id, PlayerId: "player1", Accept: true})
+	if response.GetError().GetMessage() != "no draw offer to respond to" {
+		t.Errorf("RespondDraw() to own offer = %v, want error", response)
+	}
+
+	// Declining keeps the game going
+	response, _ = server.RespondDraw(authContext("player2"), &gamespb.RespondDrawRequest{GameId: game.Id, PlayerId: "player2"})
+	if response.GetGame().GetDrawOfferedBy() != "" || response.GetGame().GetStatus() != gamespb.GameStatus_GAME_STATUS_IN_PROGRESS {
+		t.Fatalf("RespondDraw() decline = %v, want game in progress without offer", response)
+	}
+
+	// Moving instead of answering declines the offer too
+	server.OfferDraw(authContext("player1"), offer)
+	game, _ = storage.GetGame(context.Background(), game.Id)
+	game.State.CurrentPlayer = enginepb.Player_PLAYER_TWO
+	storage.SaveGame(context.Background(), game)
+	engineClient.SetMoveResponse(&enginepb.MoveResponse{
+		Result: &enginepb.MoveResponse_MoveResult{
+			MoveResult: &enginepb.MoveResult{
+				Board:         &enginepb.Board{Pits: []uint32{4, 4, 4, 4, 4, 4, 0, 0, 5, 5, 5, 5, 4, 0}},
+				CurrentPlayer: enginepb.Player_PLAYER_ONE,
+			},
+		},
+	})
+	server.Move(authContext("player2"), &gamespb.MakeGameMoveRequest{GameId: game.Id, PlayerId: "player2", PitIndex: 7})
+	stored, _ := storage.GetGame(context.Background(), game.Id)
+	if stored.DrawOfferedBy != "" {
+		t.Errorf("Move() kept draw offer by %s, want it declined", stored.DrawOfferedBy)
+	}
+
+	server.OfferDraw(authContext("player1"), offer)
+	response, _ = server.RespondDraw(authContext("player2"), &gamespb.RespondDrawRequest{GameId: game.Id, PlayerId: "player2", Accept: true})
+	if response.GetError() != nil {
+		t.Fatalf("RespondDraw() accept error = %v", response.GetError())
+	}
+
+	archived, err := archive.GetArchivedGame(context.Background(), game.Id)
+	if err != nil {
+		t.Fatalf("Drawn game should be archived: %v", err)
+	}
+	if archived.Winner != enginepb.Winner_DRAW || archived.WinnerId != "" {
+		t.Errorf("Archived game winner = %v (%q), want draw", archived.Winner, archived.WinnerId)
+	}
+	if archived.EndReason != gamespb.GameEndReason_GAME_END_REASON_DRAW_AGREED {
+		t.Errorf("Archived game EndReason = %v, want %v", archived.EndReason, gamespb.GameEndReason_GAME_END_REASON_DRAW_AGREED)
+	}
+}
+
+func TestServer_Abort(t *testing.T) {
+	tests := []struct {
+		name      string
+		moves     int
+		wantError string
+	}{
+		{
+			name:  "Before any move",
+			moves: 0,
+		},
+		{
+			name:  "After the first move",
+			moves: 1,
+		},
+		{
+			name:      "After the second move",
+			moves:     2,
+			wantError: "game can only be aborted before the second move",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewMockStorage()
+			archive := NewMockArchive()
+			server := NewServer(storage, archive, NewMockEngineClient(), "localhost:6379")
+
+			game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+			for i := 0; i < tt.moves; i++ {
+				game.Moves = append(game.Moves, &gamespb.GameMove{PlayerId: "player1"})
+			}
+			storage.SaveGame(context.Background(), game)
+
+			response, err := server.Abort(authContext("player2"), &gamespb.AbortRequest{GameId: game.Id, PlayerId: "player2"})
+			if err != nil {
+				t.Fatalf("Abort() error = %v, want nil", err)
+			}
+
+			if tt.wantError != "" {
+				if response.GetError().GetMessage() != tt.wantError {
+					t.Errorf("Abort() error = %v, want %v", response.GetError(), tt.wantError)
+				}
+				return
+			}
+
+			archived, err := archive.GetArchivedGame(context.Background(), game.Id)
+			if err != nil {
+				t.Fatalf("Aborted game should be archived: %v", err)
+			}
+			if archived.Winner != enginepb.Winner_NO_WINNER || archived.WinnerId != "" {
+				t.Errorf("Abort() winner = %v (%q), want no winner", archived.Winner, archived.WinnerId)
+			}
+			if archived.EndReason != gamespb.GameEndReason_GAME_END_REASON_ABORTED {
+				t.Errorf("Abort() EndReason = %v, want %v", archived.EndReason, gamespb.GameEndReason_GAME_END_REASON_ABORTED)
+			}
+		})
+	}
+}
+
+func TestEndReasonName(t *testing.T) {
+	tests := map[gamespb.GameEndReason]string{
+		gamespb.GameEndReason_GAME_END_REASON_COMPLETED:          events.GameOverReasonCompleted,
+		gamespb.GameEndReason_GAME_END_REASON_RESIGNATION:        events.GameOverReasonResignation,
+		gamespb.GameEndReason_GAME_END_REASON_DRAW_AGREED:        events.GameOverReasonDrawAgreed,
+		gamespb.GameEndReason_GAME_END_REASON_ABORTED:            events.GameOverReasonAborted,
+		gamespb.GameEndReason_GAME_END_REASON_TIMEOUT:            events.GameOverReasonTimeout,
+		gamespb.GameEndReason_GAME_END_REASON_ENDED_BY_MODERATOR: events.GameOverReasonEndedByModerator,
+	}
+
+	for reason, want := range tests {
+		if got := endReasonName(reason); got != want {
+			t.Errorf("endReasonName(%v) = %q, want %q", reason, got, want)
+		}
+	}
+}
+
+func TestServer_ForceEnd(t *testing.T) {
+	tests := []struct {
+		name       string
+		winnerID   string
+		wantWinner enginepb.Winner
+		wantError  string
+	}{
+		{
+			name:       "Without a result",
+			wantWinner: enginepb.Winner_NO_WINNER,
+		},
+		{
+			name:       "Naming a winner",
+			winnerID:   "player2",
+			wantWinner: enginepb.Winner_WINNER_PLAYER_TWO,
+		},
+		{
+			name:      "Naming someone else",
+			winnerID:  "player3",
+			wantError: "winner is not part of this game",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := NewMockStorage()
+			archive := NewMockArchive()
+			server := NewServer(storage, archive, NewMockEngineClient(), "localhost:6379")
+
+			game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+			storage.SaveGame(context.Background(), game)
+
+			// The moderator is not a player of the game
+			response, err := server.ForceEnd(authContext("moderator"), &gamespb.ForceEndRequest{GameId: game.Id, WinnerId: tt.winnerID, Reason: "cheating"})
+			if err != nil {
+				t.Fatalf("ForceEnd() error = %v, want nil", err)
+			}
+
+			if tt.wantError != "" {
+				if response.GetError().GetMessage() != tt.wantError {
+					t.Errorf("ForceEnd() error = %v, want %v", response.GetError(), tt.wantError)
+				}
+				return
+			}
+
+			archived, err := archive.GetArchivedGame(context.Background(), game.Id)
+			if err != nil {
+				t.Fatalf("Force-ended game should be archived: %v", err)
+			}
+			if archived.Winner != tt.wantWinner || archived.WinnerId != tt.winnerID {
+				t.Errorf("ForceEnd() winner = %v (%q), want %v (%q)", archived.Winner, archived.WinnerId, tt.wantWinner, tt.winnerID)
+			}
+			if archived.EndReason != gamespb.GameEndReason_GAME_END_REASON_ENDED_BY_MODERATOR {
+				t.Errorf("ForceEnd() EndReason = %v, want %v", archived.EndReason, gamespb.GameEndReason_GAME_END_REASON_ENDED_BY_MODERATOR)
+			}
+		})
+	}
+}
+
+func TestServer_Move_OutOfTime(t *testing.T) {
+	storage := NewMockStorage()
+	archive := NewMockArchive()
+	engineClient := NewMockEngineClient()
+	server := NewServer(storage, archive, engineClient, "localhost:6379")
+
+	tc := &gamespb.TimeControl{PerMoveMs: 30000}
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	game.TimeControl = tc
+	game.Clock = newClock(tc, time.Now().Add(-time.Minute))
+	storage.SaveGame(context.Background(), game)
+
+	request := &gamespb.MakeGameMoveRequest{
+		PlayerId: "player1",
+		GameId:   game.Id,
+		PitIndex: 0,
+	}
+
+	response, err := server.Move(authContext(request.PlayerId), request)
+	if err != nil {
+		t.Fatalf("Move() error = %v, want nil", err)
+	}
+	if response.GetError().GetMessage() != "you ran out of time" {
+		t.Errorf("Move() = %v, want out of time error", response)
+	}
+
+	archived, err := archive.GetArchivedGame(context.Background(), game.Id)
+	if err != nil {
+		t.Fatalf("Game out of time should be archived: %v", err)
+	}
+	if archived.WinnerId != "player2" || archived.EndReason != gamespb.GameEndReason_GAME_END_REASON_TIMEOUT {
+		t.Errorf("Archived game = %v (%v), want player2 winning on time", archived.WinnerId, archived.EndReason)
+	}
+}
+
+// racingStorage lets another request save the game right after each read
+type racingStorage struct {
+	*MockStorage
+}
+
+func (r *racingStorage) GetGame(ctx context.Context, gameID string) (*gamespb.Game, error) {
+	game, err := r.MockStorage.GetGame(ctx, gameID)
+	if err != nil {
+		return nil, err
+	}
+
+	other, _ := r.MockStorage.GetGame(ctx, gameID)
+	r.MockStorage.SaveGame(ctx, other)
+	return game, nil
+}
+
+func TestServer_VersionConflict(t *testing.T) {
+	storage := &racingStorage{NewMockStorage()}
+	archive := NewMockArchive()
+	engineClient := NewMockEngineClient()
+	server := NewServer(storage, archive, engineClient, "localhost:6379")
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	storage.MockStorage.SaveGame(context.Background(), game)
+
+	engineClient.SetMoveResponse(&enginepb.MoveResponse{
+		Result: &enginepb.MoveResponse_MoveResult{
+			MoveResult: &enginepb.MoveResult{
+				Board:         &enginepb.Board{Pits: []uint32{0, 5, 5, 5, 5, 4, 0, 4, 4, 4, 4, 4, 4, 0}},
+				CurrentPlayer: enginepb.Player_PLAYER_TWO,
+			},
+		},
+	})
+
+	moveResponse, err := server.Move(authContext("player1"), &gamespb.MakeGameMoveRequest{GameId: game.Id, PlayerId: "player1", PitIndex: 0})
+	if err != nil {
+		t.Fatalf("Move() error = %v, want nil", err)
+	}
+	if moveResponse.GetError().GetCode() != gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT {
+		t.Errorf("Move() = %v, want version conflict", moveResponse)
+	}
+
+	actionResponse, err := server.Resign(authContext("player1"), &gamespb.ResignRequest{GameId: game.Id, PlayerId: "player1"})
+	if err != nil {
+		t.Fatalf("Resign() error = %v, want nil", err)
+	}
+	if actionResponse.GetError().GetCode() != gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT {
+		t.Errorf("Resign() = %v, want version conflict", actionResponse)
+	}
+
+	// The losing request must not finish the game
+	if _, err := archive.GetArchivedGame(context.Background(), game.Id); err == nil {
+		t.Error("Resign() archived the game despite the conflict")
+	}
+	stored, _ := storage.MockStorage.GetGame(context.Background(), game.Id)
+	if len(stored.Moves) != 0 || stored.Status != gamespb.GameStatus_GAME_STATUS_IN_PROGRESS {
+		t.Errorf("Stored game = %v, want it unchanged", stored)
+	}
+}
+
+func TestSaveError(t *testing.T) {
+	if code := saveError(fmt.Errorf("save: %w", ErrVersionConflict), "failed to save game").Code; code != gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT {
+		t.Errorf("saveError() of a conflict code = %v, want %v", code, gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT)
+	}
+
+	// Other failures are the service's own, not the request's
+	if code := saveError(errors.New("redis unavailable"), "failed to save game").Code; code != gamespb.ErrorCode_ERROR_CODE_INTERNAL {
+		t.Errorf("saveError() of a storage failure code = %v, want %v", code, gamespb.ErrorCode_ERROR_CODE_INTERNAL)
+	}
+}
@@ -3,16 +3,38 @@ package games
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strconv"
+	"time"
 
 	gamespb "github.com/laerson/mancala/proto/games"
 	"github.com/redis/go-redis/v9"
 )
 
+const (
+	// gameDeadlinesKey is a sorted set of timed games scored by their clock deadline in Unix milliseconds
+	gameDeadlinesKey = "game_deadlines"
+
+	// finishedGamesKey is a sorted set of finished games not archived yet, scored by when they finished in Unix seconds
+	finishedGamesKey = "finished_games"
+)
+
+// ErrVersionConflict is returned by SaveGame when the game was changed since it was read
+var ErrVersionConflict = errors.New("game was changed by another request, please retry")
+
 type Storage interface {
+	// SaveGame stores the game if its version still matches the stored one and bumps the version.
+	// It returns ErrVersionConflict when another save happened in between.
 	SaveGame(ctx context.Context, game *gamespb.Game) error
 	GetGame(ctx context.Context, gameID string) (*gamespb.Game, error)
 	DeleteGame(ctx context.Context, gameID string) error
+	// ListPlayerGames returns the player's games, oldest first
+	ListPlayerGames(ctx context.Context, playerID string) ([]*gamespb.Game, error)
+	// ListExpiredGames returns the IDs of games whose player to move ran out of time by now
+	ListExpiredGames(ctx context.Context, now time.Time) ([]string, error)
+	// ListUnarchivedGames returns the IDs of stored games that finished before the given time
+	ListUnarchivedGames(ctx context.Context, finishedBefore time.Time) ([]string, error)
 }
 
 type RedisStorage struct {
@@ -32,17 +54,79 @@ func NewRedisStorage(addr, password string, db int) *RedisStorage {
 }
 
 func (r *RedisStorage) SaveGame(ctx context.Context, game *gamespb.Game) error {
+	expected := game.Version
+	game.Version++
+
 	gameJSON, err := json.Marshal(game)
 	if err != nil {
+		game.Version = expected
 		return fmt.Errorf("failed to marshal game: %w", err)
 	}
 
-	err = r.client.Set(ctx, gameKey(game.Id), gameJSON, 0).Err()
+	// Index the game under both players, keeping the creation time as score on later saves
+	createdAt := redis.Z{Score: float64(time.Now().UnixNano()), Member: game.Id}
+
+	save := func(tx *redis.Tx) error {
+		// A game that is not stored has version 0, so saving a deleted game conflicts too
+		current, err := storedVersion(ctx, tx, game.Id)
+		if err != nil {
+			return err
+		}
+		if current != expected {
+			return ErrVersionConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, gameKey(game.Id), gameJSON, 0)
+			pipe.ZAddNX(ctx, playerGamesKey(game.Player1Id), createdAt)
+			pipe.ZAddNX(ctx, playerGamesKey(game.Player2Id), createdAt)
+
+			// Index timed games by the moment the player to move runs out of time
+			if deadline, ok := clockDeadline(game); ok {
+				pipe.ZAdd(ctx, gameDeadlinesKey, redis.Z{Score: float64(deadline.UnixMilli()), Member: game.Id})
+			} else {
+				pipe.ZRem(ctx, gameDeadlinesKey, game.Id)
+			}
+
+			// Finished games stay indexed until they are archived and deleted
+			if game.Status == gamespb.GameStatus_GAME_STATUS_FINISHED {
+				pipe.ZAdd(ctx, finishedGamesKey, redis.Z{Score: float64(game.FinishedAt), Member: game.Id})
+			}
+			return nil
+		})
+		return err
+	}
+
+	err = r.client.Watch(ctx, save, gameKey(game.Id))
+	if err == nil {
+		return nil
+	}
+
+	game.Version = expected
+	if errors.Is(err, ErrVersionConflict) || errors.Is(err, redis.TxFailedErr) {
+		return ErrVersionConflict
+	}
+	return fmt.Errorf("failed to save game to redis: %w", err)
+}
+
+// storedVersion returns the version of the stored game, or 0 if there is none
+func storedVersion(ctx context.Context, tx *redis.Tx, gameID string) (uint64, error) {
+	gameJSON, err := tx.Get(ctx, gameKey(gameID)).Bytes()
+	if err == redis.Nil {
+		return 0, nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to save game to redis: %w", err)
+		return 0, err
 	}
 
-	return nil
+	var stored struct {
+		Version uint64 `json:"version"`
+	}
+	if err := json.Unmarshal(gameJSON, &stored); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal game: %w", err)
+	}
+
+	return stored.Version, nil
 }
 
 func (r *RedisStorage) GetGame(ctx context.Context, gameID string) (*gamespb.Game, error) {
@@ -64,14 +148,71 @@ func (r *RedisStorage) GetGame(ctx context.Context, gameID string) (*gamespb.Gam
 }
 
 func (r *RedisStorage) DeleteGame(ctx context.Context, gameID string) error {
-	err := r.client.Del(ctx, gameKey(gameID)).Err()
-	if err != nil {
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, gameKey(gameID))
+	pipe.ZRem(ctx, gameDeadlinesKey, gameID)
+	pipe.ZRem(ctx, finishedGamesKey, gameID)
+
+	// Drop the game from its players' indexes as well
+	if game, err := r.GetGame(ctx, gameID); err == nil {
+		pipe.ZRem(ctx, playerGamesKey(game.Player1Id), gameID)
+		pipe.ZRem(ctx, playerGamesKey(game.Player2Id), gameID)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
 		return fmt.Errorf("failed to delete game from redis: %w", err)
 	}
 
 	return nil
 }
 
+func (r *RedisStorage) ListPlayerGames(ctx context.Context, playerID string) ([]*gamespb.Game, error) {
+	gameIDs, err := r.client.ZRange(ctx, playerGamesKey(playerID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list player games from redis: %w", err)
+	}
+
+	games := make([]*gamespb.Game, 0, len(gameIDs))
+	for _, gameID := range gameIDs {
+		game, err := r.GetGame(ctx, gameID)
+		if err != nil {
+			// The game was removed after it was indexed
+			continue
+		}
+		games = append(games, game)
+	}
+
+	return games, nil
+}
+
+func (r *RedisStorage) ListExpiredGames(ctx context.Context, now time.Time) ([]string, error) {
+	gameIDs, err := r.client.ZRangeByScore(ctx, gameDeadlinesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired games from redis: %w", err)
+	}
+
+	return gameIDs, nil
+}
+
+func (r *RedisStorage) ListUnarchivedGames(ctx context.Context, finishedBefore time.Time) ([]string, error) {
+	gameIDs, err := r.client.ZRangeByScore(ctx, finishedGamesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: "(" + strconv.FormatInt(finishedBefore.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unarchived games from redis: %w", err)
+	}
+
+	return gameIDs, nil
+}
+
 func gameKey(gameID string) string {
 	return fmt.Sprintf("game:%s", gameID)
 }
+
+func playerGamesKey(playerID string) string {
+	return fmt.Sprintf("player_games:%s", playerID)
+}
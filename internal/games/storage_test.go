@@ -2,13 +2,18 @@ package games
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	enginepb "github.com/laerson/mancala/proto/engine"
+	gamespb "github.com/laerson/mancala/proto/games"
 	"github.com/testcontainers/testcontainers-go"
 	"github.com/testcontainers/testcontainers-go/modules/redis"
 )
 
 func setupRedisContainer(t *testing.T) (*redis.RedisContainer, *RedisStorage) {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
 	ctx := context.Background()
 
 	redisContainer, err := redis.Run(ctx, "redis:7-alpine")
@@ -42,7 +47,7 @@ func TestRedisStorage_SaveGame(t *testing.T) {
 	_, storage := setupRedisContainer(t)
 	ctx := context.Background()
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 
 	err := storage.SaveGame(ctx, game)
 	if err != nil {
@@ -54,7 +59,7 @@ func TestRedisStorage_GetGame(t *testing.T) {
 	_, storage := setupRedisContainer(t)
 	ctx := context.Background()
 
-	originalGame := NewGame("player1", "player2")
+	originalGame := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	err := storage.SaveGame(ctx, originalGame)
 	if err != nil {
 		t.Fatalf("SaveGame() error = %v", err)
@@ -96,7 +101,7 @@ func TestRedisStorage_DeleteGame(t *testing.T) {
 	_, storage := setupRedisContainer(t)
 	ctx := context.Background()
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	err := storage.SaveGame(ctx, game)
 	if err != nil {
 		t.Fatalf("SaveGame() error = %v", err)
@@ -117,7 +122,7 @@ func TestRedisStorage_SaveAndRetrieveComplexGame(t *testing.T) {
 	_, storage := setupRedisContainer(t)
 	ctx := context.Background()
 
-	game := NewGame("player1", "player2")
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
 	game.State.Board.Pits = []uint32{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14}
 
 	err := storage.SaveGame(ctx, game)
@@ -141,6 +146,87 @@ func TestRedisStorage_SaveAndRetrieveComplexGame(t *testing.T) {
 	}
 }
 
+func TestRedisStorage_ListPlayerGames(t *testing.T) {
+	_, storage := setupRedisContainer(t)
+	ctx := context.Background()
+
+	first := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	second := NewGame("player3", "player1", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	other := NewGame("player2", "player3", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	for _, game := range []*gamespb.Game{first, second, other} {
+		if err := storage.SaveGame(ctx, game); err != nil {
+			t.Fatalf("SaveGame() error = %v", err)
+		}
+	}
+
+	// Saving again after a move must not reorder the games
+	if err := storage.SaveGame(ctx, first); err != nil {
+		t.Fatalf("SaveGame() error = %v", err)
+	}
+
+	games, err := storage.ListPlayerGames(ctx, "player1")
+	if err != nil {
+		t.Fatalf("ListPlayerGames() error = %v", err)
+	}
+	if len(games) != 2 || games[0].Id != first.Id || games[1].Id != second.Id {
+		t.Errorf("ListPlayerGames() = %v, want games %s and %s", games, first.Id, second.Id)
+	}
+
+	if err := storage.DeleteGame(ctx, first.Id); err != nil {
+		t.Fatalf("DeleteGame() error = %v", err)
+	}
+
+	games, err = storage.ListPlayerGames(ctx, "player1")
+	if err != nil {
+		t.Fatalf("ListPlayerGames() error = %v", err)
+	}
+	if len(games) != 1 || games[0].Id != second.Id {
+		t.Errorf("ListPlayerGames() after delete = %v, want game %s", games, second.Id)
+	}
+}
+
+func TestRedisStorage_SaveGame_VersionConflict(t *testing.T) {
+	_, storage := setupRedisContainer(t)
+	ctx := context.Background()
+
+	game := NewGame("player1", "player2", enginepb.GameType_GAME_TYPE_KALAH, nil)
+	if err := storage.SaveGame(ctx, game); err != nil {
+		t.Fatalf("SaveGame() error = %v", err)
+	}
+
+	// Two requests read the same version of the game
+	first, err := storage.GetGame(ctx, game.Id)
+	if err != nil {
+		t.Fatalf("GetGame() error = %v", err)
+	}
+	second, err := storage.GetGame(ctx, game.Id)
+	if err != nil {
+		t.Fatalf("GetGame() error = %v", err)
+	}
+
+	if err := storage.SaveGame(ctx, first); err != nil {
+		t.Fatalf("SaveGame() error = %v, want nil", err)
+	}
+	if first.Version != 2 {
+		t.Errorf("SaveGame() Version = %d, want 2", first.Version)
+	}
+
+	if err := storage.SaveGame(ctx, second); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("SaveGame() with stale game error = %v, want %v", err, ErrVersionConflict)
+	}
+	if second.Version != 1 {
+		t.Errorf("SaveGame() with stale game Version = %d, want 1", second.Version)
+	}
+
+	// A deleted game cannot be brought back by a stale save
+	if err := storage.DeleteGame(ctx, game.Id); err != nil {
+		t.Fatalf("DeleteGame() error = %v", err)
+	}
+	if err := storage.SaveGame(ctx, first); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("SaveGame() after delete error = %v, want %v", err, ErrVersionConflict)
+	}
+}
+
 func TestGameKey(t *testing.T) {
 	gameID := "test-game-id"
 	expected := "game:test-game-id"
@@ -3,10 +3,12 @@ package gateway
 import (
 	"log"
 
+	"github.com/laerson/mancala/internal/auth"
 	authpb "github.com/laerson/mancala/proto/auth"
 	gamespb "github.com/laerson/mancala/proto/games"
 	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
 	notificationspb "github.com/laerson/mancala/proto/notifications"
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
@@ -17,6 +19,10 @@ type ServiceClients struct {
 	Games         gamespb.GamesClient
 	Matchmaking   matchmakingpb.MatchmakingClient
 	Notifications notificationspb.NotificationsClient
+	Tournaments   tournamentspb.TournamentsClient
+
+	// ServiceToken vouches for the client IPs the gateway forwards with logins, nil without a secret
+	ServiceToken *auth.ServiceTokenSource
 }
 
 // NewServiceClients creates and initializes all gRPC service clients
@@ -32,6 +38,13 @@ func NewServiceClients(config ServiceConfig) (*ServiceClients, func(), error) {
 	clients.Auth = authpb.NewAuthClient(authConn)
 	closers = append(closers, func() { authConn.Close() })
 
+	if config.ServiceSecret != "" {
+		clients.ServiceToken, err = auth.NewServiceTokenSource(config.AuthAddr, "gateway", config.ServiceSecret)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	// Connect to Games service
 	gamesConn, err := grpc.NewClient(config.GamesAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -46,6 +59,9 @@ func NewServiceClients(config ServiceConfig) (*ServiceClients, func(), error) {
 		return nil, nil, err
 	}
 	clients.Matchmaking = matchmakingpb.NewMatchmakingClient(matchmakingConn)
+
+	// Tournaments are served by the matchmaking service
+	clients.Tournaments = tournamentspb.NewTournamentsClient(matchmakingConn)
 	closers = append(closers, func() { matchmakingConn.Close() })
 
 	// Connect to Notifications service
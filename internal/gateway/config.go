@@ -9,16 +9,18 @@ type ServiceConfig struct {
 	MatchmakingAddr   string
 	NotificationsAddr string
 	EngineAddr        string
+	ServiceSecret     string // Exchanged with the auth service for the gateway's service token
 }
 
 // GatewayConfig holds configuration for the API gateway
 type GatewayConfig struct {
-	Port         string
-	Services     ServiceConfig
-	JWTSecret    string
-	ReadTimeout  time.Duration
-	WriteTimeout time.Duration
-	IdleTimeout  time.Duration
+	Port           string
+	Services       ServiceConfig
+	RedisAddr      string   // Holds the denylist of revoked tokens
+	TrustedProxies []string // Proxies trusted to set X-Forwarded-For, none by default
+	ReadTimeout    time.Duration
+	WriteTimeout   time.Duration
+	IdleTimeout    time.Duration
 }
 
 // DefaultConfig returns a default gateway configuration
@@ -32,7 +34,7 @@ func DefaultConfig() *GatewayConfig {
 			NotificationsAddr: "notifications:50056",
 			EngineAddr:        "engine:50051",
 		},
-		JWTSecret:    "mancala-jwt-secret-key-change-in-production",
+		RedisAddr:    "redis:6379",
 		ReadTimeout:  15 * time.Second,
 		WriteTimeout: 15 * time.Second,
 		IdleTimeout:  60 * time.Second,
@@ -0,0 +1,199 @@
+package gateway
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	authpb "github.com/laerson/mancala/proto/auth"
+	gamespb "github.com/laerson/mancala/proto/games"
+	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// AdminHandlers handles moderation endpoints. The services check the caller's
+// role, these handlers only translate requests.
+type AdminHandlers struct {
+	clients *ServiceClients
+}
+
+// NewAdminHandlers creates new admin handlers
+func NewAdminHandlers(clients *ServiceClients) *AdminHandlers {
+	return &AdminHandlers{clients: clients}
+}
+
+// BanUserRequest represents a ban
+type BanUserRequest struct {
+	Reason string `json:"reason"`
+}
+
+// SetUserRoleRequest represents a role change
+type SetUserRoleRequest struct {
+	Role string `json:"role" binding:"required"` // "player", "moderator" or "admin"
+}
+
+// ForceEndGameRequest represents a moderator ending a game
+type ForceEndGameRequest struct {
+	WinnerID string `json:"winner_id"` // Unset to end the game without a result
+	Reason   string `json:"reason"`
+}
+
+// ListUsers handles listing users, optionally searching by username
+func (h *AdminHandlers) ListUsers(c *gin.Context) {
+	var limit, offset int
+	for name, value := range map[string]*int{"limit": &limit, "offset": &offset} {
+		if str := c.Query(name); str != "" {
+			var err error
+			*value, err = strconv.Atoi(str)
+			if err != nil || *value < 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid " + name})
+				return
+			}
+		}
+	}
+
+	// Call Auth service
+	resp, err := h.clients.Auth.ListUsers(addGRPCContext(c), &authpb.ListUsersRequest{
+		Query:  c.Query("query"),
+		Limit:  int32(limit),
+		Offset: int32(offset),
+	})
+
+	if err != nil {
+		writeAdminError(c, err, "Failed to list users")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"users": resp.Users,
+		"total": resp.Total,
+	})
+}
+
+// BanUser handles banning a user
+func (h *AdminHandlers) BanUser(c *gin.Context) {
+	var req BanUserRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Auth service
+	resp, err := h.clients.Auth.BanUser(addGRPCContext(c), &authpb.BanUserRequest{
+		UserId: c.Param("user_id"),
+		Reason: req.Reason,
+	})
+
+	writeUserAction(c, resp, err, "Failed to ban user")
+}
+
+// UnbanUser handles lifting a user's ban
+func (h *AdminHandlers) UnbanUser(c *gin.Context) {
+	// Call Auth service
+	resp, err := h.clients.Auth.UnbanUser(addGRPCContext(c), &authpb.UnbanUserRequest{
+		UserId: c.Param("user_id"),
+	})
+
+	writeUserAction(c, resp, err, "Failed to unban user")
+}
+
+// SetUserRole handles changing a user's role
+func (h *AdminHandlers) SetUserRole(c *gin.Context) {
+	var req SetUserRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Auth service
+	resp, err := h.clients.Auth.SetUserRole(addGRPCContext(c), &authpb.SetUserRoleRequest{
+		UserId: c.Param("user_id"),
+		Role:   req.Role,
+	})
+
+	writeUserAction(c, resp, err, "Failed to set role")
+}
+
+// ForceEndGame handles a moderator ending a game in progress
+func (h *AdminHandlers) ForceEndGame(c *gin.Context) {
+	var req ForceEndGameRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.ForceEnd(addGRPCContext(c), &gamespb.ForceEndRequest{
+		GameId:   c.Param("game_id"),
+		WinnerId: req.WinnerID,
+		Reason:   req.Reason,
+	})
+
+	if err != nil {
+		writeAdminError(c, err, "Failed to end game")
+		return
+	}
+
+	writeGameAction(c, resp, nil, "Failed to end game")
+}
+
+// GetQueueLength handles reading the number of players waiting for a match
+func (h *AdminHandlers) GetQueueLength(c *gin.Context) {
+	// Call Matchmaking service
+	resp, err := h.clients.Matchmaking.GetQueueLength(addGRPCContext(c), &matchmakingpb.GetQueueLengthRequest{})
+	if err != nil {
+		writeAdminError(c, err, "Failed to get queue length")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"length": resp.Length})
+}
+
+// RemoveFromQueue handles taking any player out of the matchmaking queue
+func (h *AdminHandlers) RemoveFromQueue(c *gin.Context) {
+	// Call Matchmaking service
+	resp, err := h.clients.Matchmaking.RemoveFromQueue(addGRPCContext(c), &matchmakingpb.RemoveFromQueueRequest{
+		PlayerId: c.Param("player_id"),
+	})
+
+	if err != nil {
+		writeAdminError(c, err, "Failed to remove player from queue")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+	})
+}
+
+// writeUserAction writes the user returned by a ban, unban or role change
+func writeUserAction(c *gin.Context, resp *authpb.UserActionResponse, err error, failure string) {
+	if err != nil {
+		writeAdminError(c, err, failure)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+		"user":    resp.User,
+	})
+}
+
+// writeAdminError maps a service error on a moderation endpoint to an HTTP response
+func writeAdminError(c *gin.Context, err error, failure string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+	case codes.Unauthenticated:
+		c.JSON(http.StatusUnauthorized, gin.H{"error": status.Convert(err).Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": status.Convert(err).Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+	}
+}
@@ -1,10 +1,13 @@
 package gateway
 
 import (
+	"log"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	authpb "github.com/laerson/mancala/proto/auth"
+	"google.golang.org/grpc/metadata"
 )
 
 // AuthHandlers handles authentication related endpoints
@@ -29,6 +32,11 @@ type RegisterRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// LogoutRequest represents a logout request
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // Optional, deleted along with the access token
+}
+
 // Login handles user login
 func (h *AuthHandlers) Login(c *gin.Context) {
 	var req LoginRequest
@@ -37,8 +45,20 @@ func (h *AuthHandlers) Login(c *gin.Context) {
 		return
 	}
 
+	// The auth service limits failed logins per client IP, and only trusts the
+	// IP forwarded with the gateway's service token
+	ctx := addGRPCContext(c)
+	if h.clients.ServiceToken != nil {
+		token, err := h.clients.ServiceToken.Token(ctx)
+		if err != nil {
+			log.Printf("Failed to get service token: %v", err)
+		} else {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+		}
+	}
+
 	// Call Auth service
-	resp, err := h.clients.Auth.Login(addGRPCContext(c), &authpb.LoginRequest{
+	resp, err := h.clients.Auth.Login(ctx, &authpb.LoginRequest{
 		Username: req.Username,
 		Password: req.Password,
 	})
@@ -48,7 +68,14 @@ func (h *AuthHandlers) Login(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{
+	// Failed logins slow further attempts down
+	statusCode := http.StatusOK
+	if resp.RetryAfterSeconds > 0 {
+		c.Header("Retry-After", strconv.FormatInt(resp.RetryAfterSeconds, 10))
+		statusCode = http.StatusTooManyRequests
+	}
+
+	c.JSON(statusCode, gin.H{
 		"success":       resp.Success,
 		"message":       resp.Message,
 		"access_token":  resp.AccessToken,
@@ -110,3 +137,74 @@ func (h *AuthHandlers) ValidateToken(c *gin.Context) {
 		"expires_at": resp.ExpiresAt,
 	})
 }
+
+// Logout revokes the caller's access token and refresh token
+func (h *AuthHandlers) Logout(c *gin.Context) {
+	var req LogoutRequest
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	resp, err := h.clients.Auth.Logout(addGRPCContext(c), &authpb.LogoutRequest{
+		AccessToken:  c.GetString("jwt_token"),
+		RefreshToken: req.RefreshToken,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Logout failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+	})
+}
+
+// LogoutAll revokes every access and refresh token of the caller
+func (h *AuthHandlers) LogoutAll(c *gin.Context) {
+	resp, err := h.clients.Auth.LogoutAll(addGRPCContext(c), &authpb.LogoutAllRequest{
+		AccessToken: c.GetString("jwt_token"),
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Logout failed"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":                resp.Success,
+		"message":                resp.Message,
+		"revoked_access_tokens":  resp.RevokedAccessTokens,
+		"revoked_refresh_tokens": resp.RevokedRefreshTokens,
+	})
+}
+
+// JWKS serves the public keys tokens are verified with as a JSON Web Key Set
+func (h *AuthHandlers) JWKS(c *gin.Context) {
+	resp, err := h.clients.Auth.GetJWKS(c.Request.Context(), &authpb.GetJWKSRequest{})
+	if err != nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Signing keys unavailable"})
+		return
+	}
+
+	keys := make([]gin.H, 0, len(resp.Keys))
+	for _, key := range resp.Keys {
+		jwk := gin.H{"kty": key.Kty, "kid": key.Kid, "alg": key.Alg, "use": key.Use}
+		if key.Kty == "OKP" {
+			jwk["crv"] = key.Crv
+			jwk["x"] = key.X
+		} else {
+			jwk["n"] = key.N
+			jwk["e"] = key.E
+		}
+		keys = append(keys, jwk)
+	}
+
+	// Keys are published ahead of use, so caching them briefly is safe
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, gin.H{"keys": keys})
+}
@@ -2,9 +2,14 @@ package gateway
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	enginepb "github.com/laerson/mancala/proto/engine"
 	gamespb "github.com/laerson/mancala/proto/games"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // GamesHandlers handles game related endpoints
@@ -19,8 +24,39 @@ func NewGamesHandlers(clients *ServiceClients) *GamesHandlers {
 
 // CreateGameRequest represents a game creation request
 type CreateGameRequest struct {
-	Player1ID string `json:"player1_id" binding:"required"`
-	Player2ID string `json:"player2_id" binding:"required"`
+	Player1ID   string              `json:"player1_id" binding:"required"`
+	Player2ID   string              `json:"player2_id" binding:"required"`
+	Rules       *RuleSetRequest     `json:"rules"`
+	GameType    string              `json:"game_type"`    // "kalah" (default) or "oware"
+	TimeControl *TimeControlRequest `json:"time_control"` // Unset for a game without a clock
+}
+
+// TimeControlRequest represents the clock of a new game: either a base time
+// with an increment per move, or a fixed time per move
+type TimeControlRequest struct {
+	BaseMs      int64 `json:"base_ms"`
+	IncrementMs int64 `json:"increment_ms"`
+	PerMoveMs   int64 `json:"per_move_ms"`
+}
+
+// RuleSetRequest represents optional house rules for a new game
+type RuleSetRequest struct {
+	PitsPerSide    uint32 `json:"pits_per_side"`
+	InitialSeeds   uint32 `json:"initial_seeds"`
+	DisableCapture bool   `json:"disable_capture"`
+	EmptyCapture   bool   `json:"empty_capture"`
+	SweepToMover   bool   `json:"sweep_to_mover"`
+}
+
+// GameActionRequest represents a resign, draw offer or abort request
+type GameActionRequest struct {
+	PlayerID string `json:"player_id" binding:"required"`
+}
+
+// RespondDrawRequest represents an answer to the opponent's draw offer
+type RespondDrawRequest struct {
+	PlayerID string `json:"player_id" binding:"required"`
+	Accept   bool   `json:"accept"`
 }
 
 // MakeMoveRequest represents a move request
@@ -37,27 +73,83 @@ func (h *GamesHandlers) CreateGame(c *gin.Context) {
 		return
 	}
 
+	gameType, ok := parseGameType(req.GameType)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "game_type must be kalah or oware"})
+		return
+	}
+
 	// Call Games service
 	resp, err := h.clients.Games.Create(addGRPCContext(c), &gamespb.CreateGameRequest{
-		Player1Id: req.Player1ID,
-		Player2Id: req.Player2ID,
+		Player1Id:   req.Player1ID,
+		Player2Id:   req.Player2ID,
+		Rules:       req.Rules.toProto(),
+		GameType:    gameType,
+		TimeControl: req.TimeControl.toProto(),
 	})
 
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create game"})
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": status.Convert(err).Message()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create game"})
+		}
 		return
 	}
 
 	c.JSON(http.StatusCreated, gin.H{
-		"game": gin.H{
-			"id":         resp.Game.Id,
-			"player1_id": resp.Game.Player1Id,
-			"player2_id": resp.Game.Player2Id,
-			"state":      resp.Game.State,
-		},
+		"game": gameToJSON(resp.Game),
 	})
 }
 
+// parseGameType converts a game type name, defaulting to Kalah
+func parseGameType(name string) (enginepb.GameType, bool) {
+	switch name {
+	case "", "kalah":
+		return enginepb.GameType_GAME_TYPE_KALAH, true
+	case "oware":
+		return enginepb.GameType_GAME_TYPE_OWARE, true
+	default:
+		return enginepb.GameType_GAME_TYPE_KALAH, false
+	}
+}
+
+// toProto converts the requested house rules, keeping nil for standard Kalah
+func (r *RuleSetRequest) toProto() *enginepb.RuleSet {
+	if r == nil {
+		return nil
+	}
+
+	sweepPolicy := enginepb.SweepPolicy_SWEEP_TO_OWNER
+	if r.SweepToMover {
+		sweepPolicy = enginepb.SweepPolicy_SWEEP_TO_MOVER
+	}
+
+	return &enginepb.RuleSet{
+		PitsPerSide:    r.PitsPerSide,
+		InitialSeeds:   r.InitialSeeds,
+		DisableCapture: r.DisableCapture,
+		EmptyCapture:   r.EmptyCapture,
+		SweepPolicy:    sweepPolicy,
+	}
+}
+
+// toProto converts the requested time control, keeping nil for an untimed game
+func (r *TimeControlRequest) toProto() *gamespb.TimeControl {
+	if r == nil {
+		return nil
+	}
+
+	return &gamespb.TimeControl{
+		BaseMs:      r.BaseMs,
+		IncrementMs: r.IncrementMs,
+		PerMoveMs:   r.PerMoveMs,
+	}
+}
+
 // MakeMove handles game moves
 func (h *GamesHandlers) MakeMove(c *gin.Context) {
 	gameID := c.Param("game_id")
@@ -92,7 +184,7 @@ func (h *GamesHandlers) MakeMove(c *gin.Context) {
 			"result":  result.MoveResult,
 		})
 	case *gamespb.MakeGameMoveResponse_Error:
-		c.JSON(http.StatusBadRequest, gin.H{
+		c.JSON(gameErrorStatus(result.Error), gin.H{
 			"success": false,
 			"error":   result.Error.Message,
 		})
@@ -103,3 +195,286 @@ func (h *GamesHandlers) MakeMove(c *gin.Context) {
 		})
 	}
 }
+
+// Resign handles resigning a game, which the opponent wins
+func (h *GamesHandlers) Resign(c *gin.Context) {
+	var req GameActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.Resign(addGRPCContext(c), &gamespb.ResignRequest{
+		GameId:   c.Param("game_id"),
+		PlayerId: req.PlayerID,
+	})
+
+	writeGameAction(c, resp, err, "Failed to resign game")
+}
+
+// OfferDraw handles offering a draw to the opponent
+func (h *GamesHandlers) OfferDraw(c *gin.Context) {
+	var req GameActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.OfferDraw(addGRPCContext(c), &gamespb.OfferDrawRequest{
+		GameId:   c.Param("game_id"),
+		PlayerId: req.PlayerID,
+	})
+
+	writeGameAction(c, resp, err, "Failed to offer draw")
+}
+
+// RespondDraw handles accepting or declining the opponent's draw offer
+func (h *GamesHandlers) RespondDraw(c *gin.Context) {
+	var req RespondDrawRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.RespondDraw(addGRPCContext(c), &gamespb.RespondDrawRequest{
+		GameId:   c.Param("game_id"),
+		PlayerId: req.PlayerID,
+		Accept:   req.Accept,
+	})
+
+	writeGameAction(c, resp, err, "Failed to respond to draw offer")
+}
+
+// Abort handles calling off a game before the second move
+func (h *GamesHandlers) Abort(c *gin.Context) {
+	var req GameActionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.Abort(addGRPCContext(c), &gamespb.AbortRequest{
+		GameId:   c.Param("game_id"),
+		PlayerId: req.PlayerID,
+	})
+
+	writeGameAction(c, resp, err, "Failed to abort game")
+}
+
+// writeGameAction writes the game returned by a resign, draw or abort action
+func writeGameAction(c *gin.Context, resp *gamespb.GameActionResponse, err error, failure string) {
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+		return
+	}
+
+	switch result := resp.Result.(type) {
+	case *gamespb.GameActionResponse_Game:
+		c.JSON(http.StatusOK, gin.H{
+			"game": gameToJSON(result.Game),
+		})
+	case *gamespb.GameActionResponse_Error:
+		c.JSON(gameErrorStatus(result.Error), gin.H{"error": result.Error.Message})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unexpected response format"})
+	}
+}
+
+// GetGame handles fetching a single game, e.g. to rejoin it after a reconnect
+func (h *GamesHandlers) GetGame(c *gin.Context) {
+	gameID := c.Param("game_id")
+	if gameID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Game ID required"})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.Get(addGRPCContext(c), &gamespb.GetGameRequest{
+		GameId: gameID,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get game"})
+		return
+	}
+
+	switch result := resp.Result.(type) {
+	case *gamespb.GetGameResponse_Game:
+		c.JSON(http.StatusOK, gin.H{
+			"game": gameToJSON(result.Game),
+		})
+	case *gamespb.GetGameResponse_Error:
+		c.JSON(gameErrorStatus(result.Error), gin.H{"error": result.Error.Message})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unexpected response format"})
+	}
+}
+
+// SpectateGame handles fetching a game in progress for a spectator
+func (h *GamesHandlers) SpectateGame(c *gin.Context) {
+	gameID := c.Param("game_id")
+	if gameID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Game ID required"})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.Spectate(addGRPCContext(c), &gamespb.SpectateRequest{
+		GameId: gameID,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get game"})
+		return
+	}
+
+	switch result := resp.Result.(type) {
+	case *gamespb.GetGameResponse_Game:
+		c.JSON(http.StatusOK, gin.H{
+			"game": gameToJSON(result.Game),
+		})
+	case *gamespb.GetGameResponse_Error:
+		c.JSON(gameErrorStatus(result.Error), gin.H{"error": result.Error.Message})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unexpected response format"})
+	}
+}
+
+// GetReplay handles fetching every position of a game, move by move
+func (h *GamesHandlers) GetReplay(c *gin.Context) {
+	gameID := c.Param("game_id")
+	if gameID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Game ID required"})
+		return
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.GetReplay(addGRPCContext(c), &gamespb.GetReplayRequest{
+		GameId: gameID,
+	})
+
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get replay"})
+		return
+	}
+
+	switch result := resp.Result.(type) {
+	case *gamespb.GetReplayResponse_Replay:
+		c.JSON(http.StatusOK, gin.H{
+			"replay": result.Replay,
+		})
+	case *gamespb.GetReplayResponse_Error:
+		c.JSON(gameErrorStatus(result.Error), gin.H{"error": result.Error.Message})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Unexpected response format"})
+	}
+}
+
+// ListGames handles listing the authenticated player's games.
+// Query parameters: player_id (defaults to the caller), status (in_progress or finished), page_size, page_token.
+func (h *GamesHandlers) ListGames(c *gin.Context) {
+	playerID := c.Query("player_id")
+	if playerID == "" {
+		playerID = c.GetString("user_id")
+	}
+
+	var gameStatus gamespb.GameStatus
+	switch c.Query("status") {
+	case "":
+		gameStatus = gamespb.GameStatus_GAME_STATUS_UNSPECIFIED
+	case "in_progress":
+		gameStatus = gamespb.GameStatus_GAME_STATUS_IN_PROGRESS
+	case "finished":
+		gameStatus = gamespb.GameStatus_GAME_STATUS_FINISHED
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid status. Use 'in_progress' or 'finished'"})
+		return
+	}
+
+	var pageSize int
+	if sizeStr := c.Query("page_size"); sizeStr != "" {
+		var err error
+		pageSize, err = strconv.Atoi(sizeStr)
+		if err != nil || pageSize < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid page_size"})
+			return
+		}
+	}
+
+	// Call Games service
+	resp, err := h.clients.Games.ListGames(addGRPCContext(c), &gamespb.ListGamesRequest{
+		PlayerId:  playerID,
+		Status:    gameStatus,
+		PageSize:  int32(pageSize),
+		PageToken: c.Query("page_token"),
+	})
+
+	if err != nil {
+		switch status.Code(err) {
+		case codes.InvalidArgument:
+			c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+		case codes.PermissionDenied:
+			c.JSON(http.StatusForbidden, gin.H{"error": status.Convert(err).Message()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to list games"})
+		}
+		return
+	}
+
+	games := make([]gin.H, 0, len(resp.Games))
+	for _, game := range resp.Games {
+		games = append(games, gameToJSON(game))
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"games":           games,
+		"next_page_token": resp.NextPageToken,
+	})
+}
+
+// gameErrorStatus maps an in-message games service error to an HTTP status by its code
+func gameErrorStatus(gameErr *gamespb.Error) int {
+	switch gameErr.GetCode() {
+	case gamespb.ErrorCode_ERROR_CODE_NOT_FOUND:
+		return http.StatusNotFound
+	case gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED:
+		return http.StatusForbidden
+	case gamespb.ErrorCode_ERROR_CODE_GAME_FINISHED, gamespb.ErrorCode_ERROR_CODE_VERSION_CONFLICT, gamespb.ErrorCode_ERROR_CODE_NOT_YOUR_TURN:
+		return http.StatusConflict
+	case gamespb.ErrorCode_ERROR_CODE_INTERNAL:
+		return http.StatusInternalServerError
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// gameToJSON converts a game into its JSON representation
+func gameToJSON(game *gamespb.Game) gin.H {
+	return gin.H{
+		"id":              game.Id,
+		"player1_id":      game.Player1Id,
+		"player2_id":      game.Player2Id,
+		"state":           game.State,
+		"status":          game.Status.String(),
+		"moves":           game.Moves,
+		"created_at":      game.CreatedAt,
+		"finished_at":     game.FinishedAt,
+		"winner_id":       game.WinnerId,
+		"end_reason":      endReasonName(game.EndReason),
+		"draw_offered_by": game.DrawOfferedBy,
+		"time_control":    game.TimeControl,
+		"clock":           game.Clock,
+	}
+}
+
+// endReasonName returns how a game ended, e.g. "resignation", or "" while it is in progress
+func endReasonName(reason gamespb.GameEndReason) string {
+	if reason == gamespb.GameEndReason_GAME_END_REASON_UNSPECIFIED {
+		return ""
+	}
+	return strings.ToLower(strings.TrimPrefix(reason.String(), "GAME_END_REASON_"))
+}
@@ -4,7 +4,10 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	authpb "github.com/laerson/mancala/proto/auth"
 	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // MatchmakingHandlers handles matchmaking related endpoints
@@ -142,3 +145,205 @@ func (h *MatchmakingHandlers) BotMatch(c *gin.Context) {
 		"bot_name": resp.BotName,
 	})
 }
+
+// ChallengeRequest represents a challenge to a specific player
+type ChallengeRequest struct {
+	PlayerID       string `json:"player_id" binding:"required"`
+	PlayerName     string `json:"player_name" binding:"required"`
+	TargetUsername string `json:"target_username" binding:"required"`
+}
+
+// Challenge handles challenging a player by username
+func (h *MatchmakingHandlers) Challenge(c *gin.Context) {
+	var req ChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx := addGRPCContext(c)
+
+	// Look up the challenged player
+	profile, err := h.clients.Auth.GetProfile(ctx, &authpb.GetProfileRequest{Username: req.TargetUsername})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up player"})
+		return
+	}
+	if !profile.Success {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Player " + req.TargetUsername + " not found"})
+		return
+	}
+
+	// Call Matchmaking service
+	resp, err := h.clients.Matchmaking.Challenge(ctx, &matchmakingpb.ChallengeRequest{
+		Challenger: &matchmakingpb.Player{
+			Id:   req.PlayerID,
+			Name: req.PlayerName,
+		},
+		TargetUserId: profile.User.UserId,
+	})
+
+	if err != nil {
+		writeMatchmakingError(c, err, "Failed to send challenge")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":   resp.Success,
+		"message":   resp.Message,
+		"challenge": challengeToJSON(resp.Challenge),
+	})
+}
+
+// ListChallenges handles listing the pending challenges sent to a player
+func (h *MatchmakingHandlers) ListChallenges(c *gin.Context) {
+	playerID := c.Param("player_id")
+	if playerID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Player ID required"})
+		return
+	}
+
+	// Call Matchmaking service
+	resp, err := h.clients.Matchmaking.ListChallenges(addGRPCContext(c), &matchmakingpb.ListChallengesRequest{
+		PlayerId: playerID,
+	})
+
+	if err != nil {
+		writeMatchmakingError(c, err, "Failed to list challenges")
+		return
+	}
+
+	challenges := make([]gin.H, 0, len(resp.Challenges))
+	for _, challenge := range resp.Challenges {
+		challenges = append(challenges, challengeToJSON(challenge))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"challenges": challenges})
+}
+
+// RespondChallengeRequest represents an answer to a challenge
+type RespondChallengeRequest struct {
+	PlayerID   string `json:"player_id" binding:"required"`
+	PlayerName string `json:"player_name" binding:"required"`
+	Accept     bool   `json:"accept"`
+}
+
+// RespondChallenge handles accepting, declining or withdrawing a challenge
+func (h *MatchmakingHandlers) RespondChallenge(c *gin.Context) {
+	var req RespondChallengeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Matchmaking service
+	resp, err := h.clients.Matchmaking.RespondChallenge(addGRPCContext(c), &matchmakingpb.RespondChallengeRequest{
+		Player: &matchmakingpb.Player{
+			Id:   req.PlayerID,
+			Name: req.PlayerName,
+		},
+		ChallengeId: c.Param("challenge_id"),
+		Accept:      req.Accept,
+	})
+
+	if err != nil {
+		writeMatchmakingError(c, err, "Failed to respond to challenge")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+		"game_id": resp.GameId,
+	})
+}
+
+// InviteRequest represents a request to create or join an invite
+type InviteRequest struct {
+	PlayerID   string `json:"player_id" binding:"required"`
+	PlayerName string `json:"player_name" binding:"required"`
+}
+
+// CreateInvite handles creating a private invite code
+func (h *MatchmakingHandlers) CreateInvite(c *gin.Context) {
+	var req InviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Matchmaking service
+	resp, err := h.clients.Matchmaking.CreateInvite(addGRPCContext(c), &matchmakingpb.CreateInviteRequest{
+		Player: &matchmakingpb.Player{
+			Id:   req.PlayerID,
+			Name: req.PlayerName,
+		},
+	})
+
+	if err != nil {
+		writeMatchmakingError(c, err, "Failed to create invite")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+		"invite":  challengeToJSON(resp.Invite),
+	})
+}
+
+// JoinInvite handles redeeming an invite code
+func (h *MatchmakingHandlers) JoinInvite(c *gin.Context) {
+	var req InviteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Matchmaking service
+	resp, err := h.clients.Matchmaking.JoinInvite(addGRPCContext(c), &matchmakingpb.JoinInviteRequest{
+		Player: &matchmakingpb.Player{
+			Id:   req.PlayerID,
+			Name: req.PlayerName,
+		},
+		InviteCode: c.Param("code"),
+	})
+
+	if err != nil {
+		writeMatchmakingError(c, err, "Failed to join invite")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success": resp.Success,
+		"message": resp.Message,
+		"game_id": resp.GameId,
+	})
+}
+
+// writeMatchmakingError maps a Matchmaking service error to an HTTP response
+func writeMatchmakingError(c *gin.Context, err error, failure string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": status.Convert(err).Message()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+	}
+}
+
+func challengeToJSON(challenge *matchmakingpb.Challenge) gin.H {
+	if challenge == nil {
+		return nil
+	}
+
+	return gin.H{
+		"id":              challenge.Id,
+		"challenger_id":   challenge.Challenger.GetId(),
+		"challenger_name": challenge.Challenger.GetName(),
+		"target_user_id":  challenge.TargetUserId,
+		"invite_code":     challenge.InviteCode,
+		"expires_at":      challenge.ExpiresAt,
+	}
+}
@@ -7,8 +7,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/gin-contrib/sse"
 	"github.com/gin-gonic/gin"
 	notificationspb "github.com/laerson/mancala/proto/notifications"
+	"google.golang.org/grpc"
 )
 
 // NotificationsHandlers handles notification related endpoints
@@ -36,8 +38,11 @@ func (h *NotificationsHandlers) SubscribeToNotifications(c *gin.Context) {
 	c.Header("Access-Control-Allow-Origin", "*")
 
 	// Call Notifications service
+	// A reconnecting client sends the ID of the last notification it received
+	// and gets the ones it missed first
 	stream, err := h.clients.Notifications.Subscribe(addGRPCContext(c), &notificationspb.SubscribeRequest{
-		PlayerId: playerID,
+		PlayerId:    playerID,
+		LastEventId: c.GetHeader("Last-Event-ID"),
 	})
 
 	if err != nil {
@@ -46,6 +51,50 @@ func (h *NotificationsHandlers) SubscribeToNotifications(c *gin.Context) {
 		return
 	}
 
+	// Send initial connection confirmation
+	c.SSEvent("connected", gin.H{"player_id": playerID, "timestamp": time.Now().Unix()})
+	c.Writer.Flush()
+
+	relayNotifications(c, stream, "player "+playerID)
+	log.Printf("Notification subscription ended for player %s", playerID)
+}
+
+// WatchGame handles SSE connections of spectators following a live game
+func (h *NotificationsHandlers) WatchGame(c *gin.Context) {
+	gameID := c.Param("game_id")
+	if gameID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Game ID required"})
+		return
+	}
+
+	// Set headers for Server-Sent Events
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("Access-Control-Allow-Origin", "*")
+
+	// Call Notifications service
+	stream, err := h.clients.Notifications.Watch(addGRPCContext(c), &notificationspb.WatchRequest{
+		GameId: gameID,
+	})
+
+	if err != nil {
+		log.Printf("Failed to watch game: %v", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to watch game"})
+		return
+	}
+
+	// Send initial connection confirmation
+	c.SSEvent("connected", gin.H{"game_id": gameID, "timestamp": time.Now().Unix()})
+	c.Writer.Flush()
+
+	relayNotifications(c, stream, "spectators of game "+gameID)
+	log.Printf("Spectator stream ended for game %s", gameID)
+}
+
+// relayNotifications writes notifications from a gRPC stream as SSE events
+// until the stream ends or the client disconnects
+func relayNotifications(c *gin.Context, stream grpc.ServerStreamingClient[notificationspb.Notification], recipient string) {
 	// Create a context with cancellation for cleanup
 	ctx, cancel := context.WithCancel(c.Request.Context())
 	defer cancel()
@@ -56,11 +105,11 @@ func (h *NotificationsHandlers) SubscribeToNotifications(c *gin.Context) {
 		for {
 			notification, err := stream.Recv()
 			if err == io.EOF {
-				log.Printf("Notification stream ended for player %s", playerID)
+				log.Printf("Notification stream ended for %s", recipient)
 				return
 			}
 			if err != nil {
-				log.Printf("Error receiving notification for player %s: %v", playerID, err)
+				log.Printf("Error receiving notification for %s: %v", recipient, err)
 				return
 			}
 
@@ -69,21 +118,19 @@ func (h *NotificationsHandlers) SubscribeToNotifications(c *gin.Context) {
 			case <-ctx.Done():
 				return
 			default:
-				// Send notification as SSE event
-				data := formatNotificationForSSE(notification)
-				c.SSEvent("notification", data)
+				// Send notification as SSE event, its stream ID lets the client resume after it
+				c.Render(-1, sse.Event{
+					Id:    notification.StreamId,
+					Event: "notification",
+					Data:  formatNotificationForSSE(notification),
+				})
 				c.Writer.Flush()
 			}
 		}
 	}()
 
-	// Send initial connection confirmation
-	c.SSEvent("connected", gin.H{"player_id": playerID, "timestamp": time.Now().Unix()})
-	c.Writer.Flush()
-
 	// Keep connection alive until client disconnects
 	<-ctx.Done()
-	log.Printf("Notification subscription ended for player %s", playerID)
 }
 
 // formatNotificationForSSE formats a notification for Server-Sent Events
@@ -107,6 +154,25 @@ func formatNotificationForSSE(notification *notificationspb.Notification) gin.H
 				"player2_name": matchFound.Player2Name,
 			}
 		}
+	case notificationspb.NotificationType_NOTIFICATION_TYPE_MATCH_FAILED:
+		if matchFailed := notification.GetMatchFailed(); matchFailed != nil {
+			data["data"] = gin.H{
+				"player1_id": matchFailed.Player1Id,
+				"player2_id": matchFailed.Player2Id,
+				"reason":     matchFailed.Reason,
+			}
+		}
+	case notificationspb.NotificationType_NOTIFICATION_TYPE_CHALLENGE:
+		if challenge := notification.GetChallenge(); challenge != nil {
+			data["data"] = gin.H{
+				"challenge_id":    challenge.ChallengeId,
+				"challenger_id":   challenge.ChallengerId,
+				"challenger_name": challenge.ChallengerName,
+				"target_id":       challenge.TargetId,
+				"status":          challenge.Status,
+				"expires_at":      challenge.ExpiresAt,
+			}
+		}
 	case notificationspb.NotificationType_NOTIFICATION_TYPE_MOVE_MADE:
 		if moveMade := notification.GetMoveMade(); moveMade != nil {
 			data["data"] = gin.H{
@@ -114,6 +180,7 @@ func formatNotificationForSSE(notification *notificationspb.Notification) gin.H
 				"pit_index":   moveMade.PitIndex,
 				"game_state":  moveMade.GameState,
 				"move_result": moveMade.MoveResult,
+				"clock":       moveMade.Clock,
 			}
 		}
 	case notificationspb.NotificationType_NOTIFICATION_TYPE_GAME_OVER:
@@ -122,6 +189,7 @@ func formatNotificationForSSE(notification *notificationspb.Notification) gin.H
 				"final_state": gameOver.FinalState,
 				"winner_id":   gameOver.WinnerId,
 				"is_draw":     gameOver.IsDraw,
+				"reason":      gameOver.Reason,
 			}
 		}
 	}
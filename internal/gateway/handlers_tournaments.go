@@ -0,0 +1,262 @@
+package gateway
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// TournamentsHandlers handles tournament related endpoints
+type TournamentsHandlers struct {
+	clients *ServiceClients
+}
+
+// NewTournamentsHandlers creates new tournament handlers
+func NewTournamentsHandlers(clients *ServiceClients) *TournamentsHandlers {
+	return &TournamentsHandlers{clients: clients}
+}
+
+// CreateTournamentRequest represents a tournament creation request
+type CreateTournamentRequest struct {
+	PlayerID    string              `json:"player_id" binding:"required"`
+	Name        string              `json:"name" binding:"required"`
+	Format      string              `json:"format" binding:"required"` // "swiss" or "round_robin"
+	Rounds      int32               `json:"rounds"`                    // Swiss only, 0 picks the number of rounds
+	TimeControl *TimeControlRequest `json:"time_control"`              // Unset for games without a clock
+}
+
+// JoinTournamentRequest represents a tournament registration
+type JoinTournamentRequest struct {
+	PlayerID   string `json:"player_id" binding:"required"`
+	PlayerName string `json:"player_name" binding:"required"`
+}
+
+// TournamentPlayerRequest represents leaving or starting a tournament
+type TournamentPlayerRequest struct {
+	PlayerID string `json:"player_id" binding:"required"`
+}
+
+// CreateTournament handles tournament creation
+func (h *TournamentsHandlers) CreateTournament(c *gin.Context) {
+	var req CreateTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	format, ok := parseTournamentFormat(req.Format)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown tournament format " + req.Format + ", use swiss or round_robin"})
+		return
+	}
+
+	// Call Tournaments service
+	resp, err := h.clients.Tournaments.CreateTournament(addGRPCContext(c), &tournamentspb.CreateTournamentRequest{
+		OrganizerId: req.PlayerID,
+		Name:        req.Name,
+		Format:      format,
+		Rounds:      req.Rounds,
+		TimeControl: req.TimeControl.toProto(),
+	})
+
+	if err != nil {
+		writeTournamentError(c, err, "Failed to create tournament")
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"tournament": tournamentToJSON(resp.Tournament)})
+}
+
+// ListTournaments handles listing tournaments, optionally by status
+func (h *TournamentsHandlers) ListTournaments(c *gin.Context) {
+	req := &tournamentspb.ListTournamentsRequest{}
+	if statusName := c.Query("status"); statusName != "" {
+		value, ok := tournamentspb.TournamentStatus_value["TOURNAMENT_STATUS_"+strings.ToUpper(statusName)]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Unknown tournament status " + statusName})
+			return
+		}
+		req.Status = tournamentspb.TournamentStatus(value)
+	}
+
+	// Call Tournaments service
+	resp, err := h.clients.Tournaments.ListTournaments(addGRPCContext(c), req)
+	if err != nil {
+		writeTournamentError(c, err, "Failed to list tournaments")
+		return
+	}
+
+	tournaments := make([]gin.H, 0, len(resp.Tournaments))
+	for _, tournament := range resp.Tournaments {
+		tournaments = append(tournaments, tournamentToJSON(tournament))
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tournaments": tournaments})
+}
+
+// GetTournament handles retrieving a tournament with its standings
+func (h *TournamentsHandlers) GetTournament(c *gin.Context) {
+	// Call Tournaments service
+	resp, err := h.clients.Tournaments.GetTournament(addGRPCContext(c), &tournamentspb.GetTournamentRequest{
+		TournamentId: c.Param("tournament_id"),
+	})
+
+	if err != nil {
+		writeTournamentError(c, err, "Failed to get tournament")
+		return
+	}
+
+	standings := make([]gin.H, 0, len(resp.Standings))
+	for _, standing := range resp.Standings {
+		standings = append(standings, gin.H{
+			"rank":             standing.Rank,
+			"player_id":        standing.PlayerId,
+			"player_name":      standing.PlayerName,
+			"score":            standing.Score,
+			"buchholz":         standing.Buchholz,
+			"sonneborn_berger": standing.SonnebornBerger,
+			"wins":             standing.Wins,
+			"draws":            standing.Draws,
+			"losses":           standing.Losses,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"tournament": tournamentToJSON(resp.Tournament),
+		"standings":  standings,
+	})
+}
+
+// JoinTournament handles registering for a tournament
+func (h *TournamentsHandlers) JoinTournament(c *gin.Context) {
+	var req JoinTournamentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Tournaments service
+	resp, err := h.clients.Tournaments.JoinTournament(addGRPCContext(c), &tournamentspb.JoinTournamentRequest{
+		TournamentId: c.Param("tournament_id"),
+		Player: &tournamentspb.TournamentPlayer{
+			Id:   req.PlayerID,
+			Name: req.PlayerName,
+		},
+	})
+
+	if err != nil {
+		writeTournamentError(c, err, "Failed to join tournament")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tournament": tournamentToJSON(resp.Tournament)})
+}
+
+// LeaveTournament handles withdrawing a registration before the tournament starts
+func (h *TournamentsHandlers) LeaveTournament(c *gin.Context) {
+	var req TournamentPlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Tournaments service
+	resp, err := h.clients.Tournaments.LeaveTournament(addGRPCContext(c), &tournamentspb.LeaveTournamentRequest{
+		TournamentId: c.Param("tournament_id"),
+		PlayerId:     req.PlayerID,
+	})
+
+	if err != nil {
+		writeTournamentError(c, err, "Failed to leave tournament")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tournament": tournamentToJSON(resp.Tournament)})
+}
+
+// StartTournament handles the organizer closing registration and pairing the first round
+func (h *TournamentsHandlers) StartTournament(c *gin.Context) {
+	var req TournamentPlayerRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Call Tournaments service
+	resp, err := h.clients.Tournaments.StartTournament(addGRPCContext(c), &tournamentspb.StartTournamentRequest{
+		TournamentId: c.Param("tournament_id"),
+		PlayerId:     req.PlayerID,
+	})
+
+	if err != nil {
+		writeTournamentError(c, err, "Failed to start tournament")
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tournament": tournamentToJSON(resp.Tournament)})
+}
+
+// writeTournamentError maps a Tournaments service error to an HTTP response
+func writeTournamentError(c *gin.Context, err error, failure string) {
+	switch status.Code(err) {
+	case codes.InvalidArgument:
+		c.JSON(http.StatusBadRequest, gin.H{"error": status.Convert(err).Message()})
+	case codes.PermissionDenied:
+		c.JSON(http.StatusForbidden, gin.H{"error": status.Convert(err).Message()})
+	case codes.NotFound:
+		c.JSON(http.StatusNotFound, gin.H{"error": status.Convert(err).Message()})
+	case codes.FailedPrecondition, codes.Aborted:
+		c.JSON(http.StatusConflict, gin.H{"error": status.Convert(err).Message()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": failure})
+	}
+}
+
+// parseTournamentFormat maps "swiss" or "round_robin" to its proto value
+func parseTournamentFormat(name string) (tournamentspb.TournamentFormat, bool) {
+	value, ok := tournamentspb.TournamentFormat_value["TOURNAMENT_FORMAT_"+strings.ToUpper(name)]
+	if !ok || value == 0 {
+		return tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_UNSPECIFIED, false
+	}
+	return tournamentspb.TournamentFormat(value), true
+}
+
+// tournamentToJSON converts a tournament into its JSON representation
+func tournamentToJSON(tournament *tournamentspb.Tournament) gin.H {
+	players := make([]gin.H, 0, len(tournament.Players))
+	for _, player := range tournament.Players {
+		players = append(players, gin.H{"id": player.Id, "name": player.Name})
+	}
+
+	rounds := make([]gin.H, 0, len(tournament.Rounds))
+	for _, round := range tournament.Rounds {
+		pairings := make([]gin.H, 0, len(round.Pairings))
+		for _, pairing := range round.Pairings {
+			pairings = append(pairings, gin.H{
+				"player1_id": pairing.Player1Id,
+				"player2_id": pairing.Player2Id,
+				"game_id":    pairing.GameId,
+				"result":     strings.ToLower(strings.TrimPrefix(pairing.Result.String(), "PAIRING_RESULT_")),
+			})
+		}
+		rounds = append(rounds, gin.H{"number": round.Number, "pairings": pairings})
+	}
+
+	return gin.H{
+		"id":            tournament.Id,
+		"name":          tournament.Name,
+		"format":        strings.ToLower(strings.TrimPrefix(tournament.Format.String(), "TOURNAMENT_FORMAT_")),
+		"status":        strings.ToLower(strings.TrimPrefix(tournament.Status.String(), "TOURNAMENT_STATUS_")),
+		"organizer_id":  tournament.OrganizerId,
+		"total_rounds":  tournament.TotalRounds,
+		"current_round": tournament.CurrentRound,
+		"players":       players,
+		"rounds":        rounds,
+		"time_control":  tournament.TimeControl,
+		"created_at":    tournament.CreatedAt,
+	}
+}
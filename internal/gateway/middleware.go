@@ -16,13 +16,16 @@ import (
 type JWTMiddleware struct {
 	jwtManager *auth.JWTManager
 	authClient *ServiceClients
+	denylist   auth.Denylist
 }
 
-// NewJWTMiddleware creates a new JWT middleware
-func NewJWTMiddleware(jwtSecret string, clients *ServiceClients) *JWTMiddleware {
+// NewJWTMiddleware creates a new JWT middleware verifying tokens with the auth
+// service's public keys and rejecting tokens revoked on logout
+func NewJWTMiddleware(clients *ServiceClients, denylist auth.Denylist) *JWTMiddleware {
 	return &JWTMiddleware{
-		jwtManager: auth.NewJWTManager(jwtSecret, 24*time.Hour, 7*24*time.Hour),
+		jwtManager: auth.NewJWTManager(auth.NewJWKSCache(clients.Auth), 24*time.Hour, 7*24*time.Hour),
 		authClient: clients,
+		denylist:   denylist,
 	}
 }
 
@@ -54,6 +57,21 @@ func (m *JWTMiddleware) RequireAuth() gin.HandlerFunc {
 			return
 		}
 
+		// Reject tokens revoked by a logout
+		if m.denylist != nil {
+			revoked, err := m.denylist.IsRevoked(c.Request.Context(), claims.ID)
+			if err != nil {
+				c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Unable to verify token"})
+				c.Abort()
+				return
+			}
+			if revoked {
+				c.JSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+				c.Abort()
+				return
+			}
+		}
+
 		// Set user ID in context for use by handlers
 		c.Set("user_id", claims.UserID)
 		c.Set("jwt_token", token)
@@ -102,13 +120,13 @@ func addGRPCContext(c *gin.Context) context.Context {
 		ctx = context.WithValue(ctx, "user_id", userID)
 	}
 
+	// Forward the client's address, the auth service limits failed logins per IP
+	md := metadata.Pairs("x-forwarded-for", c.ClientIP())
+
 	// Add JWT token to gRPC metadata for backend service authentication
 	if token, exists := c.Get("jwt_token"); exists {
-		md := map[string]string{
-			"authorization": "Bearer " + token.(string),
-		}
-		ctx = metadata.NewOutgoingContext(ctx, metadata.New(md))
+		md.Set("authorization", "Bearer "+token.(string))
 	}
 
-	return ctx
+	return metadata.NewOutgoingContext(ctx, md)
 }
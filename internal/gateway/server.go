@@ -2,11 +2,13 @@ package gateway
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/laerson/mancala/internal/auth"
 )
 
 // Server represents the API Gateway server
@@ -36,6 +38,12 @@ func NewServer(config *GatewayConfig) (*Server, error) {
 	// Setup routes
 	server.setupRoutes()
 
+	// Client IPs are forwarded to the services, only take them from known proxies
+	if err := server.router.SetTrustedProxies(config.TrustedProxies); err != nil {
+		cleanup()
+		return nil, fmt.Errorf("invalid trusted proxies: %w", err)
+	}
+
 	// Create HTTP server
 	server.server = &http.Server{
 		Addr:         ":" + config.Port,
@@ -65,9 +73,11 @@ func (s *Server) setupRoutes() {
 	matchmakingHandlers := NewMatchmakingHandlers(s.clients)
 	gamesHandlers := NewGamesHandlers(s.clients)
 	notificationsHandlers := NewNotificationsHandlers(s.clients)
+	tournamentsHandlers := NewTournamentsHandlers(s.clients)
+	adminHandlers := NewAdminHandlers(s.clients)
 
 	// JWT middleware
-	jwtMiddleware := NewJWTMiddleware(s.config.JWTSecret, s.clients)
+	jwtMiddleware := NewJWTMiddleware(s.clients, auth.NewRedisDenylist(s.config.RedisAddr))
 
 	// Health check endpoint
 	s.router.GET("/health", func(c *gin.Context) {
@@ -78,6 +88,9 @@ func (s *Server) setupRoutes() {
 		})
 	})
 
+	// Public keys for verifying tokens, see RFC 7517
+	s.router.GET("/.well-known/jwks.json", authHandlers.JWKS)
+
 	// API version 1 routes
 	v1 := s.router.Group("/api/v1")
 
@@ -87,6 +100,8 @@ func (s *Server) setupRoutes() {
 		authGroup.POST("/login", authHandlers.Login)
 		authGroup.POST("/register", authHandlers.Register)
 		authGroup.GET("/validate", authHandlers.ValidateToken)
+		authGroup.POST("/logout", jwtMiddleware.RequireAuth(), authHandlers.Logout)
+		authGroup.POST("/logout-all", jwtMiddleware.RequireAuth(), authHandlers.LogoutAll)
 	}
 
 	// Protected routes (require authentication)
@@ -100,19 +115,56 @@ func (s *Server) setupRoutes() {
 		matchmakingGroup.POST("/bot", matchmakingHandlers.BotMatch)
 		matchmakingGroup.DELETE("/queue/:player_id", matchmakingHandlers.CancelQueue)
 		matchmakingGroup.GET("/queue/:player_id/status", matchmakingHandlers.GetQueueStatus)
+		matchmakingGroup.POST("/challenges", matchmakingHandlers.Challenge)
+		matchmakingGroup.GET("/challenges/:player_id", matchmakingHandlers.ListChallenges)
+		matchmakingGroup.POST("/challenges/:challenge_id/respond", matchmakingHandlers.RespondChallenge)
+		matchmakingGroup.POST("/invites", matchmakingHandlers.CreateInvite)
+		matchmakingGroup.POST("/invites/:code/join", matchmakingHandlers.JoinInvite)
 	}
 
 	// Games routes
 	gamesGroup := protected.Group("/games")
 	{
 		gamesGroup.POST("/", gamesHandlers.CreateGame)
+		gamesGroup.GET("/", gamesHandlers.ListGames)
+		gamesGroup.GET("/:game_id", gamesHandlers.GetGame)
+		gamesGroup.GET("/:game_id/replay", gamesHandlers.GetReplay)
+		gamesGroup.GET("/:game_id/spectate", gamesHandlers.SpectateGame)
 		gamesGroup.POST("/:game_id/move", gamesHandlers.MakeMove)
+		gamesGroup.POST("/:game_id/resign", gamesHandlers.Resign)
+		gamesGroup.POST("/:game_id/draw", gamesHandlers.OfferDraw)
+		gamesGroup.POST("/:game_id/draw/respond", gamesHandlers.RespondDraw)
+		gamesGroup.POST("/:game_id/abort", gamesHandlers.Abort)
+	}
+
+	// Tournament routes
+	tournamentsGroup := protected.Group("/tournaments")
+	{
+		tournamentsGroup.POST("/", tournamentsHandlers.CreateTournament)
+		tournamentsGroup.GET("/", tournamentsHandlers.ListTournaments)
+		tournamentsGroup.GET("/:tournament_id", tournamentsHandlers.GetTournament)
+		tournamentsGroup.POST("/:tournament_id/join", tournamentsHandlers.JoinTournament)
+		tournamentsGroup.POST("/:tournament_id/leave", tournamentsHandlers.LeaveTournament)
+		tournamentsGroup.POST("/:tournament_id/start", tournamentsHandlers.StartTournament)
 	}
 
 	// Notifications routes (Server-Sent Events)
 	notificationsGroup := protected.Group("/notifications")
 	{
 		notificationsGroup.GET("/subscribe/:player_id", notificationsHandlers.SubscribeToNotifications)
+		notificationsGroup.GET("/watch/:game_id", notificationsHandlers.WatchGame)
+	}
+
+	// Moderation routes, the services reject callers without a staff role
+	adminGroup := protected.Group("/admin")
+	{
+		adminGroup.GET("/users", adminHandlers.ListUsers)
+		adminGroup.POST("/users/:user_id/ban", adminHandlers.BanUser)
+		adminGroup.POST("/users/:user_id/unban", adminHandlers.UnbanUser)
+		adminGroup.PUT("/users/:user_id/role", adminHandlers.SetUserRole)
+		adminGroup.POST("/games/:game_id/end", adminHandlers.ForceEndGame)
+		adminGroup.GET("/queue", adminHandlers.GetQueueLength)
+		adminGroup.DELETE("/queue/:player_id", adminHandlers.RemoveFromQueue)
 	}
 
 	log.Printf("API Gateway routes configured")
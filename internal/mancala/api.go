@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -61,6 +62,19 @@ type RegisterResponse struct {
 	User         User   `json:"user"`
 }
 
+// LogoutRequest represents a logout request
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogoutResponse represents a logout response
+type LogoutResponse struct {
+	Success              bool   `json:"success"`
+	Message              string `json:"message"`
+	RevokedAccessTokens  int32  `json:"revoked_access_tokens"`  // Only set by LogoutAll
+	RevokedRefreshTokens int32  `json:"revoked_refresh_tokens"` // Only set by LogoutAll
+}
+
 // User represents user information
 type User struct {
 	UserID      string `json:"user_id"`
@@ -102,6 +116,17 @@ type MakeMoveResponse struct {
 	Error   string      `json:"error,omitempty"`
 }
 
+// GameActionRequest represents a resign, draw offer or abort request
+type GameActionRequest struct {
+	PlayerID string `json:"player_id"`
+}
+
+// RespondDrawRequest represents an answer to a draw offer
+type RespondDrawRequest struct {
+	PlayerID string `json:"player_id"`
+	Accept   bool   `json:"accept"`
+}
+
 // BotMatchRequest represents a bot match request
 type BotMatchRequest struct {
 	PlayerID      string `json:"player_id"`
@@ -118,6 +143,241 @@ type BotMatchResponse struct {
 	BotName string `json:"bot_name"`
 }
 
+// ChallengeRequest represents a challenge to a player by username
+type ChallengeRequest struct {
+	PlayerID       string `json:"player_id"`
+	PlayerName     string `json:"player_name"`
+	TargetUsername string `json:"target_username"`
+}
+
+// RespondChallengeRequest represents an answer to a challenge
+type RespondChallengeRequest struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Accept     bool   `json:"accept"`
+}
+
+// InviteRequest represents a request to create or join an invite
+type InviteRequest struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+}
+
+// Challenge represents a pending challenge or invite
+type Challenge struct {
+	ID             string `json:"id"`
+	ChallengerID   string `json:"challenger_id"`
+	ChallengerName string `json:"challenger_name"`
+	TargetUserID   string `json:"target_user_id"`
+	InviteCode     string `json:"invite_code"`
+	ExpiresAt      int64  `json:"expires_at"`
+}
+
+// ChallengeResponse represents the response to a new challenge or invite
+type ChallengeResponse struct {
+	Success   bool       `json:"success"`
+	Message   string     `json:"message"`
+	Challenge *Challenge `json:"challenge"`
+	Invite    *Challenge `json:"invite"`
+}
+
+// ListChallengesResponse represents the pending challenges sent to a player
+type ListChallengesResponse struct {
+	Challenges []Challenge `json:"challenges"`
+}
+
+// JoinGameResponse represents the response to accepting a challenge or joining an invite
+type JoinGameResponse struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	GameID  string `json:"game_id"`
+}
+
+// CreateTournamentRequest represents a new tournament
+type CreateTournamentRequest struct {
+	PlayerID string `json:"player_id"`
+	Name     string `json:"name"`
+	Format   string `json:"format"` // "swiss" or "round_robin"
+	Rounds   int32  `json:"rounds,omitempty"`
+}
+
+// JoinTournamentRequest represents a tournament registration
+type JoinTournamentRequest struct {
+	PlayerID   string `json:"player_id"`
+	PlayerName string `json:"player_name"`
+}
+
+// TournamentPlayerRequest represents leaving or starting a tournament
+type TournamentPlayerRequest struct {
+	PlayerID string `json:"player_id"`
+}
+
+// TournamentPlayer represents a registered player
+type TournamentPlayer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// TournamentPairing represents one game of a round; a bye has no player two
+type TournamentPairing struct {
+	Player1ID string `json:"player1_id"`
+	Player2ID string `json:"player2_id"`
+	GameID    string `json:"game_id"`
+	Result    string `json:"result"` // "pending", "player1_win", "player2_win", "draw" or "bye"
+}
+
+// TournamentRound represents the pairings of a round
+type TournamentRound struct {
+	Number   int32               `json:"number"`
+	Pairings []TournamentPairing `json:"pairings"`
+}
+
+// Tournament represents a Swiss or round-robin tournament
+type Tournament struct {
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	Format       string             `json:"format"`
+	Status       string             `json:"status"` // "registering", "in_progress" or "finished"
+	OrganizerID  string             `json:"organizer_id"`
+	TotalRounds  int32              `json:"total_rounds"`
+	CurrentRound int32              `json:"current_round"`
+	Players      []TournamentPlayer `json:"players"`
+	Rounds       []TournamentRound  `json:"rounds"`
+	CreatedAt    int64              `json:"created_at"`
+}
+
+// PlayerName returns the name a player registered with, or the ID if unknown
+func (t Tournament) PlayerName(playerID string) string {
+	for _, player := range t.Players {
+		if player.ID == playerID {
+			return player.Name
+		}
+	}
+	return playerID
+}
+
+// Standing represents a player's place in a tournament
+type Standing struct {
+	Rank            int32   `json:"rank"`
+	PlayerID        string  `json:"player_id"`
+	PlayerName      string  `json:"player_name"`
+	Score           float64 `json:"score"`
+	Buchholz        float64 `json:"buchholz"`
+	SonnebornBerger float64 `json:"sonneborn_berger"`
+	Wins            int32   `json:"wins"`
+	Draws           int32   `json:"draws"`
+	Losses          int32   `json:"losses"`
+}
+
+// TournamentResponse represents a tournament after a change
+type TournamentResponse struct {
+	Tournament *Tournament `json:"tournament"`
+}
+
+// GetTournamentResponse represents a tournament with its standings
+type GetTournamentResponse struct {
+	Tournament *Tournament `json:"tournament"`
+	Standings  []Standing  `json:"standings"`
+}
+
+// ListTournamentsResponse represents a list of tournaments
+type ListTournamentsResponse struct {
+	Tournaments []Tournament `json:"tournaments"`
+}
+
+// GameState represents the board and turn of a game
+type GameState struct {
+	Board         GameBoardPits `json:"board"`
+	CurrentPlayer int           `json:"current_player"`
+	GameType      int           `json:"game_type"`
+}
+
+// GameBoardPits represents the pits of a game board
+type GameBoardPits struct {
+	Pits []uint32 `json:"pits"`
+}
+
+// GameMove represents a move played in a game
+type GameMove struct {
+	PlayerID   string        `json:"player_id"`
+	PitIndex   uint32        `json:"pit_index"`
+	Timestamp  int64         `json:"timestamp"`
+	Board      GameBoardPits `json:"board"`
+	ExtraTurn  bool          `json:"extra_turn"`
+	Captured   bool          `json:"captured"`
+	NextPlayer int           `json:"next_player"`
+}
+
+// ReplayPosition represents one position of a replay
+type ReplayPosition struct {
+	MoveNumber int       `json:"move_number"`
+	Move       *GameMove `json:"move"`
+	State      GameState `json:"state"`
+}
+
+// Replay represents every position of a game
+type Replay struct {
+	GameID    string           `json:"game_id"`
+	Player1ID string           `json:"player1_id"`
+	Player2ID string           `json:"player2_id"`
+	Positions []ReplayPosition `json:"positions"`
+	Status    int              `json:"status"`
+	WinnerID  string           `json:"winner_id"`
+}
+
+// IsFinished reports whether the replayed game has ended (GAME_STATUS_FINISHED)
+func (r Replay) IsFinished() bool {
+	return r.Status == 2
+}
+
+// GetReplayResponse represents a get replay response
+type GetReplayResponse struct {
+	Replay Replay `json:"replay"`
+}
+
+// Game represents a game as returned by the API
+type Game struct {
+	ID            string       `json:"id"`
+	Player1ID     string       `json:"player1_id"`
+	Player2ID     string       `json:"player2_id"`
+	State         GameState    `json:"state"`
+	Status        string       `json:"status"`
+	Moves         []GameMove   `json:"moves"`
+	CreatedAt     int64        `json:"created_at"`
+	FinishedAt    int64        `json:"finished_at"`
+	WinnerID      string       `json:"winner_id"`
+	EndReason     string       `json:"end_reason"`      // How a finished game ended, e.g. "resignation"
+	DrawOfferedBy string       `json:"draw_offered_by"` // Player with a pending draw offer
+	TimeControl   *TimeControl `json:"time_control"`    // Unset for a game without a clock
+	Clock         *Clock       `json:"clock"`
+}
+
+// TimeControl represents the time control a game was created with
+type TimeControl struct {
+	BaseMs      int64 `json:"base_ms"`
+	IncrementMs int64 `json:"increment_ms"`
+	PerMoveMs   int64 `json:"per_move_ms"`
+}
+
+// Clock represents the remaining time of both players. The player to move
+// has been thinking since TurnStartedAtMs.
+type Clock struct {
+	Player1RemainingMs int64 `json:"player1_remaining_ms"`
+	Player2RemainingMs int64 `json:"player2_remaining_ms"`
+	TurnStartedAtMs    int64 `json:"turn_started_at_ms"`
+}
+
+// GetGameResponse represents a get game response
+type GetGameResponse struct {
+	Game Game `json:"game"`
+}
+
+// ListGamesResponse represents a list games response
+type ListGamesResponse struct {
+	Games         []Game `json:"games"`
+	NextPageToken string `json:"next_page_token"`
+}
+
 // Register registers a new user account
 func (c *APIClient) Register(username, password string) (*RegisterResponse, error) {
 	req := RegisterRequest{
@@ -158,6 +418,31 @@ func (c *APIClient) Login(username, password string) (*LoginResponse, error) {
 	return &result, nil
 }
 
+// Logout revokes the current access token and the given refresh token
+func (c *APIClient) Logout(refreshToken string) (*LogoutResponse, error) {
+	return c.logoutRequest("/api/v1/auth/logout", LogoutRequest{RefreshToken: refreshToken})
+}
+
+// LogoutAll revokes every access and refresh token of the user
+func (c *APIClient) LogoutAll() (*LogoutResponse, error) {
+	return c.logoutRequest("/api/v1/auth/logout-all", nil)
+}
+
+// logoutRequest posts a logout
+func (c *APIClient) logoutRequest(path string, body interface{}) (*LogoutResponse, error) {
+	resp, err := c.makeRequest("POST", path, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result LogoutResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // Enqueue adds a player to the matchmaking queue
 func (c *APIClient) Enqueue(playerID, playerName string) (*EnqueueResponse, error) {
 	req := EnqueueRequest{
@@ -219,6 +504,110 @@ func (c *APIClient) MakeMove(gameID, playerID string, pitIndex uint32) (*MakeMov
 	return &result, nil
 }
 
+// GetGame fetches the current state of a game
+func (c *APIClient) GetGame(gameID string) (*GetGameResponse, error) {
+	resp, err := c.makeRequest("GET", fmt.Sprintf("/api/v1/games/%s", gameID), nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result GetGameResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// SpectateGame fetches the current state of a game in progress as a spectator
+func (c *APIClient) SpectateGame(gameID string) (*GetGameResponse, error) {
+	resp, err := c.makeRequest("GET", fmt.Sprintf("/api/v1/games/%s/spectate", gameID), nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result GetGameResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// Resign resigns a game, which the opponent wins
+func (c *APIClient) Resign(gameID, playerID string) (*GetGameResponse, error) {
+	return c.gameAction(fmt.Sprintf("/api/v1/games/%s/resign", gameID), GameActionRequest{PlayerID: playerID})
+}
+
+// OfferDraw offers the opponent a draw
+func (c *APIClient) OfferDraw(gameID, playerID string) (*GetGameResponse, error) {
+	return c.gameAction(fmt.Sprintf("/api/v1/games/%s/draw", gameID), GameActionRequest{PlayerID: playerID})
+}
+
+// RespondDraw accepts or declines the opponent's draw offer
+func (c *APIClient) RespondDraw(gameID, playerID string, accept bool) (*GetGameResponse, error) {
+	return c.gameAction(fmt.Sprintf("/api/v1/games/%s/draw/respond", gameID), RespondDrawRequest{PlayerID: playerID, Accept: accept})
+}
+
+// Abort calls a game off before the second move
+func (c *APIClient) Abort(gameID, playerID string) (*GetGameResponse, error) {
+	return c.gameAction(fmt.Sprintf("/api/v1/games/%s/abort", gameID), GameActionRequest{PlayerID: playerID})
+}
+
+// gameAction posts a resign, draw or abort action and returns the updated game
+func (c *APIClient) gameAction(path string, body interface{}) (*GetGameResponse, error) {
+	resp, err := c.makeRequest("POST", path, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result GetGameResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetReplay fetches every position of a game
+func (c *APIClient) GetReplay(gameID string) (*GetReplayResponse, error) {
+	resp, err := c.makeRequest("GET", fmt.Sprintf("/api/v1/games/%s/replay", gameID), nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result GetReplayResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// ListGames lists the player's games with the given status ("in_progress", "finished" or "" for all)
+func (c *APIClient) ListGames(playerID, status, pageToken string) (*ListGamesResponse, error) {
+	query := url.Values{}
+	query.Set("player_id", playerID)
+	if status != "" {
+		query.Set("status", status)
+	}
+	if pageToken != "" {
+		query.Set("page_token", pageToken)
+	}
+
+	resp, err := c.makeRequest("GET", "/api/v1/games/?"+query.Encode(), nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListGamesResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // BotMatch creates a match against a bot opponent
 func (c *APIClient) BotMatch(playerID, playerName, botDifficulty string) (*BotMatchResponse, error) {
 	req := BotMatchRequest{
@@ -240,6 +629,164 @@ func (c *APIClient) BotMatch(playerID, playerName, botDifficulty string) (*BotMa
 	return &result, nil
 }
 
+// Challenge challenges a player by username
+func (c *APIClient) Challenge(playerID, playerName, targetUsername string) (*ChallengeResponse, error) {
+	req := ChallengeRequest{
+		PlayerID:       playerID,
+		PlayerName:     playerName,
+		TargetUsername: targetUsername,
+	}
+
+	return c.challengeRequest("/api/v1/matchmaking/challenges", req)
+}
+
+// ListChallenges lists the pending challenges sent to the player
+func (c *APIClient) ListChallenges(playerID string) (*ListChallengesResponse, error) {
+	resp, err := c.makeRequest("GET", fmt.Sprintf("/api/v1/matchmaking/challenges/%s", playerID), nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListChallengesResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// RespondChallenge accepts or declines a challenge. Declining your own challenge withdraws it.
+func (c *APIClient) RespondChallenge(challengeID, playerID, playerName string, accept bool) (*JoinGameResponse, error) {
+	req := RespondChallengeRequest{
+		PlayerID:   playerID,
+		PlayerName: playerName,
+		Accept:     accept,
+	}
+
+	return c.joinGameRequest(fmt.Sprintf("/api/v1/matchmaking/challenges/%s/respond", challengeID), req)
+}
+
+// CreateInvite creates a private invite code
+func (c *APIClient) CreateInvite(playerID, playerName string) (*ChallengeResponse, error) {
+	return c.challengeRequest("/api/v1/matchmaking/invites", InviteRequest{PlayerID: playerID, PlayerName: playerName})
+}
+
+// JoinInvite redeems an invite code
+func (c *APIClient) JoinInvite(code, playerID, playerName string) (*JoinGameResponse, error) {
+	req := InviteRequest{PlayerID: playerID, PlayerName: playerName}
+	return c.joinGameRequest(fmt.Sprintf("/api/v1/matchmaking/invites/%s/join", url.PathEscape(code)), req)
+}
+
+// challengeRequest posts a new challenge or invite
+func (c *APIClient) challengeRequest(path string, body interface{}) (*ChallengeResponse, error) {
+	resp, err := c.makeRequest("POST", path, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ChallengeResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// joinGameRequest posts an answer to a challenge or invite
+func (c *APIClient) joinGameRequest(path string, body interface{}) (*JoinGameResponse, error) {
+	resp, err := c.makeRequest("POST", path, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result JoinGameResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// CreateTournament creates a tournament organized by the player
+func (c *APIClient) CreateTournament(playerID, name, format string, rounds int32) (*TournamentResponse, error) {
+	req := CreateTournamentRequest{
+		PlayerID: playerID,
+		Name:     name,
+		Format:   format,
+		Rounds:   rounds,
+	}
+
+	return c.tournamentRequest("/api/v1/tournaments/", req)
+}
+
+// ListTournaments lists tournaments, optionally only those with the given status
+func (c *APIClient) ListTournaments(status string) (*ListTournamentsResponse, error) {
+	path := "/api/v1/tournaments/"
+	if status != "" {
+		path += "?" + url.Values{"status": {status}}.Encode()
+	}
+
+	resp, err := c.makeRequest("GET", path, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result ListTournamentsResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetTournament retrieves a tournament with its standings
+func (c *APIClient) GetTournament(tournamentID string) (*GetTournamentResponse, error) {
+	resp, err := c.makeRequest("GET", fmt.Sprintf("/api/v1/tournaments/%s", url.PathEscape(tournamentID)), nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result GetTournamentResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// JoinTournament registers the player for a tournament
+func (c *APIClient) JoinTournament(tournamentID, playerID, playerName string) (*TournamentResponse, error) {
+	req := JoinTournamentRequest{PlayerID: playerID, PlayerName: playerName}
+	return c.tournamentRequest(fmt.Sprintf("/api/v1/tournaments/%s/join", url.PathEscape(tournamentID)), req)
+}
+
+// LeaveTournament withdraws the player's registration
+func (c *APIClient) LeaveTournament(tournamentID, playerID string) (*TournamentResponse, error) {
+	req := TournamentPlayerRequest{PlayerID: playerID}
+	return c.tournamentRequest(fmt.Sprintf("/api/v1/tournaments/%s/leave", url.PathEscape(tournamentID)), req)
+}
+
+// StartTournament closes registration and pairs the first round
+func (c *APIClient) StartTournament(tournamentID, playerID string) (*TournamentResponse, error) {
+	req := TournamentPlayerRequest{PlayerID: playerID}
+	return c.tournamentRequest(fmt.Sprintf("/api/v1/tournaments/%s/start", url.PathEscape(tournamentID)), req)
+}
+
+// tournamentRequest posts a change to a tournament
+func (c *APIClient) tournamentRequest(path string, body interface{}) (*TournamentResponse, error) {
+	resp, err := c.makeRequest("POST", path, body, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var result TournamentResponse
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 // TestConnection tests if the server is reachable
 func (c *APIClient) TestConnection() error {
 	_, err := c.makeRequest("GET", "/health", nil, false)
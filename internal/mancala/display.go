@@ -3,18 +3,29 @@ package mancala
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
+// GameTypeOware marks games played by the Oware abapa rules
+const GameTypeOware = 1
+
 // GameBoard represents a Mancala game board
 type GameBoard struct {
 	Pits          []uint32 `json:"pits"`
 	CurrentPlayer int      `json:"current_player"`
+	GameType      int      `json:"game_type"`
 }
 
 // DisplayBoard displays the Mancala board in ASCII art
 func DisplayBoard(board GameBoard) {
-	if len(board.Pits) != 14 {
-		fmt.Println("Invalid board: expected 14 pits")
+	if board.GameType == GameTypeOware {
+		displayOwareBoard(board)
+		return
+	}
+
+	n := pitsPerSide(board.Pits)
+	if n == 0 {
+		fmt.Printf("Invalid board: %d pits\n", len(board.Pits))
 		return
 	}
 
@@ -23,22 +34,22 @@ func DisplayBoard(board GameBoard) {
 	fmt.Println("  Player 2's side")
 	fmt.Println()
 
-	// Top row (Player 2's pits) - indices 7-12 (reverse order for display)
+	// Top row (Player 2's pits) - indices n+1 to 2n (reverse order for display)
 	fmt.Print("  ")
-	for i := 12; i >= 7; i-- {
+	for i := 2 * n; i >= n+1; i-- {
 		fmt.Printf("[ %2d ]", board.Pits[i])
 	}
 	fmt.Println()
 
 	// Mancalas (Player 2's mancala on left, Player 1's on right)
-	fmt.Printf("[ %2d ]", board.Pits[13]) // Player 2's mancala
-	fmt.Print(strings.Repeat("      ", 6))
-	fmt.Printf("[ %2d ]", board.Pits[6]) // Player 1's mancala
+	fmt.Printf("[ %2d ]", board.Pits[2*n+1]) // Player 2's mancala
+	fmt.Print(strings.Repeat("      ", n))
+	fmt.Printf("[ %2d ]", board.Pits[n]) // Player 1's mancala
 	fmt.Println()
 
-	// Bottom row (Player 1's pits) - indices 0-5
+	// Bottom row (Player 1's pits) - indices 0 to n-1
 	fmt.Print("  ")
-	for i := 0; i <= 5; i++ {
+	for i := 0; i < n; i++ {
 		fmt.Printf("[ %2d ]", board.Pits[i])
 	}
 	fmt.Println()
@@ -46,6 +57,47 @@ func DisplayBoard(board GameBoard) {
 	fmt.Println()
 	fmt.Println("  Player 1's side")
 
+	// Show pit numbers for reference
+	fmt.Println("\n  Pit numbers (Player 1):")
+	fmt.Print("  ")
+	for i := 0; i < n; i++ {
+		fmt.Printf("  %2d  ", i)
+	}
+	fmt.Println()
+
+	displayTurn(board)
+}
+
+// displayOwareBoard displays an Oware board, whose stores only hold captured seeds
+func displayOwareBoard(board GameBoard) {
+	if len(board.Pits) != 14 {
+		fmt.Println("Invalid board: expected 14 pits")
+		return
+	}
+
+	fmt.Println()
+	fmt.Println("    OWARE BOARD")
+	fmt.Println("  Player 2's side")
+	fmt.Println()
+
+	// Top row (Player 2's houses) - indices 7-12 (reverse order for display)
+	fmt.Print("  ")
+	for i := 12; i >= 7; i-- {
+		fmt.Printf("( %2d )", board.Pits[i])
+	}
+	fmt.Println()
+
+	// Bottom row (Player 1's houses) - indices 0-5
+	fmt.Print("  ")
+	for i := 0; i <= 5; i++ {
+		fmt.Printf("( %2d )", board.Pits[i])
+	}
+	fmt.Println()
+
+	fmt.Println()
+	fmt.Println("  Player 1's side")
+	fmt.Printf("\n  Captured: Player 1 [ %2d ]  Player 2 [ %2d ]\n", board.Pits[6], board.Pits[13])
+
 	// Show pit numbers for reference
 	fmt.Println("\n  Pit numbers (Player 1):")
 	fmt.Print("  ")
@@ -54,7 +106,11 @@ func DisplayBoard(board GameBoard) {
 	}
 	fmt.Println()
 
-	// Current player indicator
+	displayTurn(board)
+}
+
+// displayTurn displays whose turn it is
+func displayTurn(board GameBoard) {
 	if board.CurrentPlayer == 0 {
 		fmt.Println("\n  >>> Player 1's turn <<<")
 	} else {
@@ -63,9 +119,135 @@ func DisplayBoard(board GameBoard) {
 	fmt.Println()
 }
 
+// DisplayGame displays a game's players, whose turn it is and its board
+func DisplayGame(game Game, playerID string) {
+	fmt.Printf("\n🎮 Game %s\n", game.ID)
+	fmt.Printf("Player 1: %s\n", game.Player1ID)
+	fmt.Printf("Player 2: %s\n", game.Player2ID)
+
+	if game.Status == "GAME_STATUS_FINISHED" {
+		if game.EndReason != "" {
+			fmt.Printf("Status: Finished (%s)\n", strings.ReplaceAll(game.EndReason, "_", " "))
+		} else {
+			fmt.Println("Status: Finished")
+		}
+	} else {
+		turnPlayerID := game.Player1ID
+		if game.State.CurrentPlayer == 1 {
+			turnPlayerID = game.Player2ID
+		}
+
+		if turnPlayerID == playerID {
+			fmt.Println("Status: Your turn")
+		} else {
+			fmt.Println("Status: Waiting for opponent")
+		}
+
+		switch game.DrawOfferedBy {
+		case "":
+		case playerID:
+			fmt.Println("🤝 You offered a draw")
+		default:
+			fmt.Println("🤝 Your opponent offers a draw. Answer with 'mancala draw accept' or 'mancala draw decline'.")
+		}
+	}
+
+	if game.Clock != nil {
+		DisplayClock(*game.Clock, game.State.CurrentPlayer, game.Status != "GAME_STATUS_FINISHED")
+	}
+
+	DisplayBoard(GameBoard{
+		Pits:          game.State.Board.Pits,
+		CurrentPlayer: game.State.CurrentPlayer,
+		GameType:      game.State.GameType,
+	})
+}
+
+// DisplayClock displays the remaining time of both players. While the game is
+// running the player to move is charged for the time since their turn started.
+func DisplayClock(clock Clock, currentPlayer int, running bool) {
+	player1, player2 := clock.Player1RemainingMs, clock.Player2RemainingMs
+	if running {
+		elapsed := time.Now().UnixMilli() - clock.TurnStartedAtMs
+		if currentPlayer == 1 {
+			player2 -= elapsed
+		} else {
+			player1 -= elapsed
+		}
+	}
+
+	fmt.Printf("⏱️  Player 1: %s   Player 2: %s\n", formatClock(player1), formatClock(player2))
+}
+
+// formatClock formats remaining milliseconds as minutes and seconds
+func formatClock(ms int64) string {
+	if ms < 0 {
+		ms = 0
+	}
+	seconds := (ms + 999) / 1000
+	return fmt.Sprintf("%d:%02d", seconds/60, seconds%60)
+}
+
+// DisplayGameResult displays a one line summary of a finished game
+func DisplayGameResult(game Game, playerID string) {
+	opponentID := game.Player2ID
+	if game.Player2ID == playerID {
+		opponentID = game.Player1ID
+	}
+
+	result := "Draw"
+	switch game.WinnerID {
+	case "":
+		if game.EndReason == "aborted" {
+			result = "Aborted"
+		}
+	case playerID:
+		result = "Won"
+	default:
+		result = "Lost"
+	}
+
+	finished := time.Unix(game.FinishedAt, 0).Format("2006-01-02 15:04")
+	fmt.Printf("%s  %-7s  vs %-36s  %2d-%-2d  %3d moves  %s\n",
+		finished, result, opponentID, ownStore(game, playerID), opponentStore(game, playerID), len(game.Moves), game.ID)
+}
+
+// ownStore returns the seeds in the player's store of a game
+func ownStore(game Game, playerID string) uint32 {
+	n := pitsPerSide(game.State.Board.Pits)
+	if n == 0 {
+		return 0
+	}
+	if game.Player2ID == playerID {
+		return game.State.Board.Pits[2*n+1]
+	}
+	return game.State.Board.Pits[n]
+}
+
+// opponentStore returns the seeds in the opponent's store of a game
+func opponentStore(game Game, playerID string) uint32 {
+	n := pitsPerSide(game.State.Board.Pits)
+	if n == 0 {
+		return 0
+	}
+	if game.Player2ID == playerID {
+		return game.State.Board.Pits[n]
+	}
+	return game.State.Board.Pits[2*n+1]
+}
+
+// pitsPerSide returns the number of playable pits per player on a board with
+// two stores, or 0 if the board has no valid layout
+func pitsPerSide(pits []uint32) int {
+	if len(pits) < 4 || len(pits)%2 != 0 {
+		return 0
+	}
+	return (len(pits) - 2) / 2
+}
+
 // DisplayWelcome displays a welcome message
 func DisplayWelcome() {
-	fmt.Println(`
+	fmt.Print(`
 ╔═══════════════════════════════════════════════════════════════╗
 ║                      MANCALA GAME CLIENT                     ║
 ║                                                               ║
@@ -76,7 +258,7 @@ func DisplayWelcome() {
 ║    mancala register             - Create new account         ║
 ║    mancala login                - Login to existing account  ║
 ║    mancala play                 - Join matchmaking queue     ║
-║    mancala status               - Check connection status    ║
+║    mancala status [game-id]     - Check status and games     ║
 ║    mancala logout               - Logout from current account║
 ║                                                               ║
 ╚═══════════════════════════════════════════════════════════════╝
@@ -128,6 +310,112 @@ func DisplayMatchFound(data map[string]interface{}) {
 	fmt.Println()
 }
 
+// DisplayChallenge displays a change of a challenge or invite the player is part of
+func DisplayChallenge(data map[string]interface{}, playerID string) {
+	challengeID, _ := data["challenge_id"].(string)
+	challengerID, _ := data["challenger_id"].(string)
+	challengerName, _ := data["challenger_name"].(string)
+	targetID, _ := data["target_id"].(string)
+	status, _ := data["status"].(string)
+	incoming := challengerID != playerID
+
+	kind := "challenge"
+	if targetID == "" {
+		kind = "invite"
+	}
+
+	switch status {
+	case "pending":
+		if incoming {
+			fmt.Printf("\n⚔️  %s challenges you to a game!\n", challengerName)
+			fmt.Printf("Accept with 'mancala challenge --accept %s'\n", challengeID)
+			fmt.Printf("Decline with 'mancala challenge --decline %s'\n", challengeID)
+		}
+	case "declined":
+		if incoming {
+			fmt.Printf("\n✅ You declined the challenge from %s.\n", challengerName)
+		} else {
+			fmt.Println("\n🚫 Your challenge was declined.")
+		}
+	case "withdrawn":
+		if incoming {
+			fmt.Printf("\n🚫 %s withdrew their challenge.\n", challengerName)
+		} else {
+			fmt.Printf("\n✅ Your %s was withdrawn.\n", kind)
+		}
+	case "expired":
+		if incoming {
+			fmt.Printf("\n⌛ The challenge from %s expired.\n", challengerName)
+		} else {
+			fmt.Printf("\n⌛ Your %s expired without an answer.\n", kind)
+		}
+	}
+}
+
+// DisplayTournament displays a tournament's standings and the pairings of its current round
+func DisplayTournament(tournament Tournament, standings []Standing, playerID string) {
+	fmt.Printf("\n🏆 %s\n", tournament.Name)
+	fmt.Println(strings.Repeat("=", len(tournament.Name)+3))
+	fmt.Printf("ID: %s\n", tournament.ID)
+	fmt.Printf("Format: %s, %d players\n", strings.ReplaceAll(tournament.Format, "_", "-"), len(tournament.Players))
+
+	switch tournament.Status {
+	case "registering":
+		fmt.Println("Status: open for registration")
+		for _, player := range tournament.Players {
+			fmt.Printf("  • %s\n", player.Name)
+		}
+		return
+	case "finished":
+		fmt.Println("Status: finished")
+	default:
+		fmt.Printf("Status: round %d of %d\n", tournament.CurrentRound, tournament.TotalRounds)
+	}
+
+	fmt.Println("\n  #  Player               Score  Buchholz  S-B    W-D-L")
+	for _, standing := range standings {
+		marker := " "
+		if standing.PlayerID == playerID {
+			marker = "▶"
+		}
+		fmt.Printf("%s%2d  %-20s %5.1f  %8.2f  %5.2f  %d-%d-%d\n", marker, standing.Rank, standing.PlayerName,
+			standing.Score, standing.Buchholz, standing.SonnebornBerger, standing.Wins, standing.Draws, standing.Losses)
+	}
+
+	if tournament.Status == "finished" || len(tournament.Rounds) == 0 {
+		return
+	}
+
+	round := tournament.Rounds[len(tournament.Rounds)-1]
+	fmt.Printf("\nRound %d pairings:\n", round.Number)
+	for _, pairing := range round.Pairings {
+		if pairing.Player2ID == "" {
+			fmt.Printf("  %s has a bye\n", tournament.PlayerName(pairing.Player1ID))
+			continue
+		}
+
+		line := fmt.Sprintf("  %s vs %s", tournament.PlayerName(pairing.Player1ID), tournament.PlayerName(pairing.Player2ID))
+		switch pairing.Result {
+		case "pending":
+			if pairing.GameID == "" {
+				line += " (waiting for the game to be created)"
+			} else {
+				line += fmt.Sprintf(" (game %s)", pairing.GameID)
+			}
+		case "player1_win":
+			line += ": 1-0"
+		case "player2_win":
+			line += ": 0-1"
+		case "draw":
+			line += ": ½-½"
+		}
+		if pairing.Player1ID == playerID || pairing.Player2ID == playerID {
+			line += "  ◀ your game"
+		}
+		fmt.Println(line)
+	}
+}
+
 // DisplayMoveResult displays the result of a move
 func DisplayMoveResult(data map[string]interface{}) {
 	fmt.Println("\n📱 MOVE MADE")
@@ -158,11 +446,34 @@ func DisplayMoveResult(data map[string]interface{}) {
 				board.CurrentPlayer = int(currentPlayer)
 			}
 
+			if gameType, ok := gameState["game_type"].(float64); ok {
+				board.GameType = int(gameType)
+			}
+
 			DisplayBoard(board)
+
+			if clockData, ok := data["clock"].(map[string]interface{}); ok {
+				DisplayClock(parseClock(clockData), board.CurrentPlayer, true)
+			}
 		}
 	}
 }
 
+// parseClock reads a clock from notification data
+func parseClock(data map[string]interface{}) Clock {
+	var clock Clock
+	if v, ok := data["player1_remaining_ms"].(float64); ok {
+		clock.Player1RemainingMs = int64(v)
+	}
+	if v, ok := data["player2_remaining_ms"].(float64); ok {
+		clock.Player2RemainingMs = int64(v)
+	}
+	if v, ok := data["turn_started_at_ms"].(float64); ok {
+		clock.TurnStartedAtMs = int64(v)
+	}
+	return clock
+}
+
 // DisplayGameOver displays game over information
 func DisplayGameOver(data map[string]interface{}) {
 	fmt.Println("\n🏁 GAME OVER! 🏁")
@@ -180,6 +491,10 @@ func DisplayGameOver(data map[string]interface{}) {
 		fmt.Println("Result: It's a draw!")
 	}
 
+	if reason, ok := data["reason"].(string); ok && reason != "" {
+		fmt.Printf("Reason: %s\n", strings.ToLower(strings.ReplaceAll(reason, "_", " ")))
+	}
+
 	// Display final board if available
 	if finalState, ok := data["final_state"].(map[string]interface{}); ok {
 		if boardData, ok := finalState["board"].([]interface{}); ok {
@@ -193,6 +508,10 @@ func DisplayGameOver(data map[string]interface{}) {
 				}
 			}
 
+			if gameType, ok := finalState["game_type"].(float64); ok {
+				board.GameType = int(gameType)
+			}
+
 			fmt.Println("\nFinal Board:")
 			DisplayBoard(board)
 		}
@@ -7,12 +7,21 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
+)
+
+const (
+	// reconnectDelay is how long to wait before reconnecting a dropped subscription
+	reconnectDelay = 2 * time.Second
+	// maxReconnectAttempts is how many reconnects in a row may fail before giving up
+	maxReconnectAttempts = 5
 )
 
 // NotificationClient handles Server-Sent Events from the notifications service
 type NotificationClient struct {
-	baseURL string
-	token   string
+	baseURL     string
+	token       string
+	lastEventID string // ID of the last notification received, sent on reconnect
 }
 
 // Notification represents a notification from the server
@@ -32,39 +41,93 @@ func NewNotificationClient(baseURL, token string) *NotificationClient {
 	}
 }
 
-// Subscribe subscribes to notifications for a player
+// Subscribe subscribes to notifications for a player. When the connection
+// drops it reconnects, and the server first replays what was missed meanwhile.
 func (nc *NotificationClient) Subscribe(ctx context.Context, playerID string, callback func(Notification)) error {
 	url := fmt.Sprintf("%s/api/v1/notifications/subscribe/%s", nc.baseURL, playerID)
 
+	everConnected := false
+	failures := 0
+	for {
+		connected, err := nc.stream(ctx, url, callback)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if connected {
+			everConnected = true
+			failures = 0
+		} else {
+			// The first connection's error, e.g. an expired login, is reported right away
+			if !everConnected || failures >= maxReconnectAttempts {
+				return err
+			}
+			failures++
+		}
+
+		fmt.Println("\n⚠️ Notification connection lost, reconnecting...")
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// Watch follows the moves and the result of a game as a spectator. It returns
+// once the game is over.
+func (nc *NotificationClient) Watch(ctx context.Context, gameID string, callback func(Notification)) error {
+	url := fmt.Sprintf("%s/api/v1/notifications/watch/%s", nc.baseURL, gameID)
+	_, err := nc.stream(ctx, url, callback)
+	return err
+}
+
+// stream reads Server-Sent Events from url and passes each notification to
+// callback. It reports whether the connection was established.
+func (nc *NotificationClient) stream(ctx context.Context, url string, callback func(Notification)) (bool, error) {
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	req.Header.Set("Authorization", "Bearer "+nc.token)
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
+	if nc.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", nc.lastEventID)
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return err
+		return false, err
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to subscribe to notifications: %s", resp.Status)
+		return false, fmt.Errorf("failed to subscribe to notifications: %s", resp.Status)
 	}
 
+	// The id field comes before the data of its event
+	eventID := ""
+
 	scanner := bufio.NewScanner(resp.Body)
 	for scanner.Scan() {
 		select {
 		case <-ctx.Done():
-			return ctx.Err()
+			return true, ctx.Err()
 		default:
 			line := scanner.Text()
-			if strings.HasPrefix(line, "data: ") {
-				data := strings.TrimPrefix(line, "data: ")
+			switch {
+			case line == "":
+				// A blank line ends the event
+				eventID = ""
+
+			case strings.HasPrefix(line, "id:"):
+				eventID = strings.TrimSpace(strings.TrimPrefix(line, "id:"))
+
+			case strings.HasPrefix(line, "data:"):
+				data := strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " ")
 
 				var notification Notification
 				if err := json.Unmarshal([]byte(data), &notification); err != nil {
@@ -73,9 +136,12 @@ func (nc *NotificationClient) Subscribe(ctx context.Context, playerID string, ca
 				}
 
 				callback(notification)
+				if eventID != "" {
+					nc.lastEventID = eventID
+				}
 			}
 		}
 	}
 
-	return scanner.Err()
+	return true, scanner.Err()
 }
@@ -0,0 +1,466 @@
+package matchmaking
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/laerson/mancala/internal/auth"
+	"github.com/laerson/mancala/internal/events"
+	gamespb "github.com/laerson/mancala/proto/games"
+	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
+)
+
+const (
+	// ChallengeTTL is how long a challenged player has to respond
+	ChallengeTTL = time.Minute
+
+	// InviteTTL is how long an invite code can be redeemed
+	InviteTTL = 10 * time.Minute
+
+	inviteCodeLength = 6
+
+	// inviteCodeAlphabet leaves out characters that are easily confused, like 0 and O. Its 32
+	// characters divide 256, so picking them by random byte is unbiased.
+	inviteCodeAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+	// inviteCodeAttempts is how many codes are tried before giving up on collisions
+	inviteCodeAttempts = 5
+)
+
+// Challenge statuses published to the notifications service
+const (
+	challengeStatusPending   = "pending"
+	challengeStatusDeclined  = "declined"
+	challengeStatusWithdrawn = "withdrawn"
+	challengeStatusExpired   = "expired"
+)
+
+// Challenge is a pending direct challenge to a specific player, or a private invite
+// that any player can redeem. Invites have no target and use their code as ID.
+type Challenge struct {
+	ID             string
+	ChallengerID   string
+	ChallengerName string
+	TargetID       string
+	ExpiresAt      time.Time
+}
+
+// IsInvite reports whether the challenge is an invite code rather than a direct challenge
+func (c *Challenge) IsInvite() bool {
+	return c.TargetID == ""
+}
+
+// ChallengeStore holds challenges and invites until they are answered or expire
+type ChallengeStore interface {
+	// AddChallenge stores a new challenge and reports false when its ID is already taken
+	AddChallenge(ctx context.Context, challenge *Challenge) (bool, error)
+	// GetChallenge returns nil without error when the challenge does not exist or has expired
+	GetChallenge(ctx context.Context, challengeID string) (*Challenge, error)
+	// TakeChallenge removes the challenge and returns it. When several callers take the
+	// same challenge only one of them gets it; the others get nil.
+	TakeChallenge(ctx context.Context, challengeID string) (*Challenge, error)
+	// ListChallenges returns the pending challenges sent to a player
+	ListChallenges(ctx context.Context, targetID string) ([]*Challenge, error)
+	// TakeExpiredChallenges removes and returns the challenges and invites that expired before now
+	TakeExpiredChallenges(ctx context.Context, now time.Time) ([]*Challenge, error)
+}
+
+// Challenge sends a challenge to a specific player, who can accept or decline it until it expires
+func (s *Server) Challenge(ctx context.Context, req *matchmakingpb.ChallengeRequest) (*matchmakingpb.ChallengeResponse, error) {
+	if err := validatePlayer(req.Challenger); err != nil {
+		return nil, err
+	}
+
+	if req.TargetUserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "target user ID is required")
+	}
+
+	// Validate that the authenticated user owns this player ID
+	if err := auth.ValidatePlayerOwnership(ctx, req.Challenger.Id); err != nil {
+		return nil, err
+	}
+
+	if req.TargetUserId == req.Challenger.Id {
+		return &matchmakingpb.ChallengeResponse{
+			Success: false,
+			Message: "You cannot challenge yourself",
+		}, nil
+	}
+
+	challenge := &Challenge{
+		ID:             uuid.New().String(),
+		ChallengerID:   req.Challenger.Id,
+		ChallengerName: req.Challenger.Name,
+		TargetID:       req.TargetUserId,
+		ExpiresAt:      time.Now().Add(ChallengeTTL),
+	}
+
+	if _, err := s.challenges.AddChallenge(ctx, challenge); err != nil {
+		log.Printf("Failed to store challenge from %s: %v", req.Challenger.Id, err)
+		return nil, status.Errorf(codes.Internal, "failed to create challenge")
+	}
+
+	log.Printf("Player %s challenged %s (challenge %s)", req.Challenger.Id, req.TargetUserId, challenge.ID)
+	s.publishChallenge(challenge, challengeStatusPending)
+
+	return &matchmakingpb.ChallengeResponse{
+		Success:   true,
+		Message:   "Challenge sent",
+		Challenge: challengeToProto(challenge),
+	}, nil
+}
+
+// RespondChallenge accepts or declines a challenge. The challenger may decline to withdraw it.
+func (s *Server) RespondChallenge(ctx context.Context, req *matchmakingpb.RespondChallengeRequest) (*matchmakingpb.RespondChallengeResponse, error) {
+	if err := validatePlayer(req.Player); err != nil {
+		return nil, err
+	}
+
+	if req.ChallengeId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "challenge ID is required")
+	}
+
+	// Validate that the authenticated user owns this player ID
+	if err := auth.ValidatePlayerOwnership(ctx, req.Player.Id); err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.challenges.GetChallenge(ctx, req.ChallengeId)
+	if err != nil {
+		log.Printf("Failed to get challenge %s: %v", req.ChallengeId, err)
+		return nil, status.Errorf(codes.Internal, "failed to get challenge")
+	}
+	if challenge == nil {
+		return &matchmakingpb.RespondChallengeResponse{
+			Success: false,
+			Message: "Challenge not found or expired",
+		}, nil
+	}
+
+	isChallenger := challenge.ChallengerID == req.Player.Id
+	if !isChallenger && challenge.TargetID != req.Player.Id {
+		return &matchmakingpb.RespondChallengeResponse{
+			Success: false,
+			Message: "This challenge was not sent to you",
+		}, nil
+	}
+	if isChallenger && req.Accept {
+		return &matchmakingpb.RespondChallengeResponse{
+			Success: false,
+			Message: "You cannot accept your own challenge",
+		}, nil
+	}
+
+	challenge, err = s.challenges.TakeChallenge(ctx, req.ChallengeId)
+	if err != nil {
+		log.Printf("Failed to take challenge %s: %v", req.ChallengeId, err)
+		return nil, status.Errorf(codes.Internal, "failed to respond to challenge")
+	}
+	if challenge == nil {
+		// Answered by someone else in the meantime
+		return &matchmakingpb.RespondChallengeResponse{
+			Success: false,
+			Message: "Challenge not found or expired",
+		}, nil
+	}
+
+	if !req.Accept {
+		if isChallenger {
+			s.publishChallenge(challenge, challengeStatusWithdrawn)
+			return &matchmakingpb.RespondChallengeResponse{
+				Success: true,
+				Message: "Challenge withdrawn",
+			}, nil
+		}
+
+		s.publishChallenge(challenge, challengeStatusDeclined)
+		return &matchmakingpb.RespondChallengeResponse{
+			Success: true,
+			Message: "Challenge declined",
+		}, nil
+	}
+
+	game, err := s.startChallengeGame(ctx, challenge, req.Player)
+	if err != nil {
+		return &matchmakingpb.RespondChallengeResponse{
+			Success: false,
+			Message: "Failed to create game, please try again",
+		}, nil
+	}
+
+	return &matchmakingpb.RespondChallengeResponse{
+		Success: true,
+		Message: fmt.Sprintf("Challenge from %s accepted", challenge.ChallengerName),
+		GameId:  game.Id,
+	}, nil
+}
+
+// ListChallenges lists the pending challenges sent to a player
+func (s *Server) ListChallenges(ctx context.Context, req *matchmakingpb.ListChallengesRequest) (*matchmakingpb.ListChallengesResponse, error) {
+	if req.PlayerId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player ID is required")
+	}
+
+	// Validate that the authenticated user owns this player ID
+	if err := auth.ValidatePlayerOwnership(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
+	challenges, err := s.challenges.ListChallenges(ctx, req.PlayerId)
+	if err != nil {
+		log.Printf("Failed to list challenges of player %s: %v", req.PlayerId, err)
+		return nil, status.Errorf(codes.Internal, "failed to list challenges")
+	}
+
+	response := &matchmakingpb.ListChallengesResponse{}
+	for _, challenge := range challenges {
+		response.Challenges = append(response.Challenges, challengeToProto(challenge))
+	}
+
+	return response, nil
+}
+
+// CreateInvite creates a short invite code that another player can redeem to start a game
+func (s *Server) CreateInvite(ctx context.Context, req *matchmakingpb.CreateInviteRequest) (*matchmakingpb.CreateInviteResponse, error) {
+	if err := validatePlayer(req.Player); err != nil {
+		return nil, err
+	}
+
+	// Validate that the authenticated user owns this player ID
+	if err := auth.ValidatePlayerOwnership(ctx, req.Player.Id); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; attempt < inviteCodeAttempts; attempt++ {
+		code, err := newInviteCode()
+		if err != nil {
+			log.Printf("Failed to generate invite code: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to create invite")
+		}
+
+		invite := &Challenge{
+			ID:             code,
+			ChallengerID:   req.Player.Id,
+			ChallengerName: req.Player.Name,
+			ExpiresAt:      time.Now().Add(InviteTTL),
+		}
+
+		added, err := s.challenges.AddChallenge(ctx, invite)
+		if err != nil {
+			log.Printf("Failed to store invite of %s: %v", req.Player.Id, err)
+			return nil, status.Errorf(codes.Internal, "failed to create invite")
+		}
+		if !added {
+			continue
+		}
+
+		log.Printf("Player %s created invite %s", req.Player.Id, code)
+
+		return &matchmakingpb.CreateInviteResponse{
+			Success: true,
+			Message: "Invite created",
+			Invite:  challengeToProto(invite),
+		}, nil
+	}
+
+	log.Printf("Failed to find a free invite code after %d attempts", inviteCodeAttempts)
+	return nil, status.Errorf(codes.Internal, "failed to create invite")
+}
+
+// JoinInvite redeems an invite code and starts the game with the player who created it
+func (s *Server) JoinInvite(ctx context.Context, req *matchmakingpb.JoinInviteRequest) (*matchmakingpb.JoinInviteResponse, error) {
+	if err := validatePlayer(req.Player); err != nil {
+		return nil, err
+	}
+
+	code := strings.ToUpper(strings.TrimSpace(req.InviteCode))
+	if code == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "invite code is required")
+	}
+
+	// Validate that the authenticated user owns this player ID
+	if err := auth.ValidatePlayerOwnership(ctx, req.Player.Id); err != nil {
+		return nil, err
+	}
+
+	invite, err := s.challenges.GetChallenge(ctx, code)
+	if err != nil {
+		log.Printf("Failed to get invite %s: %v", code, err)
+		return nil, status.Errorf(codes.Internal, "failed to get invite")
+	}
+	if invite == nil || !invite.IsInvite() {
+		return &matchmakingpb.JoinInviteResponse{
+			Success: false,
+			Message: "Invite code not found or expired",
+		}, nil
+	}
+	if invite.ChallengerID == req.Player.Id {
+		return &matchmakingpb.JoinInviteResponse{
+			Success: false,
+			Message: "You cannot join your own invite",
+		}, nil
+	}
+
+	invite, err = s.challenges.TakeChallenge(ctx, code)
+	if err != nil {
+		log.Printf("Failed to take invite %s: %v", code, err)
+		return nil, status.Errorf(codes.Internal, "failed to join invite")
+	}
+	if invite == nil {
+		// Redeemed by someone else in the meantime
+		return &matchmakingpb.JoinInviteResponse{
+			Success: false,
+			Message: "Invite code not found or expired",
+		}, nil
+	}
+
+	game, err := s.startChallengeGame(ctx, invite, req.Player)
+	if err != nil {
+		return &matchmakingpb.JoinInviteResponse{
+			Success: false,
+			Message: "Failed to create game, please try again",
+		}, nil
+	}
+
+	return &matchmakingpb.JoinInviteResponse{
+		Success: true,
+		Message: fmt.Sprintf("Joined the game of %s", invite.ChallengerName),
+		GameId:  game.Id,
+	}, nil
+}
+
+// startChallengeGame creates the game of an accepted challenge or redeemed invite, with the
+// challenger as player one. When the game cannot be created the challenge is put back so it
+// can be answered again.
+func (s *Server) startChallengeGame(ctx context.Context, challenge *Challenge, opponent *matchmakingpb.Player) (*gamespb.Game, error) {
+	gameResp, err := s.createGame(ctx, &gamespb.CreateGameRequest{
+		Player1Id: challenge.ChallengerID,
+		Player2Id: opponent.Id,
+	})
+	if err != nil {
+		log.Printf("Failed to create game for challenge %s: %v", challenge.ID, err)
+		// Restored even when the caller gave up, so the challenge can be answered again
+		if _, err := s.challenges.AddChallenge(context.WithoutCancel(ctx), challenge); err != nil {
+			log.Printf("Failed to restore challenge %s: %v", challenge.ID, err)
+		}
+		return nil, err
+	}
+
+	log.Printf("Game %s created for challenge %s", gameResp.Game.Id, challenge.ID)
+
+	// Both players learn about the game like about a queue match
+	err = s.eventPublisher.PublishMatchFound(
+		ctx,
+		gameResp.Game.Id,
+		uuid.New().String(),
+		challenge.ChallengerID,
+		challenge.ChallengerName,
+		opponent.Id,
+		opponent.Name,
+	)
+	if err != nil {
+		log.Printf("Failed to publish match found event: %v", err)
+	}
+
+	return gameResp.Game, nil
+}
+
+// processChallengeExpiry expires challenges and invites every second
+func (s *Server) processChallengeExpiry() {
+	ticker := time.NewTicker(1 * time.Second)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case now := <-ticker.C:
+			s.expireChallenges(s.ctx, now)
+		}
+	}
+}
+
+// expireChallenges removes the challenges and invites that ran out of time and tells their players
+func (s *Server) expireChallenges(ctx context.Context, now time.Time) {
+	expired, err := s.challenges.TakeExpiredChallenges(ctx, now)
+	if err != nil {
+		log.Printf("Failed to expire challenges: %v", err)
+		return
+	}
+
+	for _, challenge := range expired {
+		s.publishChallenge(challenge, challengeStatusExpired)
+	}
+}
+
+// publishChallenge tells the players of a challenge about its new status through notifications
+func (s *Server) publishChallenge(challenge *Challenge, challengeStatus string) {
+	err := s.eventPublisher.PublishChallenge(context.Background(), events.ChallengeData{
+		ChallengeID:    challenge.ID,
+		ChallengerID:   challenge.ChallengerID,
+		ChallengerName: challenge.ChallengerName,
+		TargetID:       challenge.TargetID,
+		Status:         challengeStatus,
+		ExpiresAt:      challenge.ExpiresAt.Unix(),
+	})
+	if err != nil {
+		log.Printf("Failed to publish challenge event: %v", err)
+	}
+}
+
+// validatePlayer checks that a request names the player it is made for
+func validatePlayer(player *matchmakingpb.Player) error {
+	if player == nil {
+		return status.Errorf(codes.InvalidArgument, "player is required")
+	}
+
+	if player.Id == "" {
+		return status.Errorf(codes.InvalidArgument, "player ID is required")
+	}
+
+	if player.Name == "" {
+		return status.Errorf(codes.InvalidArgument, "player name is required")
+	}
+
+	return nil
+}
+
+// newInviteCode returns a random invite code
+func newInviteCode() (string, error) {
+	randomBytes := make([]byte, inviteCodeLength)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	code := make([]byte, inviteCodeLength)
+	for i, b := range randomBytes {
+		code[i] = inviteCodeAlphabet[int(b)%len(inviteCodeAlphabet)]
+	}
+	return string(code), nil
+}
+
+func challengeToProto(challenge *Challenge) *matchmakingpb.Challenge {
+	pbChallenge := &matchmakingpb.Challenge{
+		Id: challenge.ID,
+		Challenger: &matchmakingpb.Player{
+			Id:   challenge.ChallengerID,
+			Name: challenge.ChallengerName,
+		},
+		TargetUserId: challenge.TargetID,
+		ExpiresAt:    challenge.ExpiresAt.Unix(),
+	}
+
+	if challenge.IsInvite() {
+		pbChallenge.InviteCode = challenge.ID
+	}
+
+	return pbChallenge
+}
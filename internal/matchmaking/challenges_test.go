@@ -0,0 +1,285 @@
+package matchmaking
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	gamespb "github.com/laerson/mancala/proto/games"
+	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
+)
+
+// Mock challenge store for testing
+type mockChallengeStore struct {
+	mu         sync.Mutex
+	challenges map[string]*Challenge
+}
+
+func newMockChallengeStore() *mockChallengeStore {
+	return &mockChallengeStore{challenges: make(map[string]*Challenge)}
+}
+
+func (m *mockChallengeStore) AddChallenge(ctx context.Context, challenge *Challenge) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.challenges[challenge.ID]; exists {
+		return false, nil
+	}
+	m.challenges[challenge.ID] = challenge
+	return true, nil
+}
+
+func (m *mockChallengeStore) GetChallenge(ctx context.Context, challengeID string) (*Challenge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	challenge, exists := m.challenges[challengeID]
+	if !exists || time.Now().After(challenge.ExpiresAt) {
+		return nil, nil
+	}
+	return challenge, nil
+}
+
+func (m *mockChallengeStore) TakeChallenge(ctx context.Context, challengeID string) (*Challenge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	challenge := m.challenges[challengeID]
+	delete(m.challenges, challengeID)
+	return challenge, nil
+}
+
+func (m *mockChallengeStore) ListChallenges(ctx context.Context, targetID string) ([]*Challenge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var challenges []*Challenge
+	for _, challenge := range m.challenges {
+		if challenge.TargetID == targetID && time.Now().Before(challenge.ExpiresAt) {
+			challenges = append(challenges, challenge)
+		}
+	}
+	return challenges, nil
+}
+
+func (m *mockChallengeStore) TakeExpiredChallenges(ctx context.Context, now time.Time) ([]*Challenge, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var expired []*Challenge
+	for id, challenge := range m.challenges {
+		if challenge.ExpiresAt.Before(now) {
+			expired = append(expired, challenge)
+			delete(m.challenges, id)
+		}
+	}
+	return expired, nil
+}
+
+func newChallengeTestServer(gamesClient *mockGamesClient) (*Server, *mockChallengeStore) {
+	store := newMockChallengeStore()
+	server := NewServer(NewPlayerQueue(), store, gamesClient, nil, nil, nil, &mockEventPublisher{})
+	return server, store
+}
+
+func TestServer_Challenge(t *testing.T) {
+	alice := &matchmakingpb.Player{Id: "alice", Name: "Alice"}
+	bob := &matchmakingpb.Player{Id: "bob", Name: "Bob"}
+	carol := &matchmakingpb.Player{Id: "carol", Name: "Carol"}
+
+	tests := []struct {
+		name        string
+		responder   *matchmakingpb.Player
+		accept      bool
+		wantSuccess bool
+		wantGame    bool
+		wantPending bool
+	}{
+		{
+			name:        "Target accepts",
+			responder:   bob,
+			accept:      true,
+			wantSuccess: true,
+			wantGame:    true,
+		},
+		{
+			name:        "Target declines",
+			responder:   bob,
+			accept:      false,
+			wantSuccess: true,
+		},
+		{
+			name:        "Challenger withdraws",
+			responder:   alice,
+			accept:      false,
+			wantSuccess: true,
+		},
+		{
+			name:        "Challenger cannot accept",
+			responder:   alice,
+			accept:      true,
+			wantSuccess: false,
+			wantPending: true,
+		},
+		{
+			name:        "Another player cannot respond",
+			responder:   carol,
+			accept:      true,
+			wantSuccess: false,
+			wantPending: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var createReq *gamespb.CreateGameRequest
+			server, store := newChallengeTestServer(&mockGamesClient{
+				createGameFunc: func(ctx context.Context, req *gamespb.CreateGameRequest) (*gamespb.CreateGameResponse, error) {
+					createReq = req
+					return &gamespb.CreateGameResponse{Game: &gamespb.Game{Id: "challenge-game"}}, nil
+				},
+			})
+
+			challengeResp, err := server.Challenge(authContext(alice.Id), &matchmakingpb.ChallengeRequest{
+				Challenger:   alice,
+				TargetUserId: bob.Id,
+			})
+			if err != nil || !challengeResp.Success {
+				t.Fatalf("Challenge() = %v, %v, want success", challengeResp, err)
+			}
+
+			incoming, _ := server.ListChallenges(authContext(bob.Id), &matchmakingpb.ListChallengesRequest{PlayerId: bob.Id})
+			if len(incoming.Challenges) != 1 || incoming.Challenges[0].Challenger.Name != "Alice" {
+				t.Errorf("ListChallenges() = %v, want the challenge from Alice", incoming.Challenges)
+			}
+
+			resp, err := server.RespondChallenge(authContext(tt.responder.Id), &matchmakingpb.RespondChallengeRequest{
+				Player:      tt.responder,
+				ChallengeId: challengeResp.Challenge.Id,
+				Accept:      tt.accept,
+			})
+			if err != nil {
+				t.Fatalf("RespondChallenge() error = %v", err)
+			}
+
+			if resp.Success != tt.wantSuccess {
+				t.Errorf("RespondChallenge() success = %v, want %v (%s)", resp.Success, tt.wantSuccess, resp.Message)
+			}
+
+			if gotGame := resp.GameId != ""; gotGame != tt.wantGame {
+				t.Errorf("RespondChallenge() game ID = %q, want game %v", resp.GameId, tt.wantGame)
+			}
+			if tt.wantGame && (createReq.Player1Id != alice.Id || createReq.Player2Id != bob.Id) {
+				t.Errorf("Game created for %s and %s, want alice and bob", createReq.Player1Id, createReq.Player2Id)
+			}
+
+			pending, _ := store.GetChallenge(context.Background(), challengeResp.Challenge.Id)
+			if (pending != nil) != tt.wantPending {
+				t.Errorf("Challenge pending = %v, want %v", pending != nil, tt.wantPending)
+			}
+		})
+	}
+}
+
+func TestServer_Challenge_Self(t *testing.T) {
+	server, _ := newChallengeTestServer(&mockGamesClient{})
+
+	player := &matchmakingpb.Player{Id: "alice", Name: "Alice"}
+	resp, err := server.Challenge(authContext(player.Id), &matchmakingpb.ChallengeRequest{
+		Challenger:   player,
+		TargetUserId: player.Id,
+	})
+	if err != nil {
+		t.Fatalf("Challenge() error = %v", err)
+	}
+	if resp.Success {
+		t.Error("Challenge() of yourself succeeded, want failure")
+	}
+}
+
+func TestServer_Invite(t *testing.T) {
+	alice := &matchmakingpb.Player{Id: "alice", Name: "Alice"}
+	bob := &matchmakingpb.Player{Id: "bob", Name: "Bob"}
+
+	server, _ := newChallengeTestServer(&mockGamesClient{})
+
+	inviteResp, err := server.CreateInvite(authContext(alice.Id), &matchmakingpb.CreateInviteRequest{Player: alice})
+	if err != nil || !inviteResp.Success {
+		t.Fatalf("CreateInvite() = %v, %v, want success", inviteResp, err)
+	}
+
+	code := inviteResp.Invite.InviteCode
+	if len(code) != inviteCodeLength || strings.Trim(code, inviteCodeAlphabet) != "" {
+		t.Errorf("CreateInvite() code = %q, want %d characters of the invite alphabet", code, inviteCodeLength)
+	}
+
+	// The creator cannot join their own invite
+	resp, _ := server.JoinInvite(authContext(alice.Id), &matchmakingpb.JoinInviteRequest{Player: alice, InviteCode: code})
+	if resp.Success {
+		t.Error("JoinInvite() by the creator succeeded, want failure")
+	}
+
+	// Codes are not case sensitive
+	resp, err = server.JoinInvite(authContext(bob.Id), &matchmakingpb.JoinInviteRequest{Player: bob, InviteCode: strings.ToLower(code)})
+	if err != nil || !resp.Success || resp.GameId == "" {
+		t.Fatalf("JoinInvite() = %v, %v, want a game", resp, err)
+	}
+
+	// An invite can only be redeemed once
+	resp, _ = server.JoinInvite(authContext(bob.Id), &matchmakingpb.JoinInviteRequest{Player: bob, InviteCode: code})
+	if resp.Success {
+		t.Error("JoinInvite() of a redeemed code succeeded, want failure")
+	}
+}
+
+func TestServer_JoinInvite_GameCreationFails(t *testing.T) {
+	alice := &matchmakingpb.Player{Id: "alice", Name: "Alice"}
+	bob := &matchmakingpb.Player{Id: "bob", Name: "Bob"}
+
+	server, store := newChallengeTestServer(&mockGamesClient{
+		createGameFunc: func(ctx context.Context, req *gamespb.CreateGameRequest) (*gamespb.CreateGameResponse, error) {
+			return nil, context.DeadlineExceeded
+		},
+	})
+	server.createGameBackoff = time.Millisecond
+
+	inviteResp, _ := server.CreateInvite(authContext(alice.Id), &matchmakingpb.CreateInviteRequest{Player: alice})
+
+	resp, err := server.JoinInvite(authContext(bob.Id), &matchmakingpb.JoinInviteRequest{Player: bob, InviteCode: inviteResp.Invite.InviteCode})
+	if err != nil || resp.Success {
+		t.Fatalf("JoinInvite() = %v, %v, want failure", resp, err)
+	}
+
+	// The invite stays open for another try
+	if invite, _ := store.GetChallenge(context.Background(), inviteResp.Invite.InviteCode); invite == nil {
+		t.Error("Invite removed after the game could not be created, want it kept")
+	}
+}
+
+func TestServer_ExpireChallenges(t *testing.T) {
+	server, store := newChallengeTestServer(&mockGamesClient{})
+
+	store.AddChallenge(context.Background(), &Challenge{ID: "expired", ChallengerID: "alice", TargetID: "bob", ExpiresAt: time.Now().Add(-time.Second)})
+	store.AddChallenge(context.Background(), &Challenge{ID: "pending", ChallengerID: "alice", TargetID: "bob", ExpiresAt: time.Now().Add(time.Minute)})
+
+	server.expireChallenges(context.Background(), time.Now())
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	if _, exists := store.challenges["expired"]; exists {
+		t.Error("Expired challenge still stored")
+	}
+	if _, exists := store.challenges["pending"]; !exists {
+		t.Error("Pending challenge removed")
+	}
+
+	publisher := server.eventPublisher.(*mockEventPublisher)
+	publisher.mu.Lock()
+	defer publisher.mu.Unlock()
+	if len(publisher.challenges) != 1 || publisher.challenges[0].ChallengeID != "expired" || publisher.challenges[0].Status != challengeStatusExpired {
+		t.Errorf("Published challenges %+v, want the expired one", publisher.challenges)
+	}
+}
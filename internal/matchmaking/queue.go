@@ -1,19 +1,54 @@
 package matchmaking
 
 import (
+	"context"
+	"math"
 	"sync"
 	"time"
 
 	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
 )
 
+const (
+	// InitialRatingWindow is the largest rating gap accepted for a player who just joined the queue
+	InitialRatingWindow = 100.0
+
+	// RatingWindowGrowth is how far the accepted rating gap widens per second in the queue
+	RatingWindowGrowth = 10.0
+)
+
 type QueuedPlayer struct {
 	Player    *matchmakingpb.Player
 	QueueID   string
 	QueueTime time.Time
+	Rating    float64
 	Stream    matchmakingpb.Matchmaking_StreamUpdatesServer // Optional streaming connection
 }
 
+// RatingWindow returns the largest rating gap the player accepts at the given moment
+func (qp *QueuedPlayer) RatingWindow(now time.Time) float64 {
+	return InitialRatingWindow + now.Sub(qp.QueueTime).Seconds()*RatingWindowGrowth
+}
+
+// Queue holds the players waiting for an opponent
+type Queue interface {
+	// Enqueue adds the player at the back of the queue, replacing an earlier entry of the same player
+	Enqueue(ctx context.Context, player *matchmakingpb.Player, queueID string, rating float64) error
+	// RemovePlayer takes the player out of the queue and reports whether they were queued
+	RemovePlayer(ctx context.Context, playerID string) (bool, error)
+	// GetPlayerStatus returns nil and position -1 when the player is not queued
+	GetPlayerStatus(ctx context.Context, playerID string) (*QueuedPlayer, int32, error)
+	// TryMatchPlayers removes and returns two players who can be paired, or nils when there are none
+	TryMatchPlayers(ctx context.Context) (*QueuedPlayer, *QueuedPlayer, error)
+	// Requeue puts matched players back at the head of the queue, in the given order and with their
+	// original queue time. Players who joined the queue again in the meantime keep their new entry.
+	Requeue(ctx context.Context, players ...*QueuedPlayer) error
+	// SetPlayerStream attaches the update stream of a queued player
+	SetPlayerStream(ctx context.Context, playerID string, stream matchmakingpb.Matchmaking_StreamUpdatesServer) error
+	GetQueueLength(ctx context.Context) (int, error)
+}
+
+// PlayerQueue is an in-memory queue. It is lost on restart and cannot be shared between replicas.
 type PlayerQueue struct {
 	mu      sync.Mutex
 	players map[string]*QueuedPlayer // key: player_id
@@ -27,33 +62,45 @@ func NewPlayerQueue() *PlayerQueue {
 	}
 }
 
-func (pq *PlayerQueue) Enqueue(player *matchmakingpb.Player, queueID string) {
+func (pq *PlayerQueue) Enqueue(ctx context.Context, player *matchmakingpb.Player, queueID string, rating float64) error {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
 	// Remove player if already queued
-	pq.removePlayerLocked(player.Id)
+	if queuedPlayer := pq.removePlayerLocked(player.Id); queuedPlayer != nil {
+		notifyQueueCancelled(queuedPlayer)
+	}
 
 	queuedPlayer := &QueuedPlayer{
 		Player:    player,
 		QueueID:   queueID,
 		QueueTime: time.Now(),
+		Rating:    rating,
 	}
 
 	pq.players[player.Id] = queuedPlayer
 	pq.queue = append(pq.queue, queuedPlayer)
+	return nil
 }
 
-func (pq *PlayerQueue) RemovePlayer(playerID string) bool {
+func (pq *PlayerQueue) RemovePlayer(ctx context.Context, playerID string) (bool, error) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
-	return pq.removePlayerLocked(playerID)
+
+	queuedPlayer := pq.removePlayerLocked(playerID)
+	if queuedPlayer == nil {
+		return false, nil
+	}
+
+	notifyQueueCancelled(queuedPlayer)
+	return true, nil
 }
 
-func (pq *PlayerQueue) removePlayerLocked(playerID string) bool {
+// removePlayerLocked takes the player out of the queue and returns their entry, or nil if they were not queued
+func (pq *PlayerQueue) removePlayerLocked(playerID string) *QueuedPlayer {
 	queuedPlayer, exists := pq.players[playerID]
 	if !exists {
-		return false
+		return nil
 	}
 
 	// Remove from map
@@ -67,29 +114,16 @@ func (pq *PlayerQueue) removePlayerLocked(playerID string) bool {
 		}
 	}
 
-	// Notify via stream if connected
-	if queuedPlayer.Stream != nil {
-		queuedPlayer.Stream.Send(&matchmakingpb.MatchmakingUpdate{
-			QueueId: queuedPlayer.QueueID,
-			Status:  matchmakingpb.QueueStatus_CANCELLED,
-			Update: &matchmakingpb.MatchmakingUpdate_QueueCancelled{
-				QueueCancelled: &matchmakingpb.QueueCancelled{
-					Reason: "Queue cancelled by player",
-				},
-			},
-		})
-	}
-
-	return true
+	return queuedPlayer
 }
 
-func (pq *PlayerQueue) GetPlayerStatus(playerID string) (*QueuedPlayer, int32) {
+func (pq *PlayerQueue) GetPlayerStatus(ctx context.Context, playerID string) (*QueuedPlayer, int32, error) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
 	queuedPlayer, exists := pq.players[playerID]
 	if !exists {
-		return nil, -1
+		return nil, -1, nil
 	}
 
 	// Find position in queue
@@ -101,39 +135,87 @@ func (pq *PlayerQueue) GetPlayerStatus(playerID string) (*QueuedPlayer, int32) {
 		}
 	}
 
-	return queuedPlayer, position
+	return queuedPlayer, position, nil
 }
 
-func (pq *PlayerQueue) TryMatchPlayers() (*QueuedPlayer, *QueuedPlayer) {
+// TryMatchPlayers pairs the longest waiting player with the closest rated opponent
+// whose rating gap is within the window of either of them
+func (pq *PlayerQueue) TryMatchPlayers(ctx context.Context) (*QueuedPlayer, *QueuedPlayer, error) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
-	if len(pq.queue) < 2 {
-		return nil, nil
+	now := time.Now()
+	for i, player1 := range pq.queue {
+		var player2 *QueuedPlayer
+		bestGap := math.Inf(1)
+
+		for _, candidate := range pq.queue[i+1:] {
+			gap := math.Abs(player1.Rating - candidate.Rating)
+			window := math.Max(player1.RatingWindow(now), candidate.RatingWindow(now))
+			if gap <= window && gap < bestGap {
+				player2 = candidate
+				bestGap = gap
+			}
+		}
+
+		if player2 != nil {
+			// Remove both players from queue
+			pq.removePlayerLocked(player1.Player.Id)
+			pq.removePlayerLocked(player2.Player.Id)
+
+			return player1, player2, nil
+		}
 	}
 
-	// Take first two players
-	player1 := pq.queue[0]
-	player2 := pq.queue[1]
+	return nil, nil, nil
+}
+
+func (pq *PlayerQueue) Requeue(ctx context.Context, players ...*QueuedPlayer) error {
+	pq.mu.Lock()
+	defer pq.mu.Unlock()
 
-	// Remove both players from queue
-	pq.removePlayerLocked(player1.Player.Id)
-	pq.removePlayerLocked(player2.Player.Id)
+	// Go backwards so the first player ends up first
+	for i := len(players) - 1; i >= 0; i-- {
+		player := players[i]
+		if _, exists := pq.players[player.Player.Id]; exists {
+			continue
+		}
 
-	return player1, player2
+		pq.players[player.Player.Id] = player
+		pq.queue = append([]*QueuedPlayer{player}, pq.queue...)
+	}
+	return nil
 }
 
-func (pq *PlayerQueue) SetPlayerStream(playerID string, stream matchmakingpb.Matchmaking_StreamUpdatesServer) {
+func (pq *PlayerQueue) SetPlayerStream(ctx context.Context, playerID string, stream matchmakingpb.Matchmaking_StreamUpdatesServer) error {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
 
 	if queuedPlayer, exists := pq.players[playerID]; exists {
 		queuedPlayer.Stream = stream
 	}
+	return nil
 }
 
-func (pq *PlayerQueue) GetQueueLength() int {
+func (pq *PlayerQueue) GetQueueLength(ctx context.Context) (int, error) {
 	pq.mu.Lock()
 	defer pq.mu.Unlock()
-	return len(pq.queue)
+	return len(pq.queue), nil
+}
+
+// notifyQueueCancelled tells a removed player over their stream, if connected, that they left the queue
+func notifyQueueCancelled(queuedPlayer *QueuedPlayer) {
+	if queuedPlayer.Stream == nil {
+		return
+	}
+
+	queuedPlayer.Stream.Send(&matchmakingpb.MatchmakingUpdate{
+		QueueId: queuedPlayer.QueueID,
+		Status:  matchmakingpb.QueueStatus_CANCELLED,
+		Update: &matchmakingpb.MatchmakingUpdate_QueueCancelled{
+			QueueCancelled: &matchmakingpb.QueueCancelled{
+				Reason: "Queue cancelled by player",
+			},
+		},
+	})
 }
@@ -1,10 +1,12 @@
 package matchmaking
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/laerson/mancala/internal/auth"
 	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
 )
 
@@ -16,13 +18,13 @@ func TestPlayerQueue_Enqueue(t *testing.T) {
 	}
 	queueID := "queue123"
 
-	queue.Enqueue(player, queueID)
+	queue.Enqueue(context.Background(), player, queueID, auth.DefaultRating)
 
-	if queue.GetQueueLength() != 1 {
-		t.Errorf("Expected queue length 1, got %d", queue.GetQueueLength())
+	if length, _ := queue.GetQueueLength(context.Background()); length != 1 {
+		t.Errorf("Expected queue length 1, got %d", length)
 	}
 
-	queuedPlayer, position := queue.GetPlayerStatus("player1")
+	queuedPlayer, position, _ := queue.GetPlayerStatus(context.Background(), "player1")
 	if queuedPlayer == nil {
 		t.Fatal("Expected player to be in queue")
 	}
@@ -44,15 +46,15 @@ func TestPlayerQueue_EnqueueDuplicate(t *testing.T) {
 	}
 
 	// Enqueue the same player twice
-	queue.Enqueue(player, "queue1")
-	queue.Enqueue(player, "queue2")
+	queue.Enqueue(context.Background(), player, "queue1", auth.DefaultRating)
+	queue.Enqueue(context.Background(), player, "queue2", auth.DefaultRating)
 
 	// Should only have one entry
-	if queue.GetQueueLength() != 1 {
-		t.Errorf("Expected queue length 1 after duplicate enqueue, got %d", queue.GetQueueLength())
+	if length, _ := queue.GetQueueLength(context.Background()); length != 1 {
+		t.Errorf("Expected queue length 1 after duplicate enqueue, got %d", length)
 	}
 
-	queuedPlayer, _ := queue.GetPlayerStatus("player1")
+	queuedPlayer, _, _ := queue.GetPlayerStatus(context.Background(), "player1")
 	if queuedPlayer == nil {
 		t.Fatal("Expected player to be in queue")
 	}
@@ -70,22 +72,22 @@ func TestPlayerQueue_RemovePlayer(t *testing.T) {
 		Name: "Alice",
 	}
 
-	queue.Enqueue(player, "queue1")
+	queue.Enqueue(context.Background(), player, "queue1", auth.DefaultRating)
 
-	if queue.GetQueueLength() != 1 {
-		t.Errorf("Expected queue length 1, got %d", queue.GetQueueLength())
+	if length, _ := queue.GetQueueLength(context.Background()); length != 1 {
+		t.Errorf("Expected queue length 1, got %d", length)
 	}
 
-	removed := queue.RemovePlayer("player1")
+	removed, _ := queue.RemovePlayer(context.Background(), "player1")
 	if !removed {
 		t.Error("Expected player to be removed")
 	}
 
-	if queue.GetQueueLength() != 0 {
-		t.Errorf("Expected queue length 0 after removal, got %d", queue.GetQueueLength())
+	if length, _ := queue.GetQueueLength(context.Background()); length != 0 {
+		t.Errorf("Expected queue length 0 after removal, got %d", length)
 	}
 
-	queuedPlayer, position := queue.GetPlayerStatus("player1")
+	queuedPlayer, position, _ := queue.GetPlayerStatus(context.Background(), "player1")
 	if queuedPlayer != nil {
 		t.Error("Expected player to not be in queue after removal")
 	}
@@ -97,7 +99,7 @@ func TestPlayerQueue_RemovePlayer(t *testing.T) {
 func TestPlayerQueue_RemoveNonExistentPlayer(t *testing.T) {
 	queue := NewPlayerQueue()
 
-	removed := queue.RemovePlayer("nonexistent")
+	removed, _ := queue.RemovePlayer(context.Background(), "nonexistent")
 	if removed {
 		t.Error("Expected removal of non-existent player to return false")
 	}
@@ -107,21 +109,21 @@ func TestPlayerQueue_TryMatchPlayers(t *testing.T) {
 	queue := NewPlayerQueue()
 
 	// Test with empty queue
-	player1, player2 := queue.TryMatchPlayers()
+	player1, player2, _ := queue.TryMatchPlayers(context.Background())
 	if player1 != nil || player2 != nil {
 		t.Error("Expected no match with empty queue")
 	}
 
 	// Test with one player
-	queue.Enqueue(&matchmakingpb.Player{Id: "player1", Name: "Alice"}, "queue1")
-	player1, player2 = queue.TryMatchPlayers()
+	queue.Enqueue(context.Background(), &matchmakingpb.Player{Id: "player1", Name: "Alice"}, "queue1", auth.DefaultRating)
+	player1, player2, _ = queue.TryMatchPlayers(context.Background())
 	if player1 != nil || player2 != nil {
 		t.Error("Expected no match with only one player")
 	}
 
 	// Test with two players
-	queue.Enqueue(&matchmakingpb.Player{Id: "player2", Name: "Bob"}, "queue2")
-	player1, player2 = queue.TryMatchPlayers()
+	queue.Enqueue(context.Background(), &matchmakingpb.Player{Id: "player2", Name: "Bob"}, "queue2", auth.DefaultRating)
+	player1, player2, _ = queue.TryMatchPlayers(context.Background())
 
 	if player1 == nil || player2 == nil {
 		t.Fatal("Expected match with two players")
@@ -136,8 +138,52 @@ func TestPlayerQueue_TryMatchPlayers(t *testing.T) {
 	}
 
 	// Queue should be empty after matching
-	if queue.GetQueueLength() != 0 {
-		t.Errorf("Expected empty queue after matching, got length %d", queue.GetQueueLength())
+	if length, _ := queue.GetQueueLength(context.Background()); length != 0 {
+		t.Errorf("Expected empty queue after matching, got length %d", length)
+	}
+}
+
+func TestPlayerQueue_Requeue(t *testing.T) {
+	ctx := context.Background()
+	queue := NewPlayerQueue()
+
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player1", Name: "Alice"}, "queue1", auth.DefaultRating)
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player2", Name: "Bob"}, "queue2", auth.DefaultRating)
+	player1, player2, _ := queue.TryMatchPlayers(ctx)
+	if player1 == nil || player2 == nil {
+		t.Fatal("Expected match with two players")
+	}
+
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player3", Name: "Charlie"}, "queue3", auth.DefaultRating)
+
+	if err := queue.Requeue(ctx, player1, player2); err != nil {
+		t.Fatalf("Requeue() error = %v", err)
+	}
+
+	// The requeued players go ahead of the player who joined while they were matched
+	for i, want := range []*QueuedPlayer{player1, player2} {
+		queuedPlayer, position, _ := queue.GetPlayerStatus(ctx, want.Player.Id)
+		if position != int32(i+1) {
+			t.Errorf("Requeued %s at position %d, want %d", want.Player.Id, position, i+1)
+		}
+		if queuedPlayer == nil || !queuedPlayer.QueueTime.Equal(want.QueueTime) || queuedPlayer.QueueID != want.QueueID {
+			t.Errorf("Requeued %s = %v, want the original entry", want.Player.Id, queuedPlayer)
+		}
+	}
+	if _, position, _ := queue.GetPlayerStatus(ctx, "player3"); position != 3 {
+		t.Errorf("player3 at position %d, want 3", position)
+	}
+
+	// A player who joined the queue again keeps the new entry
+	player1, player2, _ = queue.TryMatchPlayers(ctx)
+	queue.Enqueue(ctx, player1.Player, "queue4", auth.DefaultRating)
+	queue.Requeue(ctx, player1, player2)
+
+	if queuedPlayer, _, _ := queue.GetPlayerStatus(ctx, player1.Player.Id); queuedPlayer.QueueID != "queue4" {
+		t.Errorf("Requeue() replaced the new entry of %s, queue ID = %s", player1.Player.Id, queuedPlayer.QueueID)
+	}
+	if length, _ := queue.GetQueueLength(ctx); length != 3 {
+		t.Errorf("GetQueueLength() = %d, want 3", length)
 	}
 }
 
@@ -152,12 +198,12 @@ func TestPlayerQueue_QueueOrder(t *testing.T) {
 	}
 
 	for i, player := range players {
-		queue.Enqueue(player, fmt.Sprintf("queue%d", i+1))
+		queue.Enqueue(context.Background(), player, fmt.Sprintf("queue%d", i+1), auth.DefaultRating)
 	}
 
 	// Check positions
 	for i, player := range players {
-		_, position := queue.GetPlayerStatus(player.Id)
+		_, position, _ := queue.GetPlayerStatus(context.Background(), player.Id)
 		expectedPosition := int32(i + 1)
 		if position != expectedPosition {
 			t.Errorf("Expected position %d for %s, got %d", expectedPosition, player.Id, position)
@@ -165,13 +211,13 @@ func TestPlayerQueue_QueueOrder(t *testing.T) {
 	}
 
 	// Match first two players
-	player1, player2 := queue.TryMatchPlayers()
+	player1, player2, _ := queue.TryMatchPlayers(context.Background())
 	if player1.Player.Id != "player1" || player2.Player.Id != "player2" {
 		t.Error("Expected to match first two players in queue order")
 	}
 
 	// Player3 should now be at position 1
-	_, position := queue.GetPlayerStatus("player3")
+	_, position, _ := queue.GetPlayerStatus(context.Background(), "player3")
 	if position != 1 {
 		t.Errorf("Expected player3 to be at position 1 after match, got %d", position)
 	}
@@ -185,10 +231,10 @@ func TestPlayerQueue_QueueTime(t *testing.T) {
 	}
 
 	beforeEnqueue := time.Now()
-	queue.Enqueue(player, "queue1")
+	queue.Enqueue(context.Background(), player, "queue1", auth.DefaultRating)
 	afterEnqueue := time.Now()
 
-	queuedPlayer, _ := queue.GetPlayerStatus("player1")
+	queuedPlayer, _, _ := queue.GetPlayerStatus(context.Background(), "player1")
 	if queuedPlayer == nil {
 		t.Fatal("Expected player to be in queue")
 	}
@@ -210,7 +256,7 @@ func TestPlayerQueue_ConcurrentAccess(t *testing.T) {
 				Id:   fmt.Sprintf("player%d", i),
 				Name: fmt.Sprintf("Player%d", i),
 			}
-			queue.Enqueue(player, fmt.Sprintf("queue%d", i))
+			queue.Enqueue(context.Background(), player, fmt.Sprintf("queue%d", i), auth.DefaultRating)
 		}
 		done <- true
 	}()
@@ -218,7 +264,7 @@ func TestPlayerQueue_ConcurrentAccess(t *testing.T) {
 	// Simulate concurrent matching
 	go func() {
 		for i := 0; i < 50; i++ {
-			queue.TryMatchPlayers()
+			queue.TryMatchPlayers(context.Background())
 			time.Sleep(time.Millisecond)
 		}
 		done <- true
@@ -229,8 +275,42 @@ func TestPlayerQueue_ConcurrentAccess(t *testing.T) {
 	<-done
 
 	// Queue should be consistent (no crashes/panics)
-	length := queue.GetQueueLength()
+	length, _ := queue.GetQueueLength(context.Background())
 	if length < 0 || length > 100 {
 		t.Errorf("Unexpected queue length after concurrent access: %d", length)
 	}
 }
+
+func TestPlayerQueue_TryMatchPlayers_RatingWindow(t *testing.T) {
+	queue := NewPlayerQueue()
+	queue.Enqueue(context.Background(), &matchmakingpb.Player{Id: "player1", Name: "Alice"}, "queue1", 1500)
+	queue.Enqueue(context.Background(), &matchmakingpb.Player{Id: "player2", Name: "Bob"}, "queue2", 1900)
+	queue.Enqueue(context.Background(), &matchmakingpb.Player{Id: "player3", Name: "Charlie"}, "queue3", 1560)
+
+	// The closest rated opponent is chosen over the next in line
+	player1, player2, _ := queue.TryMatchPlayers(context.Background())
+	if player1 == nil || player2 == nil {
+		t.Fatal("Expected a match between close ratings")
+	}
+	if player1.Player.Id != "player1" || player2.Player.Id != "player3" {
+		t.Errorf("Expected player1 to be matched with player3, got %s and %s", player1.Player.Id, player2.Player.Id)
+	}
+
+	// A gap of 400 is too wide for players who just joined
+	queue.Enqueue(context.Background(), &matchmakingpb.Player{Id: "player4", Name: "Dave"}, "queue4", 1500)
+	if player1, player2, _ := queue.TryMatchPlayers(context.Background()); player1 != nil || player2 != nil {
+		t.Fatalf("Expected no match for a wide rating gap, got %s and %s", player1.Player.Id, player2.Player.Id)
+	}
+
+	// The window widens with the time spent in the queue
+	waiting, _, _ := queue.GetPlayerStatus(context.Background(), "player2")
+	waiting.QueueTime = time.Now().Add(-35 * time.Second)
+
+	player1, player2, _ = queue.TryMatchPlayers(context.Background())
+	if player1 == nil || player2 == nil {
+		t.Fatal("Expected a match once the rating window has widened")
+	}
+	if player1.Player.Id != "player2" || player2.Player.Id != "player4" {
+		t.Errorf("Expected player2 to be matched with player4, got %s and %s", player1.Player.Id, player2.Player.Id)
+	}
+}
@@ -0,0 +1,200 @@
+package matchmaking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+const (
+	// challengesKey is a sorted set of challenge IDs scored by their expiry in Unix milliseconds
+	challengesKey = "matchmaking:challenges"
+
+	// challengeKeyPrefix prefixes the key holding the JSON record of a challenge
+	challengeKeyPrefix = "matchmaking:challenge:"
+
+	// incomingChallengesKeyPrefix prefixes the set of challenge IDs sent to a player
+	incomingChallengesKeyPrefix = "matchmaking:challenges:to:"
+
+	// challengeKeyGrace keeps a record around after it expires, so the sweeper can still tell its players
+	challengeKeyGrace = time.Minute
+)
+
+// challengeRecord is a challenge as stored in Redis
+type challengeRecord struct {
+	ID             string `json:"id"`
+	ChallengerID   string `json:"challenger_id"`
+	ChallengerName string `json:"challenger_name"`
+	TargetID       string `json:"target_id,omitempty"`
+	ExpiresAtMs    int64  `json:"expires_at_ms"`
+}
+
+// RedisChallengeStore stores challenges and invites in Redis, so every matchmaking replica can answer them
+type RedisChallengeStore struct {
+	redisClient *redis.Client
+}
+
+// NewRedisChallengeStore creates a new Redis backed challenge store
+func NewRedisChallengeStore(redisAddr string) *RedisChallengeStore {
+	return &RedisChallengeStore{
+		redisClient: redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		}),
+	}
+}
+
+func (r *RedisChallengeStore) AddChallenge(ctx context.Context, challenge *Challenge) (bool, error) {
+	record, err := json.Marshal(challengeRecord{
+		ID:             challenge.ID,
+		ChallengerID:   challenge.ChallengerID,
+		ChallengerName: challenge.ChallengerName,
+		TargetID:       challenge.TargetID,
+		ExpiresAtMs:    challenge.ExpiresAt.UnixMilli(),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal challenge: %w", err)
+	}
+
+	ttl := time.Until(challenge.ExpiresAt) + challengeKeyGrace
+	added, err := r.redisClient.SetNX(ctx, challengeKey(challenge.ID), record, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to store challenge: %w", err)
+	}
+	if !added {
+		return false, nil
+	}
+
+	pipe := r.redisClient.TxPipeline()
+	pipe.ZAdd(ctx, challengesKey, &redis.Z{Score: float64(challenge.ExpiresAt.UnixMilli()), Member: challenge.ID})
+	if !challenge.IsInvite() {
+		pipe.SAdd(ctx, incomingChallengesKey(challenge.TargetID), challenge.ID)
+		pipe.Expire(ctx, incomingChallengesKey(challenge.TargetID), ttl)
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return false, fmt.Errorf("failed to index challenge: %w", err)
+	}
+
+	return true, nil
+}
+
+func (r *RedisChallengeStore) GetChallenge(ctx context.Context, challengeID string) (*Challenge, error) {
+	record, err := r.redisClient.Get(ctx, challengeKey(challengeID)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get challenge: %w", err)
+	}
+
+	challenge, err := decodeChallenge(record)
+	if err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(challenge.ExpiresAt) {
+		return nil, nil
+	}
+
+	return challenge, nil
+}
+
+func (r *RedisChallengeStore) TakeChallenge(ctx context.Context, challengeID string) (*Challenge, error) {
+	// Reading and deleting in one transaction hands the record to a single caller
+	pipe := r.redisClient.TxPipeline()
+	record := pipe.Get(ctx, challengeKey(challengeID))
+	pipe.Del(ctx, challengeKey(challengeID))
+	pipe.ZRem(ctx, challengesKey, challengeID)
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to take challenge: %w", err)
+	}
+
+	if record.Err() == redis.Nil {
+		return nil, nil
+	}
+
+	challenge, err := decodeChallenge(record.Val())
+	if err != nil {
+		return nil, err
+	}
+
+	if !challenge.IsInvite() {
+		if err := r.redisClient.SRem(ctx, incomingChallengesKey(challenge.TargetID), challengeID).Err(); err != nil {
+			return nil, fmt.Errorf("failed to remove challenge from player: %w", err)
+		}
+	}
+
+	return challenge, nil
+}
+
+func (r *RedisChallengeStore) ListChallenges(ctx context.Context, targetID string) ([]*Challenge, error) {
+	challengeIDs, err := r.redisClient.SMembers(ctx, incomingChallengesKey(targetID)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list challenges: %w", err)
+	}
+
+	var challenges []*Challenge
+	for _, challengeID := range challengeIDs {
+		challenge, err := r.GetChallenge(ctx, challengeID)
+		if err != nil {
+			return nil, err
+		}
+		if challenge != nil {
+			challenges = append(challenges, challenge)
+		}
+	}
+
+	return challenges, nil
+}
+
+func (r *RedisChallengeStore) TakeExpiredChallenges(ctx context.Context, now time.Time) ([]*Challenge, error) {
+	challengeIDs, err := r.redisClient.ZRangeByScore(ctx, challengesKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.UnixMilli(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list expired challenges: %w", err)
+	}
+
+	// Replicas sweep concurrently; whoever takes a challenge reports it
+	var expired []*Challenge
+	for _, challengeID := range challengeIDs {
+		challenge, err := r.TakeChallenge(ctx, challengeID)
+		if err != nil {
+			return expired, err
+		}
+		if challenge != nil {
+			expired = append(expired, challenge)
+		}
+	}
+
+	return expired, nil
+}
+
+func decodeChallenge(recordJSON string) (*Challenge, error) {
+	var record challengeRecord
+	if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal challenge: %w", err)
+	}
+
+	return &Challenge{
+		ID:             record.ID,
+		ChallengerID:   record.ChallengerID,
+		ChallengerName: record.ChallengerName,
+		TargetID:       record.TargetID,
+		ExpiresAt:      time.UnixMilli(record.ExpiresAtMs),
+	}, nil
+}
+
+func challengeKey(challengeID string) string {
+	return challengeKeyPrefix + challengeID
+}
+
+func incomingChallengesKey(targetID string) string {
+	return incomingChallengesKeyPrefix + targetID
+}
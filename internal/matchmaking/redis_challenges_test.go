@@ -0,0 +1,50 @@
+package matchmaking
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRedisChallengeStore(t *testing.T) {
+	store := NewRedisChallengeStore(startRedis(t))
+	ctx := context.Background()
+
+	challenge := &Challenge{ID: "challenge1", ChallengerID: "alice", ChallengerName: "Alice", TargetID: "bob", ExpiresAt: time.Now().Add(time.Minute)}
+	invite := &Challenge{ID: "ABC234", ChallengerID: "alice", ChallengerName: "Alice", ExpiresAt: time.Now().Add(time.Minute)}
+
+	for _, c := range []*Challenge{challenge, invite} {
+		if added, err := store.AddChallenge(ctx, c); err != nil || !added {
+			t.Fatalf("AddChallenge(%s) = %v, %v, want added", c.ID, added, err)
+		}
+	}
+	if added, _ := store.AddChallenge(ctx, invite); added {
+		t.Error("AddChallenge() with a taken ID = true, want false")
+	}
+
+	incoming, err := store.ListChallenges(ctx, "bob")
+	if err != nil || len(incoming) != 1 || incoming[0].ChallengerName != "Alice" {
+		t.Errorf("ListChallenges() = %v, %v, want the challenge from Alice", incoming, err)
+	}
+
+	got, err := store.GetChallenge(ctx, "ABC234")
+	if err != nil || got == nil || !got.IsInvite() {
+		t.Errorf("GetChallenge() = %v, %v, want the invite", got, err)
+	}
+
+	// Only the first taker gets the challenge
+	if taken, _ := store.TakeChallenge(ctx, "challenge1"); taken == nil || taken.TargetID != "bob" {
+		t.Errorf("TakeChallenge() = %v, want the challenge", taken)
+	}
+	if taken, _ := store.TakeChallenge(ctx, "challenge1"); taken != nil {
+		t.Errorf("TakeChallenge() again = %v, want nil", taken)
+	}
+	if incoming, _ := store.ListChallenges(ctx, "bob"); len(incoming) != 0 {
+		t.Errorf("ListChallenges() after take = %v, want none", incoming)
+	}
+
+	expired, err := store.TakeExpiredChallenges(ctx, time.Now().Add(2*time.Minute))
+	if err != nil || len(expired) != 1 || expired[0].ID != "ABC234" {
+		t.Errorf("TakeExpiredChallenges() = %v, %v, want the invite", expired, err)
+	}
+}
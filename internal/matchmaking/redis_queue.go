@@ -0,0 +1,325 @@
+package matchmaking
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+
+	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
+)
+
+// The scripts below touch both keys, so they share a hash tag and land in the
+// same slot of a Redis cluster.
+const (
+	// queueKey is a sorted set of queued player IDs scored by their queue time in Unix milliseconds.
+	// Requeued players are scored ahead of the head of the queue instead.
+	queueKey = "{matchmaking}:queue"
+
+	// queuedPlayersKey is a hash of the JSON records of queued players, keyed by player ID
+	queuedPlayersKey = "{matchmaking}:players"
+)
+
+// matchPlayersScript atomically finds the pair TryMatchPlayers describes, removes both
+// players from the queue and returns their records, so replicas never match a player twice.
+//
+// KEYS[1]: queue sorted set; KEYS[2]: player records hash; ARGV[1]: now in Unix milliseconds;
+// ARGV[2]: initial rating window; ARGV[3]: rating window growth per second
+var matchPlayersScript = redis.NewScript(`
+local ids = redis.call('ZRANGE', KEYS[1], 0, -1)
+local players = {}
+for _, id in ipairs(ids) do
+	local record = redis.call('HGET', KEYS[2], id)
+	if record then
+		local player = cjson.decode(record)
+		local waited = (tonumber(ARGV[1]) - player.queue_time_ms) / 1000
+		table.insert(players, {
+			id = id,
+			record = record,
+			rating = player.rating,
+			window = tonumber(ARGV[2]) + waited * tonumber(ARGV[3]),
+		})
+	else
+		redis.call('ZREM', KEYS[1], id)
+	end
+end
+
+for i = 1, #players do
+	local best, bestGap = nil, math.huge
+	for j = i + 1, #players do
+		local gap = math.abs(players[i].rating - players[j].rating)
+		if gap <= math.max(players[i].window, players[j].window) and gap < bestGap then
+			best, bestGap = j, gap
+		end
+	end
+
+	if best then
+		local pair = {players[i], players[best]}
+		for _, player in ipairs(pair) do
+			redis.call('ZREM', KEYS[1], player.id)
+			redis.call('HDEL', KEYS[2], player.id)
+		end
+		return {pair[1].record, pair[2].record}
+	end
+end
+
+return {}
+`)
+
+// requeuePlayersScript puts players back at the head of the queue unless they have joined it again.
+// Their score is their queue time, or just below the current head when someone else joined earlier.
+//
+// KEYS[1]: queue sorted set; KEYS[2]: player records hash; ARGV: for every player, from last to
+// first, its ID, record and queue time in Unix milliseconds. Returns the IDs of the requeued players.
+var requeuePlayersScript = redis.NewScript(`
+local head = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local headScore = head[2] and tonumber(head[2])
+local requeued = {}
+
+for i = 1, #ARGV, 3 do
+	local id, record, score = ARGV[i], ARGV[i + 1], tonumber(ARGV[i + 2])
+	if redis.call('HEXISTS', KEYS[2], id) == 0 then
+		if headScore and score >= headScore then
+			score = headScore - 1
+		end
+		redis.call('HSET', KEYS[2], id, record)
+		redis.call('ZADD', KEYS[1], score, id)
+		headScore = score
+		table.insert(requeued, id)
+	end
+end
+
+return requeued
+`)
+
+// queuedPlayerRecord is a queued player as stored in Redis
+type queuedPlayerRecord struct {
+	ID          string  `json:"id"`
+	Name        string  `json:"name"`
+	QueueID     string  `json:"queue_id"`
+	QueueTimeMs int64   `json:"queue_time_ms"`
+	Rating      float64 `json:"rating"`
+}
+
+// RedisQueue stores the queue in Redis, so it survives restarts and is shared by every
+// matchmaking replica. Update streams cannot be shared: each replica only knows the
+// streams of the players connected to it.
+type RedisQueue struct {
+	redisClient *redis.Client
+	mu          sync.Mutex
+	streams     map[string]matchmakingpb.Matchmaking_StreamUpdatesServer // key: player_id
+}
+
+// NewRedisQueue creates a new Redis backed matchmaking queue
+func NewRedisQueue(redisAddr string) *RedisQueue {
+	return &RedisQueue{
+		redisClient: redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		}),
+		streams: make(map[string]matchmakingpb.Matchmaking_StreamUpdatesServer),
+	}
+}
+
+func (r *RedisQueue) Enqueue(ctx context.Context, player *matchmakingpb.Player, queueID string, rating float64) error {
+	queueTime := time.Now()
+	record, err := encodePlayer(&QueuedPlayer{
+		Player:    player,
+		QueueID:   queueID,
+		QueueTime: queueTime,
+		Rating:    rating,
+	})
+	if err != nil {
+		return err
+	}
+
+	// Enqueueing again moves the player to the back of the queue
+	pipe := r.redisClient.TxPipeline()
+	previous := pipe.HGet(ctx, queuedPlayersKey, player.Id)
+	pipe.HSet(ctx, queuedPlayersKey, player.Id, record)
+	pipe.ZAdd(ctx, queueKey, &redis.Z{Score: float64(queueTime.UnixMilli()), Member: player.Id})
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return fmt.Errorf("failed to enqueue player: %w", err)
+	}
+
+	if previous.Err() == nil {
+		r.dropPlayer(previous.Val())
+	}
+
+	return nil
+}
+
+func (r *RedisQueue) RemovePlayer(ctx context.Context, playerID string) (bool, error) {
+	pipe := r.redisClient.TxPipeline()
+	record := pipe.HGet(ctx, queuedPlayersKey, playerID)
+	removed := pipe.ZRem(ctx, queueKey, playerID)
+	pipe.HDel(ctx, queuedPlayersKey, playerID)
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return false, fmt.Errorf("failed to remove player from queue: %w", err)
+	}
+
+	if removed.Val() == 0 {
+		return false, nil
+	}
+
+	r.dropPlayer(record.Val())
+	return true, nil
+}
+
+func (r *RedisQueue) GetPlayerStatus(ctx context.Context, playerID string) (*QueuedPlayer, int32, error) {
+	pipe := r.redisClient.TxPipeline()
+	record := pipe.HGet(ctx, queuedPlayersKey, playerID)
+	rank := pipe.ZRank(ctx, queueKey, playerID)
+
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, -1, fmt.Errorf("failed to get queue status: %w", err)
+	}
+
+	if record.Err() == redis.Nil || rank.Err() == redis.Nil {
+		return nil, -1, nil
+	}
+
+	queuedPlayer, err := r.decodePlayer(record.Val())
+	if err != nil {
+		return nil, -1, err
+	}
+
+	return queuedPlayer, int32(rank.Val() + 1), nil // 1-based position
+}
+
+func (r *RedisQueue) TryMatchPlayers(ctx context.Context) (*QueuedPlayer, *QueuedPlayer, error) {
+	result, err := matchPlayersScript.Run(ctx, r.redisClient, []string{queueKey, queuedPlayersKey},
+		time.Now().UnixMilli(), InitialRatingWindow, RatingWindowGrowth,
+	).Result()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to match players: %w", err)
+	}
+
+	records, ok := result.([]interface{})
+	if !ok || len(records) != 2 {
+		return nil, nil, nil
+	}
+
+	var pair [2]*QueuedPlayer
+	for i, record := range records {
+		recordJSON, _ := record.(string)
+		queuedPlayer, err := r.decodePlayer(recordJSON)
+		if err != nil {
+			return nil, nil, err
+		}
+		pair[i] = queuedPlayer
+	}
+
+	// The players have left the queue, so their streams are no longer tracked
+	r.mu.Lock()
+	delete(r.streams, pair[0].Player.Id)
+	delete(r.streams, pair[1].Player.Id)
+	r.mu.Unlock()
+
+	return pair[0], pair[1], nil
+}
+
+func (r *RedisQueue) Requeue(ctx context.Context, players ...*QueuedPlayer) error {
+	var args []interface{}
+	for i := len(players) - 1; i >= 0; i-- {
+		record, err := encodePlayer(players[i])
+		if err != nil {
+			return err
+		}
+		args = append(args, players[i].Player.Id, record, players[i].QueueTime.UnixMilli())
+	}
+
+	requeued, err := requeuePlayersScript.Run(ctx, r.redisClient, []string{queueKey, queuedPlayersKey}, args...).StringSlice()
+	if err != nil {
+		return fmt.Errorf("failed to requeue players: %w", err)
+	}
+
+	// Keep the streams of the requeued players connected to this replica
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, player := range players {
+		for _, id := range requeued {
+			if id == player.Player.Id && player.Stream != nil {
+				r.streams[id] = player.Stream
+			}
+		}
+	}
+	return nil
+}
+
+func (r *RedisQueue) SetPlayerStream(ctx context.Context, playerID string, stream matchmakingpb.Matchmaking_StreamUpdatesServer) error {
+	err := r.redisClient.ZScore(ctx, queueKey, playerID).Err()
+	if err == redis.Nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to look up queued player: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.streams[playerID] = stream
+	return nil
+}
+
+func (r *RedisQueue) GetQueueLength(ctx context.Context) (int, error) {
+	length, err := r.redisClient.ZCard(ctx, queueKey).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queue length: %w", err)
+	}
+	return int(length), nil
+}
+
+// dropPlayer tells a player who left the queue over their stream, if connected to this replica, and forgets the stream
+func (r *RedisQueue) dropPlayer(recordJSON string) {
+	queuedPlayer, err := r.decodePlayer(recordJSON)
+	if err != nil {
+		return
+	}
+
+	notifyQueueCancelled(queuedPlayer)
+
+	r.mu.Lock()
+	delete(r.streams, queuedPlayer.Player.Id)
+	r.mu.Unlock()
+}
+
+// encodePlayer turns a queued player into the record stored in Redis
+func encodePlayer(queuedPlayer *QueuedPlayer) (string, error) {
+	record, err := json.Marshal(queuedPlayerRecord{
+		ID:          queuedPlayer.Player.Id,
+		Name:        queuedPlayer.Player.Name,
+		QueueID:     queuedPlayer.QueueID,
+		QueueTimeMs: queuedPlayer.QueueTime.UnixMilli(),
+		Rating:      queuedPlayer.Rating,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal queued player: %w", err)
+	}
+	return string(record), nil
+}
+
+// decodePlayer turns a stored record back into a queued player, attaching the stream
+// connected to this replica, if any
+func (r *RedisQueue) decodePlayer(recordJSON string) (*QueuedPlayer, error) {
+	var record queuedPlayerRecord
+	if err := json.Unmarshal([]byte(recordJSON), &record); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal queued player: %w", err)
+	}
+
+	r.mu.Lock()
+	stream := r.streams[record.ID]
+	r.mu.Unlock()
+
+	return &QueuedPlayer{
+		Player:    &matchmakingpb.Player{Id: record.ID, Name: record.Name},
+		QueueID:   record.QueueID,
+		QueueTime: time.UnixMilli(record.QueueTimeMs),
+		Rating:    record.Rating,
+		Stream:    stream,
+	}, nil
+}
@@ -0,0 +1,152 @@
+package matchmaking
+
+import (
+	"context"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/redis"
+
+	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
+)
+
+// startRedis starts a Redis container for the test and returns its address
+func startRedis(t *testing.T) string {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	redisContainer, err := redis.Run(ctx, "redis:7-alpine")
+	if err != nil {
+		t.Fatalf("failed to start redis container: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+			t.Logf("failed to terminate redis container: %v", err)
+		}
+	})
+
+	host, err := redisContainer.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get redis host: %v", err)
+	}
+
+	port, err := redisContainer.MappedPort(ctx, "6379")
+	if err != nil {
+		t.Fatalf("failed to get redis port: %v", err)
+	}
+
+	return host + ":" + port.Port()
+}
+
+func setupRedisQueue(t *testing.T) *RedisQueue {
+	return NewRedisQueue(startRedis(t))
+}
+
+func TestRedisQueue_EnqueueAndStatus(t *testing.T) {
+	queue := setupRedisQueue(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"player1", "player2"} {
+		if err := queue.Enqueue(ctx, &matchmakingpb.Player{Id: id, Name: id}, "queue-"+id, 1500); err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	// Enqueueing again moves the player to the back
+	if err := queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player1", Name: "player1"}, "queue-again", 1500); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+
+	queuedPlayer, position, err := queue.GetPlayerStatus(ctx, "player1")
+	if err != nil {
+		t.Fatalf("GetPlayerStatus() error = %v", err)
+	}
+	if queuedPlayer == nil || queuedPlayer.QueueID != "queue-again" || position != 2 {
+		t.Errorf("GetPlayerStatus() = %v at %d, want queue-again at 2", queuedPlayer, position)
+	}
+
+	if length, _ := queue.GetQueueLength(ctx); length != 2 {
+		t.Errorf("GetQueueLength() = %d, want 2", length)
+	}
+
+	removed, err := queue.RemovePlayer(ctx, "player2")
+	if err != nil || !removed {
+		t.Errorf("RemovePlayer() = %v, %v, want true", removed, err)
+	}
+	if removed, _ := queue.RemovePlayer(ctx, "player2"); removed {
+		t.Error("RemovePlayer() of a player no longer queued = true, want false")
+	}
+
+	queuedPlayer, position, err = queue.GetPlayerStatus(ctx, "player2")
+	if err != nil || queuedPlayer != nil || position != -1 {
+		t.Errorf("GetPlayerStatus() after removal = %v at %d (%v), want nil at -1", queuedPlayer, position, err)
+	}
+}
+
+func TestRedisQueue_TryMatchPlayers(t *testing.T) {
+	queue := setupRedisQueue(t)
+	ctx := context.Background()
+
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player1", Name: "Alice"}, "queue1", 1500)
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player2", Name: "Bob"}, "queue2", 1900)
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player3", Name: "Charlie"}, "queue3", 1560)
+
+	// Another replica sees the same queue
+	other := &RedisQueue{redisClient: queue.redisClient, streams: make(map[string]matchmakingpb.Matchmaking_StreamUpdatesServer)}
+
+	player1, player2, err := other.TryMatchPlayers(ctx)
+	if err != nil {
+		t.Fatalf("TryMatchPlayers() error = %v", err)
+	}
+	if player1 == nil || player2 == nil {
+		t.Fatal("Expected a match between close ratings")
+	}
+	if player1.Player.Id != "player1" || player2.Player.Id != "player3" || player2.Player.Name != "Charlie" {
+		t.Errorf("Expected player1 to be matched with player3, got %v and %v", player1.Player, player2.Player)
+	}
+
+	// The matched players are gone for every replica
+	player1, player2, err = queue.TryMatchPlayers(ctx)
+	if err != nil || player1 != nil || player2 != nil {
+		t.Errorf("TryMatchPlayers() with one player left = %v, %v (%v), want no match", player1, player2, err)
+	}
+	if length, _ := queue.GetQueueLength(ctx); length != 1 {
+		t.Errorf("GetQueueLength() = %d, want 1", length)
+	}
+	if records, _ := queue.redisClient.HLen(ctx, queuedPlayersKey).Result(); records != 1 {
+		t.Errorf("%d player records left, want 1: the matched players' records are removed", records)
+	}
+}
+
+func TestRedisQueue_Requeue(t *testing.T) {
+	queue := setupRedisQueue(t)
+	ctx := context.Background()
+
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player1", Name: "Alice"}, "queue1", 1500)
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player2", Name: "Bob"}, "queue2", 1500)
+	player1, player2, err := queue.TryMatchPlayers(ctx)
+	if err != nil || player1 == nil || player2 == nil {
+		t.Fatalf("TryMatchPlayers() = %v, %v (%v), want a match", player1, player2, err)
+	}
+
+	queue.Enqueue(ctx, &matchmakingpb.Player{Id: "player3", Name: "Charlie"}, "queue3", 1500)
+
+	if err := queue.Requeue(ctx, player1, player2); err != nil {
+		t.Fatalf("Requeue() error = %v", err)
+	}
+
+	for i, want := range []*QueuedPlayer{player1, player2} {
+		queuedPlayer, position, err := queue.GetPlayerStatus(ctx, want.Player.Id)
+		if err != nil || position != int32(i+1) {
+			t.Errorf("Requeued %s at position %d (%v), want %d", want.Player.Id, position, err, i+1)
+		}
+		if queuedPlayer != nil && !queuedPlayer.QueueTime.Equal(want.QueueTime) {
+			t.Errorf("Requeued %s with queue time %v, want %v", want.Player.Id, queuedPlayer.QueueTime, want.QueueTime)
+		}
+	}
+	if _, position, _ := queue.GetPlayerStatus(ctx, "player3"); position != 3 {
+		t.Errorf("player3 at position %d, want 3", position)
+	}
+}
@@ -4,39 +4,111 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sync"
 	"time"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
 	"github.com/laerson/mancala/internal/auth"
+	"github.com/laerson/mancala/internal/bot"
 	"github.com/laerson/mancala/internal/events"
+	authpb "github.com/laerson/mancala/proto/auth"
 	botpb "github.com/laerson/mancala/proto/bot"
+	enginepb "github.com/laerson/mancala/proto/engine"
 	gamespb "github.com/laerson/mancala/proto/games"
 	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
 )
 
+// RatingsClient is the subset of the Auth service used to look up player ratings
+type RatingsClient interface {
+	GetRatings(ctx context.Context, req *authpb.GetRatingsRequest, opts ...grpc.CallOption) (*authpb.GetRatingsResponse, error)
+}
+
+const (
+	// createGameAttempts is how many times a match tries to create its game before giving up
+	createGameAttempts = 3
+
+	// DefaultCreateGameBackoff is the wait before the first retry; it doubles with every retry
+	DefaultCreateGameBackoff = 500 * time.Millisecond
+
+	// createGameTimeout bounds each attempt at creating a game
+	createGameTimeout = 5 * time.Second
+)
+
+// EventPublisher publishes the events of matches and challenges, see events.EventPublisher
+type EventPublisher interface {
+	PublishMatchFound(ctx context.Context, gameID, matchID, player1ID, player1Name, player2ID, player2Name string) error
+	PublishMatchFailed(ctx context.Context, player1ID, player2ID, reason string) error
+	PublishChallenge(ctx context.Context, data events.ChallengeData) error
+}
+
 type Server struct {
 	matchmakingpb.UnimplementedMatchmakingServer
-	queue          *PlayerQueue
+	queue          Queue
+	challenges     ChallengeStore
 	gamesClient    gamespb.GamesClient
 	botClient      botpb.BotClient
-	eventPublisher *events.EventPublisher
+	ratingsClient  RatingsClient
+	botGames       bot.GameRegistry
+	eventPublisher EventPublisher
+
+	createGameBackoff time.Duration
+
+	mu      sync.Mutex
+	running bool
+	ctx     context.Context
+	cancel  context.CancelFunc
 }
 
-func NewServer(gamesClient gamespb.GamesClient, botClient botpb.BotClient, redisAddr string) *Server {
-	server := &Server{
-		queue:          NewPlayerQueue(),
+// NewServer creates a matchmaking server. Without a ratings client every player has the default rating.
+// Players are only matched and challenges only expire once Start is called.
+func NewServer(queue Queue, challenges ChallengeStore, gamesClient gamespb.GamesClient, botClient botpb.BotClient, ratingsClient RatingsClient, botGames bot.GameRegistry, eventPublisher EventPublisher) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Server{
+		queue:          queue,
+		challenges:     challenges,
 		gamesClient:    gamesClient,
 		botClient:      botClient,
-		eventPublisher: events.NewEventPublisher(redisAddr),
+		ratingsClient:  ratingsClient,
+		botGames:       botGames,
+		eventPublisher: eventPublisher,
+
+		createGameBackoff: DefaultCreateGameBackoff,
+
+		ctx:    ctx,
+		cancel: cancel,
 	}
+}
+
+// Start begins matching queued players and expiring challenges in the background
+func (s *Server) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-	// Start background matchmaking process
-	go server.processMatchmaking()
+	if s.running {
+		return
+	}
 
-	return server
+	s.running = true
+	go s.processMatchmaking()
+	go s.processChallengeExpiry()
+}
+
+// Stop stops matching players and expiring challenges
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	s.cancel()
+	s.running = false
 }
 
 func (s *Server) Enqueue(ctx context.Context, req *matchmakingpb.EnqueueRequest) (*matchmakingpb.EnqueueResponse, error) {
@@ -58,9 +130,13 @@ func (s *Server) Enqueue(ctx context.Context, req *matchmakingpb.EnqueueRequest)
 	}
 
 	queueID := uuid.New().String()
-	s.queue.Enqueue(req.Player, queueID)
+	rating := s.lookupRating(ctx, req.Player.Id)
+	if err := s.queue.Enqueue(ctx, req.Player, queueID, rating); err != nil {
+		log.Printf("Failed to enqueue player %s: %v", req.Player.Id, err)
+		return nil, status.Errorf(codes.Internal, "failed to join the queue")
+	}
 
-	log.Printf("Player %s (%s) enqueued with queue ID %s", req.Player.Id, req.Player.Name, queueID)
+	log.Printf("Player %s (%s, rated %.0f) enqueued with queue ID %s", req.Player.Id, req.Player.Name, rating, queueID)
 
 	return &matchmakingpb.EnqueueResponse{
 		Success: true,
@@ -69,6 +145,26 @@ func (s *Server) Enqueue(ctx context.Context, req *matchmakingpb.EnqueueRequest)
 	}, nil
 }
 
+// lookupRating returns the player's rating, falling back to the default rating when it cannot be fetched
+func (s *Server) lookupRating(ctx context.Context, playerID string) float64 {
+	if s.ratingsClient == nil {
+		return auth.DefaultRating
+	}
+
+	resp, err := s.ratingsClient.GetRatings(ctx, &authpb.GetRatingsRequest{UserIds: []string{playerID}})
+	if err != nil {
+		log.Printf("Failed to get rating of player %s: %v", playerID, err)
+		return auth.DefaultRating
+	}
+
+	for _, rating := range resp.Ratings {
+		if rating.UserId == playerID {
+			return float64(rating.Rating)
+		}
+	}
+	return auth.DefaultRating
+}
+
 func (s *Server) CancelQueue(ctx context.Context, req *matchmakingpb.CancelQueueRequest) (*matchmakingpb.CancelQueueResponse, error) {
 	if req.PlayerId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "player ID is required")
@@ -79,7 +175,11 @@ func (s *Server) CancelQueue(ctx context.Context, req *matchmakingpb.CancelQueue
 		return nil, err
 	}
 
-	removed := s.queue.RemovePlayer(req.PlayerId)
+	removed, err := s.queue.RemovePlayer(ctx, req.PlayerId)
+	if err != nil {
+		log.Printf("Failed to remove player %s from queue: %v", req.PlayerId, err)
+		return nil, status.Errorf(codes.Internal, "failed to leave the queue")
+	}
 	if !removed {
 		return &matchmakingpb.CancelQueueResponse{
 			Success: false,
@@ -95,6 +195,50 @@ func (s *Server) CancelQueue(ctx context.Context, req *matchmakingpb.CancelQueue
 	}, nil
 }
 
+// RemoveFromQueue takes any player out of the queue on a moderator's behalf,
+// the interceptor only lets moderators and admins through
+func (s *Server) RemoveFromQueue(ctx context.Context, req *matchmakingpb.RemoveFromQueueRequest) (*matchmakingpb.CancelQueueResponse, error) {
+	if req.PlayerId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player ID is required")
+	}
+
+	moderatorID, err := auth.GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	removed, err := s.queue.RemovePlayer(ctx, req.PlayerId)
+	if err != nil {
+		log.Printf("Failed to remove player %s from queue: %v", req.PlayerId, err)
+		return nil, status.Errorf(codes.Internal, "failed to remove player from the queue")
+	}
+	if !removed {
+		return &matchmakingpb.CancelQueueResponse{
+			Success: false,
+			Message: "Player not found in queue",
+		}, nil
+	}
+
+	log.Printf("Player %s removed from queue by moderator %s", req.PlayerId, moderatorID)
+
+	return &matchmakingpb.CancelQueueResponse{
+		Success: true,
+		Message: "Player removed from queue",
+	}, nil
+}
+
+// GetQueueLength returns the number of queued players, the interceptor only
+// lets moderators and admins through
+func (s *Server) GetQueueLength(ctx context.Context, req *matchmakingpb.GetQueueLengthRequest) (*matchmakingpb.GetQueueLengthResponse, error) {
+	length, err := s.queue.GetQueueLength(ctx)
+	if err != nil {
+		log.Printf("Failed to get queue length: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to get queue length")
+	}
+
+	return &matchmakingpb.GetQueueLengthResponse{Length: int32(length)}, nil
+}
+
 func (s *Server) GetQueueStatus(ctx context.Context, req *matchmakingpb.GetQueueStatusRequest) (*matchmakingpb.GetQueueStatusResponse, error) {
 	if req.PlayerId == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "player ID is required")
@@ -105,7 +249,11 @@ func (s *Server) GetQueueStatus(ctx context.Context, req *matchmakingpb.GetQueue
 		return nil, err
 	}
 
-	queuedPlayer, position := s.queue.GetPlayerStatus(req.PlayerId)
+	queuedPlayer, position, err := s.queue.GetPlayerStatus(ctx, req.PlayerId)
+	if err != nil {
+		log.Printf("Failed to get queue status of player %s: %v", req.PlayerId, err)
+		return nil, status.Errorf(codes.Internal, "failed to get queue status")
+	}
 	if queuedPlayer == nil {
 		return &matchmakingpb.GetQueueStatusResponse{
 			Status:        matchmakingpb.QueueStatus_CANCELLED,
@@ -130,10 +278,17 @@ func (s *Server) StreamUpdates(req *matchmakingpb.StreamUpdatesRequest, stream m
 	}
 
 	// Set the stream for this player
-	s.queue.SetPlayerStream(req.PlayerId, stream)
+	ctx := stream.Context()
+	if err := s.queue.SetPlayerStream(ctx, req.PlayerId, stream); err != nil {
+		log.Printf("Failed to attach stream of player %s: %v", req.PlayerId, err)
+		return status.Errorf(codes.Internal, "failed to stream queue updates")
+	}
 
 	// Send initial queue position
-	queuedPlayer, position := s.queue.GetPlayerStatus(req.PlayerId)
+	queuedPlayer, position, err := s.queue.GetPlayerStatus(ctx, req.PlayerId)
+	if err != nil {
+		log.Printf("Failed to get queue status of player %s: %v", req.PlayerId, err)
+	}
 	if queuedPlayer != nil {
 		stream.Send(&matchmakingpb.MatchmakingUpdate{
 			QueueId: queuedPlayer.QueueID,
@@ -147,7 +302,7 @@ func (s *Server) StreamUpdates(req *matchmakingpb.StreamUpdatesRequest, stream m
 	}
 
 	// Keep connection alive until context is done
-	<-stream.Context().Done()
+	<-ctx.Done()
 	return nil
 }
 
@@ -155,10 +310,19 @@ func (s *Server) processMatchmaking() {
 	ticker := time.NewTicker(1 * time.Second)
 	defer ticker.Stop()
 
-	for range ticker.C {
-		player1, player2 := s.queue.TryMatchPlayers()
-		if player1 != nil && player2 != nil {
-			go s.createMatch(player1, player2)
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			player1, player2, err := s.queue.TryMatchPlayers(s.ctx)
+			if err != nil {
+				log.Printf("Failed to match players: %v", err)
+				continue
+			}
+			if player1 != nil && player2 != nil {
+				go s.createMatch(player1, player2)
+			}
 		}
 	}
 }
@@ -172,10 +336,10 @@ func (s *Server) createMatch(player1, player2 *QueuedPlayer) {
 		Player2Id: player2.Player.Id,
 	}
 
-	gameResp, err := s.gamesClient.Create(context.Background(), gameReq)
+	gameResp, err := s.createGame(s.ctx, gameReq)
 	if err != nil {
 		log.Printf("Failed to create game: %v", err)
-		// TODO: Re-queue players or notify them of failure
+		s.requeueMatch(player1, player2)
 		return
 	}
 
@@ -201,6 +365,72 @@ func (s *Server) createMatch(player1, player2 *QueuedPlayer) {
 	s.notifyPlayerMatch(player2, player1, gameResp.Game, matchID)
 }
 
+// createGame creates the game of a match, retrying with exponential backoff while the Games
+// service is unavailable or overloaded. Create is not idempotent, so other errors, timeouts
+// included, are not retried: the game may have been created.
+func (s *Server) createGame(ctx context.Context, req *gamespb.CreateGameRequest) (*gamespb.CreateGameResponse, error) {
+	backoff := s.createGameBackoff
+	for attempt := 1; ; attempt++ {
+		attemptCtx, cancel := context.WithTimeout(ctx, createGameTimeout)
+		resp, err := s.gamesClient.Create(attemptCtx, req)
+		cancel()
+		if err == nil {
+			return resp, nil
+		}
+		if attempt == createGameAttempts || !retryableCreateError(err) {
+			return nil, err
+		}
+
+		log.Printf("Failed to create game (attempt %d of %d), retrying in %v: %v", attempt, createGameAttempts, backoff, err)
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// retryableCreateError reports whether a failed Create certainly created no game and may succeed later
+func retryableCreateError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	default:
+		return false
+	}
+}
+
+// requeueMatch puts the players of a match whose game could not be created back at the head of the queue and tells them
+func (s *Server) requeueMatch(player1, player2 *QueuedPlayer) {
+	if err := s.queue.Requeue(context.Background(), player1, player2); err != nil {
+		log.Printf("Failed to requeue %s and %s: %v", player1.Player.Id, player2.Player.Id, err)
+	}
+
+	reason := "The game could not be created, you are back in the queue"
+	err := s.eventPublisher.PublishMatchFailed(context.Background(), player1.Player.Id, player2.Player.Id, reason)
+	if err != nil {
+		log.Printf("Failed to publish match failed event: %v", err)
+	}
+
+	s.notifyPlayerMatchFailed(player1, reason)
+	s.notifyPlayerMatchFailed(player2, reason)
+}
+
+func (s *Server) notifyPlayerMatchFailed(player *QueuedPlayer, reason string) {
+	if player.Stream != nil {
+		player.Stream.Send(&matchmakingpb.MatchmakingUpdate{
+			QueueId: player.QueueID,
+			Status:  matchmakingpb.QueueStatus_QUEUED,
+			Update: &matchmakingpb.MatchmakingUpdate_MatchFailed{
+				MatchFailed: &matchmakingpb.MatchFailed{
+					Reason: reason,
+				},
+			},
+		})
+	}
+}
+
 func (s *Server) notifyPlayerMatch(player, opponent *QueuedPlayer, game *gamespb.Game, matchID string) {
 	if player.Stream != nil {
 		player.Stream.Send(&matchmakingpb.MatchmakingUpdate{
@@ -289,6 +519,21 @@ func (s *Server) BotMatch(ctx context.Context, req *matchmakingpb.BotMatchReques
 		}, nil
 	}
 
+	// Record the bot seat so the bot driver plays its moves
+	err = s.botGames.RegisterGame(ctx, &bot.BotGame{
+		GameID:     gameResp.Game.Id,
+		BotID:      botResp.Bot.Id,
+		Seat:       enginepb.Player_PLAYER_TWO,
+		Difficulty: botDifficulty,
+	})
+	if err != nil {
+		log.Printf("Failed to register bot game: %v", err)
+		return &matchmakingpb.BotMatchResponse{
+			Success: false,
+			Message: "Failed to set up bot opponent",
+		}, nil
+	}
+
 	log.Printf("Bot game created: %s (Player: %s vs Bot: %s)", gameResp.Game.Id, req.Player.Name, botResp.Bot.Name)
 
 	return &matchmakingpb.BotMatchResponse{
@@ -3,6 +3,8 @@ package matchmaking
 import (
 	"context"
 	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -10,6 +12,9 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/laerson/mancala/internal/auth"
+	"github.com/laerson/mancala/internal/events"
+	authpb "github.com/laerson/mancala/proto/auth"
 	gamespb "github.com/laerson/mancala/proto/games"
 	matchmakingpb "github.com/laerson/mancala/proto/matchmaking"
 )
@@ -38,8 +43,122 @@ func (m *mockGamesClient) Move(ctx context.Context, req *gamespb.MakeGameMoveReq
 	return nil, nil
 }
 
+func (m *mockGamesClient) Get(ctx context.Context, req *gamespb.GetGameRequest, opts ...grpc.CallOption) (*gamespb.GetGameResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) Spectate(ctx context.Context, req *gamespb.SpectateRequest, opts ...grpc.CallOption) (*gamespb.GetGameResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) ListGames(ctx context.Context, req *gamespb.ListGamesRequest, opts ...grpc.CallOption) (*gamespb.ListGamesResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) GetReplay(ctx context.Context, req *gamespb.GetReplayRequest, opts ...grpc.CallOption) (*gamespb.GetReplayResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) Resign(ctx context.Context, req *gamespb.ResignRequest, opts ...grpc.CallOption) (*gamespb.GameActionResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) OfferDraw(ctx context.Context, req *gamespb.OfferDrawRequest, opts ...grpc.CallOption) (*gamespb.GameActionResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) RespondDraw(ctx context.Context, req *gamespb.RespondDrawRequest, opts ...grpc.CallOption) (*gamespb.GameActionResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) Abort(ctx context.Context, req *gamespb.AbortRequest, opts ...grpc.CallOption) (*gamespb.GameActionResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+func (m *mockGamesClient) ForceEnd(ctx context.Context, req *gamespb.ForceEndRequest, opts ...grpc.CallOption) (*gamespb.GameActionResponse, error) {
+	// Not needed for matchmaking tests
+	return nil, nil
+}
+
+// mockEventPublisher records the published match failures and challenges
+type mockEventPublisher struct {
+	mu           sync.Mutex
+	matchesFound int
+	matchFailed  []string // Reasons
+	challenges   []events.ChallengeData
+}
+
+func (m *mockEventPublisher) PublishMatchFound(ctx context.Context, gameID, matchID, player1ID, player1Name, player2ID, player2Name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matchesFound++
+	return nil
+}
+
+func (m *mockEventPublisher) PublishMatchFailed(ctx context.Context, player1ID, player2ID, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.matchFailed = append(m.matchFailed, reason)
+	return nil
+}
+
+func (m *mockEventPublisher) PublishChallenge(ctx context.Context, data events.ChallengeData) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.challenges = append(m.challenges, data)
+	return nil
+}
+
+// newTestServer creates a server with in-memory stores. Its background loops
+// only run once the test calls Start.
+func newTestServer(queue Queue, gamesClient *mockGamesClient, ratingsClient RatingsClient) (*Server, *mockEventPublisher) {
+	publisher := &mockEventPublisher{}
+	return NewServer(queue, newMockChallengeStore(), gamesClient, nil, ratingsClient, nil, publisher), publisher
+}
+
+type mockRatingsClient struct {
+	ratings map[string]int32
+}
+
+func (m *mockRatingsClient) GetRatings(ctx context.Context, req *authpb.GetRatingsRequest, opts ...grpc.CallOption) (*authpb.GetRatingsResponse, error) {
+	resp := &authpb.GetRatingsResponse{}
+	for _, userID := range req.UserIds {
+		if rating, exists := m.ratings[userID]; exists {
+			resp.Ratings = append(resp.Ratings, &authpb.PlayerRating{UserId: userID, Rating: rating})
+		}
+	}
+	return resp, nil
+}
+
+// authContext returns a context carrying the authenticated user ID, as set by the auth interceptor
+// mockUpdateStream records the updates sent to a player
+type mockUpdateStream struct {
+	matchmakingpb.Matchmaking_StreamUpdatesServer
+	mu      sync.Mutex
+	updates []*matchmakingpb.MatchmakingUpdate
+}
+
+func (m *mockUpdateStream) Send(update *matchmakingpb.MatchmakingUpdate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.updates = append(m.updates, update)
+	return nil
+}
+
+func authContext(userID string) context.Context {
+	return context.WithValue(context.Background(), "user_id", userID)
+}
+
 func TestServer_Enqueue(t *testing.T) {
-	server := NewServer(&mockGamesClient{}, "redis:6379")
+	server, _ := newTestServer(NewPlayerQueue(), &mockGamesClient{}, nil)
 
 	tests := []struct {
 		name    string
@@ -91,7 +210,7 @@ func TestServer_Enqueue(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp, err := server.Enqueue(context.Background(), tt.req)
+			resp, err := server.Enqueue(authContext(tt.req.GetPlayer().GetId()), tt.req)
 
 			if tt.wantErr {
 				if err == nil {
@@ -123,7 +242,7 @@ func TestServer_Enqueue(t *testing.T) {
 }
 
 func TestServer_CancelQueue(t *testing.T) {
-	server := NewServer(&mockGamesClient{}, "redis:6379")
+	server, _ := newTestServer(NewPlayerQueue(), &mockGamesClient{}, nil)
 
 	// First enqueue a player
 	enqueueReq := &matchmakingpb.EnqueueRequest{
@@ -132,7 +251,7 @@ func TestServer_CancelQueue(t *testing.T) {
 			Name: "Alice",
 		},
 	}
-	enqueueResp, err := server.Enqueue(context.Background(), enqueueReq)
+	enqueueResp, err := server.Enqueue(authContext(enqueueReq.Player.Id), enqueueReq)
 	if err != nil {
 		t.Fatalf("Failed to enqueue player: %v", err)
 	}
@@ -175,7 +294,7 @@ func TestServer_CancelQueue(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp, err := server.CancelQueue(context.Background(), tt.req)
+			resp, err := server.CancelQueue(authContext(tt.req.PlayerId), tt.req)
 
 			if tt.wantErr {
 				if err == nil {
@@ -201,8 +320,38 @@ func TestServer_CancelQueue(t *testing.T) {
 	}
 }
 
+func TestServer_RemoveFromQueue(t *testing.T) {
+	server, _ := newTestServer(NewPlayerQueue(), &mockGamesClient{}, nil)
+
+	for _, id := range []string{"player1", "player2"} {
+		req := &matchmakingpb.EnqueueRequest{Player: &matchmakingpb.Player{Id: id, Name: id}}
+		if _, err := server.Enqueue(authContext(id), req); err != nil {
+			t.Fatalf("Failed to enqueue player: %v", err)
+		}
+	}
+
+	lengthResp, err := server.GetQueueLength(authContext("moderator"), &matchmakingpb.GetQueueLengthRequest{})
+	if err != nil || lengthResp.Length != 2 {
+		t.Fatalf("GetQueueLength() = %v, %v, want 2", lengthResp, err)
+	}
+
+	// A moderator removes a player they do not own
+	resp, err := server.RemoveFromQueue(authContext("moderator"), &matchmakingpb.RemoveFromQueueRequest{PlayerId: "player1"})
+	if err != nil || !resp.Success {
+		t.Fatalf("RemoveFromQueue() = %v, %v, want success", resp, err)
+	}
+	if lengthResp, _ := server.GetQueueLength(authContext("moderator"), &matchmakingpb.GetQueueLengthRequest{}); lengthResp.Length != 1 {
+		t.Errorf("GetQueueLength() after RemoveFromQueue() = %d, want 1", lengthResp.Length)
+	}
+
+	resp, err = server.RemoveFromQueue(authContext("moderator"), &matchmakingpb.RemoveFromQueueRequest{PlayerId: "player1"})
+	if err != nil || resp.Success {
+		t.Errorf("RemoveFromQueue() of a player not queued = %v, %v, want no success", resp, err)
+	}
+}
+
 func TestServer_GetQueueStatus(t *testing.T) {
-	server := NewServer(&mockGamesClient{}, "redis:6379")
+	server, _ := newTestServer(NewPlayerQueue(), &mockGamesClient{}, nil)
 
 	// Enqueue a player
 	enqueueReq := &matchmakingpb.EnqueueRequest{
@@ -211,7 +360,7 @@ func TestServer_GetQueueStatus(t *testing.T) {
 			Name: "Alice",
 		},
 	}
-	_, err := server.Enqueue(context.Background(), enqueueReq)
+	_, err := server.Enqueue(authContext(enqueueReq.Player.Id), enqueueReq)
 	if err != nil {
 		t.Fatalf("Failed to enqueue player: %v", err)
 	}
@@ -254,7 +403,7 @@ func TestServer_GetQueueStatus(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			resp, err := server.GetQueueStatus(context.Background(), tt.req)
+			resp, err := server.GetQueueStatus(authContext(tt.req.PlayerId), tt.req)
 
 			if tt.wantErr {
 				if err == nil {
@@ -285,11 +434,14 @@ func TestServer_GetQueueStatus(t *testing.T) {
 
 func TestServer_AutoMatching(t *testing.T) {
 	// Create a mock games client that tracks game creation
+	var mu sync.Mutex
 	var createdGames []string
 	mockClient := &mockGamesClient{
 		createGameFunc: func(ctx context.Context, req *gamespb.CreateGameRequest) (*gamespb.CreateGameResponse, error) {
 			gameID := "game-" + req.Player1Id + "-" + req.Player2Id
+			mu.Lock()
 			createdGames = append(createdGames, gameID)
+			mu.Unlock()
 			return &gamespb.CreateGameResponse{
 				Game: &gamespb.Game{
 					Id:        gameID,
@@ -300,7 +452,7 @@ func TestServer_AutoMatching(t *testing.T) {
 		},
 	}
 
-	server := NewServer(mockClient, "redis:6379")
+	server, _ := newTestServer(NewPlayerQueue(), mockClient, nil)
 
 	// Enqueue two players
 	players := []*matchmakingpb.Player{
@@ -310,16 +462,20 @@ func TestServer_AutoMatching(t *testing.T) {
 
 	for _, player := range players {
 		req := &matchmakingpb.EnqueueRequest{Player: player}
-		_, err := server.Enqueue(context.Background(), req)
+		_, err := server.Enqueue(authContext(req.Player.Id), req)
 		if err != nil {
 			t.Fatalf("Failed to enqueue player %s: %v", player.Id, err)
 		}
 	}
 
 	// Wait a bit for the background matching process
+	server.Start()
+	t.Cleanup(server.Stop)
 	time.Sleep(2 * time.Second)
 
 	// Check that a game was created
+	mu.Lock()
+	defer mu.Unlock()
 	if len(createdGames) != 1 {
 		t.Errorf("Expected 1 game to be created, got %d", len(createdGames))
 	}
@@ -327,7 +483,7 @@ func TestServer_AutoMatching(t *testing.T) {
 	// Check that both players are no longer in queue
 	for _, player := range players {
 		req := &matchmakingpb.GetQueueStatusRequest{PlayerId: player.Id}
-		resp, err := server.GetQueueStatus(context.Background(), req)
+		resp, err := server.GetQueueStatus(authContext(req.PlayerId), req)
 		if err != nil {
 			t.Errorf("Failed to get status for player %s: %v", player.Id, err)
 			continue
@@ -339,7 +495,7 @@ func TestServer_AutoMatching(t *testing.T) {
 }
 
 func TestServer_EnqueueMultiplePlayers(t *testing.T) {
-	server := NewServer(&mockGamesClient{}, "redis:6379")
+	server, _ := newTestServer(NewPlayerQueue(), &mockGamesClient{}, nil)
 
 	// Enqueue multiple players
 	playerCount := 5
@@ -350,7 +506,7 @@ func TestServer_EnqueueMultiplePlayers(t *testing.T) {
 				Name: fmt.Sprintf("Player%d", i),
 			},
 		}
-		_, err := server.Enqueue(context.Background(), req)
+		_, err := server.Enqueue(authContext(req.Player.Id), req)
 		if err != nil {
 			t.Fatalf("Failed to enqueue player%d: %v", i, err)
 		}
@@ -361,7 +517,7 @@ func TestServer_EnqueueMultiplePlayers(t *testing.T) {
 		req := &matchmakingpb.GetQueueStatusRequest{
 			PlayerId: fmt.Sprintf("player%d", i),
 		}
-		resp, err := server.GetQueueStatus(context.Background(), req)
+		resp, err := server.GetQueueStatus(authContext(req.PlayerId), req)
 		if err != nil {
 			t.Errorf("Failed to get status for player%d: %v", i, err)
 			continue
@@ -375,7 +531,7 @@ func TestServer_EnqueueMultiplePlayers(t *testing.T) {
 }
 
 func TestServer_ReenqueueSamePlayer(t *testing.T) {
-	server := NewServer(&mockGamesClient{}, "redis:6379")
+	server, _ := newTestServer(NewPlayerQueue(), &mockGamesClient{}, nil)
 
 	player := &matchmakingpb.Player{
 		Id:   "player1",
@@ -385,12 +541,12 @@ func TestServer_ReenqueueSamePlayer(t *testing.T) {
 	// Enqueue the player twice
 	req := &matchmakingpb.EnqueueRequest{Player: player}
 
-	resp1, err := server.Enqueue(context.Background(), req)
+	resp1, err := server.Enqueue(authContext(req.Player.Id), req)
 	if err != nil {
 		t.Fatalf("Failed first enqueue: %v", err)
 	}
 
-	resp2, err := server.Enqueue(context.Background(), req)
+	resp2, err := server.Enqueue(authContext(req.Player.Id), req)
 	if err != nil {
 		t.Fatalf("Failed second enqueue: %v", err)
 	}
@@ -402,7 +558,7 @@ func TestServer_ReenqueueSamePlayer(t *testing.T) {
 
 	// Player should still be at position 1 (only one instance in queue)
 	statusReq := &matchmakingpb.GetQueueStatusRequest{PlayerId: "player1"}
-	statusResp, err := server.GetQueueStatus(context.Background(), statusReq)
+	statusResp, err := server.GetQueueStatus(authContext(statusReq.PlayerId), statusReq)
 	if err != nil {
 		t.Fatalf("Failed to get queue status: %v", err)
 	}
@@ -411,3 +567,144 @@ func TestServer_ReenqueueSamePlayer(t *testing.T) {
 		t.Errorf("Expected position 1 after re-enqueue, got %d", statusResp.QueuePosition)
 	}
 }
+
+func TestServer_Enqueue_Rating(t *testing.T) {
+	ratings := &mockRatingsClient{ratings: map[string]int32{"player1": 1720}}
+	server, _ := newTestServer(NewPlayerQueue(), &mockGamesClient{}, ratings)
+
+	for _, player := range []*matchmakingpb.Player{{Id: "player1", Name: "Alice"}, {Id: "player2", Name: "Bob"}} {
+		_, err := server.Enqueue(authContext(player.Id), &matchmakingpb.EnqueueRequest{Player: player})
+		if err != nil {
+			t.Fatalf("Enqueue() error = %v", err)
+		}
+	}
+
+	tests := []struct {
+		playerID   string
+		wantRating float64
+	}{
+		{playerID: "player1", wantRating: 1720},
+		{playerID: "player2", wantRating: auth.DefaultRating}, // no rated games yet
+	}
+
+	for _, tt := range tests {
+		queuedPlayer, _, _ := server.queue.GetPlayerStatus(context.Background(), tt.playerID)
+		if queuedPlayer == nil {
+			t.Fatalf("Expected %s to be queued", tt.playerID)
+		}
+		if queuedPlayer.Rating != tt.wantRating {
+			t.Errorf("Enqueue() rating of %s = %v, want %v", tt.playerID, queuedPlayer.Rating, tt.wantRating)
+		}
+	}
+}
+
+func TestServer_CreateMatch_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		failures     int32
+		failWith     codes.Code
+		wantAttempts int32
+		wantRequeued bool
+	}{
+		{
+			name:         "Succeeds after a failure",
+			failures:     1,
+			failWith:     codes.Unavailable,
+			wantAttempts: 2,
+			wantRequeued: false,
+		},
+		{
+			name:         "Retries while overloaded",
+			failures:     1,
+			failWith:     codes.ResourceExhausted,
+			wantAttempts: 2,
+			wantRequeued: false,
+		},
+		{
+			name:         "Gives up after every attempt fails",
+			failures:     createGameAttempts,
+			failWith:     codes.Unavailable,
+			wantAttempts: createGameAttempts,
+			wantRequeued: true,
+		},
+		{
+			name:         "Does not retry a rejected request",
+			failures:     1,
+			failWith:     codes.InvalidArgument,
+			wantAttempts: 1,
+			wantRequeued: true,
+		},
+		{
+			// The game may have been created, a retry could create a second one
+			name:         "Does not retry a timeout",
+			failures:     1,
+			failWith:     codes.DeadlineExceeded,
+			wantAttempts: 1,
+			wantRequeued: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			mockClient := &mockGamesClient{
+				createGameFunc: func(ctx context.Context, req *gamespb.CreateGameRequest) (*gamespb.CreateGameResponse, error) {
+					if _, ok := ctx.Deadline(); !ok {
+						t.Error("Create() called without a deadline")
+					}
+					if atomic.AddInt32(&attempts, 1) <= tt.failures {
+						return nil, status.Error(tt.failWith, "games service failed")
+					}
+					return &gamespb.CreateGameResponse{Game: &gamespb.Game{Id: "game-1"}}, nil
+				},
+			}
+
+			queue := NewPlayerQueue()
+			server, publisher := newTestServer(queue, mockClient, nil)
+			server.createGameBackoff = time.Millisecond
+
+			stream := &mockUpdateStream{}
+			queueTime := time.Now().Add(-time.Minute)
+			player1 := &QueuedPlayer{Player: &matchmakingpb.Player{Id: "player1", Name: "Alice"}, QueueID: "queue1", QueueTime: queueTime, Stream: stream}
+			player2 := &QueuedPlayer{Player: &matchmakingpb.Player{Id: "player2", Name: "Bob"}, QueueID: "queue2", QueueTime: queueTime}
+
+			server.createMatch(player1, player2)
+
+			if got := atomic.LoadInt32(&attempts); got != tt.wantAttempts {
+				t.Errorf("createMatch() attempts = %d, want %d", got, tt.wantAttempts)
+			}
+
+			queuedPlayer, position, _ := queue.GetPlayerStatus(context.Background(), "player1")
+			if requeued := queuedPlayer != nil; requeued != tt.wantRequeued {
+				t.Fatalf("createMatch() requeued = %v, want %v", requeued, tt.wantRequeued)
+			}
+
+			stream.mu.Lock()
+			defer stream.mu.Unlock()
+			if len(stream.updates) != 1 {
+				t.Fatalf("createMatch() sent %d updates, want 1", len(stream.updates))
+			}
+			update := stream.updates[0]
+
+			if !tt.wantRequeued {
+				if update.GetGameCreated() == nil {
+					t.Errorf("createMatch() update = %v, want GameCreated", update)
+				}
+				return
+			}
+
+			publisher.mu.Lock()
+			defer publisher.mu.Unlock()
+			if len(publisher.matchFailed) != 1 {
+				t.Errorf("createMatch() published %d match failures, want 1", len(publisher.matchFailed))
+			}
+
+			if position != 1 || !queuedPlayer.QueueTime.Equal(queueTime) {
+				t.Errorf("Requeued player1 at position %d with queue time %v, want 1 and %v", position, queuedPlayer.QueueTime, queueTime)
+			}
+			if update.GetMatchFailed() == nil || update.Status != matchmakingpb.QueueStatus_QUEUED {
+				t.Errorf("createMatch() update = %v, want MatchFailed", update)
+			}
+		})
+	}
+}
@@ -1,128 +1,279 @@
 package notifications
 
 import (
+	"context"
 	"log"
 	"sync"
 
 	notificationspb "github.com/laerson/mancala/proto/notifications"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-// ClientConnection represents a connected client
+// sendBufferSize is the number of notifications queued for a connection. A
+// client that falls this far behind is disconnected and resumes by reconnecting
+// with the ID of the last notification it received.
+const sendBufferSize = 64
+
+// maxHeldNotifications bounds the live notifications held back while missed
+// ones are replayed to a reconnecting client
+const maxHeldNotifications = 1024
+
+// ClientConnection represents a connected client. Notifications are queued and
+// sent by the connection's own RPC handler, so a slow client never holds up the
+// event consumer.
 type ClientConnection struct {
 	playerID string
 	stream   notificationspb.Notifications_SubscribeServer
-	done     chan bool
+	queue    chan *notificationspb.Notification
+	evicted  chan struct{}
+
+	mu      sync.Mutex
+	closed  bool
+	holding bool
+	held    []*notificationspb.Notification
+}
+
+// newClientConnection creates a connection with an empty send buffer
+func newClientConnection(playerID string, stream notificationspb.Notifications_SubscribeServer) *ClientConnection {
+	return &ClientConnection{
+		playerID: playerID,
+		stream:   stream,
+		queue:    make(chan *notificationspb.Notification, sendBufferSize),
+		evicted:  make(chan struct{}),
+	}
+}
+
+// enqueue queues a notification without blocking. It reports false when the buffer is full.
+func (c *ClientConnection) enqueue(notification *notificationspb.Notification) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.closed {
+		return true
+	}
+
+	// Held notifications wait for the replay, they don't count against the buffer
+	if c.holding {
+		if len(c.held) >= maxHeldNotifications {
+			return false
+		}
+		c.held = append(c.held, notification)
+		return true
+	}
+
+	select {
+	case c.queue <- notification:
+		return true
+	default:
+		return false
+	}
+}
+
+// hold holds live notifications back until the connection is served, so a
+// long replay doesn't fill the send buffer
+func (c *ClientConnection) hold() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.holding = true
+}
+
+// release stops holding live notifications back and returns those held
+func (c *ClientConnection) release() []*notificationspb.Notification {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	held := c.held
+	c.holding = false
+	c.held = nil
+	return held
+}
+
+// end lets the connection send what is queued and then finish
+func (c *ClientConnection) end() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.queue)
+	}
+}
+
+// evict disconnects the connection without sending what is queued
+func (c *ClientConnection) evict() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.closed {
+		c.closed = true
+		close(c.evicted)
+	}
+}
+
+// serve sends the notifications held back during a replay, then the queued
+// ones until the client leaves, the connection ends or it is evicted.
+// Notifications up to the stream ID skipUpTo are dropped, they were replayed
+// already.
+func (c *ClientConnection) serve(ctx context.Context, skipUpTo string) error {
+	for _, notification := range c.release() {
+		if skipUpTo != "" && !streamIDAfter(notification.StreamId, skipUpTo) {
+			continue
+		}
+		if err := c.stream.Send(notification); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-c.evicted:
+			return status.Errorf(codes.ResourceExhausted, "too many undelivered notifications, reconnect to resume")
+		case notification, ok := <-c.queue:
+			if !ok {
+				return nil
+			}
+			if skipUpTo != "" && !streamIDAfter(notification.StreamId, skipUpTo) {
+				continue
+			}
+			if err := c.stream.Send(notification); err != nil {
+				return err
+			}
+		}
+	}
 }
 
 // ClientManager manages client connections and notifications
 type ClientManager struct {
-	mu               sync.RWMutex
-	clients          map[string]*ClientConnection // playerID -> connection
-	gameParticipants map[string][]string          // gameID -> []playerID
+	mu         sync.RWMutex
+	clients    map[string]map[*ClientConnection]bool // playerID -> connections
+	spectators map[string]map[*ClientConnection]bool // gameID -> watching connections
 }
 
 // NewClientManager creates a new client manager
 func NewClientManager() *ClientManager {
 	return &ClientManager{
-		clients:          make(map[string]*ClientConnection),
-		gameParticipants: make(map[string][]string),
+		clients:    make(map[string]map[*ClientConnection]bool),
+		spectators: make(map[string]map[*ClientConnection]bool),
 	}
 }
 
-// AddClient adds a new client connection
-func (cm *ClientManager) AddClient(playerID string, stream notificationspb.Notifications_SubscribeServer) {
+// AddClient adds a new client connection. A player may be connected from any
+// number of terminals or devices at once, and each of them is notified.
+func (cm *ClientManager) AddClient(playerID string, stream notificationspb.Notifications_SubscribeServer) *ClientConnection {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	// Close existing connection if any
-	if existingClient, exists := cm.clients[playerID]; exists {
-		close(existingClient.done)
-	}
+	client := newClientConnection(playerID, stream)
+	addConnection(cm.clients, playerID, client)
+	log.Printf("Client connected: %s (%d connections)", playerID, len(cm.clients[playerID]))
 
-	client := &ClientConnection{
-		playerID: playerID,
-		stream:   stream,
-		done:     make(chan bool),
-	}
-
-	cm.clients[playerID] = client
-	log.Printf("Client connected: %s", playerID)
+	return client
 }
 
 // RemoveClient removes a client connection
-func (cm *ClientManager) RemoveClient(playerID string) {
+func (cm *ClientManager) RemoveClient(playerID string, client *ClientConnection) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	if client, exists := cm.clients[playerID]; exists {
-		close(client.done)
-		delete(cm.clients, playerID)
+	if removeConnection(cm.clients, playerID, client) {
 		log.Printf("Client disconnected: %s", playerID)
 	}
 }
 
-// NotifyPlayer sends a notification to a specific player
+// NotifyPlayer queues a notification for every connection of a player
 func (cm *ClientManager) NotifyPlayer(playerID string, notification *notificationspb.Notification) {
-	cm.mu.RLock()
-	client, exists := cm.clients[playerID]
-	cm.mu.RUnlock()
-
-	if !exists {
+	connections := cm.connections(cm.clients, playerID)
+	if len(connections) == 0 {
 		log.Printf("Player %s not connected, skipping notification", playerID)
 		return
 	}
 
-	select {
-	case <-client.done:
-		// Client disconnected, remove it
-		cm.RemoveClient(playerID)
-		return
-	default:
-		// Send notification
-		if err := client.stream.Send(notification); err != nil {
-			log.Printf("Failed to send notification to player %s: %v", playerID, err)
-			cm.RemoveClient(playerID)
-		} else {
-			log.Printf("Sent %s notification to player %s", notification.Type, playerID)
+	for _, client := range connections {
+		if client.enqueue(notification) {
+			continue
 		}
+
+		// Slow consumer, it resumes from its last notification when it reconnects
+		log.Printf("Send buffer of player %s is full, disconnecting the connection", playerID)
+		cm.mu.Lock()
+		removeConnection(cm.clients, playerID, client)
+		cm.mu.Unlock()
+		client.evict()
 	}
 }
 
-// AddGameParticipants adds players to a game for notification tracking
-func (cm *ClientManager) AddGameParticipants(gameID string, playerIDs []string) {
+// AddSpectator adds a connection watching a game. A user may watch any number
+// of games from any number of connections.
+func (cm *ClientManager) AddSpectator(gameID, userID string, stream notificationspb.Notifications_WatchServer) *ClientConnection {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	cm.gameParticipants[gameID] = playerIDs
-	log.Printf("Added game participants for game %s: %v", gameID, playerIDs)
+	spectator := newClientConnection(userID, stream)
+	addConnection(cm.spectators, gameID, spectator)
+	log.Printf("User %s is watching game %s", userID, gameID)
+
+	return spectator
 }
 
-// GetGameParticipants gets the players in a game
-func (cm *ClientManager) GetGameParticipants(gameID string) []string {
-	cm.mu.RLock()
-	defer cm.mu.RUnlock()
+// RemoveSpectator removes a connection watching a game
+func (cm *ClientManager) RemoveSpectator(gameID string, spectator *ClientConnection) {
+	cm.mu.Lock()
+	defer cm.mu.Unlock()
+
+	if removeConnection(cm.spectators, gameID, spectator) {
+		log.Printf("User %s stopped watching game %s", spectator.playerID, gameID)
+	}
+}
 
-	if participants, exists := cm.gameParticipants[gameID]; exists {
-		return participants
+// NotifySpectators queues a notification for everyone watching a game
+func (cm *ClientManager) NotifySpectators(gameID string, notification *notificationspb.Notification) {
+	for _, spectator := range cm.connections(cm.spectators, gameID) {
+		if spectator.enqueue(notification) {
+			continue
+		}
+
+		log.Printf("Send buffer of spectator %s of game %s is full, disconnecting the connection", spectator.playerID, gameID)
+		cm.mu.Lock()
+		removeConnection(cm.spectators, gameID, spectator)
+		cm.mu.Unlock()
+		spectator.evict()
 	}
-	return []string{}
 }
 
-// RemoveGameParticipants removes game participants tracking
-func (cm *ClientManager) RemoveGameParticipants(gameID string) {
+// EndSpectators ends the streams of everyone watching a game once the
+// notifications queued for them are sent
+func (cm *ClientManager) EndSpectators(gameID string) {
 	cm.mu.Lock()
 	defer cm.mu.Unlock()
 
-	delete(cm.gameParticipants, gameID)
-	log.Printf("Removed game participants for game %s", gameID)
+	for spectator := range cm.spectators[gameID] {
+		spectator.end()
+	}
+	delete(cm.spectators, gameID)
 }
 
-// GetConnectedClientsCount returns the number of connected clients
+// GetSpectatorsCount returns the number of connections watching a game
+func (cm *ClientManager) GetSpectatorsCount(gameID string) int {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	return len(cm.spectators[gameID])
+}
+
+// GetConnectedClientsCount returns the number of client connections
 func (cm *ClientManager) GetConnectedClientsCount() int {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
 
-	return len(cm.clients)
+	count := 0
+	for _, connections := range cm.clients {
+		count += len(connections)
+	}
+	return count
 }
 
 // IsPlayerConnected checks if a player is currently connected
@@ -130,6 +281,39 @@ func (cm *ClientManager) IsPlayerConnected(playerID string) bool {
 	cm.mu.RLock()
 	defer cm.mu.RUnlock()
 
-	_, exists := cm.clients[playerID]
-	return exists
+	return len(cm.clients[playerID]) > 0
+}
+
+// connections returns a snapshot of the connections under a key
+func (cm *ClientManager) connections(byKey map[string]map[*ClientConnection]bool, key string) []*ClientConnection {
+	cm.mu.RLock()
+	defer cm.mu.RUnlock()
+
+	connections := make([]*ClientConnection, 0, len(byKey[key]))
+	for connection := range byKey[key] {
+		connections = append(connections, connection)
+	}
+	return connections
+}
+
+// addConnection adds a connection under a key, the caller must hold the lock
+func addConnection(byKey map[string]map[*ClientConnection]bool, key string, connection *ClientConnection) {
+	if byKey[key] == nil {
+		byKey[key] = make(map[*ClientConnection]bool)
+	}
+	byKey[key][connection] = true
+}
+
+// removeConnection removes a connection under a key and reports whether it was
+// there, the caller must hold the lock
+func removeConnection(byKey map[string]map[*ClientConnection]bool, key string, connection *ClientConnection) bool {
+	if !byKey[key][connection] {
+		return false
+	}
+
+	delete(byKey[key], connection)
+	if len(byKey[key]) == 0 {
+		delete(byKey, key)
+	}
+	return true
 }
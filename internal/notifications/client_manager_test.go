@@ -0,0 +1,176 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	notificationspb "github.com/laerson/mancala/proto/notifications"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockStream records the notifications sent to a client
+type mockStream struct {
+	grpc.ServerStream
+	mu   sync.Mutex
+	sent []*notificationspb.Notification
+}
+
+func (m *mockStream) Send(notification *notificationspb.Notification) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, notification)
+	return nil
+}
+
+func (m *mockStream) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockStream) count() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return len(m.sent)
+}
+
+func TestClientManager_MultipleConnectionsPerPlayer(t *testing.T) {
+	cm := NewClientManager()
+
+	// E.g. 'mancala play' in one terminal and a phone
+	terminal := cm.AddClient("p1", &mockStream{})
+	phone := cm.AddClient("p1", &mockStream{})
+	other := cm.AddClient("p2", &mockStream{})
+
+	cm.NotifyPlayer("p1", &notificationspb.Notification{StreamId: "1-0"})
+
+	if len(terminal.queue) != 1 || len(phone.queue) != 1 {
+		t.Errorf("NotifyPlayer() queued %d and %d notifications, want 1 for each connection", len(terminal.queue), len(phone.queue))
+	}
+	if len(other.queue) != 0 {
+		t.Errorf("NotifyPlayer() queued %d notifications for another player", len(other.queue))
+	}
+
+	cm.RemoveClient("p1", terminal)
+	if !cm.IsPlayerConnected("p1") || cm.GetConnectedClientsCount() != 2 {
+		t.Errorf("After closing one connection: connected = %v with %d connections, want true with 2",
+			cm.IsPlayerConnected("p1"), cm.GetConnectedClientsCount())
+	}
+}
+
+func TestClientManager_SlowConsumerIsEvicted(t *testing.T) {
+	cm := NewClientManager()
+	slow := cm.AddClient("p1", &mockStream{})
+	fast := cm.AddClient("p1", &mockStream{})
+
+	// Only the fast connection is drained
+	for i := 0; i <= sendBufferSize; i++ {
+		cm.NotifyPlayer("p1", &notificationspb.Notification{})
+		<-fast.queue
+	}
+
+	select {
+	case <-slow.evicted:
+	default:
+		t.Fatal("NotifyPlayer() did not evict a connection with a full buffer")
+	}
+	if err := slow.serve(context.Background(), ""); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("serve() of an evicted connection error = %v, want ResourceExhausted", err)
+	}
+	if got := cm.GetConnectedClientsCount(); got != 1 {
+		t.Errorf("GetConnectedClientsCount() = %d, want 1", got)
+	}
+}
+
+func TestClientConnection_ServeSkipsReplayedNotifications(t *testing.T) {
+	stream := &mockStream{}
+	client := newClientConnection("p1", stream)
+
+	// Queued during the replay, the first one was replayed already
+	client.enqueue(&notificationspb.Notification{StreamId: "5-0"})
+	client.enqueue(&notificationspb.Notification{StreamId: "7-0"})
+	client.end()
+
+	if err := client.serve(context.Background(), "6-0"); err != nil {
+		t.Fatalf("serve() error = %v", err)
+	}
+	if stream.count() != 1 || stream.sent[0].StreamId != "7-0" {
+		t.Errorf("serve() sent %v, want only 7-0", stream.sent)
+	}
+}
+
+func TestClientManager_Spectators(t *testing.T) {
+	cm := NewClientManager()
+
+	first, second := &mockStream{}, &mockStream{}
+	firstSpectator := cm.AddSpectator("game1", "alice", first)
+	secondSpectator := cm.AddSpectator("game1", "alice", second) // A second terminal of the same user
+	otherSpectator := cm.AddSpectator("game2", "bob", &mockStream{})
+
+	notification := &notificationspb.Notification{Type: notificationspb.NotificationType_NOTIFICATION_TYPE_MOVE_MADE, GameId: "game1"}
+	cm.NotifySpectators("game1", notification)
+
+	if len(firstSpectator.queue) != 1 || len(secondSpectator.queue) != 1 {
+		t.Errorf("NotifySpectators() queued %d and %d notifications, want 1 for each connection",
+			len(firstSpectator.queue), len(secondSpectator.queue))
+	}
+	if len(otherSpectator.queue) != 0 {
+		t.Errorf("NotifySpectators() queued %d notifications for a spectator of another game", len(otherSpectator.queue))
+	}
+
+	cm.EndSpectators("game1")
+	if got := cm.GetSpectatorsCount("game1"); got != 0 {
+		t.Errorf("GetSpectatorsCount() after EndSpectators() = %d, want 0", got)
+	}
+
+	// The stream ends once the queued notifications are sent
+	if err := firstSpectator.serve(context.Background(), ""); err != nil {
+		t.Fatalf("serve() error = %v", err)
+	}
+	if first.count() != 1 {
+		t.Errorf("serve() sent %d notifications before ending, want 1", first.count())
+	}
+
+	if got := cm.GetSpectatorsCount("game2"); got != 1 {
+		t.Errorf("GetSpectatorsCount() of another game = %d, want 1", got)
+	}
+}
+
+func TestClientConnection_HoldsLiveNotificationsDuringReplay(t *testing.T) {
+	cm := NewClientManager()
+	stream := &mockStream{}
+	client := cm.AddClient("p1", stream)
+	client.hold()
+
+	// A long replay, during which more live notifications arrive than the buffer holds
+	for i := 1; i <= 2*sendBufferSize; i++ {
+		cm.NotifyPlayer("p1", &notificationspb.Notification{StreamId: fmt.Sprintf("%d-0", i)})
+	}
+
+	select {
+	case <-client.evicted:
+		t.Fatal("NotifyPlayer() evicted a connection during its replay")
+	default:
+	}
+
+	// The replay covered the first ten, the rest follow it in order
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		for stream.count() < 2*sendBufferSize-10 {
+			time.Sleep(10 * time.Millisecond)
+		}
+		cancel()
+	}()
+	if err := client.serve(ctx, "10-0"); err != nil {
+		t.Fatalf("serve() error = %v", err)
+	}
+
+	if stream.sent[0].StreamId != "11-0" || stream.sent[len(stream.sent)-1].StreamId != fmt.Sprintf("%d-0", 2*sendBufferSize) {
+		t.Errorf("serve() sent %s to %s, want 11-0 to %d-0", stream.sent[0].StreamId, stream.sent[len(stream.sent)-1].StreamId, 2*sendBufferSize)
+	}
+}
@@ -1,6 +1,9 @@
 package notifications
 
 import (
+	"strconv"
+	"strings"
+
 	"github.com/laerson/mancala/internal/events"
 	enginepb "github.com/laerson/mancala/proto/engine"
 	notificationspb "github.com/laerson/mancala/proto/notifications"
@@ -25,6 +28,41 @@ func createMatchFoundNotification(event events.Event, data events.MatchFoundData
 	}
 }
 
+// createMatchFailedNotification creates a match failed notification from event data
+func createMatchFailedNotification(event events.Event, data events.MatchFailedData) *notificationspb.Notification {
+	return &notificationspb.Notification{
+		Id:        event.ID,
+		Type:      notificationspb.NotificationType_NOTIFICATION_TYPE_MATCH_FAILED,
+		Timestamp: event.Timestamp,
+		Data: &notificationspb.Notification_MatchFailed{
+			MatchFailed: &notificationspb.MatchFailedNotification{
+				Player1Id: data.Player1ID,
+				Player2Id: data.Player2ID,
+				Reason:    data.Reason,
+			},
+		},
+	}
+}
+
+// createChallengeNotification creates a challenge notification from event data
+func createChallengeNotification(event events.Event, data events.ChallengeData) *notificationspb.Notification {
+	return &notificationspb.Notification{
+		Id:        event.ID,
+		Type:      notificationspb.NotificationType_NOTIFICATION_TYPE_CHALLENGE,
+		Timestamp: event.Timestamp,
+		Data: &notificationspb.Notification_Challenge{
+			Challenge: &notificationspb.ChallengeNotification{
+				ChallengeId:    data.ChallengeID,
+				ChallengerId:   data.ChallengerID,
+				ChallengerName: data.ChallengerName,
+				TargetId:       data.TargetID,
+				Status:         data.Status,
+				ExpiresAt:      data.ExpiresAt,
+			},
+		},
+	}
+}
+
 // createMoveMadeNotification creates a move made notification from event data
 func createMoveMadeNotification(event events.Event, data events.MoveMadeData) *notificationspb.Notification {
 	return &notificationspb.Notification{
@@ -38,6 +76,7 @@ func createMoveMadeNotification(event events.Event, data events.MoveMadeData) *n
 				PitIndex:   data.PitIndex,
 				GameState:  convertMapToGameState(data.GameState),
 				MoveResult: convertMapToMoveResult(data.MoveResult),
+				Clock:      convertMapToClock(data.Clock),
 			},
 		},
 	}
@@ -55,6 +94,7 @@ func createGameOverNotification(event events.Event, data events.GameOverData) *n
 				FinalState: convertMapToGameState(data.FinalState),
 				WinnerId:   data.WinnerID,
 				IsDraw:     data.IsDraw,
+				Reason:     data.Reason,
 			},
 		},
 	}
@@ -90,9 +130,67 @@ func convertMapToGameState(stateMap map[string]interface{}) *enginepb.GameState
 		}
 	}
 
+	// Convert game type
+	if gameTypeInterface, exists := stateMap["game_type"]; exists {
+		if gameType, ok := gameTypeInterface.(float64); ok {
+			gameState.GameType = enginepb.GameType(gameType)
+		}
+	}
+
+	// Convert rule set
+	if rulesInterface, exists := stateMap["rules"]; exists {
+		if rulesMap, ok := rulesInterface.(map[string]interface{}); ok {
+			gameState.Rules = convertMapToRuleSet(rulesMap)
+		}
+	}
+
 	return gameState
 }
 
+// convertMapToRuleSet converts a map to a RuleSet proto message
+func convertMapToRuleSet(rulesMap map[string]interface{}) *enginepb.RuleSet {
+	ruleSet := &enginepb.RuleSet{}
+
+	if pitsPerSide, ok := rulesMap["pits_per_side"].(float64); ok {
+		ruleSet.PitsPerSide = uint32(pitsPerSide)
+	}
+	if initialSeeds, ok := rulesMap["initial_seeds"].(float64); ok {
+		ruleSet.InitialSeeds = uint32(initialSeeds)
+	}
+	if disableCapture, ok := rulesMap["disable_capture"].(bool); ok {
+		ruleSet.DisableCapture = disableCapture
+	}
+	if emptyCapture, ok := rulesMap["empty_capture"].(bool); ok {
+		ruleSet.EmptyCapture = emptyCapture
+	}
+	if sweepPolicy, ok := rulesMap["sweep_policy"].(float64); ok {
+		ruleSet.SweepPolicy = enginepb.SweepPolicy(sweepPolicy)
+	}
+
+	return ruleSet
+}
+
+// convertMapToClock converts a map to a ClockState proto message
+func convertMapToClock(clockMap map[string]interface{}) *notificationspb.ClockState {
+	if clockMap == nil {
+		return nil
+	}
+
+	clock := &notificationspb.ClockState{}
+
+	if remaining, ok := clockMap["player1_remaining_ms"].(float64); ok {
+		clock.Player1RemainingMs = int64(remaining)
+	}
+	if remaining, ok := clockMap["player2_remaining_ms"].(float64); ok {
+		clock.Player2RemainingMs = int64(remaining)
+	}
+	if startedAt, ok := clockMap["turn_started_at_ms"].(float64); ok {
+		clock.TurnStartedAtMs = int64(startedAt)
+	}
+
+	return clock
+}
+
 // convertMapToMoveResult converts a map to a MoveResult proto message
 func convertMapToMoveResult(resultMap map[string]interface{}) *enginepb.MoveResult {
 	if resultMap == nil {
@@ -139,3 +237,43 @@ func convertMapToMoveResult(resultMap map[string]interface{}) *enginepb.MoveResu
 
 	return moveResult
 }
+
+// parseStreamID splits a Redis stream ID into its milliseconds and sequence number
+func parseStreamID(id string) (uint64, uint64, bool) {
+	millis, sequence, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+
+	ms, err := strconv.ParseUint(millis, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseUint(sequence, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+// validStreamID reports whether id is a Redis stream ID such as "1700000000000-0"
+func validStreamID(id string) bool {
+	_, _, ok := parseStreamID(id)
+	return ok
+}
+
+// streamIDMillis returns the time of a Redis stream ID in Unix milliseconds
+func streamIDMillis(id string) int64 {
+	ms, _, _ := parseStreamID(id)
+	return int64(ms)
+}
+
+// streamIDAfter reports whether stream ID a comes after b
+func streamIDAfter(a, b string) bool {
+	aMs, aSeq, _ := parseStreamID(a)
+	bMs, bSeq, _ := parseStreamID(b)
+	if aMs != bMs {
+		return aMs > bMs
+	}
+	return aSeq > bSeq
+}
@@ -0,0 +1,110 @@
+package notifications
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// ParticipantStore remembers the players of each game so move notifications
+// reach the opponent, whichever service created the game
+type ParticipantStore interface {
+	// AddGameParticipants records the players of a game
+	AddGameParticipants(ctx context.Context, gameID string, playerIDs []string) error
+	// GetGameParticipants returns the players of a game, or none for an unknown game
+	GetGameParticipants(ctx context.Context, gameID string) ([]string, error)
+	// RemoveGameParticipants forgets the players of a finished game
+	RemoveGameParticipants(ctx context.Context, gameID string) error
+}
+
+// participantsRetention is how long the players of a finished game are kept.
+// Moves name both players, so participants only route the moves of events
+// published before they did, read by an instance that is still behind.
+const participantsRetention = 10 * time.Minute
+
+// RedisParticipantStore keeps game participants in Redis, so routing survives
+// restarts of the notifications service
+type RedisParticipantStore struct {
+	client *redis.Client
+}
+
+// NewRedisParticipantStore creates a participant store backed by Redis
+func NewRedisParticipantStore(redisAddr string) *RedisParticipantStore {
+	rdb := redis.NewClient(&redis.Options{
+		Addr: redisAddr,
+	})
+
+	// Test connection
+	if err := rdb.Ping(context.Background()).Err(); err != nil {
+		log.Printf("Warning: Failed to connect to Redis for game participants: %v", err)
+	}
+
+	return &RedisParticipantStore{client: rdb}
+}
+
+// participantsKey returns the key of the set of a game's players
+func participantsKey(gameID string) string {
+	return "notifications:game:" + gameID + ":participants"
+}
+
+// AddGameParticipants records the players of a game
+func (r *RedisParticipantStore) AddGameParticipants(ctx context.Context, gameID string, playerIDs []string) error {
+	members := make([]interface{}, len(playerIDs))
+	for i, playerID := range playerIDs {
+		members[i] = playerID
+	}
+
+	return r.client.SAdd(ctx, participantsKey(gameID), members...).Err()
+}
+
+// GetGameParticipants returns the players of a game
+func (r *RedisParticipantStore) GetGameParticipants(ctx context.Context, gameID string) ([]string, error) {
+	return r.client.SMembers(ctx, participantsKey(gameID)).Result()
+}
+
+// RemoveGameParticipants forgets the players of a finished game once participantsRetention has passed
+func (r *RedisParticipantStore) RemoveGameParticipants(ctx context.Context, gameID string) error {
+	return r.client.Expire(ctx, participantsKey(gameID), participantsRetention).Err()
+}
+
+// MemoryParticipantStore keeps game participants in memory, for tests and single instance setups
+type MemoryParticipantStore struct {
+	mu           sync.RWMutex
+	participants map[string][]string // gameID -> []playerID
+}
+
+// NewMemoryParticipantStore creates an in-memory participant store
+func NewMemoryParticipantStore() *MemoryParticipantStore {
+	return &MemoryParticipantStore{
+		participants: make(map[string][]string),
+	}
+}
+
+// AddGameParticipants records the players of a game
+func (m *MemoryParticipantStore) AddGameParticipants(ctx context.Context, gameID string, playerIDs []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.participants[gameID] = append([]string(nil), playerIDs...)
+	return nil
+}
+
+// GetGameParticipants returns the players of a game
+func (m *MemoryParticipantStore) GetGameParticipants(ctx context.Context, gameID string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return append([]string(nil), m.participants[gameID]...), nil
+}
+
+// RemoveGameParticipants forgets the players of a finished game
+func (m *MemoryParticipantStore) RemoveGameParticipants(ctx context.Context, gameID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.participants, gameID)
+	return nil
+}
@@ -0,0 +1,72 @@
+package notifications
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/redis"
+)
+
+// startRedis starts a Redis container for the test and returns its address
+func startRedis(t *testing.T) string {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	redisContainer, err := redis.Run(ctx, "redis:7-alpine")
+	if err != nil {
+		t.Fatalf("failed to start redis container: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+			t.Logf("failed to terminate redis container: %v", err)
+		}
+	})
+
+	host, err := redisContainer.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get redis host: %v", err)
+	}
+
+	port, err := redisContainer.MappedPort(ctx, "6379")
+	if err != nil {
+		t.Fatalf("failed to get redis port: %v", err)
+	}
+
+	return host + ":" + port.Port()
+}
+
+func TestRedisParticipantStore(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	store := NewRedisParticipantStore(redisAddr)
+	if err := store.AddGameParticipants(ctx, "game1", []string{"p1", "p2"}); err != nil {
+		t.Fatalf("AddGameParticipants() error = %v", err)
+	}
+
+	// A new instance, e.g. after a restart, still knows the players
+	participants, err := NewRedisParticipantStore(redisAddr).GetGameParticipants(ctx, "game1")
+	if err != nil {
+		t.Fatalf("GetGameParticipants() error = %v", err)
+	}
+	sort.Strings(participants)
+	if len(participants) != 2 || participants[0] != "p1" || participants[1] != "p2" {
+		t.Errorf("GetGameParticipants() = %v, want [p1 p2]", participants)
+	}
+
+	if err := store.RemoveGameParticipants(ctx, "game1"); err != nil {
+		t.Fatalf("RemoveGameParticipants() error = %v", err)
+	}
+
+	// Instances that are behind can still route the game's last moves for a while
+	if participants, _ := store.GetGameParticipants(ctx, "game1"); len(participants) != 2 {
+		t.Errorf("GetGameParticipants() right after removal = %v, want [p1 p2]", participants)
+	}
+	if ttl, err := store.client.TTL(ctx, participantsKey("game1")).Result(); err != nil || ttl <= 0 || ttl > participantsRetention {
+		t.Errorf("TTL after removal = %v, %v, want at most %v", ttl, err, participantsRetention)
+	}
+}
@@ -1,29 +1,43 @@
 package notifications
 
 import (
+	"context"
 	"log"
 
 	"github.com/laerson/mancala/internal/auth"
+	gamespb "github.com/laerson/mancala/proto/games"
 	notificationspb "github.com/laerson/mancala/proto/notifications"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
+// GamesClient is the subset of the Games service used to check watched games
+type GamesClient interface {
+	Spectate(ctx context.Context, req *gamespb.SpectateRequest, opts ...grpc.CallOption) (*gamespb.GetGameResponse, error)
+}
+
 // Server implements the Notifications gRPC service
 type Server struct {
 	notificationspb.UnimplementedNotificationsServer
 	clientManager   *ClientManager
 	eventSubscriber *EventSubscriber
+	gamesClient     GamesClient
 }
 
 // NewServer creates a new notification server
-func NewServer(redisAddr string) *Server {
+func NewServer(redisAddr, instanceID string, gamesClient GamesClient) *Server {
 	clientManager := NewClientManager()
-	eventSubscriber := NewEventSubscriber(redisAddr, clientManager)
+	eventSubscriber := NewEventSubscriber(redisAddr, clientManager, NewRedisParticipantStore(redisAddr))
+	if instanceID != "" {
+		eventSubscriber.setInstance(instanceID)
+	}
 
 	server := &Server{
 		clientManager:   clientManager,
 		eventSubscriber: eventSubscriber,
+		gamesClient:     gamesClient,
 	}
 
 	// Start the event subscriber
@@ -45,19 +59,102 @@ func (s *Server) Subscribe(req *notificationspb.SubscribeRequest, stream notific
 		return status.Errorf(codes.InvalidArgument, "player_id is required")
 	}
 
+	if req.LastEventId != "" && !validStreamID(req.LastEventId) {
+		return status.Errorf(codes.InvalidArgument, "last_event_id must be a stream ID such as 1700000000000-0")
+	}
+
 	log.Printf("Player %s subscribing to notifications", req.PlayerId)
 
-	// Add client to manager
-	s.clientManager.AddClient(req.PlayerId, stream)
+	// Add client to manager, live notifications queue up from now on
+	client := s.clientManager.AddClient(req.PlayerId, stream)
+	defer s.clientManager.RemoveClient(req.PlayerId, client)
+
+	replayedUpTo := ""
+	if req.LastEventId != "" {
+		// Live notifications wait for the replay instead of filling the buffer
+		client.hold()
 
-	// Keep the connection alive
-	<-stream.Context().Done()
+		var err error
+		replayedUpTo, err = s.eventSubscriber.Replay(stream.Context(), req.PlayerId, req.LastEventId, stream.Send)
+		if err != nil {
+			log.Printf("Failed to replay missed notifications to player %s: %v", req.PlayerId, err)
+		}
+	}
 
-	// Clean up when client disconnects
-	s.clientManager.RemoveClient(req.PlayerId)
+	// Send the queued notifications, skipping those that were replayed, until the client leaves
+	err := client.serve(stream.Context(), replayedUpTo)
 	log.Printf("Player %s unsubscribed from notifications", req.PlayerId)
 
-	return nil
+	return err
+}
+
+// Watch streams the moves and the result of a game in progress to any
+// authenticated user. Spectators only receive notifications, moves are still
+// validated against the game's players by the games service.
+func (s *Server) Watch(req *notificationspb.WatchRequest, stream notificationspb.Notifications_WatchServer) error {
+	userID, err := auth.GetUserIDFromContext(stream.Context())
+	if err != nil {
+		return status.Errorf(codes.Unauthenticated, "authentication required")
+	}
+
+	if req.GameId == "" {
+		return status.Errorf(codes.InvalidArgument, "game_id is required")
+	}
+
+	// Watching before checking the game means a GAME_OVER published in between still ends the stream
+	spectator := s.clientManager.AddSpectator(req.GameId, userID, stream)
+	defer s.clientManager.RemoveSpectator(req.GameId, spectator)
+
+	if err := s.checkWatchable(stream.Context(), req.GameId); err != nil {
+		return err
+	}
+
+	// Send the game's notifications until the client leaves or the game ends
+	return spectator.serve(stream.Context(), "")
+}
+
+// checkWatchable returns an error unless the game exists and is in progress,
+// the stream of any other game would never end
+func (s *Server) checkWatchable(ctx context.Context, gameID string) error {
+	if s.gamesClient == nil {
+		return status.Errorf(codes.Unavailable, "games service unavailable")
+	}
+
+	// The games service checks the spectator's own token
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = metadata.NewOutgoingContext(ctx, metadata.Pairs("authorization", firstValue(md.Get("authorization"))))
+
+	resp, err := s.gamesClient.Spectate(ctx, &gamespb.SpectateRequest{GameId: gameID})
+	if err != nil {
+		log.Printf("Failed to check watched game %s: %v", gameID, err)
+		return status.Errorf(codes.Unavailable, "failed to load game")
+	}
+
+	gameErr := resp.GetError()
+	if gameErr == nil {
+		return nil
+	}
+
+	switch gameErr.Code {
+	case gamespb.ErrorCode_ERROR_CODE_NOT_FOUND:
+		return status.Errorf(codes.NotFound, "%s", gameErr.Message)
+	case gamespb.ErrorCode_ERROR_CODE_GAME_FINISHED:
+		return status.Errorf(codes.FailedPrecondition, "%s", gameErr.Message)
+	case gamespb.ErrorCode_ERROR_CODE_PERMISSION_DENIED:
+		return status.Errorf(codes.PermissionDenied, "%s", gameErr.Message)
+	case gamespb.ErrorCode_ERROR_CODE_INTERNAL:
+		return status.Errorf(codes.Internal, "%s", gameErr.Message)
+	default:
+		return status.Errorf(codes.InvalidArgument, "%s", gameErr.Message)
+	}
+}
+
+// firstValue returns the first of a metadata key's values, if any
+func firstValue(values []string) string {
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
 }
 
 // Stop gracefully shuts down the notification server
@@ -65,12 +162,6 @@ func (s *Server) Stop() {
 	s.eventSubscriber.Stop()
 }
 
-// NotifyMatchFound is a helper method for external services to notify about matches
-func (s *Server) NotifyMatchFound(gameID string, player1ID, player2ID string) {
-	// Add game participants for tracking
-	s.clientManager.AddGameParticipants(gameID, []string{player1ID, player2ID})
-}
-
 // GetConnectedClientsCount returns the number of connected clients (for monitoring)
 func (s *Server) GetConnectedClientsCount() int {
 	return s.clientManager.GetConnectedClientsCount()
@@ -0,0 +1,114 @@
+package notifications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/laerson/mancala/internal/events"
+	gamespb "github.com/laerson/mancala/proto/games"
+	notificationspb "github.com/laerson/mancala/proto/notifications"
+)
+
+// mockGamesClient spectates the games in progress it knows
+type mockGamesClient struct {
+	games map[string]gamespb.GameStatus
+}
+
+func (m *mockGamesClient) Spectate(ctx context.Context, req *gamespb.SpectateRequest, opts ...grpc.CallOption) (*gamespb.GetGameResponse, error) {
+	gameStatus, ok := m.games[req.GameId]
+	switch {
+	case !ok:
+		return &gamespb.GetGameResponse{Result: &gamespb.GetGameResponse_Error{
+			Error: &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_NOT_FOUND, Message: "game not found"},
+		}}, nil
+	case gameStatus == gamespb.GameStatus_GAME_STATUS_FINISHED:
+		return &gamespb.GetGameResponse{Result: &gamespb.GetGameResponse_Error{
+			Error: &gamespb.Error{Code: gamespb.ErrorCode_ERROR_CODE_GAME_FINISHED, Message: "game is already finished"},
+		}}, nil
+	default:
+		return &gamespb.GetGameResponse{Result: &gamespb.GetGameResponse_Game{
+			Game: &gamespb.Game{Id: req.GameId, Status: gameStatus},
+		}}, nil
+	}
+}
+
+// spectatorStream is the stream of an authenticated spectator
+type spectatorStream struct {
+	mockStream
+	ctx context.Context
+}
+
+func (s *spectatorStream) Context() context.Context {
+	return s.ctx
+}
+
+func newTestServer() *Server {
+	cm := NewClientManager()
+	return &Server{
+		clientManager:   cm,
+		eventSubscriber: NewEventSubscriber("localhost:6379", cm, NewMemoryParticipantStore()),
+		gamesClient: &mockGamesClient{games: map[string]gamespb.GameStatus{
+			"live":     gamespb.GameStatus_GAME_STATUS_IN_PROGRESS,
+			"finished": gamespb.GameStatus_GAME_STATUS_FINISHED,
+		}},
+	}
+}
+
+func TestServer_WatchRejectsGamesNotInProgress(t *testing.T) {
+	server := newTestServer()
+
+	for gameID, want := range map[string]codes.Code{
+		"unknown":  codes.NotFound,
+		"finished": codes.FailedPrecondition,
+	} {
+		ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), "user_id", "spectator"), 5*time.Second)
+		err := server.Watch(&notificationspb.WatchRequest{GameId: gameID}, &spectatorStream{ctx: ctx})
+		cancel()
+
+		if status.Code(err) != want {
+			t.Errorf("Watch() of the %s game error = %v, want %v", gameID, err, want)
+		}
+		if count := server.clientManager.GetSpectatorsCount(gameID); count != 0 {
+			t.Errorf("Watch() of the %s game left %d spectators", gameID, count)
+		}
+	}
+}
+
+func TestServer_WatchEndsOnGameOver(t *testing.T) {
+	server := newTestServer()
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), "user_id", "spectator"), 5*time.Second)
+	defer cancel()
+
+	stream := &spectatorStream{ctx: ctx}
+	done := make(chan error, 1)
+	go func() {
+		done <- server.Watch(&notificationspb.WatchRequest{GameId: "live"}, stream)
+	}()
+
+	for server.clientManager.GetSpectatorsCount("live") == 0 {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	server.eventSubscriber.processMessage(streamMessage(t, "1-0", events.Event{
+		Type:   events.EventTypeGameOver,
+		GameID: "live",
+		Data:   map[string]interface{}{"player1_id": "p1", "player2_id": "p2", "winner_id": "p1"},
+	}))
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Watch() error = %v, want nil", err)
+		}
+	case <-ctx.Done():
+		t.Fatal("Watch() still streaming after GAME_OVER")
+	}
+	if stream.count() != 1 {
+		t.Errorf("Watch() sent %d notifications, want the game over", stream.count())
+	}
+}
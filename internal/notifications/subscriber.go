@@ -3,20 +3,50 @@ package notifications
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"log"
+	"os"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
 	"github.com/laerson/mancala/internal/events"
+	notificationspb "github.com/laerson/mancala/proto/notifications"
 )
 
-// EventSubscriber subscribes to Redis streams and distributes events to clients
+const (
+	// consumerGroupPrefix prefixes the consumer group of every notifications instance
+	consumerGroupPrefix = "notifications-service:"
+	// claimMinIdle is how long a delivered event may stay unacknowledged before it is reclaimed
+	claimMinIdle = time.Minute
+	// claimInterval is how often pending events are reclaimed and stale groups removed
+	claimInterval = 30 * time.Second
+	// claimBatchSize is the number of pending events reclaimed at a time
+	claimBatchSize = 10
+	// staleGroupIdle is how long the consumers of another instance's group must be idle before it is removed
+	staleGroupIdle = time.Hour
+	// replayWindow is how far back missed notifications are replayed to a reconnecting client
+	replayWindow = time.Hour
+	// replayBatchSize is the number of stream entries read at a time during a replay
+	replayBatchSize = 100
+)
+
+// EventSubscriber subscribes to Redis streams and distributes events to clients.
+//
+// Players are connected to a single instance, so every instance reads every
+// event through a consumer group of its own. The group keeps its position in
+// the stream, so an instance that restarts under the same name resumes where
+// it stopped and reclaims the events it read but never acknowledged. Instances
+// must therefore keep their name across restarts, e.g. as StatefulSet pods.
 type EventSubscriber struct {
 	redisClient   *redis.Client
 	clientManager *ClientManager
+	participants  ParticipantStore
 	consumerGroup string
 	consumerName  string
+	claimMinIdle  time.Duration
 	mu            sync.RWMutex
 	running       bool
 	ctx           context.Context
@@ -24,21 +54,29 @@ type EventSubscriber struct {
 }
 
 // NewEventSubscriber creates a new event subscriber
-func NewEventSubscriber(redisAddr string, clientManager *ClientManager) *EventSubscriber {
+func NewEventSubscriber(redisAddr string, clientManager *ClientManager, participants ParticipantStore) *EventSubscriber {
 	rdb := redis.NewClient(&redis.Options{
 		Addr: redisAddr,
 	})
 
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &EventSubscriber{
+	es := &EventSubscriber{
 		redisClient:   rdb,
 		clientManager: clientManager,
-		consumerGroup: "notifications-service",
-		consumerName:  "notification-consumer-1",
+		participants:  participants,
+		claimMinIdle:  claimMinIdle,
 		ctx:           ctx,
 		cancel:        cancel,
 	}
+	es.setInstance(instanceName())
+	return es
+}
+
+// setInstance names this instance, and with it its consumer group
+func (es *EventSubscriber) setInstance(instance string) {
+	es.consumerGroup = consumerGroupPrefix + instance
+	es.consumerName = instance
 }
 
 // Start begins consuming events from Redis streams
@@ -50,9 +88,7 @@ func (es *EventSubscriber) Start() error {
 		return nil
 	}
 
-	// Create consumer group if it doesn't exist
-	err := es.redisClient.XGroupCreateMkStream(es.ctx, events.EventsStreamKey, es.consumerGroup, "0").Err()
-	if err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
+	if err := es.createGroup(); err != nil {
 		log.Printf("Failed to create consumer group: %v", err)
 		return err
 	}
@@ -64,6 +100,16 @@ func (es *EventSubscriber) Start() error {
 	return nil
 }
 
+// createGroup creates this instance's consumer group if it doesn't exist. A new
+// group starts at the end of the stream, since no client was connected before.
+func (es *EventSubscriber) createGroup() error {
+	err := es.redisClient.XGroupCreateMkStream(es.ctx, events.EventsStreamKey, es.consumerGroup, "$").Err()
+	if err != nil && err.Error() != "BUSYGROUP Consumer Group name already exists" {
+		return err
+	}
+	return nil
+}
+
 // Stop stops the event subscriber
 func (es *EventSubscriber) Stop() {
 	es.mu.Lock()
@@ -78,13 +124,22 @@ func (es *EventSubscriber) Stop() {
 	log.Println("Event subscriber stopped")
 }
 
-// consume continuously reads events from Redis streams
+// consume continuously reads events from Redis streams. Reclaiming runs on the
+// same goroutine, so a client's stream is never sent to concurrently.
 func (es *EventSubscriber) consume() {
+	var nextClaim time.Time
+
 	for {
 		select {
 		case <-es.ctx.Done():
 			return
 		default:
+			if time.Now().After(nextClaim) {
+				es.claimPending()
+				es.removeStaleGroups()
+				nextClaim = time.Now().Add(claimInterval)
+			}
+
 			// Read messages from the stream
 			streams, err := es.redisClient.XReadGroup(es.ctx, &redis.XReadGroupArgs{
 				Group:    es.consumerGroup,
@@ -95,7 +150,12 @@ func (es *EventSubscriber) consume() {
 			}).Result()
 
 			if err != nil {
-				if err != redis.Nil {
+				if strings.HasPrefix(err.Error(), "NOGROUP") {
+					// The group was removed, e.g. by another instance that took it for stale
+					if err := es.createGroup(); err != nil {
+						log.Printf("Failed to recreate consumer group: %v", err)
+					}
+				} else if err != redis.Nil && es.ctx.Err() == nil {
 					log.Printf("Error reading from stream: %v", err)
 				}
 				continue
@@ -114,90 +174,319 @@ func (es *EventSubscriber) consume() {
 	}
 }
 
+// claimPending processes the events of this instance's group that were delivered
+// but not acknowledged for claimMinIdle, e.g. because the instance crashed.
+// XPENDING and XCLAIM are used rather than XAUTOCLAIM, whose Redis 7 reply the
+// v8 client can't read.
+func (es *EventSubscriber) claimPending() {
+	for es.ctx.Err() == nil {
+		pending, err := es.redisClient.XPendingExt(es.ctx, &redis.XPendingExtArgs{
+			Stream: events.EventsStreamKey,
+			Group:  es.consumerGroup,
+			Idle:   es.claimMinIdle,
+			Start:  "-",
+			End:    "+",
+			Count:  claimBatchSize,
+		}).Result()
+
+		if err != nil {
+			if es.ctx.Err() == nil {
+				log.Printf("Failed to list pending events: %v", err)
+			}
+			return
+		}
+		if len(pending) == 0 {
+			return
+		}
+
+		// Claimed entries are no longer idle, so the next batch holds others
+		ids := make([]string, len(pending))
+		for i, entry := range pending {
+			ids[i] = entry.ID
+		}
+
+		messages, err := es.redisClient.XClaim(es.ctx, &redis.XClaimArgs{
+			Stream:   events.EventsStreamKey,
+			Group:    es.consumerGroup,
+			Consumer: es.consumerName,
+			MinIdle:  es.claimMinIdle,
+			Messages: ids,
+		}).Result()
+
+		if err != nil {
+			if es.ctx.Err() == nil {
+				log.Printf("Failed to reclaim pending events: %v", err)
+			}
+			return
+		}
+
+		for _, message := range messages {
+			log.Printf("Reclaimed pending event %s", message.ID)
+			es.processMessage(message)
+			es.redisClient.XAck(es.ctx, events.EventsStreamKey, es.consumerGroup, message.ID)
+		}
+
+		if len(pending) < claimBatchSize {
+			return
+		}
+	}
+}
+
+// removeStaleGroups removes the consumer groups of instances that are gone, so
+// the stream doesn't keep a group for every instance that ever ran. A restarted
+// instance takes its group back long before, so only instances removed for good,
+// e.g. by scaling down, lose their group. The events left pending in it were for
+// clients of that instance, which get them replayed when they reconnect.
+func (es *EventSubscriber) removeStaleGroups() {
+	groups, err := es.redisClient.XInfoGroups(es.ctx, events.EventsStreamKey).Result()
+	if err != nil {
+		if es.ctx.Err() == nil {
+			log.Printf("Failed to list consumer groups: %v", err)
+		}
+		return
+	}
+
+	for _, group := range groups {
+		if !strings.HasPrefix(group.Name, consumerGroupPrefix) || group.Name == es.consumerGroup {
+			continue
+		}
+
+		consumers, err := es.redisClient.XInfoConsumers(es.ctx, events.EventsStreamKey, group.Name).Result()
+		if err != nil || len(consumers) == 0 {
+			// A new instance's group has no consumer until its first read
+			continue
+		}
+
+		stale := true
+		for _, consumer := range consumers {
+			if time.Duration(consumer.Idle)*time.Millisecond < staleGroupIdle {
+				stale = false
+				break
+			}
+		}
+
+		if stale {
+			if err := es.redisClient.XGroupDestroy(es.ctx, events.EventsStreamKey, group.Name).Err(); err != nil {
+				log.Printf("Failed to remove stale consumer group %s: %v", group.Name, err)
+				continue
+			}
+			log.Printf("Removed stale consumer group %s", group.Name)
+		}
+	}
+}
+
 // processMessage processes a single Redis stream message
 func (es *EventSubscriber) processMessage(message redis.XMessage) {
-	eventDataStr, ok := message.Values["data"].(string)
+	event, ok := parseEvent(message)
 	if !ok {
-		log.Printf("Invalid event data format in message %s", message.ID)
 		return
 	}
 
+	log.Printf("Processing event %s (%s) for game %s", event.ID, event.Type, event.GameID)
+
+	if event.Type == events.EventTypeGameCreated {
+		es.handleGameCreated(event)
+		return
+	}
+
+	notification, recipients := es.route(es.ctx, event)
+	if notification == nil {
+		return
+	}
+	notification.StreamId = message.ID
+
+	for _, playerID := range recipients {
+		es.clientManager.NotifyPlayer(playerID, notification)
+	}
+
+	switch event.Type {
+	case events.EventTypeMoveMade:
+		es.clientManager.NotifySpectators(event.GameID, notification)
+	case events.EventTypeGameOver:
+		es.clientManager.NotifySpectators(event.GameID, notification)
+		es.handleGameOver(event)
+	}
+}
+
+// parseEvent reads the event of a Redis stream message
+func parseEvent(message redis.XMessage) (events.Event, bool) {
 	var event events.Event
+
+	eventDataStr, ok := message.Values["data"].(string)
+	if !ok {
+		log.Printf("Invalid event data format in message %s", message.ID)
+		return event, false
+	}
+
 	if err := json.Unmarshal([]byte(eventDataStr), &event); err != nil {
 		log.Printf("Failed to unmarshal event %s: %v", message.ID, err)
-		return
+		return event, false
 	}
 
-	log.Printf("Processing event %s (%s) for game %s", event.ID, event.Type, event.GameID)
+	return event, true
+}
 
-	// Route event to appropriate handler
+// route returns the notification of an event and the players it is for, or
+// nil for events that aren't delivered to players
+func (es *EventSubscriber) route(ctx context.Context, event events.Event) (*notificationspb.Notification, []string) {
 	switch event.Type {
 	case events.EventTypeMatchFound:
-		es.handleMatchFound(event)
+		var data events.MatchFoundData
+		if err := mapToStruct(event.Data, &data); err != nil {
+			log.Printf("Failed to parse match found data: %v", err)
+			return nil, nil
+		}
+
+		// Notify both players about the match
+		return createMatchFoundNotification(event, data), []string{data.Player1ID, data.Player2ID}
+
+	case events.EventTypeMatchFailed:
+		var data events.MatchFailedData
+		if err := mapToStruct(event.Data, &data); err != nil {
+			log.Printf("Failed to parse match failed data: %v", err)
+			return nil, nil
+		}
+
+		// Tell both players they are back in the queue
+		return createMatchFailedNotification(event, data), []string{data.Player1ID, data.Player2ID}
+
+	case events.EventTypeChallenge:
+		var data events.ChallengeData
+		if err := mapToStruct(event.Data, &data); err != nil {
+			log.Printf("Failed to parse challenge data: %v", err)
+			return nil, nil
+		}
+
+		// Notify the challenger and the challenged player, if any
+		recipients := []string{data.ChallengerID}
+		if data.TargetID != "" {
+			recipients = append(recipients, data.TargetID)
+		}
+		return createChallengeNotification(event, data), recipients
+
 	case events.EventTypeMoveMade:
-		es.handleMoveMade(event)
+		var data events.MoveMadeData
+		if err := mapToStruct(event.Data, &data); err != nil {
+			log.Printf("Failed to parse move made data: %v", err)
+			return nil, nil
+		}
+
+		// Notify the opponent. Events published before they named the players
+		// are routed by the game's participants.
+		gameParticipants := []string{data.Player1ID, data.Player2ID}
+		if data.Player1ID == "" || data.Player2ID == "" {
+			var err error
+			gameParticipants, err = es.participants.GetGameParticipants(ctx, event.GameID)
+			if err != nil {
+				log.Printf("Failed to get participants of game %s: %v", event.GameID, err)
+			}
+		}
+
+		var recipients []string
+		for _, playerID := range gameParticipants {
+			// Don't notify the player who made the move
+			if playerID != data.PlayerID {
+				recipients = append(recipients, playerID)
+			}
+		}
+		return createMoveMadeNotification(event, data), recipients
+
 	case events.EventTypeGameOver:
-		es.handleGameOver(event)
+		var data events.GameOverData
+		if err := mapToStruct(event.Data, &data); err != nil {
+			log.Printf("Failed to parse game over data: %v", err)
+			return nil, nil
+		}
+
+		// Notify both players, the event names them
+		return createGameOverNotification(event, data), []string{data.Player1ID, data.Player2ID}
+
+	case events.EventTypeAccountLocked:
+		// Security events are for operators, not players
+		return nil, nil
+
 	default:
 		log.Printf("Unknown event type: %s", event.Type)
+		return nil, nil
 	}
 }
 
-// handleMatchFound processes match found events
-func (es *EventSubscriber) handleMatchFound(event events.Event) {
-	var data events.MatchFoundData
+// handleGameCreated records the players of a new game for routing its move notifications
+func (es *EventSubscriber) handleGameCreated(event events.Event) {
+	var data events.GameCreatedData
 	if err := mapToStruct(event.Data, &data); err != nil {
-		log.Printf("Failed to parse match found data: %v", err)
+		log.Printf("Failed to parse game created data: %v", err)
 		return
 	}
 
-	// Notify both players about the match
-	players := []string{data.Player1ID, data.Player2ID}
-	notification := createMatchFoundNotification(event, data)
-
-	for _, playerID := range players {
-		es.clientManager.NotifyPlayer(playerID, notification)
+	err := es.participants.AddGameParticipants(es.ctx, event.GameID, []string{data.Player1ID, data.Player2ID})
+	if err != nil {
+		log.Printf("Failed to record participants of game %s: %v", event.GameID, err)
 	}
 }
 
-// handleMoveMade processes move made events
-func (es *EventSubscriber) handleMoveMade(event events.Event) {
-	var data events.MoveMadeData
-	if err := mapToStruct(event.Data, &data); err != nil {
-		log.Printf("Failed to parse move made data: %v", err)
-		return
+// handleGameOver cleans up after a game once its players and spectators were notified
+func (es *EventSubscriber) handleGameOver(event events.Event) {
+	// Clean up game participants tracking and end the spectators' streams
+	if err := es.participants.RemoveGameParticipants(es.ctx, event.GameID); err != nil {
+		log.Printf("Failed to remove participants of game %s: %v", event.GameID, err)
 	}
+	es.clientManager.EndSpectators(event.GameID)
+}
 
-	// Get game participants to notify the opponent
-	gameParticipants := es.clientManager.GetGameParticipants(event.GameID)
-	notification := createMoveMadeNotification(event, data)
+// Replay sends a player the notifications published after lastEventID, going
+// back at most replayWindow. It returns the ID of the last stream entry it
+// read, so live notifications up to that entry can be skipped.
+func (es *EventSubscriber) Replay(ctx context.Context, playerID, lastEventID string, send func(*notificationspb.Notification) error) (string, error) {
+	start := "(" + lastEventID
+	if oldest := time.Now().Add(-replayWindow).UnixMilli(); streamIDMillis(lastEventID) < oldest {
+		start = fmt.Sprintf("%d-0", oldest)
+	}
 
-	for _, playerID := range gameParticipants {
-		// Don't notify the player who made the move
-		if playerID != data.PlayerID {
-			es.clientManager.NotifyPlayer(playerID, notification)
+	replayedUpTo := lastEventID
+	replayed := 0
+	for {
+		messages, err := es.redisClient.XRangeN(ctx, events.EventsStreamKey, start, "+", replayBatchSize).Result()
+		if err != nil {
+			return replayedUpTo, err
 		}
-	}
-}
 
-// handleGameOver processes game over events
-func (es *EventSubscriber) handleGameOver(event events.Event) {
-	var data events.GameOverData
-	if err := mapToStruct(event.Data, &data); err != nil {
-		log.Printf("Failed to parse game over data: %v", err)
-		return
-	}
+		for _, message := range messages {
+			replayedUpTo = message.ID
 
-	// Notify all game participants
-	gameParticipants := es.clientManager.GetGameParticipants(event.GameID)
-	notification := createGameOverNotification(event, data)
+			event, ok := parseEvent(message)
+			if !ok || event.Type == events.EventTypeGameCreated {
+				continue
+			}
 
-	for _, playerID := range gameParticipants {
-		es.clientManager.NotifyPlayer(playerID, notification)
+			notification, recipients := es.route(ctx, event)
+			if notification == nil || !contains(recipients, playerID) {
+				continue
+			}
+			notification.StreamId = message.ID
+
+			if err := send(notification); err != nil {
+				return replayedUpTo, err
+			}
+			replayed++
+		}
+
+		if len(messages) < replayBatchSize {
+			log.Printf("Replayed %d missed notifications to player %s", replayed, playerID)
+			return replayedUpTo, nil
+		}
+		start = "(" + replayedUpTo
 	}
+}
 
-	// Clean up game participants tracking
-	es.clientManager.RemoveGameParticipants(event.GameID)
+// contains reports whether a player is one of the recipients
+func contains(recipients []string, playerID string) bool {
+	for _, recipient := range recipients {
+		if recipient == playerID {
+			return true
+		}
+	}
+	return false
 }
 
 // Helper function to convert map to struct
@@ -208,3 +497,12 @@ func mapToStruct(input map[string]interface{}, output interface{}) error {
 	}
 	return json.Unmarshal(bytes, output)
 }
+
+// instanceName names this instance, and with it its consumer group
+func instanceName() string {
+	name, err := os.Hostname()
+	if err != nil || name == "" {
+		name = uuid.New().String()
+	}
+	return name
+}
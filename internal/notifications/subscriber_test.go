@@ -0,0 +1,250 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/laerson/mancala/internal/events"
+	notificationspb "github.com/laerson/mancala/proto/notifications"
+)
+
+// streamMessage wraps an event in a Redis stream message as published by the EventPublisher
+func streamMessage(t *testing.T, id string, event events.Event) redis.XMessage {
+	t.Helper()
+
+	eventJSON, err := json.Marshal(event)
+	if err != nil {
+		t.Fatalf("failed to marshal event: %v", err)
+	}
+	return redis.XMessage{ID: id, Values: map[string]interface{}{"data": string(eventJSON)}}
+}
+
+func TestEventSubscriber_GameCreatedRoutesMoves(t *testing.T) {
+	cm := NewClientManager()
+	participants := NewMemoryParticipantStore()
+	subscriber := NewEventSubscriber("localhost:6379", cm, participants)
+
+	player1 := cm.AddClient("p1", &mockStream{})
+	player2 := cm.AddClient("p2", &mockStream{})
+
+	// A game created without matchmaking, e.g. a bot game or a direct creation
+	subscriber.processMessage(streamMessage(t, "1-0", events.Event{
+		Type:   events.EventTypeGameCreated,
+		GameID: "game1",
+		Data:   map[string]interface{}{"player1_id": "p1", "player2_id": "p2"},
+	}))
+
+	subscriber.processMessage(streamMessage(t, "2-0", events.Event{
+		Type:   events.EventTypeMoveMade,
+		GameID: "game1",
+		Data:   map[string]interface{}{"player_id": "p1", "pit_index": 2},
+	}))
+
+	if len(player2.queue) != 1 {
+		t.Fatalf("processMessage() queued %d notifications for the opponent, want 1", len(player2.queue))
+	}
+	if len(player1.queue) != 0 {
+		t.Errorf("processMessage() queued %d notifications for the mover, want 0", len(player1.queue))
+	}
+	if got := <-player2.queue; got.Type != notificationspb.NotificationType_NOTIFICATION_TYPE_MOVE_MADE || got.StreamId != "2-0" {
+		t.Errorf("processMessage() notification = %v %q, want MOVE_MADE with stream ID 2-0", got.Type, got.StreamId)
+	}
+
+	subscriber.processMessage(streamMessage(t, "3-0", events.Event{
+		Type:   events.EventTypeGameOver,
+		GameID: "game1",
+		Data:   map[string]interface{}{"player1_id": "p1", "player2_id": "p2", "winner_id": "p1"},
+	}))
+
+	if len(player1.queue) != 1 || len(player2.queue) != 1 {
+		t.Errorf("processMessage() of game over queued %d and %d notifications, want 1 for each player", len(player1.queue), len(player2.queue))
+	}
+
+	remaining, _ := participants.GetGameParticipants(context.Background(), "game1")
+	if len(remaining) != 0 {
+		t.Errorf("GetGameParticipants() after game over = %v, want none", remaining)
+	}
+}
+
+func TestEventSubscriber_EveryInstanceSeesEveryEvent(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	// Two instances with a player connected to each
+	instances := make([]*mockStream, 2)
+	for i, name := range []string{"instance-a", "instance-b"} {
+		cm := NewClientManager()
+		subscriber := NewEventSubscriber(redisAddr, cm, NewRedisParticipantStore(redisAddr))
+		subscriber.consumerGroup = consumerGroupPrefix + name
+		subscriber.consumerName = name
+
+		if err := subscriber.Start(); err != nil {
+			t.Fatalf("Start() error = %v", err)
+		}
+		t.Cleanup(subscriber.Stop)
+
+		instances[i] = &mockStream{}
+		client := cm.AddClient("p1", instances[i])
+
+		serveCtx, stopServing := context.WithCancel(ctx)
+		t.Cleanup(stopServing)
+		go client.serve(serveCtx, "")
+	}
+
+	publisher := events.NewEventPublisher(redisAddr)
+	if err := publisher.PublishMatchFailed(ctx, "p1", "p2", "test"); err != nil {
+		t.Fatalf("PublishMatchFailed() error = %v", err)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && (instances[0].count() == 0 || instances[1].count() == 0) {
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	for i, stream := range instances {
+		if stream.count() != 1 {
+			t.Errorf("Instance %d sent %d notifications, want 1", i, stream.count())
+		}
+	}
+}
+
+func TestEventSubscriber_ReplayMissedNotifications(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	subscriber := NewEventSubscriber(redisAddr, NewClientManager(), NewRedisParticipantStore(redisAddr))
+	publisher := events.NewEventPublisher(redisAddr)
+
+	if err := publisher.PublishMatchFailed(ctx, "p1", "p2", "before"); err != nil {
+		t.Fatalf("PublishMatchFailed() error = %v", err)
+	}
+	before, err := subscriber.redisClient.XRevRangeN(ctx, events.EventsStreamKey, "+", "-", 1).Result()
+	if err != nil || len(before) != 1 {
+		t.Fatalf("XRevRangeN() = %v, %v", before, err)
+	}
+
+	// Missed while disconnected: one for p1 and one for other players
+	publisher.PublishMatchFailed(ctx, "p1", "p2", "missed")
+	publisher.PublishMatchFailed(ctx, "p3", "p4", "someone else")
+
+	var replayed []*notificationspb.Notification
+	replayedUpTo, err := subscriber.Replay(ctx, "p1", before[0].ID, func(notification *notificationspb.Notification) error {
+		replayed = append(replayed, notification)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if len(replayed) != 1 || replayed[0].GetMatchFailed().Reason != "missed" {
+		t.Fatalf("Replay() sent %v, want only the missed notification", replayed)
+	}
+	if !streamIDAfter(replayedUpTo, replayed[0].StreamId) {
+		t.Errorf("Replay() read up to %s, want past the last event %s", replayedUpTo, replayed[0].StreamId)
+	}
+}
+
+func TestEventSubscriber_RouteMoveAfterGameOver(t *testing.T) {
+	participants := NewMemoryParticipantStore()
+	subscriber := NewEventSubscriber("localhost:6379", NewClientManager(), participants)
+
+	// The participants of a finished game are gone, the move still names both players
+	move := events.Event{
+		Type:   events.EventTypeMoveMade,
+		GameID: "game1",
+		Data:   map[string]interface{}{"player_id": "p1", "player1_id": "p1", "player2_id": "p2", "pit_index": 2},
+	}
+	notification, recipients := subscriber.route(context.Background(), move)
+	if notification == nil || len(recipients) != 1 || recipients[0] != "p2" {
+		t.Errorf("route() of a move of a finished game = %v, %v, want the opponent p2", notification, recipients)
+	}
+}
+
+func TestEventSubscriber_ReplayAfterGameOver(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	participants := NewRedisParticipantStore(redisAddr)
+	subscriber := NewEventSubscriber(redisAddr, NewClientManager(), participants)
+	publisher := events.NewEventPublisher(redisAddr)
+
+	if err := publisher.PublishMatchFailed(ctx, "p3", "p4", "before"); err != nil {
+		t.Fatalf("PublishMatchFailed() error = %v", err)
+	}
+	before, err := subscriber.redisClient.XRevRangeN(ctx, events.EventsStreamKey, "+", "-", 1).Result()
+	if err != nil || len(before) != 1 {
+		t.Fatalf("XRevRangeN() = %v, %v", before, err)
+	}
+
+	// p2 missed the last move and the end of a game whose participants are already forgotten
+	publisher.PublishMoveMade(ctx, "game1", "p1", "p2", "p1", 2, nil, nil, nil)
+	publisher.PublishGameOver(ctx, "game1", "p1", "p2", "p1", false, events.GameOverReasonCompleted, nil)
+	participants.client.Del(ctx, participantsKey("game1"))
+
+	var replayed []*notificationspb.Notification
+	if _, err := subscriber.Replay(ctx, "p2", before[0].ID, func(notification *notificationspb.Notification) error {
+		replayed = append(replayed, notification)
+		return nil
+	}); err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	if len(replayed) != 2 || replayed[0].Type != notificationspb.NotificationType_NOTIFICATION_TYPE_MOVE_MADE || replayed[1].Type != notificationspb.NotificationType_NOTIFICATION_TYPE_GAME_OVER {
+		t.Errorf("Replay() sent %v, want the move and the game over", replayed)
+	}
+}
+
+func TestEventSubscriber_ReplacementInstanceReclaimsPending(t *testing.T) {
+	redisAddr := startRedis(t)
+	ctx := context.Background()
+
+	crashed := NewEventSubscriber(redisAddr, NewClientManager(), NewRedisParticipantStore(redisAddr))
+	crashed.setInstance("notifications-0")
+	if err := crashed.createGroup(); err != nil {
+		t.Fatalf("createGroup() error = %v", err)
+	}
+
+	publisher := events.NewEventPublisher(redisAddr)
+	if err := publisher.PublishMatchFailed(ctx, "p1", "p2", "test"); err != nil {
+		t.Fatalf("PublishMatchFailed() error = %v", err)
+	}
+
+	// The pod reads the event and crashes before acknowledging it
+	streams, err := crashed.redisClient.XReadGroup(ctx, &redis.XReadGroupArgs{
+		Group:    crashed.consumerGroup,
+		Consumer: crashed.consumerName,
+		Streams:  []string{events.EventsStreamKey, ">"},
+	}).Result()
+	if err != nil || len(streams) != 1 || len(streams[0].Messages) != 1 {
+		t.Fatalf("XReadGroup() = %v, %v, want the event", streams, err)
+	}
+
+	// Its replacement comes back under the same name and delivers the event
+	cm := NewClientManager()
+	replacement := NewEventSubscriber(redisAddr, cm, NewRedisParticipantStore(redisAddr))
+	replacement.setInstance("notifications-0")
+	replacement.claimMinIdle = 100 * time.Millisecond
+
+	stream := &mockStream{}
+	client := cm.AddClient("p1", stream)
+	serveCtx, stopServing := context.WithCancel(ctx)
+	t.Cleanup(stopServing)
+	go client.serve(serveCtx, "")
+
+	time.Sleep(200 * time.Millisecond)
+	if err := replacement.Start(); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	t.Cleanup(replacement.Stop)
+
+	deadline := time.Now().Add(5 * time.Second)
+	for time.Now().Before(deadline) && stream.count() == 0 {
+		time.Sleep(50 * time.Millisecond)
+	}
+	if stream.count() != 1 {
+		t.Errorf("Replacement sent %d notifications, want the pending one", stream.count())
+	}
+}
@@ -0,0 +1,69 @@
+package rules
+
+import (
+	"fmt"
+
+	enginepb "github.com/laerson/mancala/proto/engine"
+)
+
+// Game plays one family of mancala games. Every family uses a board of pits with
+// one store per player, so callers can score a position by comparing the stores.
+type Game interface {
+	// InitialBoard returns the starting board
+	InitialBoard() []uint32
+	// LegalMoves returns the pits the player may sow, in board order
+	LegalMoves(pits []uint32, player enginepb.Player) []uint32
+	// ApplyMove sows the given pit and returns the resulting position. The input board is left untouched.
+	ApplyMove(pits []uint32, player enginepb.Player, pit uint32) (*MoveResult, error)
+	// StoreIndex returns the board index of the player's store
+	StoreIndex(player enginepb.Player) uint32
+}
+
+// Validate checks that the game type is known and that its rule set describes a playable variant
+func Validate(gameType enginepb.GameType, rs *enginepb.RuleSet) error {
+	switch gameType {
+	case enginepb.GameType_GAME_TYPE_KALAH:
+		return ValidateRuleSet(rs)
+	case enginepb.GameType_GAME_TYPE_OWARE:
+		if rs != nil {
+			return fmt.Errorf("%w: house rules only apply to Kalah", ErrInvalidRuleSet)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown game type %d", gameType)
+	}
+}
+
+// For returns the rules of a validated game type
+func For(gameType enginepb.GameType, rs *enginepb.RuleSet) Game {
+	if gameType == enginepb.GameType_GAME_TYPE_OWARE {
+		return oware{}
+	}
+	return kalah{rs: rs}
+}
+
+// ForState returns the rules a game state is played by
+func ForState(state *enginepb.GameState) Game {
+	return For(state.GetGameType(), state.GetRules())
+}
+
+// kalah adapts the Kalah functions of this package to the Game interface
+type kalah struct {
+	rs *enginepb.RuleSet
+}
+
+func (k kalah) InitialBoard() []uint32 {
+	return InitialBoard(k.rs)
+}
+
+func (k kalah) LegalMoves(pits []uint32, player enginepb.Player) []uint32 {
+	return LegalMoves(k.rs, pits, player)
+}
+
+func (k kalah) ApplyMove(pits []uint32, player enginepb.Player, pit uint32) (*MoveResult, error) {
+	return ApplyMove(k.rs, pits, player, pit)
+}
+
+func (k kalah) StoreIndex(player enginepb.Player) uint32 {
+	return StoreIndex(k.rs, player)
+}
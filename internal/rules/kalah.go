@@ -0,0 +1,261 @@
+// Package rules implements the Kalah and Oware rules as pure functions over a board,
+// so the engine service and the bot AI play by exactly the same rules.
+package rules
+
+import (
+	"fmt"
+
+	enginepb "github.com/laerson/mancala/proto/engine"
+)
+
+const (
+	// BoardSize is the number of pits on a standard board, including both stores
+	BoardSize = 14
+	// PitsPerSide is the number of playable pits for each player on a standard board
+	PitsPerSide = 6
+	// InitialSeeds is the number of seeds in each pit at the start of a standard game
+	InitialSeeds = 4
+
+	// MaxPitsPerSide and MaxInitialSeeds bound house-rule variants
+	MaxPitsPerSide  = 12
+	MaxInitialSeeds = 12
+)
+
+var ErrInvalidBoard = fmt.Errorf("board size does not match the rule set")
+var ErrInvalidPitIndex = fmt.Errorf("invalid pit index for current player")
+var ErrEmptyPit = fmt.Errorf("pit cannot be empty")
+var ErrInvalidRuleSet = fmt.Errorf("invalid rule set")
+
+// MoveResult is the outcome of applying a single move to a board
+type MoveResult struct {
+	Pits       []uint32
+	NextPlayer enginepb.Player
+	ExtraTurn  bool
+	Captured   bool
+	IsFinished bool
+	Winner     enginepb.Winner
+}
+
+// ValidateRuleSet checks that a rule set describes a playable variant. A nil rule set is standard Kalah.
+func ValidateRuleSet(rs *enginepb.RuleSet) error {
+	if rs.GetPitsPerSide() > MaxPitsPerSide {
+		return fmt.Errorf("%w: at most %d pits per side", ErrInvalidRuleSet, MaxPitsPerSide)
+	}
+	if rs.GetInitialSeeds() > MaxInitialSeeds {
+		return fmt.Errorf("%w: at most %d initial seeds", ErrInvalidRuleSet, MaxInitialSeeds)
+	}
+	if rs.GetSweepPolicy() != enginepb.SweepPolicy_SWEEP_TO_OWNER && rs.GetSweepPolicy() != enginepb.SweepPolicy_SWEEP_TO_MOVER {
+		return fmt.Errorf("%w: unknown sweep policy", ErrInvalidRuleSet)
+	}
+	if rs.GetDisableCapture() && rs.GetEmptyCapture() {
+		return fmt.Errorf("%w: empty capture requires captures", ErrInvalidRuleSet)
+	}
+	return nil
+}
+
+// InitialBoard returns the starting board of the rule set
+func InitialBoard(rs *enginepb.RuleSet) []uint32 {
+	n := pitsPerSide(rs)
+	seeds := initialSeeds(rs)
+
+	board := make([]uint32, 2*n+2)
+	for i := range board {
+		if uint32(i) != n && uint32(i) != 2*n+1 {
+			board[i] = seeds
+		}
+	}
+	return board
+}
+
+// ValidateMove checks that the player may sow the given pit on the given board
+func ValidateMove(rs *enginepb.RuleSet, pits []uint32, player enginepb.Player, pit uint32) error {
+	if len(pits) != boardSize(rs) {
+		return ErrInvalidBoard
+	}
+
+	if !IsPlayablePit(rs, pit, player) {
+		return ErrInvalidPitIndex
+	}
+
+	if pits[pit] == 0 {
+		return ErrEmptyPit
+	}
+
+	return nil
+}
+
+// ApplyMove sows the given pit and resolves captures, extra turns and the end of the game.
+// The input board is left untouched.
+func ApplyMove(rs *enginepb.RuleSet, pits []uint32, player enginepb.Player, pit uint32) (*MoveResult, error) {
+	if err := ValidateMove(rs, pits, player, pit); err != nil {
+		return nil, err
+	}
+
+	board := make([]uint32, len(pits))
+	copy(board, pits)
+
+	size := uint32(len(board))
+	ownStore := StoreIndex(rs, player)
+	opponentStore := StoreIndex(rs, Opponent(player))
+
+	seeds := board[pit]
+	board[pit] = 0
+
+	// distribution logic
+	for seeds > 0 {
+		pit = (pit + 1) % size
+		if pit == opponentStore {
+			pit = (pit + 1) % size // Skip opponent's store
+		}
+		board[pit] += 1
+		seeds -= 1
+	}
+
+	// Check Capture
+	captured := false
+	if !rs.GetDisableCapture() && IsPlayablePit(rs, pit, player) && board[pit] == 1 {
+		opposite := OppositePit(rs, pit)
+		if board[opposite] > 0 || rs.GetEmptyCapture() {
+			board[ownStore] += 1 + board[opposite]
+			board[opposite] = 0
+			board[pit] = 0
+			captured = true
+		}
+	}
+
+	result := &MoveResult{
+		Pits:       board,
+		NextPlayer: player,
+		ExtraTurn:  pit == ownStore,
+		Captured:   captured,
+		Winner:     enginepb.Winner_NO_WINNER,
+	}
+
+	// The game ends as soon as either side runs out of seeds
+	if sideIsEmpty(rs, board, enginepb.Player_PLAYER_ONE) || sideIsEmpty(rs, board, enginepb.Player_PLAYER_TWO) {
+		if rs.GetSweepPolicy() == enginepb.SweepPolicy_SWEEP_TO_MOVER {
+			sweep(rs, board, enginepb.Player_PLAYER_ONE, player)
+			sweep(rs, board, enginepb.Player_PLAYER_TWO, player)
+		} else {
+			sweep(rs, board, enginepb.Player_PLAYER_ONE, enginepb.Player_PLAYER_ONE)
+			sweep(rs, board, enginepb.Player_PLAYER_TWO, enginepb.Player_PLAYER_TWO)
+		}
+		result.IsFinished = true
+		result.Winner = winner(rs, board)
+	}
+
+	if !result.ExtraTurn {
+		result.NextPlayer = Opponent(player)
+	}
+
+	return result, nil
+}
+
+// LegalMoves returns the non-empty pits the player may sow, in board order
+func LegalMoves(rs *enginepb.RuleSet, pits []uint32, player enginepb.Player) []uint32 {
+	var moves []uint32
+	if len(pits) != boardSize(rs) {
+		return moves
+	}
+
+	first := firstPit(rs, player)
+	for pit := first; pit < first+pitsPerSide(rs); pit++ {
+		if pits[pit] > 0 {
+			moves = append(moves, pit)
+		}
+	}
+
+	return moves
+}
+
+// IsPlayablePit returns true if the given pit is playable by the given player.
+func IsPlayablePit(rs *enginepb.RuleSet, p uint32, player enginepb.Player) bool {
+	first := firstPit(rs, player)
+	return p >= first && p < first+pitsPerSide(rs)
+}
+
+// StoreIndex returns the board index of the player's store
+func StoreIndex(rs *enginepb.RuleSet, player enginepb.Player) uint32 {
+	n := pitsPerSide(rs)
+	if player == enginepb.Player_PLAYER_TWO {
+		return 2*n + 1
+	}
+	return n
+}
+
+// OppositePit returns the pit facing the given pit across the board
+func OppositePit(rs *enginepb.RuleSet, pit uint32) uint32 {
+	return 2*pitsPerSide(rs) - pit
+}
+
+// Opponent returns the other player
+func Opponent(player enginepb.Player) enginepb.Player {
+	if player == enginepb.Player_PLAYER_ONE {
+		return enginepb.Player_PLAYER_TWO
+	}
+	return enginepb.Player_PLAYER_ONE
+}
+
+// pitsPerSide returns the number of playable pits per player, defaulting to the standard board
+func pitsPerSide(rs *enginepb.RuleSet) uint32 {
+	if rs.GetPitsPerSide() == 0 {
+		return PitsPerSide
+	}
+	return rs.GetPitsPerSide()
+}
+
+// initialSeeds returns the seeds per pit at the start, defaulting to the standard game
+func initialSeeds(rs *enginepb.RuleSet) uint32 {
+	if rs.GetInitialSeeds() == 0 {
+		return InitialSeeds
+	}
+	return rs.GetInitialSeeds()
+}
+
+// boardSize returns the number of pits on the board, including both stores
+func boardSize(rs *enginepb.RuleSet) int {
+	return int(2*pitsPerSide(rs) + 2)
+}
+
+// firstPit returns the board index of the player's first playable pit
+func firstPit(rs *enginepb.RuleSet, player enginepb.Player) uint32 {
+	if player == enginepb.Player_PLAYER_TWO {
+		return pitsPerSide(rs) + 1
+	}
+	return 0
+}
+
+// sideIsEmpty reports whether all of the player's pits are empty
+func sideIsEmpty(rs *enginepb.RuleSet, board []uint32, player enginepb.Player) bool {
+	first := firstPit(rs, player)
+	for _, v := range board[first : first+pitsPerSide(rs)] {
+		if v > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// sweep moves the seeds left on the side's pits into the collector's store
+func sweep(rs *enginepb.RuleSet, board []uint32, side, collector enginepb.Player) {
+	first := firstPit(rs, side)
+	store := StoreIndex(rs, collector)
+	for i := first; i < first+pitsPerSide(rs); i++ {
+		board[store] += board[i]
+		board[i] = 0
+	}
+}
+
+// winner compares the stores of a finished board
+func winner(rs *enginepb.RuleSet, board []uint32) enginepb.Winner {
+	storeOne := board[StoreIndex(rs, enginepb.Player_PLAYER_ONE)]
+	storeTwo := board[StoreIndex(rs, enginepb.Player_PLAYER_TWO)]
+	switch {
+	case storeOne > storeTwo:
+		return enginepb.Winner_WINNER_PLAYER_ONE
+	case storeTwo > storeOne:
+		return enginepb.Winner_WINNER_PLAYER_TWO
+	default:
+		return enginepb.Winner_DRAW
+	}
+}
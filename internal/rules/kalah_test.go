@@ -0,0 +1,201 @@
+package rules
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	enginepb "github.com/laerson/mancala/proto/engine"
+)
+
+func TestApplyMove(t *testing.T) {
+	tests := []struct {
+		name   string
+		rules  *enginepb.RuleSet
+		pits   []uint32
+		player enginepb.Player
+		pit    uint32
+		want   *MoveResult
+	}{
+		{
+			name:   "player two skips player one's store",
+			pits:   []uint32{1, 1, 1, 1, 1, 1, 0, 4, 4, 4, 4, 4, 8, 0},
+			player: enginepb.Player_PLAYER_TWO,
+			pit:    12,
+			want: &MoveResult{
+				Pits:       []uint32{2, 2, 2, 2, 2, 2, 0, 5, 4, 4, 4, 4, 0, 1},
+				NextPlayer: enginepb.Player_PLAYER_ONE,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "capture for player two",
+			pits:   []uint32{1, 1, 1, 1, 1, 3, 0, 1, 0, 0, 1, 0, 0, 0},
+			player: enginepb.Player_PLAYER_TWO,
+			pit:    7,
+			want: &MoveResult{
+				Pits:       []uint32{1, 1, 1, 1, 0, 3, 0, 0, 0, 0, 1, 0, 0, 2},
+				NextPlayer: enginepb.Player_PLAYER_ONE,
+				Captured:   true,
+				IsFinished: false,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "extra turn for player two",
+			pits:   []uint32{1, 1, 1, 1, 1, 1, 0, 0, 0, 0, 0, 0, 1, 0},
+			player: enginepb.Player_PLAYER_TWO,
+			pit:    12,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 0, 6, 0, 0, 0, 0, 0, 0, 1},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				ExtraTurn:  true,
+				IsFinished: true,
+				Winner:     enginepb.Winner_WINNER_PLAYER_ONE,
+			},
+		},
+		{
+			name:   "remaining seeds are swept to their owner",
+			pits:   []uint32{0, 0, 0, 0, 0, 1, 10, 2, 0, 0, 0, 0, 3, 5},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    5,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 0, 11, 0, 0, 0, 0, 0, 0, 10},
+				NextPlayer: enginepb.Player_PLAYER_ONE,
+				ExtraTurn:  true,
+				IsFinished: true,
+				Winner:     enginepb.Winner_WINNER_PLAYER_ONE,
+			},
+		},
+		{
+			name:   "four pits per side",
+			rules:  &enginepb.RuleSet{PitsPerSide: 4},
+			pits:   []uint32{1, 1, 1, 6, 0, 2, 2, 2, 2, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    3,
+			want: &MoveResult{
+				Pits:       []uint32{2, 1, 1, 0, 1, 3, 3, 3, 3, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "captures disabled",
+			rules:  &enginepb.RuleSet{DisableCapture: true},
+			pits:   []uint32{1, 0, 0, 0, 0, 1, 0, 1, 1, 1, 1, 1, 1, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    0,
+			want: &MoveResult{
+				Pits:       []uint32{0, 1, 0, 0, 0, 1, 0, 1, 1, 1, 1, 1, 1, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "empty capture takes the last seed alone",
+			rules:  &enginepb.RuleSet{EmptyCapture: true},
+			pits:   []uint32{1, 0, 0, 0, 0, 1, 0, 1, 1, 1, 1, 0, 1, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    0,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 0, 1, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Captured:   true,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "remaining seeds are swept to the mover",
+			rules:  &enginepb.RuleSet{SweepPolicy: enginepb.SweepPolicy_SWEEP_TO_MOVER},
+			pits:   []uint32{0, 0, 0, 0, 0, 1, 10, 2, 0, 0, 0, 0, 3, 5},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    5,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 0, 16, 0, 0, 0, 0, 0, 0, 5},
+				NextPlayer: enginepb.Player_PLAYER_ONE,
+				ExtraTurn:  true,
+				IsFinished: true,
+				Winner:     enginepb.Winner_WINNER_PLAYER_ONE,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			original := append([]uint32(nil), tt.pits...)
+
+			got, err := ApplyMove(tt.rules, tt.pits, tt.player, tt.pit)
+			if err != nil {
+				t.Fatalf("ApplyMove() error = %v", err)
+			}
+			if !cmp.Equal(got, tt.want) {
+				t.Errorf("ApplyMove() = %+v, want %+v", got, tt.want)
+			}
+			if !cmp.Equal(tt.pits, original) {
+				t.Errorf("ApplyMove() modified input board to %v", tt.pits)
+			}
+		})
+	}
+}
+
+func TestLegalMoves(t *testing.T) {
+	pits := []uint32{0, 2, 0, 1, 0, 0, 5, 3, 0, 0, 0, 0, 1, 2}
+
+	if got, want := LegalMoves(nil, pits, enginepb.Player_PLAYER_ONE), []uint32{1, 3}; !cmp.Equal(got, want) {
+		t.Errorf("LegalMoves(PLAYER_ONE) = %v, want %v", got, want)
+	}
+	if got, want := LegalMoves(nil, pits, enginepb.Player_PLAYER_TWO), []uint32{7, 12}; !cmp.Equal(got, want) {
+		t.Errorf("LegalMoves(PLAYER_TWO) = %v, want %v", got, want)
+	}
+	if got := LegalMoves(nil, pits[:5], enginepb.Player_PLAYER_ONE); len(got) != 0 {
+		t.Errorf("LegalMoves() on invalid board = %v, want none", got)
+	}
+}
+
+func TestInitialBoard(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules *enginepb.RuleSet
+		want  []uint32
+	}{
+		{
+			name: "standard",
+			want: []uint32{4, 4, 4, 4, 4, 4, 0, 4, 4, 4, 4, 4, 4, 0},
+		},
+		{
+			name:  "three pits with six seeds",
+			rules: &enginepb.RuleSet{PitsPerSide: 3, InitialSeeds: 6},
+			want:  []uint32{6, 6, 6, 0, 6, 6, 6, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := InitialBoard(tt.rules); !cmp.Equal(got, tt.want) {
+				t.Errorf("InitialBoard() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateRuleSet(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   *enginepb.RuleSet
+		wantErr bool
+	}{
+		{name: "standard", rules: nil},
+		{name: "house rules", rules: &enginepb.RuleSet{PitsPerSide: 8, InitialSeeds: 3, EmptyCapture: true, SweepPolicy: enginepb.SweepPolicy_SWEEP_TO_MOVER}},
+		{name: "too many pits", rules: &enginepb.RuleSet{PitsPerSide: MaxPitsPerSide + 1}, wantErr: true},
+		{name: "too many seeds", rules: &enginepb.RuleSet{InitialSeeds: MaxInitialSeeds + 1}, wantErr: true},
+		{name: "unknown sweep policy", rules: &enginepb.RuleSet{SweepPolicy: 7}, wantErr: true},
+		{name: "empty capture without captures", rules: &enginepb.RuleSet{DisableCapture: true, EmptyCapture: true}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ValidateRuleSet(tt.rules); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRuleSet() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package rules
+
+import (
+	"fmt"
+
+	enginepb "github.com/laerson/mancala/proto/engine"
+)
+
+// Oware abapa is played on the same fourteen pit board as standard Kalah. The stores
+// at indices 6 and 13 are never sown into and only hold the seeds each player captured.
+const (
+	// OwareInitialSeeds is the number of seeds in each house at the start of the game
+	OwareInitialSeeds = 4
+	// OwareWinningScore is the number of captured seeds that wins the game outright
+	OwareWinningScore = 25
+)
+
+var ErrMustFeed = fmt.Errorf("move must give the opponent seeds")
+
+// oware implements Oware abapa: sowing skips the origin house, 2s and 3s are captured
+// backwards on the opponent's side, a grand slam captures nothing and a player must
+// feed an opponent who has no seeds. Endless cycles are not detected.
+type oware struct{}
+
+func (oware) InitialBoard() []uint32 {
+	board := make([]uint32, BoardSize)
+	for i := range board {
+		if uint32(i) != PitsPerSide && uint32(i) != 2*PitsPerSide+1 {
+			board[i] = OwareInitialSeeds
+		}
+	}
+	return board
+}
+
+func (o oware) LegalMoves(pits []uint32, player enginepb.Player) []uint32 {
+	var moves []uint32
+	if len(pits) != BoardSize {
+		return moves
+	}
+
+	mustFeed := sideIsEmpty(nil, pits, Opponent(player))
+	first := firstPit(nil, player)
+	for pit := first; pit < first+PitsPerSide; pit++ {
+		if pits[pit] == 0 {
+			continue
+		}
+		// A house feeds the opponent when its seeds reach past the player's last house
+		if mustFeed && pits[pit] < first+PitsPerSide-pit {
+			continue
+		}
+		moves = append(moves, pit)
+	}
+
+	return moves
+}
+
+func (o oware) ApplyMove(pits []uint32, player enginepb.Player, pit uint32) (*MoveResult, error) {
+	if err := ValidateMove(nil, pits, player, pit); err != nil {
+		return nil, err
+	}
+	if !containsMove(o.LegalMoves(pits, player), pit) {
+		return nil, ErrMustFeed
+	}
+
+	board := make([]uint32, len(pits))
+	copy(board, pits)
+
+	origin := pit
+	seeds := board[pit]
+	board[pit] = 0
+
+	// Sow around the houses, skipping both stores and the origin house on a full lap
+	for seeds > 0 {
+		pit = owareNextHouse(pit)
+		if pit == origin {
+			continue
+		}
+		board[pit] += 1
+		seeds -= 1
+	}
+
+	// Capture 2s and 3s backwards from the last house while they are on the opponent's side
+	opponent := Opponent(player)
+	var houses []uint32
+	var captured uint32
+	for IsPlayablePit(nil, pit, opponent) && (board[pit] == 2 || board[pit] == 3) {
+		houses = append(houses, pit)
+		captured += board[pit]
+		pit = owarePreviousHouse(pit)
+	}
+
+	// A grand slam would leave the opponent without seeds, so it captures nothing
+	if captured > 0 && captured < sideSeeds(board, opponent) {
+		for _, house := range houses {
+			board[house] = 0
+		}
+		board[StoreIndex(nil, player)] += captured
+	} else {
+		captured = 0
+	}
+
+	result := &MoveResult{
+		Pits:       board,
+		NextPlayer: opponent,
+		Captured:   captured > 0,
+		Winner:     enginepb.Winner_NO_WINNER,
+	}
+
+	storeOne := board[StoreIndex(nil, enginepb.Player_PLAYER_ONE)]
+	storeTwo := board[StoreIndex(nil, enginepb.Player_PLAYER_TWO)]
+	switch {
+	case storeOne >= OwareWinningScore || storeTwo >= OwareWinningScore || (storeOne == OwareWinningScore-1 && storeTwo == storeOne):
+		result.IsFinished = true
+	case len(o.LegalMoves(board, opponent)) == 0:
+		// The opponent cannot feed the mover, so they collect every seed left on the board
+		sweep(nil, board, enginepb.Player_PLAYER_ONE, opponent)
+		sweep(nil, board, enginepb.Player_PLAYER_TWO, opponent)
+		result.IsFinished = true
+	}
+
+	if result.IsFinished {
+		result.Winner = winner(nil, board)
+	}
+
+	return result, nil
+}
+
+func (oware) StoreIndex(player enginepb.Player) uint32 {
+	return StoreIndex(nil, player)
+}
+
+// owareNextHouse returns the house sown after the given one, skipping the stores
+func owareNextHouse(pit uint32) uint32 {
+	pit = (pit + 1) % BoardSize
+	if pit == PitsPerSide || pit == 2*PitsPerSide+1 {
+		pit = (pit + 1) % BoardSize
+	}
+	return pit
+}
+
+// owarePreviousHouse returns the house sown before the given one, skipping the stores
+func owarePreviousHouse(pit uint32) uint32 {
+	pit = (pit + BoardSize - 1) % BoardSize
+	if pit == PitsPerSide || pit == 2*PitsPerSide+1 {
+		pit = (pit + BoardSize - 1) % BoardSize
+	}
+	return pit
+}
+
+// sideSeeds returns the number of seeds in the player's houses
+func sideSeeds(board []uint32, player enginepb.Player) uint32 {
+	var seeds uint32
+	first := firstPit(nil, player)
+	for _, v := range board[first : first+PitsPerSide] {
+		seeds += v
+	}
+	return seeds
+}
+
+// containsMove reports whether the pit is one of the moves
+func containsMove(moves []uint32, pit uint32) bool {
+	for _, move := range moves {
+		if move == pit {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,139 @@
+package rules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	enginepb "github.com/laerson/mancala/proto/engine"
+)
+
+func TestOwareApplyMove(t *testing.T) {
+	tests := []struct {
+		name   string
+		pits   []uint32
+		player enginepb.Player
+		pit    uint32
+		want   *MoveResult
+	}{
+		{
+			name:   "opening move skips the store",
+			pits:   []uint32{4, 4, 4, 4, 4, 4, 0, 4, 4, 4, 4, 4, 4, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    5,
+			want: &MoveResult{
+				Pits:       []uint32{4, 4, 4, 4, 4, 0, 0, 5, 5, 5, 5, 4, 4, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "full lap skips the origin house",
+			pits:   []uint32{12, 0, 0, 0, 0, 0, 0, 1, 1, 1, 1, 1, 1, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    0,
+			want: &MoveResult{
+				Pits:       []uint32{0, 2, 1, 1, 1, 1, 0, 2, 2, 2, 2, 2, 2, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "captures 2s and 3s backwards",
+			pits:   []uint32{0, 0, 0, 0, 3, 0, 0, 1, 2, 5, 0, 0, 0, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    4,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 1, 5, 0, 0, 5, 0, 0, 0, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Captured:   true,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "grand slam captures nothing",
+			pits:   []uint32{0, 0, 0, 0, 3, 0, 0, 1, 2, 0, 0, 0, 0, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    4,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 1, 0, 2, 3, 0, 0, 0, 0, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Winner:     enginepb.Winner_NO_WINNER,
+			},
+		},
+		{
+			name:   "capturing 25 seeds wins",
+			pits:   []uint32{0, 0, 0, 0, 0, 1, 24, 2, 0, 0, 0, 0, 1, 0},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    5,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 0, 27, 0, 0, 0, 0, 0, 1, 0},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				Captured:   true,
+				IsFinished: true,
+				Winner:     enginepb.Winner_WINNER_PLAYER_ONE,
+			},
+		},
+		{
+			name:   "opponent unable to feed collects the remaining seeds",
+			pits:   []uint32{0, 0, 0, 0, 0, 1, 20, 0, 1, 0, 0, 0, 0, 5},
+			player: enginepb.Player_PLAYER_ONE,
+			pit:    5,
+			want: &MoveResult{
+				Pits:       []uint32{0, 0, 0, 0, 0, 0, 20, 0, 0, 0, 0, 0, 0, 7},
+				NextPlayer: enginepb.Player_PLAYER_TWO,
+				IsFinished: true,
+				Winner:     enginepb.Winner_WINNER_PLAYER_ONE,
+			},
+		},
+	}
+
+	game := For(enginepb.GameType_GAME_TYPE_OWARE, nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := game.ApplyMove(tt.pits, tt.player, tt.pit)
+			if err != nil {
+				t.Fatalf("ApplyMove() error = %v, want nil", err)
+			}
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("ApplyMove() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestOwareMustFeed(t *testing.T) {
+	game := For(enginepb.GameType_GAME_TYPE_OWARE, nil)
+	pits := []uint32{1, 0, 0, 0, 0, 1, 10, 0, 0, 0, 0, 0, 0, 12}
+
+	if diff := cmp.Diff([]uint32{5}, game.LegalMoves(pits, enginepb.Player_PLAYER_ONE)); diff != "" {
+		t.Errorf("LegalMoves() mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := game.ApplyMove(pits, enginepb.Player_PLAYER_ONE, 0); !errors.Is(err, ErrMustFeed) {
+		t.Errorf("ApplyMove() error = %v, want %v", err, ErrMustFeed)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		gameType enginepb.GameType
+		rules    *enginepb.RuleSet
+		wantErr  bool
+	}{
+		{name: "kalah house rules", gameType: enginepb.GameType_GAME_TYPE_KALAH, rules: &enginepb.RuleSet{PitsPerSide: 4}},
+		{name: "oware", gameType: enginepb.GameType_GAME_TYPE_OWARE},
+		{name: "oware with house rules", gameType: enginepb.GameType_GAME_TYPE_OWARE, rules: &enginepb.RuleSet{}, wantErr: true},
+		{name: "unknown game type", gameType: enginepb.GameType(7), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.gameType, tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,337 @@
+package tournaments
+
+import (
+	"math"
+	"sort"
+
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+)
+
+// maxPairingSteps bounds the search for a Swiss round without rematches. Past it,
+// rematches are allowed rather than stalling the tournament on a large field.
+const maxPairingSteps = 100000
+
+// TotalRounds returns the number of rounds a tournament plays with the given number of players.
+// Round-robin plays everyone once; Swiss plays the requested rounds, or enough to find a
+// winner when none were requested, but never more than round-robin would.
+func TotalRounds(format tournamentspb.TournamentFormat, requested, players int) int {
+	maxRounds := players - 1
+	if players%2 == 1 {
+		maxRounds = players
+	}
+
+	if format == tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN {
+		return maxRounds
+	}
+
+	rounds := requested
+	if rounds <= 0 {
+		rounds = int(math.Ceil(math.Log2(float64(players))))
+	}
+	if rounds > maxRounds {
+		rounds = maxRounds
+	}
+	if rounds < 1 {
+		rounds = 1
+	}
+	return rounds
+}
+
+// PairRound returns the pairings of the tournament's next round
+func PairRound(tournament *tournamentspb.Tournament) []*tournamentspb.Pairing {
+	if tournament.Format == tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN {
+		return PairRoundRobin(tournament.Players, len(tournament.Rounds))
+	}
+	return PairSwiss(tournament)
+}
+
+// PairRoundRobin returns the pairings of a 0-based round of a round-robin with the circle method:
+// the first player stays put while the others rotate around them. With an odd number of
+// players, whoever meets the empty seat has a bye.
+func PairRoundRobin(players []*tournamentspb.TournamentPlayer, round int) []*tournamentspb.Pairing {
+	seats := make([]string, 0, len(players)+1)
+	for _, player := range players {
+		seats = append(seats, player.Id)
+	}
+	if len(seats)%2 == 1 {
+		seats = append(seats, "")
+	}
+
+	n := len(seats)
+	if n < 2 {
+		return nil
+	}
+
+	// Rotate every seat but the first by the round number
+	rotated := make([]string, n)
+	rotated[0] = seats[0]
+	for i := 1; i < n; i++ {
+		rotated[1+(i-1+round)%(n-1)] = seats[i]
+	}
+
+	pairings := make([]*tournamentspb.Pairing, 0, n/2)
+	for i := 0; i < n/2; i++ {
+		player1, player2 := rotated[i], rotated[n-1-i]
+
+		// Alternate who moves first, so the fixed player does not always start
+		if (i == 0 && round%2 == 1) || (i > 0 && i%2 == 1) {
+			player1, player2 = player2, player1
+		}
+
+		pairings = append(pairings, newPairing(player1, player2))
+	}
+
+	return pairings
+}
+
+// PairSwiss returns the pairings of the next Swiss round. Players are paired down the
+// standings with the closest-ranked opponent they have not met yet. With an odd number of
+// players, the lowest-ranked player without a bye sits the round out.
+func PairSwiss(tournament *tournamentspb.Tournament) []*tournamentspb.Pairing {
+	standings := Standings(tournament)
+	ranked := make([]string, 0, len(standings))
+	for _, standing := range standings {
+		ranked = append(ranked, standing.PlayerId)
+	}
+
+	history := newPairingHistory(tournament)
+
+	var bye *tournamentspb.Pairing
+	if len(ranked)%2 == 1 {
+		byeIndex := len(ranked) - 1
+		for i := len(ranked) - 1; i >= 0; i-- {
+			if !history.byes[ranked[i]] {
+				byeIndex = i
+				break
+			}
+		}
+
+		bye = newPairing(ranked[byeIndex], "")
+		ranked = append(ranked[:byeIndex:byeIndex], ranked[byeIndex+1:]...)
+	}
+
+	steps := 0
+	pairs, ok := pairWithoutRematches(ranked, history, &steps)
+	if !ok {
+		// Everyone has met everyone close by, so pair straight down the standings
+		pairs = nil
+		for i := 0; i+1 < len(ranked); i += 2 {
+			pairs = append(pairs, [2]string{ranked[i], ranked[i+1]})
+		}
+	}
+
+	pairings := make([]*tournamentspb.Pairing, 0, len(pairs)+1)
+	for _, pair := range pairs {
+		player1, player2 := pair[0], pair[1]
+
+		// The player who started fewer games moves first
+		if history.starts[player2] < history.starts[player1] {
+			player1, player2 = player2, player1
+		}
+
+		pairings = append(pairings, newPairing(player1, player2))
+	}
+	if bye != nil {
+		pairings = append(pairings, bye)
+	}
+
+	return pairings
+}
+
+// pairWithoutRematches pairs the ranked players, each with the highest-ranked opponent left
+// that they have not met, backtracking when that leaves someone without an opponent
+func pairWithoutRematches(ranked []string, history *pairingHistory, steps *int) ([][2]string, bool) {
+	if len(ranked) == 0 {
+		return nil, true
+	}
+
+	*steps++
+	if *steps > maxPairingSteps {
+		return nil, false
+	}
+
+	first := ranked[0]
+	for i := 1; i < len(ranked); i++ {
+		opponent := ranked[i]
+		if history.met(first, opponent) {
+			continue
+		}
+
+		rest := make([]string, 0, len(ranked)-2)
+		rest = append(rest, ranked[1:i]...)
+		rest = append(rest, ranked[i+1:]...)
+
+		if pairs, ok := pairWithoutRematches(rest, history, steps); ok {
+			return append([][2]string{{first, opponent}}, pairs...), true
+		}
+		if *steps > maxPairingSteps {
+			return nil, false
+		}
+	}
+
+	return nil, false
+}
+
+// pairingHistory is what earlier rounds tell about each player
+type pairingHistory struct {
+	opponents map[string]map[string]bool
+	byes      map[string]bool
+	starts    map[string]int
+}
+
+func newPairingHistory(tournament *tournamentspb.Tournament) *pairingHistory {
+	history := &pairingHistory{
+		opponents: make(map[string]map[string]bool),
+		byes:      make(map[string]bool),
+		starts:    make(map[string]int),
+	}
+
+	for _, round := range tournament.Rounds {
+		for _, pairing := range round.Pairings {
+			if pairing.Player2Id == "" {
+				history.byes[pairing.Player1Id] = true
+				continue
+			}
+
+			history.addOpponent(pairing.Player1Id, pairing.Player2Id)
+			history.addOpponent(pairing.Player2Id, pairing.Player1Id)
+			history.starts[pairing.Player1Id]++
+		}
+	}
+
+	return history
+}
+
+func (h *pairingHistory) addOpponent(playerID, opponentID string) {
+	if h.opponents[playerID] == nil {
+		h.opponents[playerID] = make(map[string]bool)
+	}
+	h.opponents[playerID][opponentID] = true
+}
+
+func (h *pairingHistory) met(playerID, opponentID string) bool {
+	return h.opponents[playerID][opponentID]
+}
+
+// Standings ranks the tournament's players by score, then Buchholz, then Sonneborn-Berger.
+// Players level on all three share a rank; pending games do not count yet.
+func Standings(tournament *tournamentspb.Tournament) []*tournamentspb.Standing {
+	standings := make([]*tournamentspb.Standing, 0, len(tournament.Players))
+	byPlayer := make(map[string]*tournamentspb.Standing, len(tournament.Players))
+	for _, player := range tournament.Players {
+		standing := &tournamentspb.Standing{PlayerId: player.Id, PlayerName: player.Name}
+		standings = append(standings, standing)
+		byPlayer[player.Id] = standing
+	}
+
+	// Scores first, as the tiebreaks are built from the opponents' final scores
+	var games []*tournamentspb.Pairing
+	for _, round := range tournament.Rounds {
+		for _, pairing := range round.Pairings {
+			player1, player2 := byPlayer[pairing.Player1Id], byPlayer[pairing.Player2Id]
+			if player1 == nil {
+				continue
+			}
+
+			switch pairing.Result {
+			case tournamentspb.PairingResult_PAIRING_RESULT_BYE:
+				player1.Score++
+				player1.Wins++
+				continue
+			case tournamentspb.PairingResult_PAIRING_RESULT_PENDING:
+				continue
+			}
+
+			if player2 == nil {
+				continue
+			}
+
+			switch pairing.Result {
+			case tournamentspb.PairingResult_PAIRING_RESULT_PLAYER1_WIN:
+				player1.Score++
+				player1.Wins++
+				player2.Losses++
+			case tournamentspb.PairingResult_PAIRING_RESULT_PLAYER2_WIN:
+				player2.Score++
+				player2.Wins++
+				player1.Losses++
+			case tournamentspb.PairingResult_PAIRING_RESULT_DRAW:
+				player1.Score += 0.5
+				player2.Score += 0.5
+				player1.Draws++
+				player2.Draws++
+			}
+			games = append(games, pairing)
+		}
+	}
+
+	for _, game := range games {
+		player1, player2 := byPlayer[game.Player1Id], byPlayer[game.Player2Id]
+		player1.Buchholz += player2.Score
+		player2.Buchholz += player1.Score
+
+		switch game.Result {
+		case tournamentspb.PairingResult_PAIRING_RESULT_PLAYER1_WIN:
+			player1.SonnebornBerger += player2.Score
+		case tournamentspb.PairingResult_PAIRING_RESULT_PLAYER2_WIN:
+			player2.SonnebornBerger += player1.Score
+		case tournamentspb.PairingResult_PAIRING_RESULT_DRAW:
+			player1.SonnebornBerger += player2.Score / 2
+			player2.SonnebornBerger += player1.Score / 2
+		}
+	}
+
+	// Stable, so fully tied players keep their registration order
+	sort.SliceStable(standings, func(i, j int) bool {
+		return compareStandings(standings[i], standings[j]) < 0
+	})
+
+	for i, standing := range standings {
+		standing.Rank = int32(i + 1)
+		if i > 0 && compareStandings(standings[i-1], standing) == 0 {
+			standing.Rank = standings[i-1].Rank
+		}
+	}
+
+	return standings
+}
+
+// compareStandings orders standings best first
+func compareStandings(a, b *tournamentspb.Standing) int {
+	for _, values := range [][2]float64{
+		{a.Score, b.Score},
+		{a.Buchholz, b.Buchholz},
+		{a.SonnebornBerger, b.SonnebornBerger},
+	} {
+		switch {
+		case values[0] > values[1]:
+			return -1
+		case values[0] < values[1]:
+			return 1
+		}
+	}
+	return 0
+}
+
+// roundComplete reports whether every game of the round has a result
+func roundComplete(round *tournamentspb.Round) bool {
+	for _, pairing := range round.Pairings {
+		if pairing.Result == tournamentspb.PairingResult_PAIRING_RESULT_PENDING {
+			return false
+		}
+	}
+	return true
+}
+
+// newPairing pairs two players, or gives the other player a bye when one seat is empty
+func newPairing(player1ID, player2ID string) *tournamentspb.Pairing {
+	if player1ID == "" {
+		player1ID, player2ID = player2ID, player1ID
+	}
+
+	pairing := &tournamentspb.Pairing{Player1Id: player1ID, Player2Id: player2ID}
+	if player2ID == "" {
+		pairing.Result = tournamentspb.PairingResult_PAIRING_RESULT_BYE
+	}
+	return pairing
+}
@@ -0,0 +1,228 @@
+package tournaments
+
+import (
+	"fmt"
+	"testing"
+
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+)
+
+func testPlayers(n int) []*tournamentspb.TournamentPlayer {
+	players := make([]*tournamentspb.TournamentPlayer, n)
+	for i := range players {
+		players[i] = &tournamentspb.TournamentPlayer{Id: fmt.Sprintf("p%d", i+1), Name: fmt.Sprintf("Player %d", i+1)}
+	}
+	return players
+}
+
+func TestTotalRounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		format    tournamentspb.TournamentFormat
+		requested int
+		players   int
+		want      int
+	}{
+		{"Round robin, even", tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN, 0, 6, 5},
+		{"Round robin, odd", tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN, 2, 5, 5},
+		{"Swiss, default", tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 0, 16, 4},
+		{"Swiss, default rounded up", tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 0, 9, 4},
+		{"Swiss, requested", tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 3, 16, 3},
+		{"Swiss, capped", tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 10, 4, 3},
+		{"Swiss, two players", tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 0, 2, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TotalRounds(tt.format, tt.requested, tt.players); got != tt.want {
+				t.Errorf("TotalRounds() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPairRoundRobin(t *testing.T) {
+	for _, n := range []int{2, 3, 4, 5, 8} {
+		t.Run(fmt.Sprintf("%d players", n), func(t *testing.T) {
+			players := testPlayers(n)
+			rounds := TotalRounds(tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN, 0, n)
+
+			met := make(map[[2]string]int)
+			byes := make(map[string]int)
+			for round := 0; round < rounds; round++ {
+				seen := make(map[string]bool)
+				for _, pairing := range PairRoundRobin(players, round) {
+					for _, id := range []string{pairing.Player1Id, pairing.Player2Id} {
+						if id != "" && seen[id] {
+							t.Fatalf("Round %d pairs %s twice", round+1, id)
+						}
+						seen[id] = true
+					}
+
+					if pairing.Player2Id == "" {
+						if pairing.Result != tournamentspb.PairingResult_PAIRING_RESULT_BYE {
+							t.Errorf("Bye of %s has result %v, want bye", pairing.Player1Id, pairing.Result)
+						}
+						byes[pairing.Player1Id]++
+						continue
+					}
+
+					a, b := pairing.Player1Id, pairing.Player2Id
+					if a > b {
+						a, b = b, a
+					}
+					met[[2]string{a, b}]++
+				}
+			}
+
+			// Everyone meets everyone exactly once
+			for i, a := range players {
+				for _, b := range players[i+1:] {
+					if got := met[[2]string{a.Id, b.Id}]; got != 1 {
+						t.Errorf("%s and %s met %d times, want 1", a.Id, b.Id, got)
+					}
+				}
+				if n%2 == 1 && byes[a.Id] != 1 {
+					t.Errorf("%s had %d byes, want 1", a.Id, byes[a.Id])
+				}
+			}
+		})
+	}
+}
+
+func TestPairSwiss(t *testing.T) {
+	tournament := &tournamentspb.Tournament{
+		Format:  tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS,
+		Players: testPlayers(5),
+	}
+
+	met := make(map[[2]string]bool)
+	byes := make(map[string]bool)
+	for round := 1; round <= 4; round++ {
+		pairings := PairSwiss(tournament)
+		if len(pairings) != 3 {
+			t.Fatalf("Round %d has %d pairings, want 3", round, len(pairings))
+		}
+
+		for _, pairing := range pairings {
+			if pairing.Player2Id == "" {
+				if byes[pairing.Player1Id] {
+					t.Errorf("Round %d gives %s a second bye", round, pairing.Player1Id)
+				}
+				byes[pairing.Player1Id] = true
+				continue
+			}
+
+			key := [2]string{pairing.Player1Id, pairing.Player2Id}
+			if key[0] > key[1] {
+				key[0], key[1] = key[1], key[0]
+			}
+			if met[key] {
+				t.Errorf("Round %d is a rematch of %s and %s", round, key[0], key[1])
+			}
+			met[key] = true
+
+			// The higher-numbered player always wins, which keeps score groups apart
+			pairing.Result = tournamentspb.PairingResult_PAIRING_RESULT_PLAYER1_WIN
+			if pairing.Player2Id > pairing.Player1Id {
+				pairing.Result = tournamentspb.PairingResult_PAIRING_RESULT_PLAYER2_WIN
+			}
+		}
+
+		tournament.Rounds = append(tournament.Rounds, &tournamentspb.Round{Number: int32(round), Pairings: pairings})
+	}
+}
+
+func TestPairSwiss_ScoreGroups(t *testing.T) {
+	// After one round, the two winners meet and the two losers meet
+	tournament := &tournamentspb.Tournament{
+		Format:  tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS,
+		Players: testPlayers(4),
+		Rounds: []*tournamentspb.Round{{
+			Number: 1,
+			Pairings: []*tournamentspb.Pairing{
+				{Player1Id: "p1", Player2Id: "p2", Result: tournamentspb.PairingResult_PAIRING_RESULT_PLAYER2_WIN},
+				{Player1Id: "p3", Player2Id: "p4", Result: tournamentspb.PairingResult_PAIRING_RESULT_PLAYER1_WIN},
+			},
+		}},
+	}
+
+	pairings := PairSwiss(tournament)
+	if len(pairings) != 2 {
+		t.Fatalf("PairSwiss() = %d pairings, want 2", len(pairings))
+	}
+
+	winners := map[string]bool{pairings[0].Player1Id: true, pairings[0].Player2Id: true}
+	if !winners["p2"] || !winners["p3"] {
+		t.Errorf("PairSwiss() top board = %s vs %s, want p2 and p3", pairings[0].Player1Id, pairings[0].Player2Id)
+	}
+
+	// p2 started no game yet, so p2 moves first this time
+	if pairings[0].Player1Id != "p2" {
+		t.Errorf("PairSwiss() top board player one = %s, want p2", pairings[0].Player1Id)
+	}
+}
+
+func TestStandings(t *testing.T) {
+	// p1 beats p2 and draws p3; p2 beats p3; p4 has a bye and loses to p3
+	tournament := &tournamentspb.Tournament{
+		Players: testPlayers(4),
+		Rounds: []*tournamentspb.Round{
+			{Number: 1, Pairings: []*tournamentspb.Pairing{
+				{Player1Id: "p1", Player2Id: "p2", Result: tournamentspb.PairingResult_PAIRING_RESULT_PLAYER1_WIN},
+				{Player1Id: "p4", Result: tournamentspb.PairingResult_PAIRING_RESULT_BYE},
+			}},
+			{Number: 2, Pairings: []*tournamentspb.Pairing{
+				{Player1Id: "p3", Player2Id: "p1", Result: tournamentspb.PairingResult_PAIRING_RESULT_DRAW},
+				{Player1Id: "p2", Player2Id: "p3", Result: tournamentspb.PairingResult_PAIRING_RESULT_PLAYER1_WIN},
+			}},
+			{Number: 3, Pairings: []*tournamentspb.Pairing{
+				{Player1Id: "p4", Player2Id: "p3", Result: tournamentspb.PairingResult_PAIRING_RESULT_PLAYER2_WIN},
+				{Player1Id: "p1", Player2Id: "p2"}, // Still being played
+			}},
+		},
+	}
+
+	// Scores: p1 1.5, p2 1, p3 1.5, p4 1
+	want := []struct {
+		rank            int32
+		playerID        string
+		score           float64
+		buchholz        float64
+		sonnebornBerger float64
+	}{
+		{1, "p3", 1.5, 3.5, 1.75}, // Opponents p1, p2 and p4; drew p1, beat p4
+		{2, "p1", 1.5, 2.5, 1.75}, // Opponents p2 and p3; beat p2, drew p3
+		{3, "p2", 1, 3, 1.5},      // Opponents p1 and p3; beat p3
+		{4, "p4", 1, 1.5, 0},      // The bye scores but adds no opponent
+	}
+
+	standings := Standings(tournament)
+	if len(standings) != len(want) {
+		t.Fatalf("Standings() = %d standings, want %d", len(standings), len(want))
+	}
+
+	for i, w := range want {
+		got := standings[i]
+		if got.Rank != w.rank || got.PlayerId != w.playerID || got.Score != w.score ||
+			got.Buchholz != w.buchholz || got.SonnebornBerger != w.sonnebornBerger {
+			t.Errorf("Standings()[%d] = rank %d %s score %v buchholz %v sb %v, want rank %d %s score %v buchholz %v sb %v",
+				i, got.Rank, got.PlayerId, got.Score, got.Buchholz, got.SonnebornBerger,
+				w.rank, w.playerID, w.score, w.buchholz, w.sonnebornBerger)
+		}
+	}
+}
+
+func TestStandings_SharedRank(t *testing.T) {
+	tournament := &tournamentspb.Tournament{
+		Players: testPlayers(2),
+		Rounds: []*tournamentspb.Round{{Number: 1, Pairings: []*tournamentspb.Pairing{
+			{Player1Id: "p1", Player2Id: "p2", Result: tournamentspb.PairingResult_PAIRING_RESULT_DRAW},
+		}}},
+	}
+
+	standings := Standings(tournament)
+	if standings[0].Rank != 1 || standings[1].Rank != 1 {
+		t.Errorf("Standings() ranks = %d, %d, want both 1", standings[0].Rank, standings[1].Rank)
+	}
+}
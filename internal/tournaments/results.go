@@ -0,0 +1,108 @@
+package tournaments
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/laerson/mancala/internal/events"
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+)
+
+// Start begins following game results and sweeping running tournaments
+func (s *Server) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.running {
+		return nil
+	}
+
+	if err := s.consumer.Start(); err != nil {
+		return err
+	}
+
+	s.running = true
+	go s.sweep()
+
+	return nil
+}
+
+// Stop stops following game results
+func (s *Server) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.running {
+		return
+	}
+
+	s.consumer.Stop()
+	s.cancel()
+	s.running = false
+}
+
+// handleEvent records the result of a GAME_OVER event
+func (s *Server) handleEvent(ctx context.Context, event events.Event) error {
+	if event.Type != events.EventTypeGameOver {
+		return nil
+	}
+	return s.handleGameOver(ctx, event)
+}
+
+// handleGameOver records the result of a tournament game and advances its tournament
+func (s *Server) handleGameOver(ctx context.Context, event events.Event) error {
+	var data events.GameOverData
+	bytes, err := json.Marshal(event.Data)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		return fmt.Errorf("failed to parse game over data: %w", err)
+	}
+
+	tournamentID, err := s.recordResult(ctx, event.GameID, data)
+	if err != nil || tournamentID == "" {
+		return err
+	}
+
+	_, err = s.advance(ctx, tournamentID)
+	return err
+}
+
+// sweep periodically advances every running tournament, creating games that failed to be
+// created and pairing rounds whose last result arrived while another replica held the tournament
+func (s *Server) sweep() {
+	ticker := time.NewTicker(s.sweepInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-ticker.C:
+			s.advanceAll(s.ctx)
+		}
+	}
+}
+
+// advanceAll advances every running tournament
+func (s *Server) advanceAll(ctx context.Context) {
+	tournaments, err := s.storage.ListTournaments(ctx)
+	if err != nil {
+		log.Printf("Failed to list tournaments to advance: %v", err)
+		return
+	}
+
+	for _, tournament := range tournaments {
+		if tournament.Status != tournamentspb.TournamentStatus_TOURNAMENT_STATUS_IN_PROGRESS {
+			continue
+		}
+
+		if _, err := s.advance(ctx, tournament.Id); err != nil {
+			log.Printf("Failed to advance tournament %s: %v", tournament.Id, err)
+		}
+	}
+}
@@ -0,0 +1,478 @@
+package tournaments
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/laerson/mancala/internal/auth"
+	"github.com/laerson/mancala/internal/events"
+	gamespb "github.com/laerson/mancala/proto/games"
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+)
+
+const (
+	// maxNameLength bounds tournament names
+	maxNameLength = 64
+
+	// saveAttempts is how often an update is retried when another replica saved the tournament first
+	saveAttempts = 5
+
+	// advanceLockTTL bounds how long a replica may hold a tournament while creating its games
+	advanceLockTTL = 30 * time.Second
+
+	// createGameTimeout bounds each game creation, well within advanceLockTTL
+	createGameTimeout = 5 * time.Second
+
+	// DefaultSweepInterval is how often running tournaments are checked for games that still
+	// need creating or rounds that still need pairing
+	DefaultSweepInterval = 10 * time.Second
+)
+
+// errUnchanged tells update that there is nothing to save
+var errUnchanged = errors.New("tournament unchanged")
+
+// GamesClient is the subset of the Games service used to create tournament games
+type GamesClient interface {
+	Create(ctx context.Context, req *gamespb.CreateGameRequest, opts ...grpc.CallOption) (*gamespb.CreateGameResponse, error)
+}
+
+// Server runs tournaments: it registers players, pairs every round, creates its games and
+// advances to the next round once GAME_OVER events have settled the current one
+type Server struct {
+	tournamentspb.UnimplementedTournamentsServer
+	storage        Storage
+	gamesClient    GamesClient
+	eventPublisher *events.EventPublisher
+	consumer       *events.Consumer
+	sweepInterval  time.Duration
+	mu             sync.Mutex
+	running        bool
+	ctx            context.Context
+	cancel         context.CancelFunc
+}
+
+// NewServer creates a tournament server. Call Start to follow game results.
+func NewServer(storage Storage, gamesClient GamesClient, redisAddr string) *Server {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	s := &Server{
+		storage:        storage,
+		gamesClient:    gamesClient,
+		eventPublisher: events.NewEventPublisher(redisAddr),
+		sweepInterval:  DefaultSweepInterval,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	s.consumer = events.NewConsumer(redisAddr, "tournaments", s.handleEvent)
+	return s
+}
+
+func (s *Server) CreateTournament(ctx context.Context, req *tournamentspb.CreateTournamentRequest) (*tournamentspb.CreateTournamentResponse, error) {
+	if req.OrganizerId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "organizer ID is required")
+	}
+
+	if err := auth.ValidatePlayerOwnership(ctx, req.OrganizerId); err != nil {
+		return nil, err
+	}
+
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "tournament name is required")
+	}
+	if len(name) > maxNameLength {
+		return nil, status.Errorf(codes.InvalidArgument, "tournament name must be at most %d characters", maxNameLength)
+	}
+
+	switch req.Format {
+	case tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN:
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "tournament format must be swiss or round robin")
+	}
+
+	if req.Rounds < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "rounds cannot be negative")
+	}
+
+	tournament := &tournamentspb.Tournament{
+		Id:          uuid.New().String(),
+		Name:        name,
+		Format:      req.Format,
+		Status:      tournamentspb.TournamentStatus_TOURNAMENT_STATUS_REGISTERING,
+		OrganizerId: req.OrganizerId,
+		TotalRounds: req.Rounds,
+		TimeControl: req.TimeControl,
+		CreatedAt:   time.Now().Unix(),
+	}
+
+	if err := s.storage.SaveTournament(ctx, tournament); err != nil {
+		log.Printf("Failed to save tournament: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to create tournament")
+	}
+
+	log.Printf("Tournament %s (%s) created by %s", tournament.Id, tournament.Name, tournament.OrganizerId)
+	return &tournamentspb.CreateTournamentResponse{Tournament: tournament}, nil
+}
+
+func (s *Server) JoinTournament(ctx context.Context, req *tournamentspb.JoinTournamentRequest) (*tournamentspb.JoinTournamentResponse, error) {
+	if req.Player == nil || req.Player.Id == "" || req.Player.Name == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player ID and name are required")
+	}
+
+	if err := auth.ValidatePlayerOwnership(ctx, req.Player.Id); err != nil {
+		return nil, err
+	}
+
+	tournament, err := s.update(ctx, req.TournamentId, func(tournament *tournamentspb.Tournament) error {
+		if tournament.Status != tournamentspb.TournamentStatus_TOURNAMENT_STATUS_REGISTERING {
+			return status.Errorf(codes.FailedPrecondition, "registration for this tournament is closed")
+		}
+
+		if playerIndex(tournament, req.Player.Id) >= 0 {
+			return errUnchanged
+		}
+
+		tournament.Players = append(tournament.Players, &tournamentspb.TournamentPlayer{
+			Id:   req.Player.Id,
+			Name: req.Player.Name,
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tournamentspb.JoinTournamentResponse{Tournament: tournament}, nil
+}
+
+func (s *Server) LeaveTournament(ctx context.Context, req *tournamentspb.LeaveTournamentRequest) (*tournamentspb.LeaveTournamentResponse, error) {
+	if req.PlayerId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player ID is required")
+	}
+
+	if err := auth.ValidatePlayerOwnership(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
+	tournament, err := s.update(ctx, req.TournamentId, func(tournament *tournamentspb.Tournament) error {
+		if tournament.Status != tournamentspb.TournamentStatus_TOURNAMENT_STATUS_REGISTERING {
+			return status.Errorf(codes.FailedPrecondition, "players cannot leave a tournament that has started")
+		}
+
+		index := playerIndex(tournament, req.PlayerId)
+		if index < 0 {
+			return status.Errorf(codes.FailedPrecondition, "player is not registered for this tournament")
+		}
+
+		tournament.Players = append(tournament.Players[:index], tournament.Players[index+1:]...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &tournamentspb.LeaveTournamentResponse{Tournament: tournament}, nil
+}
+
+func (s *Server) StartTournament(ctx context.Context, req *tournamentspb.StartTournamentRequest) (*tournamentspb.StartTournamentResponse, error) {
+	if req.PlayerId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "player ID is required")
+	}
+
+	if err := auth.ValidatePlayerOwnership(ctx, req.PlayerId); err != nil {
+		return nil, err
+	}
+
+	_, err := s.update(ctx, req.TournamentId, func(tournament *tournamentspb.Tournament) error {
+		if tournament.OrganizerId != req.PlayerId {
+			return status.Errorf(codes.PermissionDenied, "only the organizer can start the tournament")
+		}
+
+		if tournament.Status != tournamentspb.TournamentStatus_TOURNAMENT_STATUS_REGISTERING {
+			return status.Errorf(codes.FailedPrecondition, "tournament has already started")
+		}
+
+		if len(tournament.Players) < 2 {
+			return status.Errorf(codes.FailedPrecondition, "a tournament needs at least 2 players")
+		}
+
+		tournament.TotalRounds = int32(TotalRounds(tournament.Format, int(tournament.TotalRounds), len(tournament.Players)))
+		tournament.Status = tournamentspb.TournamentStatus_TOURNAMENT_STATUS_IN_PROGRESS
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Printf("Tournament %s started", req.TournamentId)
+
+	tournament, err := s.advance(ctx, req.TournamentId)
+	if err != nil {
+		log.Printf("Failed to pair the first round of tournament %s: %v", req.TournamentId, err)
+		return nil, status.Errorf(codes.Internal, "tournament started, but the first round could not be paired yet")
+	}
+
+	return &tournamentspb.StartTournamentResponse{Tournament: tournament}, nil
+}
+
+func (s *Server) GetTournament(ctx context.Context, req *tournamentspb.GetTournamentRequest) (*tournamentspb.GetTournamentResponse, error) {
+	tournament, err := s.getTournament(ctx, req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tournamentspb.GetTournamentResponse{
+		Tournament: tournament,
+		Standings:  Standings(tournament),
+	}, nil
+}
+
+func (s *Server) ListTournaments(ctx context.Context, req *tournamentspb.ListTournamentsRequest) (*tournamentspb.ListTournamentsResponse, error) {
+	tournaments, err := s.storage.ListTournaments(ctx)
+	if err != nil {
+		log.Printf("Failed to list tournaments: %v", err)
+		return nil, status.Errorf(codes.Internal, "failed to list tournaments")
+	}
+
+	resp := &tournamentspb.ListTournamentsResponse{}
+	for _, tournament := range tournaments {
+		if req.Status == tournamentspb.TournamentStatus_TOURNAMENT_STATUS_UNSPECIFIED || tournament.Status == req.Status {
+			resp.Tournaments = append(resp.Tournaments, tournament)
+		}
+	}
+
+	return resp, nil
+}
+
+// advance moves a running tournament along: it pairs the next round once the current one is
+// complete, or finishes the tournament after its last round, and then creates the games that
+// are still missing. Only one replica advances a tournament at a time; the others leave it be.
+func (s *Server) advance(ctx context.Context, tournamentID string) (*tournamentspb.Tournament, error) {
+	token, err := s.storage.LockTournament(ctx, tournamentID, advanceLockTTL)
+	if err != nil {
+		return nil, err
+	}
+	if token == "" {
+		return s.getTournament(ctx, tournamentID)
+	}
+	defer func() {
+		if err := s.storage.UnlockTournament(context.Background(), tournamentID, token); err != nil {
+			log.Printf("Failed to unlock tournament %s: %v", tournamentID, err)
+		}
+	}()
+
+	tournament, err := s.update(ctx, tournamentID, func(tournament *tournamentspb.Tournament) error {
+		if tournament.Status != tournamentspb.TournamentStatus_TOURNAMENT_STATUS_IN_PROGRESS {
+			return errUnchanged
+		}
+
+		if len(tournament.Rounds) > 0 && !roundComplete(tournament.Rounds[len(tournament.Rounds)-1]) {
+			return errUnchanged
+		}
+
+		if tournament.CurrentRound >= tournament.TotalRounds {
+			tournament.Status = tournamentspb.TournamentStatus_TOURNAMENT_STATUS_FINISHED
+			return nil
+		}
+
+		tournament.CurrentRound++
+		tournament.Rounds = append(tournament.Rounds, &tournamentspb.Round{
+			Number:   tournament.CurrentRound,
+			Pairings: PairRound(tournament),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if tournament.Status == tournamentspb.TournamentStatus_TOURNAMENT_STATUS_FINISHED {
+		return tournament, nil
+	}
+
+	round := tournament.Rounds[len(tournament.Rounds)-1]
+	for i, pairing := range round.Pairings {
+		if pairing.GameId != "" || pairing.Result != tournamentspb.PairingResult_PAIRING_RESULT_PENDING {
+			continue
+		}
+
+		updated, err := s.createPairingGame(ctx, tournament, round.Number, i)
+		if err != nil {
+			// The sweeper tries again later
+			log.Printf("Failed to create game for round %d of tournament %s: %v", round.Number, tournamentID, err)
+			continue
+		}
+		tournament = updated
+	}
+
+	return tournament, nil
+}
+
+// createPairingGame creates the game of a pairing, records it and tells the players. The
+// request ID is recorded on the pairing before the game is created, so when recording the
+// game fails the next attempt gets the same game back instead of a second one.
+func (s *Server) createPairingGame(ctx context.Context, tournament *tournamentspb.Tournament, roundNumber int32, index int) (*tournamentspb.Tournament, error) {
+	tournament, err := s.update(ctx, tournament.Id, func(tournament *tournamentspb.Tournament) error {
+		pairing := tournament.Rounds[roundNumber-1].Pairings[index]
+		if pairing.GameId != "" {
+			return fmt.Errorf("pairing already has game %s", pairing.GameId)
+		}
+		if pairing.GameRequestId != "" {
+			return errUnchanged
+		}
+		pairing.GameRequestId = uuid.New().String()
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	pairing := tournament.Rounds[roundNumber-1].Pairings[index]
+
+	createCtx, cancel := context.WithTimeout(ctx, createGameTimeout)
+	defer cancel()
+
+	resp, err := s.gamesClient.Create(createCtx, &gamespb.CreateGameRequest{
+		Player1Id:   pairing.Player1Id,
+		Player2Id:   pairing.Player2Id,
+		TimeControl: tournament.TimeControl,
+		RequestId:   pairing.GameRequestId,
+	})
+	if err != nil {
+		return nil, err
+	}
+	gameID := resp.Game.Id
+
+	updated, err := s.update(ctx, tournament.Id, func(tournament *tournamentspb.Tournament) error {
+		pairing := tournament.Rounds[roundNumber-1].Pairings[index]
+		if pairing.GameId != "" {
+			return fmt.Errorf("pairing already has game %s", pairing.GameId)
+		}
+		pairing.GameId = gameID
+		pairing.GameRequestId = ""
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	matchID := fmt.Sprintf("%s-%d-%d", tournament.Id, roundNumber, index+1)
+	err = s.eventPublisher.PublishMatchFound(ctx, gameID, matchID,
+		pairing.Player1Id, playerName(updated, pairing.Player1Id),
+		pairing.Player2Id, playerName(updated, pairing.Player2Id))
+	if err != nil {
+		log.Printf("Failed to publish match found event: %v", err)
+	}
+
+	log.Printf("Created game %s for round %d of tournament %s", gameID, roundNumber, tournament.Id)
+	return updated, nil
+}
+
+// recordResult settles the pairing a finished game was played for. An aborted game is
+// forgotten instead, so the pairing gets a new game.
+func (s *Server) recordResult(ctx context.Context, gameID string, data events.GameOverData) (string, error) {
+	tournamentID, err := s.storage.FindGameTournament(ctx, gameID)
+	if err != nil || tournamentID == "" {
+		return "", err
+	}
+
+	_, err = s.update(ctx, tournamentID, func(tournament *tournamentspb.Tournament) error {
+		for _, round := range tournament.Rounds {
+			for _, pairing := range round.Pairings {
+				if pairing.GameId != gameID || pairing.Result != tournamentspb.PairingResult_PAIRING_RESULT_PENDING {
+					continue
+				}
+
+				switch {
+				case data.Reason == events.GameOverReasonAborted:
+					pairing.GameId = ""
+				case data.IsDraw:
+					pairing.Result = tournamentspb.PairingResult_PAIRING_RESULT_DRAW
+				case data.WinnerID == pairing.Player1Id:
+					pairing.Result = tournamentspb.PairingResult_PAIRING_RESULT_PLAYER1_WIN
+				case data.WinnerID == pairing.Player2Id:
+					pairing.Result = tournamentspb.PairingResult_PAIRING_RESULT_PLAYER2_WIN
+				default:
+					pairing.Result = tournamentspb.PairingResult_PAIRING_RESULT_DRAW
+				}
+				return nil
+			}
+		}
+		return errUnchanged
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tournamentID, nil
+}
+
+// update applies change to the latest version of the tournament and saves it, starting over
+// when another replica saved the tournament in between. Errors from change are returned as they are.
+func (s *Server) update(ctx context.Context, tournamentID string, change func(*tournamentspb.Tournament) error) (*tournamentspb.Tournament, error) {
+	for attempt := 1; ; attempt++ {
+		tournament, err := s.getTournament(ctx, tournamentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := change(tournament); err != nil {
+			if errors.Is(err, errUnchanged) {
+				return tournament, nil
+			}
+			return nil, err
+		}
+
+		err = s.storage.SaveTournament(ctx, tournament)
+		if err == nil {
+			return tournament, nil
+		}
+		if !errors.Is(err, ErrVersionConflict) || attempt == saveAttempts {
+			log.Printf("Failed to save tournament %s: %v", tournamentID, err)
+			return nil, status.Errorf(codes.Aborted, "failed to save tournament, please retry")
+		}
+	}
+}
+
+func (s *Server) getTournament(ctx context.Context, tournamentID string) (*tournamentspb.Tournament, error) {
+	if tournamentID == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "tournament ID is required")
+	}
+
+	tournament, err := s.storage.GetTournament(ctx, tournamentID)
+	if errors.Is(err, ErrTournamentNotFound) {
+		return nil, status.Errorf(codes.NotFound, "tournament not found")
+	}
+	if err != nil {
+		log.Printf("Failed to get tournament %s: %v", tournamentID, err)
+		return nil, status.Errorf(codes.Internal, "failed to get tournament")
+	}
+
+	return tournament, nil
+}
+
+// playerIndex returns the index of the player in the tournament, or -1 if not registered
+func playerIndex(tournament *tournamentspb.Tournament, playerID string) int {
+	for i, player := range tournament.Players {
+		if player.Id == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+func playerName(tournament *tournamentspb.Tournament, playerID string) string {
+	if index := playerIndex(tournament, playerID); index >= 0 {
+		return tournament.Players[index].Name
+	}
+	return playerID
+}
@@ -0,0 +1,415 @@
+package tournaments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/laerson/mancala/internal/events"
+	gamespb "github.com/laerson/mancala/proto/games"
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+)
+
+// Mock storage for testing
+type mockStorage struct {
+	mu          sync.Mutex
+	tournaments map[string][]byte
+	games       map[string]string
+	locks       map[string]string
+	saveErr     error
+}
+
+func newMockStorage() *mockStorage {
+	return &mockStorage{
+		tournaments: make(map[string][]byte),
+		games:       make(map[string]string),
+		locks:       make(map[string]string),
+	}
+}
+
+func (m *mockStorage) SaveTournament(ctx context.Context, tournament *tournamentspb.Tournament) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+
+	var stored tournamentspb.Tournament
+	if data, exists := m.tournaments[tournament.Id]; exists {
+		json.Unmarshal(data, &stored)
+	}
+	if stored.Version != tournament.Version {
+		return ErrVersionConflict
+	}
+
+	tournament.Version++
+	data, _ := json.Marshal(tournament)
+	m.tournaments[tournament.Id] = data
+
+	for _, round := range tournament.Rounds {
+		for _, pairing := range round.Pairings {
+			if pairing.GameId != "" {
+				m.games[pairing.GameId] = tournament.Id
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockStorage) GetTournament(ctx context.Context, tournamentID string) (*tournamentspb.Tournament, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	data, exists := m.tournaments[tournamentID]
+	if !exists {
+		return nil, ErrTournamentNotFound
+	}
+
+	var tournament tournamentspb.Tournament
+	if err := json.Unmarshal(data, &tournament); err != nil {
+		return nil, err
+	}
+	return &tournament, nil
+}
+
+func (m *mockStorage) ListTournaments(ctx context.Context) ([]*tournamentspb.Tournament, error) {
+	m.mu.Lock()
+	ids := make([]string, 0, len(m.tournaments))
+	for id := range m.tournaments {
+		ids = append(ids, id)
+	}
+	m.mu.Unlock()
+
+	sort.Strings(ids)
+	var tournaments []*tournamentspb.Tournament
+	for _, id := range ids {
+		tournament, _ := m.GetTournament(ctx, id)
+		tournaments = append(tournaments, tournament)
+	}
+	return tournaments, nil
+}
+
+func (m *mockStorage) FindGameTournament(ctx context.Context, gameID string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.games[gameID], nil
+}
+
+func (m *mockStorage) LockTournament(ctx context.Context, tournamentID string, ttl time.Duration) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, locked := m.locks[tournamentID]; locked {
+		return "", nil
+	}
+	m.locks[tournamentID] = "token"
+	return "token", nil
+}
+
+func (m *mockStorage) UnlockTournament(ctx context.Context, tournamentID, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.locks[tournamentID] == token {
+		delete(m.locks, tournamentID)
+	}
+	return nil
+}
+
+// Mock games client for testing. Like the games service, it returns the same game for a repeated request ID.
+type mockGamesClient struct {
+	mu         sync.Mutex
+	created    []*gamespb.CreateGameRequest
+	requests   map[string]string
+	fail       bool
+	noDeadline bool
+	onCreate   func()
+}
+
+func (m *mockGamesClient) Create(ctx context.Context, req *gamespb.CreateGameRequest, opts ...grpc.CallOption) (*gamespb.CreateGameResponse, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fail {
+		return nil, status.Errorf(codes.Unavailable, "games service unavailable")
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		m.noDeadline = true
+	}
+	if m.onCreate != nil {
+		m.onCreate()
+	}
+
+	if gameID, exists := m.requests[req.RequestId]; exists && req.RequestId != "" {
+		return &gamespb.CreateGameResponse{Game: &gamespb.Game{Id: gameID}}, nil
+	}
+
+	m.created = append(m.created, req)
+	gameID := fmt.Sprintf("game-%d", len(m.created))
+	if m.requests == nil {
+		m.requests = make(map[string]string)
+	}
+	m.requests[req.RequestId] = gameID
+	return &gamespb.CreateGameResponse{Game: &gamespb.Game{Id: gameID}}, nil
+}
+
+func authContext(userID string) context.Context {
+	return context.WithValue(context.Background(), "user_id", userID)
+}
+
+// newTestTournament creates a tournament organized by p1 with the given players registered
+func newTestTournament(t *testing.T, server *Server, format tournamentspb.TournamentFormat, players int) string {
+	t.Helper()
+
+	resp, err := server.CreateTournament(authContext("p1"), &tournamentspb.CreateTournamentRequest{
+		OrganizerId: "p1",
+		Name:        "Friday Open",
+		Format:      format,
+	})
+	if err != nil {
+		t.Fatalf("CreateTournament() error = %v", err)
+	}
+
+	for _, player := range testPlayers(players) {
+		_, err := server.JoinTournament(authContext(player.Id), &tournamentspb.JoinTournamentRequest{
+			TournamentId: resp.Tournament.Id,
+			Player:       player,
+		})
+		if err != nil {
+			t.Fatalf("JoinTournament(%s) error = %v", player.Id, err)
+		}
+	}
+
+	return resp.Tournament.Id
+}
+
+// finishGame feeds a GAME_OVER event for the game to the server
+func finishGame(t *testing.T, server *Server, pairing *tournamentspb.Pairing, data events.GameOverData) {
+	t.Helper()
+
+	data.Player1ID, data.Player2ID = pairing.Player1Id, pairing.Player2Id
+	bytes, _ := json.Marshal(data)
+	var eventData map[string]interface{}
+	json.Unmarshal(bytes, &eventData)
+
+	event := events.Event{Type: events.EventTypeGameOver, GameID: pairing.GameId, Data: eventData}
+	if err := server.handleGameOver(context.Background(), event); err != nil {
+		t.Fatalf("handleGameOver() error = %v", err)
+	}
+}
+
+func TestServer_CreateTournament(t *testing.T) {
+	server := NewServer(newMockStorage(), &mockGamesClient{}, "redis:6379")
+
+	tests := []struct {
+		name     string
+		ctx      context.Context
+		req      *tournamentspb.CreateTournamentRequest
+		wantCode codes.Code
+	}{
+		{
+			name:     "Valid swiss tournament",
+			ctx:      authContext("p1"),
+			req:      &tournamentspb.CreateTournamentRequest{OrganizerId: "p1", Name: "Open", Format: tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "Missing name",
+			ctx:      authContext("p1"),
+			req:      &tournamentspb.CreateTournamentRequest{OrganizerId: "p1", Name: "  ", Format: tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Missing format",
+			ctx:      authContext("p1"),
+			req:      &tournamentspb.CreateTournamentRequest{OrganizerId: "p1", Name: "Open"},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:     "Organized for someone else",
+			ctx:      authContext("p2"),
+			req:      &tournamentspb.CreateTournamentRequest{OrganizerId: "p1", Name: "Open", Format: tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS},
+			wantCode: codes.PermissionDenied,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := server.CreateTournament(tt.ctx, tt.req)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("CreateTournament() code = %v, want %v (%v)", code, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && resp.Tournament.Status != tournamentspb.TournamentStatus_TOURNAMENT_STATUS_REGISTERING {
+				t.Errorf("CreateTournament() status = %v, want registering", resp.Tournament.Status)
+			}
+		})
+	}
+}
+
+func TestServer_StartTournament(t *testing.T) {
+	server := NewServer(newMockStorage(), &mockGamesClient{}, "redis:6379")
+	tournamentID := newTestTournament(t, server, tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 1)
+
+	// Only the organizer can start, and not alone
+	_, err := server.StartTournament(authContext("p2"), &tournamentspb.StartTournamentRequest{TournamentId: tournamentID, PlayerId: "p2"})
+	if code := status.Code(err); code != codes.PermissionDenied {
+		t.Errorf("StartTournament() by another player code = %v, want PermissionDenied", code)
+	}
+
+	_, err = server.StartTournament(authContext("p1"), &tournamentspb.StartTournamentRequest{TournamentId: tournamentID, PlayerId: "p1"})
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("StartTournament() with one player code = %v, want FailedPrecondition", code)
+	}
+
+	player := &tournamentspb.TournamentPlayer{Id: "p2", Name: "Player 2"}
+	server.JoinTournament(authContext("p2"), &tournamentspb.JoinTournamentRequest{TournamentId: tournamentID, Player: player})
+
+	resp, err := server.StartTournament(authContext("p1"), &tournamentspb.StartTournamentRequest{TournamentId: tournamentID, PlayerId: "p1"})
+	if err != nil {
+		t.Fatalf("StartTournament() error = %v", err)
+	}
+	if resp.Tournament.CurrentRound != 1 || resp.Tournament.Rounds[0].Pairings[0].GameId == "" {
+		t.Errorf("StartTournament() = round %d %v, want round 1 with its game", resp.Tournament.CurrentRound, resp.Tournament.Rounds)
+	}
+
+	// Registration is closed once started
+	late := &tournamentspb.TournamentPlayer{Id: "p3", Name: "Player 3"}
+	_, err = server.JoinTournament(authContext("p3"), &tournamentspb.JoinTournamentRequest{TournamentId: tournamentID, Player: late})
+	if code := status.Code(err); code != codes.FailedPrecondition {
+		t.Errorf("JoinTournament() after start code = %v, want FailedPrecondition", code)
+	}
+}
+
+func TestServer_RoundRobin(t *testing.T) {
+	gamesClient := &mockGamesClient{}
+	server := NewServer(newMockStorage(), gamesClient, "redis:6379")
+	tournamentID := newTestTournament(t, server, tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN, 3)
+
+	resp, err := server.StartTournament(authContext("p1"), &tournamentspb.StartTournamentRequest{TournamentId: tournamentID, PlayerId: "p1"})
+	if err != nil {
+		t.Fatalf("StartTournament() error = %v", err)
+	}
+	if resp.Tournament.TotalRounds != 3 {
+		t.Fatalf("StartTournament() total rounds = %d, want 3", resp.Tournament.TotalRounds)
+	}
+
+	// Player one of every game wins
+	for round := 1; round <= 3; round++ {
+		tournament, _ := server.GetTournament(context.Background(), &tournamentspb.GetTournamentRequest{TournamentId: tournamentID})
+		if tournament.Tournament.CurrentRound != int32(round) {
+			t.Fatalf("Current round = %d, want %d", tournament.Tournament.CurrentRound, round)
+		}
+
+		for _, pairing := range tournament.Tournament.Rounds[round-1].Pairings {
+			if pairing.Player2Id == "" {
+				continue
+			}
+			finishGame(t, server, pairing, events.GameOverData{WinnerID: pairing.Player1Id})
+		}
+	}
+
+	resp2, _ := server.GetTournament(context.Background(), &tournamentspb.GetTournamentRequest{TournamentId: tournamentID})
+	if resp2.Tournament.Status != tournamentspb.TournamentStatus_TOURNAMENT_STATUS_FINISHED {
+		t.Errorf("Tournament status = %v, want finished", resp2.Tournament.Status)
+	}
+
+	// Three games and three byes were played in total
+	if len(gamesClient.created) != 3 {
+		t.Errorf("Games created = %d, want 3", len(gamesClient.created))
+	}
+
+	var total float64
+	for _, standing := range resp2.Standings {
+		total += standing.Score
+	}
+	if total != 6 {
+		t.Errorf("Total score = %v, want 6", total)
+	}
+}
+
+func TestServer_AbortedGameIsReplayed(t *testing.T) {
+	gamesClient := &mockGamesClient{}
+	server := NewServer(newMockStorage(), gamesClient, "redis:6379")
+	tournamentID := newTestTournament(t, server, tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 2)
+
+	resp, _ := server.StartTournament(authContext("p1"), &tournamentspb.StartTournamentRequest{TournamentId: tournamentID, PlayerId: "p1"})
+	pairing := resp.Tournament.Rounds[0].Pairings[0]
+
+	finishGame(t, server, pairing, events.GameOverData{Reason: events.GameOverReasonAborted})
+
+	tournament, _ := server.GetTournament(context.Background(), &tournamentspb.GetTournamentRequest{TournamentId: tournamentID})
+	replayed := tournament.Tournament.Rounds[0].Pairings[0]
+	if replayed.Result != tournamentspb.PairingResult_PAIRING_RESULT_PENDING || replayed.GameId == pairing.GameId || replayed.GameId == "" {
+		t.Errorf("Pairing after abort = %v, want a new pending game", replayed)
+	}
+}
+
+func TestServer_AdvanceCreatesMissingGames(t *testing.T) {
+	gamesClient := &mockGamesClient{fail: true}
+	server := NewServer(newMockStorage(), gamesClient, "redis:6379")
+	tournamentID := newTestTournament(t, server, tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 4)
+
+	resp, err := server.StartTournament(authContext("p1"), &tournamentspb.StartTournamentRequest{TournamentId: tournamentID, PlayerId: "p1"})
+	if err != nil {
+		t.Fatalf("StartTournament() error = %v", err)
+	}
+	for _, pairing := range resp.Tournament.Rounds[0].Pairings {
+		if pairing.GameId != "" {
+			t.Errorf("Pairing has game %s while the games service is down", pairing.GameId)
+		}
+	}
+
+	// The sweeper creates the games once the games service is back
+	gamesClient.fail = false
+	server.advanceAll(context.Background())
+
+	tournament, _ := server.GetTournament(context.Background(), &tournamentspb.GetTournamentRequest{TournamentId: tournamentID})
+	for _, pairing := range tournament.Tournament.Rounds[0].Pairings {
+		if pairing.GameId == "" {
+			t.Errorf("Pairing %s vs %s has no game after the sweep", pairing.Player1Id, pairing.Player2Id)
+		}
+	}
+}
+
+func TestServer_RetriedGameCreationReusesGame(t *testing.T) {
+	storage := newMockStorage()
+	gamesClient := &mockGamesClient{}
+	server := NewServer(storage, gamesClient, "redis:6379")
+	tournamentID := newTestTournament(t, server, tournamentspb.TournamentFormat_TOURNAMENT_FORMAT_SWISS, 2)
+
+	// The game is created but recording it fails
+	gamesClient.onCreate = func() {
+		storage.mu.Lock()
+		storage.saveErr = errors.New("redis unavailable")
+		storage.mu.Unlock()
+	}
+	server.StartTournament(authContext("p1"), &tournamentspb.StartTournamentRequest{TournamentId: tournamentID, PlayerId: "p1"})
+
+	gamesClient.onCreate = nil
+	storage.mu.Lock()
+	storage.saveErr = nil
+	storage.mu.Unlock()
+	server.advanceAll(context.Background())
+
+	tournament, _ := server.GetTournament(context.Background(), &tournamentspb.GetTournamentRequest{TournamentId: tournamentID})
+	pairing := tournament.Tournament.Rounds[0].Pairings[0]
+	if pairing.GameId != "game-1" || pairing.GameRequestId != "" {
+		t.Errorf("Pairing game = %q (request %q), want game-1", pairing.GameId, pairing.GameRequestId)
+	}
+	if len(gamesClient.created) != 1 {
+		t.Errorf("Games created = %d, want 1", len(gamesClient.created))
+	}
+	if gamesClient.noDeadline {
+		t.Error("Game was created without a deadline")
+	}
+}
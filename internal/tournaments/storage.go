@@ -0,0 +1,225 @@
+package tournaments
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/google/uuid"
+
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+)
+
+const (
+	// tournamentsKey is a sorted set of tournament IDs scored by their creation time in Unix milliseconds
+	tournamentsKey = "tournaments"
+
+	// tournamentKeyPrefix prefixes the key holding the JSON record of a tournament
+	tournamentKeyPrefix = "tournament:"
+
+	// tournamentGameKeyPrefix prefixes the key mapping a tournament game to its tournament
+	tournamentGameKeyPrefix = "tournament_game:"
+
+	// tournamentLockKeyPrefix prefixes the key of the lock held while a tournament's games are created
+	tournamentLockKeyPrefix = "tournament_lock:"
+)
+
+var (
+	// ErrTournamentNotFound is returned for tournaments that do not exist
+	ErrTournamentNotFound = errors.New("tournament not found")
+
+	// ErrVersionConflict is returned by SaveTournament when the tournament was changed since it was read
+	ErrVersionConflict = errors.New("tournament was changed by another request, please retry")
+)
+
+// unlockScript releases a lock only if it is still held with the caller's token
+var unlockScript = redis.NewScript(`
+if redis.call('GET', KEYS[1]) == ARGV[1] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`)
+
+type Storage interface {
+	// SaveTournament stores the tournament if its version still matches the stored one and bumps the version.
+	// It returns ErrVersionConflict when another save happened in between.
+	SaveTournament(ctx context.Context, tournament *tournamentspb.Tournament) error
+	// GetTournament returns ErrTournamentNotFound for unknown tournaments
+	GetTournament(ctx context.Context, tournamentID string) (*tournamentspb.Tournament, error)
+	// ListTournaments returns every tournament, newest first
+	ListTournaments(ctx context.Context) ([]*tournamentspb.Tournament, error)
+	// FindGameTournament returns the ID of the tournament the game was paired in, or "" if none
+	FindGameTournament(ctx context.Context, gameID string) (string, error)
+	// LockTournament takes the tournament's lock for at most ttl. It returns an empty token
+	// when another caller holds the lock.
+	LockTournament(ctx context.Context, tournamentID string, ttl time.Duration) (string, error)
+	UnlockTournament(ctx context.Context, tournamentID, token string) error
+}
+
+// RedisStorage stores tournaments in Redis, so every replica can run them
+type RedisStorage struct {
+	redisClient *redis.Client
+}
+
+// NewRedisStorage creates a new Redis backed tournament storage
+func NewRedisStorage(redisAddr string) *RedisStorage {
+	return &RedisStorage{
+		redisClient: redis.NewClient(&redis.Options{
+			Addr: redisAddr,
+		}),
+	}
+}
+
+func (r *RedisStorage) SaveTournament(ctx context.Context, tournament *tournamentspb.Tournament) error {
+	expected := tournament.Version
+	tournament.Version++
+
+	tournamentJSON, err := json.Marshal(tournament)
+	if err != nil {
+		tournament.Version = expected
+		return fmt.Errorf("failed to marshal tournament: %w", err)
+	}
+
+	createdAt := &redis.Z{Score: float64(tournament.CreatedAt * 1000), Member: tournament.Id}
+
+	save := func(tx *redis.Tx) error {
+		// A tournament that is not stored has version 0
+		current, err := r.storedVersion(ctx, tx, tournament.Id)
+		if err != nil {
+			return err
+		}
+		if current != expected {
+			return ErrVersionConflict
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, tournamentKey(tournament.Id), tournamentJSON, 0)
+			pipe.ZAddNX(ctx, tournamentsKey, createdAt)
+
+			// Index the games, so their results find their way back
+			for _, round := range tournament.Rounds {
+				for _, pairing := range round.Pairings {
+					if pairing.GameId != "" {
+						pipe.Set(ctx, tournamentGameKey(pairing.GameId), tournament.Id, 0)
+					}
+				}
+			}
+			return nil
+		})
+		return err
+	}
+
+	err = r.redisClient.Watch(ctx, save, tournamentKey(tournament.Id))
+	if err == nil {
+		return nil
+	}
+
+	tournament.Version = expected
+	if errors.Is(err, ErrVersionConflict) || errors.Is(err, redis.TxFailedErr) {
+		return ErrVersionConflict
+	}
+	return fmt.Errorf("failed to save tournament to redis: %w", err)
+}
+
+// storedVersion returns the version of the stored tournament, or 0 if there is none
+func (r *RedisStorage) storedVersion(ctx context.Context, tx *redis.Tx, tournamentID string) (uint64, error) {
+	tournamentJSON, err := tx.Get(ctx, tournamentKey(tournamentID)).Bytes()
+	if err == redis.Nil {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	var stored struct {
+		Version uint64 `json:"version"`
+	}
+	if err := json.Unmarshal(tournamentJSON, &stored); err != nil {
+		return 0, fmt.Errorf("failed to unmarshal tournament: %w", err)
+	}
+
+	return stored.Version, nil
+}
+
+func (r *RedisStorage) GetTournament(ctx context.Context, tournamentID string) (*tournamentspb.Tournament, error) {
+	tournamentJSON, err := r.redisClient.Get(ctx, tournamentKey(tournamentID)).Bytes()
+	if err == redis.Nil {
+		return nil, ErrTournamentNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tournament from redis: %w", err)
+	}
+
+	var tournament tournamentspb.Tournament
+	if err := json.Unmarshal(tournamentJSON, &tournament); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tournament: %w", err)
+	}
+
+	return &tournament, nil
+}
+
+func (r *RedisStorage) ListTournaments(ctx context.Context) ([]*tournamentspb.Tournament, error) {
+	tournamentIDs, err := r.redisClient.ZRevRange(ctx, tournamentsKey, 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tournaments from redis: %w", err)
+	}
+
+	tournaments := make([]*tournamentspb.Tournament, 0, len(tournamentIDs))
+	for _, tournamentID := range tournamentIDs {
+		tournament, err := r.GetTournament(ctx, tournamentID)
+		if errors.Is(err, ErrTournamentNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		tournaments = append(tournaments, tournament)
+	}
+
+	return tournaments, nil
+}
+
+func (r *RedisStorage) FindGameTournament(ctx context.Context, gameID string) (string, error) {
+	tournamentID, err := r.redisClient.Get(ctx, tournamentGameKey(gameID)).Result()
+	if err == redis.Nil {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to look up game tournament: %w", err)
+	}
+	return tournamentID, nil
+}
+
+func (r *RedisStorage) LockTournament(ctx context.Context, tournamentID string, ttl time.Duration) (string, error) {
+	token := uuid.New().String()
+	locked, err := r.redisClient.SetNX(ctx, tournamentLockKey(tournamentID), token, ttl).Result()
+	if err != nil {
+		return "", fmt.Errorf("failed to lock tournament: %w", err)
+	}
+	if !locked {
+		return "", nil
+	}
+	return token, nil
+}
+
+func (r *RedisStorage) UnlockTournament(ctx context.Context, tournamentID, token string) error {
+	if err := unlockScript.Run(ctx, r.redisClient, []string{tournamentLockKey(tournamentID)}, token).Err(); err != nil {
+		return fmt.Errorf("failed to unlock tournament: %w", err)
+	}
+	return nil
+}
+
+func tournamentKey(tournamentID string) string {
+	return tournamentKeyPrefix + tournamentID
+}
+
+func tournamentGameKey(gameID string) string {
+	return tournamentGameKeyPrefix + gameID
+}
+
+func tournamentLockKey(tournamentID string) string {
+	return tournamentLockKeyPrefix + tournamentID
+}
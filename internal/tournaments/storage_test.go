@@ -0,0 +1,90 @@
+package tournaments
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/modules/redis"
+
+	tournamentspb "github.com/laerson/mancala/proto/tournaments"
+)
+
+// startRedis starts a Redis container for the test and returns its address
+func startRedis(t *testing.T) string {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	redisContainer, err := redis.Run(ctx, "redis:7-alpine")
+	if err != nil {
+		t.Fatalf("failed to start redis container: %v", err)
+	}
+
+	t.Cleanup(func() {
+		if err := testcontainers.TerminateContainer(redisContainer); err != nil {
+			t.Logf("failed to terminate redis container: %v", err)
+		}
+	})
+
+	host, err := redisContainer.Host(ctx)
+	if err != nil {
+		t.Fatalf("failed to get redis host: %v", err)
+	}
+
+	port, err := redisContainer.MappedPort(ctx, "6379")
+	if err != nil {
+		t.Fatalf("failed to get redis port: %v", err)
+	}
+
+	return host + ":" + port.Port()
+}
+
+func TestRedisStorage(t *testing.T) {
+	storage := NewRedisStorage(startRedis(t))
+	ctx := context.Background()
+
+	if _, err := storage.GetTournament(ctx, "missing"); !errors.Is(err, ErrTournamentNotFound) {
+		t.Errorf("GetTournament() of a missing tournament error = %v, want ErrTournamentNotFound", err)
+	}
+
+	tournament := &tournamentspb.Tournament{Id: "t1", Name: "Open", CreatedAt: time.Now().Unix()}
+	if err := storage.SaveTournament(ctx, tournament); err != nil {
+		t.Fatalf("SaveTournament() error = %v", err)
+	}
+
+	// A stale copy cannot overwrite a newer save
+	stale, _ := storage.GetTournament(ctx, "t1")
+	tournament.Rounds = []*tournamentspb.Round{{Number: 1, Pairings: []*tournamentspb.Pairing{{Player1Id: "p1", Player2Id: "p2", GameId: "g1"}}}}
+	if err := storage.SaveTournament(ctx, tournament); err != nil {
+		t.Fatalf("SaveTournament() error = %v", err)
+	}
+	if err := storage.SaveTournament(ctx, stale); !errors.Is(err, ErrVersionConflict) {
+		t.Errorf("SaveTournament() of a stale copy error = %v, want ErrVersionConflict", err)
+	}
+
+	if tournamentID, err := storage.FindGameTournament(ctx, "g1"); err != nil || tournamentID != "t1" {
+		t.Errorf("FindGameTournament() = %q, %v, want t1", tournamentID, err)
+	}
+
+	tournaments, err := storage.ListTournaments(ctx)
+	if err != nil || len(tournaments) != 1 || tournaments[0].Version != 2 {
+		t.Errorf("ListTournaments() = %v, %v, want t1 at version 2", tournaments, err)
+	}
+
+	token, err := storage.LockTournament(ctx, "t1", time.Minute)
+	if err != nil || token == "" {
+		t.Fatalf("LockTournament() = %q, %v, want the lock", token, err)
+	}
+	if other, _ := storage.LockTournament(ctx, "t1", time.Minute); other != "" {
+		t.Error("LockTournament() of a held lock succeeded")
+	}
+	if err := storage.UnlockTournament(ctx, "t1", token); err != nil {
+		t.Fatalf("UnlockTournament() error = %v", err)
+	}
+	if again, _ := storage.LockTournament(ctx, "t1", time.Minute); again == "" {
+		t.Error("LockTournament() after unlock failed")
+	}
+}
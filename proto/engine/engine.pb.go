@@ -119,6 +119,178 @@ func (Winner) EnumDescriptor() ([]byte, []int) {
 	return file_proto_engine_engine_proto_rawDescGZIP(), []int{1}
 }
 
+// GameType selects the family of mancala rules a game is played by
+type GameType int32
+
+const (
+	GameType_GAME_TYPE_KALAH GameType = 0
+	GameType_GAME_TYPE_OWARE GameType = 1 // Oware abapa; stores only hold captured seeds
+)
+
+// Enum value maps for GameType.
+var (
+	GameType_name = map[int32]string{
+		0: "GAME_TYPE_KALAH",
+		1: "GAME_TYPE_OWARE",
+	}
+	GameType_value = map[string]int32{
+		"GAME_TYPE_KALAH": 0,
+		"GAME_TYPE_OWARE": 1,
+	}
+)
+
+func (x GameType) Enum() *GameType {
+	p := new(GameType)
+	*p = x
+	return p
+}
+
+func (x GameType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameType) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_engine_engine_proto_enumTypes[2].Descriptor()
+}
+
+func (GameType) Type() protoreflect.EnumType {
+	return &file_proto_engine_engine_proto_enumTypes[2]
+}
+
+func (x GameType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameType.Descriptor instead.
+func (GameType) EnumDescriptor() ([]byte, []int) {
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{2}
+}
+
+// SweepPolicy decides who collects the seeds left on the board when the game ends
+type SweepPolicy int32
+
+const (
+	SweepPolicy_SWEEP_TO_OWNER SweepPolicy = 0 // Each player collects the seeds on their own side
+	SweepPolicy_SWEEP_TO_MOVER SweepPolicy = 1 // The player who made the last move collects all seeds
+)
+
+// Enum value maps for SweepPolicy.
+var (
+	SweepPolicy_name = map[int32]string{
+		0: "SWEEP_TO_OWNER",
+		1: "SWEEP_TO_MOVER",
+	}
+	SweepPolicy_value = map[string]int32{
+		"SWEEP_TO_OWNER": 0,
+		"SWEEP_TO_MOVER": 1,
+	}
+)
+
+func (x SweepPolicy) Enum() *SweepPolicy {
+	p := new(SweepPolicy)
+	*p = x
+	return p
+}
+
+func (x SweepPolicy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SweepPolicy) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_engine_engine_proto_enumTypes[3].Descriptor()
+}
+
+func (SweepPolicy) Type() protoreflect.EnumType {
+	return &file_proto_engine_engine_proto_enumTypes[3]
+}
+
+func (x SweepPolicy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SweepPolicy.Descriptor instead.
+func (SweepPolicy) EnumDescriptor() ([]byte, []int) {
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{3}
+}
+
+// RuleSet describes a Kalah variant. The zero value is standard six-pit, four-seed Kalah.
+// House rules do not apply to Oware.
+type RuleSet struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	PitsPerSide    uint32                 `protobuf:"varint,1,opt,name=pits_per_side,json=pitsPerSide,proto3" json:"pits_per_side,omitempty"`  // 0 means 6
+	InitialSeeds   uint32                 `protobuf:"varint,2,opt,name=initial_seeds,json=initialSeeds,proto3" json:"initial_seeds,omitempty"` // 0 means 4
+	DisableCapture bool                   `protobuf:"varint,3,opt,name=disable_capture,json=disableCapture,proto3" json:"disable_capture,omitempty"`
+	EmptyCapture   bool                   `protobuf:"varint,4,opt,name=empty_capture,json=emptyCapture,proto3" json:"empty_capture,omitempty"` // Capture the last seed even when the opposite pit is empty
+	SweepPolicy    SweepPolicy            `protobuf:"varint,5,opt,name=sweep_policy,json=sweepPolicy,proto3,enum=proto.engine.SweepPolicy" json:"sweep_policy,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RuleSet) Reset() {
+	*x = RuleSet{}
+	mi := &file_proto_engine_engine_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RuleSet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RuleSet) ProtoMessage() {}
+
+func (x *RuleSet) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_engine_engine_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RuleSet.ProtoReflect.Descriptor instead.
+func (*RuleSet) Descriptor() ([]byte, []int) {
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RuleSet) GetPitsPerSide() uint32 {
+	if x != nil {
+		return x.PitsPerSide
+	}
+	return 0
+}
+
+func (x *RuleSet) GetInitialSeeds() uint32 {
+	if x != nil {
+		return x.InitialSeeds
+	}
+	return 0
+}
+
+func (x *RuleSet) GetDisableCapture() bool {
+	if x != nil {
+		return x.DisableCapture
+	}
+	return false
+}
+
+func (x *RuleSet) GetEmptyCapture() bool {
+	if x != nil {
+		return x.EmptyCapture
+	}
+	return false
+}
+
+func (x *RuleSet) GetSweepPolicy() SweepPolicy {
+	if x != nil {
+		return x.SweepPolicy
+	}
+	return SweepPolicy_SWEEP_TO_OWNER
+}
+
 type Board struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Pits          []uint32               `protobuf:"varint,1,rep,packed,name=pits,proto3" json:"pits,omitempty"`
@@ -128,7 +300,7 @@ type Board struct {
 
 func (x *Board) Reset() {
 	*x = Board{}
-	mi := &file_proto_engine_engine_proto_msgTypes[0]
+	mi := &file_proto_engine_engine_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -140,7 +312,7 @@ func (x *Board) String() string {
 func (*Board) ProtoMessage() {}
 
 func (x *Board) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_engine_engine_proto_msgTypes[0]
+	mi := &file_proto_engine_engine_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -153,7 +325,7 @@ func (x *Board) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Board.ProtoReflect.Descriptor instead.
 func (*Board) Descriptor() ([]byte, []int) {
-	return file_proto_engine_engine_proto_rawDescGZIP(), []int{0}
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Board) GetPits() []uint32 {
@@ -167,13 +339,15 @@ type GameState struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Board         *Board                 `protobuf:"bytes,1,opt,name=board,proto3" json:"board,omitempty"`
 	CurrentPlayer Player                 `protobuf:"varint,2,opt,name=current_player,json=currentPlayer,proto3,enum=proto.engine.Player" json:"current_player,omitempty"`
+	Rules         *RuleSet               `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"`
+	GameType      GameType               `protobuf:"varint,4,opt,name=game_type,json=gameType,proto3,enum=proto.engine.GameType" json:"game_type,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GameState) Reset() {
 	*x = GameState{}
-	mi := &file_proto_engine_engine_proto_msgTypes[1]
+	mi := &file_proto_engine_engine_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -185,7 +359,7 @@ func (x *GameState) String() string {
 func (*GameState) ProtoMessage() {}
 
 func (x *GameState) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_engine_engine_proto_msgTypes[1]
+	mi := &file_proto_engine_engine_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -198,7 +372,7 @@ func (x *GameState) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameState.ProtoReflect.Descriptor instead.
 func (*GameState) Descriptor() ([]byte, []int) {
-	return file_proto_engine_engine_proto_rawDescGZIP(), []int{1}
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *GameState) GetBoard() *Board {
@@ -215,6 +389,20 @@ func (x *GameState) GetCurrentPlayer() Player {
 	return Player_PLAYER_ONE
 }
 
+func (x *GameState) GetRules() *RuleSet {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *GameState) GetGameType() GameType {
+	if x != nil {
+		return x.GameType
+	}
+	return GameType_GAME_TYPE_KALAH
+}
+
 type MoveRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	GameState     *GameState             `protobuf:"bytes,1,opt,name=game_state,json=gameState,proto3" json:"game_state,omitempty"`
@@ -225,7 +413,7 @@ type MoveRequest struct {
 
 func (x *MoveRequest) Reset() {
 	*x = MoveRequest{}
-	mi := &file_proto_engine_engine_proto_msgTypes[2]
+	mi := &file_proto_engine_engine_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -237,7 +425,7 @@ func (x *MoveRequest) String() string {
 func (*MoveRequest) ProtoMessage() {}
 
 func (x *MoveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_engine_engine_proto_msgTypes[2]
+	mi := &file_proto_engine_engine_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -250,7 +438,7 @@ func (x *MoveRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveRequest.ProtoReflect.Descriptor instead.
 func (*MoveRequest) Descriptor() ([]byte, []int) {
-	return file_proto_engine_engine_proto_rawDescGZIP(), []int{2}
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MoveRequest) GetGameState() *GameState {
@@ -276,7 +464,7 @@ type Error struct {
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_proto_engine_engine_proto_msgTypes[3]
+	mi := &file_proto_engine_engine_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -288,7 +476,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_engine_engine_proto_msgTypes[3]
+	mi := &file_proto_engine_engine_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -301,7 +489,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_proto_engine_engine_proto_rawDescGZIP(), []int{3}
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Error) GetMessage() string {
@@ -317,13 +505,15 @@ type MoveResult struct {
 	CurrentPlayer Player                 `protobuf:"varint,2,opt,name=current_player,json=currentPlayer,proto3,enum=proto.engine.Player" json:"current_player,omitempty"`
 	IsFinished    bool                   `protobuf:"varint,3,opt,name=is_finished,json=isFinished,proto3" json:"is_finished,omitempty"`
 	Winner        Winner                 `protobuf:"varint,4,opt,name=winner,proto3,enum=proto.engine.Winner" json:"winner,omitempty"`
+	ExtraTurn     bool                   `protobuf:"varint,5,opt,name=extra_turn,json=extraTurn,proto3" json:"extra_turn,omitempty"` // The last seed landed in the mover's store
+	Captured      bool                   `protobuf:"varint,6,opt,name=captured,proto3" json:"captured,omitempty"`                    // The last seed captured the opposite pit
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MoveResult) Reset() {
 	*x = MoveResult{}
-	mi := &file_proto_engine_engine_proto_msgTypes[4]
+	mi := &file_proto_engine_engine_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +525,7 @@ func (x *MoveResult) String() string {
 func (*MoveResult) ProtoMessage() {}
 
 func (x *MoveResult) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_engine_engine_proto_msgTypes[4]
+	mi := &file_proto_engine_engine_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +538,7 @@ func (x *MoveResult) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveResult.ProtoReflect.Descriptor instead.
 func (*MoveResult) Descriptor() ([]byte, []int) {
-	return file_proto_engine_engine_proto_rawDescGZIP(), []int{4}
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *MoveResult) GetBoard() *Board {
@@ -379,6 +569,20 @@ func (x *MoveResult) GetWinner() Winner {
 	return Winner_NO_WINNER
 }
 
+func (x *MoveResult) GetExtraTurn() bool {
+	if x != nil {
+		return x.ExtraTurn
+	}
+	return false
+}
+
+func (x *MoveResult) GetCaptured() bool {
+	if x != nil {
+		return x.Captured
+	}
+	return false
+}
+
 type MoveResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Result:
@@ -392,7 +596,7 @@ type MoveResponse struct {
 
 func (x *MoveResponse) Reset() {
 	*x = MoveResponse{}
-	mi := &file_proto_engine_engine_proto_msgTypes[5]
+	mi := &file_proto_engine_engine_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -404,7 +608,7 @@ func (x *MoveResponse) String() string {
 func (*MoveResponse) ProtoMessage() {}
 
 func (x *MoveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_engine_engine_proto_msgTypes[5]
+	mi := &file_proto_engine_engine_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -417,7 +621,7 @@ func (x *MoveResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveResponse.ProtoReflect.Descriptor instead.
 func (*MoveResponse) Descriptor() ([]byte, []int) {
-	return file_proto_engine_engine_proto_rawDescGZIP(), []int{5}
+	return file_proto_engine_engine_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *MoveResponse) GetResult() isMoveResponse_Result {
@@ -465,25 +669,36 @@ var File_proto_engine_engine_proto protoreflect.FileDescriptor
 
 const file_proto_engine_engine_proto_rawDesc = "" +
 	"\n" +
-	"\x19proto/engine/engine.proto\x12\fproto.engine\"\x1b\n" +
+	"\x19proto/engine/engine.proto\x12\fproto.engine\"\xde\x01\n" +
+	"\aRuleSet\x12\"\n" +
+	"\rpits_per_side\x18\x01 \x01(\rR\vpitsPerSide\x12#\n" +
+	"\rinitial_seeds\x18\x02 \x01(\rR\finitialSeeds\x12'\n" +
+	"\x0fdisable_capture\x18\x03 \x01(\bR\x0edisableCapture\x12#\n" +
+	"\rempty_capture\x18\x04 \x01(\bR\femptyCapture\x12<\n" +
+	"\fsweep_policy\x18\x05 \x01(\x0e2\x19.proto.engine.SweepPolicyR\vsweepPolicy\"\x1b\n" +
 	"\x05Board\x12\x12\n" +
-	"\x04pits\x18\x01 \x03(\rR\x04pits\"s\n" +
+	"\x04pits\x18\x01 \x03(\rR\x04pits\"\xd5\x01\n" +
 	"\tGameState\x12)\n" +
 	"\x05board\x18\x01 \x01(\v2\x13.proto.engine.BoardR\x05board\x12;\n" +
-	"\x0ecurrent_player\x18\x02 \x01(\x0e2\x14.proto.engine.PlayerR\rcurrentPlayer\"b\n" +
+	"\x0ecurrent_player\x18\x02 \x01(\x0e2\x14.proto.engine.PlayerR\rcurrentPlayer\x12+\n" +
+	"\x05rules\x18\x03 \x01(\v2\x15.proto.engine.RuleSetR\x05rules\x123\n" +
+	"\tgame_type\x18\x04 \x01(\x0e2\x16.proto.engine.GameTypeR\bgameType\"b\n" +
 	"\vMoveRequest\x126\n" +
 	"\n" +
 	"game_state\x18\x01 \x01(\v2\x17.proto.engine.GameStateR\tgameState\x12\x1b\n" +
 	"\tpit_index\x18\x02 \x01(\rR\bpitIndex\"!\n" +
 	"\x05Error\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"\xc3\x01\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xfe\x01\n" +
 	"\n" +
 	"MoveResult\x12)\n" +
 	"\x05board\x18\x01 \x01(\v2\x13.proto.engine.BoardR\x05board\x12;\n" +
 	"\x0ecurrent_player\x18\x02 \x01(\x0e2\x14.proto.engine.PlayerR\rcurrentPlayer\x12\x1f\n" +
 	"\vis_finished\x18\x03 \x01(\bR\n" +
 	"isFinished\x12,\n" +
-	"\x06winner\x18\x04 \x01(\x0e2\x14.proto.engine.WinnerR\x06winner\"\x82\x01\n" +
+	"\x06winner\x18\x04 \x01(\x0e2\x14.proto.engine.WinnerR\x06winner\x12\x1d\n" +
+	"\n" +
+	"extra_turn\x18\x05 \x01(\bR\textraTurn\x12\x1a\n" +
+	"\bcaptured\x18\x06 \x01(\bR\bcaptured\"\x82\x01\n" +
 	"\fMoveResponse\x12;\n" +
 	"\vmove_result\x18\x01 \x01(\v2\x18.proto.engine.MoveResultH\x00R\n" +
 	"moveResult\x12+\n" +
@@ -498,7 +713,13 @@ const file_proto_engine_engine_proto_rawDesc = "" +
 	"\tNO_WINNER\x10\x00\x12\x15\n" +
 	"\x11WINNER_PLAYER_ONE\x10\x01\x12\x15\n" +
 	"\x11WINNER_PLAYER_TWO\x10\x02\x12\b\n" +
-	"\x04DRAW\x10\x032G\n" +
+	"\x04DRAW\x10\x03*4\n" +
+	"\bGameType\x12\x13\n" +
+	"\x0fGAME_TYPE_KALAH\x10\x00\x12\x13\n" +
+	"\x0fGAME_TYPE_OWARE\x10\x01*5\n" +
+	"\vSweepPolicy\x12\x12\n" +
+	"\x0eSWEEP_TO_OWNER\x10\x00\x12\x12\n" +
+	"\x0eSWEEP_TO_MOVER\x10\x012G\n" +
 	"\x06Engine\x12=\n" +
 	"\x04Move\x12\x19.proto.engine.MoveRequest\x1a\x1a.proto.engine.MoveResponseB2Z0github.com/laerson/mancala/proto/engine;enginepbb\x06proto3"
 
@@ -514,34 +735,40 @@ func file_proto_engine_engine_proto_rawDescGZIP() []byte {
 	return file_proto_engine_engine_proto_rawDescData
 }
 
-var file_proto_engine_engine_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_proto_engine_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_engine_engine_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_engine_engine_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_engine_engine_proto_goTypes = []any{
 	(Player)(0),          // 0: proto.engine.Player
 	(Winner)(0),          // 1: proto.engine.Winner
-	(*Board)(nil),        // 2: proto.engine.Board
-	(*GameState)(nil),    // 3: proto.engine.GameState
-	(*MoveRequest)(nil),  // 4: proto.engine.MoveRequest
-	(*Error)(nil),        // 5: proto.engine.Error
-	(*MoveResult)(nil),   // 6: proto.engine.MoveResult
-	(*MoveResponse)(nil), // 7: proto.engine.MoveResponse
+	(GameType)(0),        // 2: proto.engine.GameType
+	(SweepPolicy)(0),     // 3: proto.engine.SweepPolicy
+	(*RuleSet)(nil),      // 4: proto.engine.RuleSet
+	(*Board)(nil),        // 5: proto.engine.Board
+	(*GameState)(nil),    // 6: proto.engine.GameState
+	(*MoveRequest)(nil),  // 7: proto.engine.MoveRequest
+	(*Error)(nil),        // 8: proto.engine.Error
+	(*MoveResult)(nil),   // 9: proto.engine.MoveResult
+	(*MoveResponse)(nil), // 10: proto.engine.MoveResponse
 }
 var file_proto_engine_engine_proto_depIdxs = []int32{
-	2, // 0: proto.engine.GameState.board:type_name -> proto.engine.Board
-	0, // 1: proto.engine.GameState.current_player:type_name -> proto.engine.Player
-	3, // 2: proto.engine.MoveRequest.game_state:type_name -> proto.engine.GameState
-	2, // 3: proto.engine.MoveResult.board:type_name -> proto.engine.Board
-	0, // 4: proto.engine.MoveResult.current_player:type_name -> proto.engine.Player
-	1, // 5: proto.engine.MoveResult.winner:type_name -> proto.engine.Winner
-	6, // 6: proto.engine.MoveResponse.move_result:type_name -> proto.engine.MoveResult
-	5, // 7: proto.engine.MoveResponse.error:type_name -> proto.engine.Error
-	4, // 8: proto.engine.Engine.Move:input_type -> proto.engine.MoveRequest
-	7, // 9: proto.engine.Engine.Move:output_type -> proto.engine.MoveResponse
-	9, // [9:10] is the sub-list for method output_type
-	8, // [8:9] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	3,  // 0: proto.engine.RuleSet.sweep_policy:type_name -> proto.engine.SweepPolicy
+	5,  // 1: proto.engine.GameState.board:type_name -> proto.engine.Board
+	0,  // 2: proto.engine.GameState.current_player:type_name -> proto.engine.Player
+	4,  // 3: proto.engine.GameState.rules:type_name -> proto.engine.RuleSet
+	2,  // 4: proto.engine.GameState.game_type:type_name -> proto.engine.GameType
+	6,  // 5: proto.engine.MoveRequest.game_state:type_name -> proto.engine.GameState
+	5,  // 6: proto.engine.MoveResult.board:type_name -> proto.engine.Board
+	0,  // 7: proto.engine.MoveResult.current_player:type_name -> proto.engine.Player
+	1,  // 8: proto.engine.MoveResult.winner:type_name -> proto.engine.Winner
+	9,  // 9: proto.engine.MoveResponse.move_result:type_name -> proto.engine.MoveResult
+	8,  // 10: proto.engine.MoveResponse.error:type_name -> proto.engine.Error
+	7,  // 11: proto.engine.Engine.Move:input_type -> proto.engine.MoveRequest
+	10, // 12: proto.engine.Engine.Move:output_type -> proto.engine.MoveResponse
+	12, // [12:13] is the sub-list for method output_type
+	11, // [11:12] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_proto_engine_engine_proto_init() }
@@ -549,7 +776,7 @@ func file_proto_engine_engine_proto_init() {
 	if File_proto_engine_engine_proto != nil {
 		return
 	}
-	file_proto_engine_engine_proto_msgTypes[5].OneofWrappers = []any{
+	file_proto_engine_engine_proto_msgTypes[6].OneofWrappers = []any{
 		(*MoveResponse_MoveResult)(nil),
 		(*MoveResponse_Error)(nil),
 	}
@@ -558,8 +785,8 @@ func file_proto_engine_engine_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_engine_engine_proto_rawDesc), len(file_proto_engine_engine_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   6,
+			NumEnums:      4,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
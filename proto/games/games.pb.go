@@ -22,31 +22,1350 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type GameStatus int32
+
+const (
+	GameStatus_GAME_STATUS_UNSPECIFIED GameStatus = 0
+	GameStatus_GAME_STATUS_IN_PROGRESS GameStatus = 1
+	GameStatus_GAME_STATUS_FINISHED    GameStatus = 2
+)
+
+// Enum value maps for GameStatus.
+var (
+	GameStatus_name = map[int32]string{
+		0: "GAME_STATUS_UNSPECIFIED",
+		1: "GAME_STATUS_IN_PROGRESS",
+		2: "GAME_STATUS_FINISHED",
+	}
+	GameStatus_value = map[string]int32{
+		"GAME_STATUS_UNSPECIFIED": 0,
+		"GAME_STATUS_IN_PROGRESS": 1,
+		"GAME_STATUS_FINISHED":    2,
+	}
+)
+
+func (x GameStatus) Enum() *GameStatus {
+	p := new(GameStatus)
+	*p = x
+	return p
+}
+
+func (x GameStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_games_games_proto_enumTypes[0].Descriptor()
+}
+
+func (GameStatus) Type() protoreflect.EnumType {
+	return &file_proto_games_games_proto_enumTypes[0]
+}
+
+func (x GameStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameStatus.Descriptor instead.
+func (GameStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{0}
+}
+
+// GameEndReason records how a finished game ended
+type GameEndReason int32
+
+const (
+	GameEndReason_GAME_END_REASON_UNSPECIFIED        GameEndReason = 0
+	GameEndReason_GAME_END_REASON_COMPLETED          GameEndReason = 1 // Played out on the board
+	GameEndReason_GAME_END_REASON_RESIGNATION        GameEndReason = 2
+	GameEndReason_GAME_END_REASON_DRAW_AGREED        GameEndReason = 3
+	GameEndReason_GAME_END_REASON_ABORTED            GameEndReason = 4 // Abandoned before the second move, without a result
+	GameEndReason_GAME_END_REASON_TIMEOUT            GameEndReason = 5 // The player to move ran out of time
+	GameEndReason_GAME_END_REASON_ENDED_BY_MODERATOR GameEndReason = 6
+)
+
+// Enum value maps for GameEndReason.
+var (
+	GameEndReason_name = map[int32]string{
+		0: "GAME_END_REASON_UNSPECIFIED",
+		1: "GAME_END_REASON_COMPLETED",
+		2: "GAME_END_REASON_RESIGNATION",
+		3: "GAME_END_REASON_DRAW_AGREED",
+		4: "GAME_END_REASON_ABORTED",
+		5: "GAME_END_REASON_TIMEOUT",
+		6: "GAME_END_REASON_ENDED_BY_MODERATOR",
+	}
+	GameEndReason_value = map[string]int32{
+		"GAME_END_REASON_UNSPECIFIED":        0,
+		"GAME_END_REASON_COMPLETED":          1,
+		"GAME_END_REASON_RESIGNATION":        2,
+		"GAME_END_REASON_DRAW_AGREED":        3,
+		"GAME_END_REASON_ABORTED":            4,
+		"GAME_END_REASON_TIMEOUT":            5,
+		"GAME_END_REASON_ENDED_BY_MODERATOR": 6,
+	}
+)
+
+func (x GameEndReason) Enum() *GameEndReason {
+	p := new(GameEndReason)
+	*p = x
+	return p
+}
+
+func (x GameEndReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (GameEndReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_games_games_proto_enumTypes[1].Descriptor()
+}
+
+func (GameEndReason) Type() protoreflect.EnumType {
+	return &file_proto_games_games_proto_enumTypes[1]
+}
+
+func (x GameEndReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use GameEndReason.Descriptor instead.
+func (GameEndReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{1}
+}
+
+// ErrorCode tells callers what went wrong without parsing the message
+type ErrorCode int32
+
+const (
+	ErrorCode_ERROR_CODE_UNSPECIFIED       ErrorCode = 0 // Refused for another reason, e.g. an illegal move
+	ErrorCode_ERROR_CODE_NOT_FOUND         ErrorCode = 1
+	ErrorCode_ERROR_CODE_PERMISSION_DENIED ErrorCode = 2 // Not the caller's player or game
+	ErrorCode_ERROR_CODE_GAME_FINISHED     ErrorCode = 3
+	ErrorCode_ERROR_CODE_VERSION_CONFLICT  ErrorCode = 4 // Another request changed the game first, retry
+	ErrorCode_ERROR_CODE_NOT_YOUR_TURN     ErrorCode = 5
+	ErrorCode_ERROR_CODE_INTERNAL          ErrorCode = 6 // The games service or a service it uses failed, not the request
+)
+
+// Enum value maps for ErrorCode.
+var (
+	ErrorCode_name = map[int32]string{
+		0: "ERROR_CODE_UNSPECIFIED",
+		1: "ERROR_CODE_NOT_FOUND",
+		2: "ERROR_CODE_PERMISSION_DENIED",
+		3: "ERROR_CODE_GAME_FINISHED",
+		4: "ERROR_CODE_VERSION_CONFLICT",
+		5: "ERROR_CODE_NOT_YOUR_TURN",
+		6: "ERROR_CODE_INTERNAL",
+	}
+	ErrorCode_value = map[string]int32{
+		"ERROR_CODE_UNSPECIFIED":       0,
+		"ERROR_CODE_NOT_FOUND":         1,
+		"ERROR_CODE_PERMISSION_DENIED": 2,
+		"ERROR_CODE_GAME_FINISHED":     3,
+		"ERROR_CODE_VERSION_CONFLICT":  4,
+		"ERROR_CODE_NOT_YOUR_TURN":     5,
+		"ERROR_CODE_INTERNAL":          6,
+	}
+)
+
+func (x ErrorCode) Enum() *ErrorCode {
+	p := new(ErrorCode)
+	*p = x
+	return p
+}
+
+func (x ErrorCode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorCode) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_games_games_proto_enumTypes[2].Descriptor()
+}
+
+func (ErrorCode) Type() protoreflect.EnumType {
+	return &file_proto_games_games_proto_enumTypes[2]
+}
+
+func (x ErrorCode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorCode.Descriptor instead.
+func (ErrorCode) EnumDescriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{2}
+}
+
+// TimeControl is chosen when a game is created. Either base_ms (plus increment_ms
+// after every move) or per_move_ms is set; the zero value plays without a clock.
+type TimeControl struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BaseMs        int64                  `protobuf:"varint,1,opt,name=base_ms,json=baseMs,proto3" json:"base_ms,omitempty"`
+	IncrementMs   int64                  `protobuf:"varint,2,opt,name=increment_ms,json=incrementMs,proto3" json:"increment_ms,omitempty"`
+	PerMoveMs     int64                  `protobuf:"varint,3,opt,name=per_move_ms,json=perMoveMs,proto3" json:"per_move_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeControl) Reset() {
+	*x = TimeControl{}
+	mi := &file_proto_games_games_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeControl) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeControl) ProtoMessage() {}
+
+func (x *TimeControl) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeControl.ProtoReflect.Descriptor instead.
+func (*TimeControl) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TimeControl) GetBaseMs() int64 {
+	if x != nil {
+		return x.BaseMs
+	}
+	return 0
+}
+
+func (x *TimeControl) GetIncrementMs() int64 {
+	if x != nil {
+		return x.IncrementMs
+	}
+	return 0
+}
+
+func (x *TimeControl) GetPerMoveMs() int64 {
+	if x != nil {
+		return x.PerMoveMs
+	}
+	return 0
+}
+
+// Clock holds each player's remaining time. The player to move has been thinking
+// since turn_started_at_ms, which is not yet charged to their remaining time.
+type Clock struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Player1RemainingMs int64                  `protobuf:"varint,1,opt,name=player1_remaining_ms,json=player1RemainingMs,proto3" json:"player1_remaining_ms,omitempty"`
+	Player2RemainingMs int64                  `protobuf:"varint,2,opt,name=player2_remaining_ms,json=player2RemainingMs,proto3" json:"player2_remaining_ms,omitempty"`
+	TurnStartedAtMs    int64                  `protobuf:"varint,3,opt,name=turn_started_at_ms,json=turnStartedAtMs,proto3" json:"turn_started_at_ms,omitempty"` // Unix milliseconds
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *Clock) Reset() {
+	*x = Clock{}
+	mi := &file_proto_games_games_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Clock) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Clock) ProtoMessage() {}
+
+func (x *Clock) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Clock.ProtoReflect.Descriptor instead.
+func (*Clock) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Clock) GetPlayer1RemainingMs() int64 {
+	if x != nil {
+		return x.Player1RemainingMs
+	}
+	return 0
+}
+
+func (x *Clock) GetPlayer2RemainingMs() int64 {
+	if x != nil {
+		return x.Player2RemainingMs
+	}
+	return 0
+}
+
+func (x *Clock) GetTurnStartedAtMs() int64 {
+	if x != nil {
+		return x.TurnStartedAtMs
+	}
+	return 0
+}
+
+type GameMove struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	PitIndex      uint32                 `protobuf:"varint,2,opt,name=pit_index,json=pitIndex,proto3" json:"pit_index,omitempty"`
+	Timestamp     int64                  `protobuf:"varint,3,opt,name=timestamp,proto3" json:"timestamp,omitempty"` // Unix timestamp
+	Board         *engine.Board          `protobuf:"bytes,4,opt,name=board,proto3" json:"board,omitempty"`          // Board after the move
+	ExtraTurn     bool                   `protobuf:"varint,5,opt,name=extra_turn,json=extraTurn,proto3" json:"extra_turn,omitempty"`
+	Captured      bool                   `protobuf:"varint,6,opt,name=captured,proto3" json:"captured,omitempty"`
+	NextPlayer    engine.Player          `protobuf:"varint,7,opt,name=next_player,json=nextPlayer,proto3,enum=proto.engine.Player" json:"next_player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameMove) Reset() {
+	*x = GameMove{}
+	mi := &file_proto_games_games_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameMove) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameMove) ProtoMessage() {}
+
+func (x *GameMove) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameMove.ProtoReflect.Descriptor instead.
+func (*GameMove) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GameMove) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GameMove) GetPitIndex() uint32 {
+	if x != nil {
+		return x.PitIndex
+	}
+	return 0
+}
+
+func (x *GameMove) GetTimestamp() int64 {
+	if x != nil {
+		return x.Timestamp
+	}
+	return 0
+}
+
+func (x *GameMove) GetBoard() *engine.Board {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *GameMove) GetExtraTurn() bool {
+	if x != nil {
+		return x.ExtraTurn
+	}
+	return false
+}
+
+func (x *GameMove) GetCaptured() bool {
+	if x != nil {
+		return x.Captured
+	}
+	return false
+}
+
+func (x *GameMove) GetNextPlayer() engine.Player {
+	if x != nil {
+		return x.NextPlayer
+	}
+	return engine.Player(0)
+}
+
 type Game struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	State         *engine.GameState      `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
 	Player1Id     string                 `protobuf:"bytes,3,opt,name=player1_id,json=player1Id,proto3" json:"player1_id,omitempty"`
 	Player2Id     string                 `protobuf:"bytes,4,opt,name=player2_id,json=player2Id,proto3" json:"player2_id,omitempty"`
+	Status        GameStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=proto.games.GameStatus" json:"status,omitempty"`
+	Moves         []*GameMove            `protobuf:"bytes,6,rep,name=moves,proto3" json:"moves,omitempty"`
+	CreatedAt     int64                  `protobuf:"varint,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`    // Unix timestamp
+	FinishedAt    int64                  `protobuf:"varint,8,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"` // Unix timestamp, 0 while in progress
+	Winner        engine.Winner          `protobuf:"varint,9,opt,name=winner,proto3,enum=proto.engine.Winner" json:"winner,omitempty"`
+	WinnerId      string                 `protobuf:"bytes,10,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // Empty on a draw
+	InitialBoard  *engine.Board          `protobuf:"bytes,11,opt,name=initial_board,json=initialBoard,proto3" json:"initial_board,omitempty"`
+	EndReason     GameEndReason          `protobuf:"varint,12,opt,name=end_reason,json=endReason,proto3,enum=proto.games.GameEndReason" json:"end_reason,omitempty"`
+	DrawOfferedBy string                 `protobuf:"bytes,13,opt,name=draw_offered_by,json=drawOfferedBy,proto3" json:"draw_offered_by,omitempty"` // Player with a pending draw offer, empty if none
+	TimeControl   *TimeControl           `protobuf:"bytes,14,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`
+	Clock         *Clock                 `protobuf:"bytes,15,opt,name=clock,proto3" json:"clock,omitempty"`      // Unset for games without a time control
+	Version       uint64                 `protobuf:"varint,16,opt,name=version,proto3" json:"version,omitempty"` // Bumped on every save, which fails if the game changed since it was read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Game) Reset() {
+	*x = Game{}
+	mi := &file_proto_games_games_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Game) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Game) ProtoMessage() {}
+
+func (x *Game) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Game.ProtoReflect.Descriptor instead.
+func (*Game) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Game) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Game) GetState() *engine.GameState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *Game) GetPlayer1Id() string {
+	if x != nil {
+		return x.Player1Id
+	}
+	return ""
+}
+
+func (x *Game) GetPlayer2Id() string {
+	if x != nil {
+		return x.Player2Id
+	}
+	return ""
+}
+
+func (x *Game) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *Game) GetMoves() []*GameMove {
+	if x != nil {
+		return x.Moves
+	}
+	return nil
+}
+
+func (x *Game) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Game) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *Game) GetWinner() engine.Winner {
+	if x != nil {
+		return x.Winner
+	}
+	return engine.Winner(0)
+}
+
+func (x *Game) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *Game) GetInitialBoard() *engine.Board {
+	if x != nil {
+		return x.InitialBoard
+	}
+	return nil
+}
+
+func (x *Game) GetEndReason() GameEndReason {
+	if x != nil {
+		return x.EndReason
+	}
+	return GameEndReason_GAME_END_REASON_UNSPECIFIED
+}
+
+func (x *Game) GetDrawOfferedBy() string {
+	if x != nil {
+		return x.DrawOfferedBy
+	}
+	return ""
+}
+
+func (x *Game) GetTimeControl() *TimeControl {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+func (x *Game) GetClock() *Clock {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *Game) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type CreateGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player1Id     string                 `protobuf:"bytes,1,opt,name=player1_id,json=player1Id,proto3" json:"player1_id,omitempty"`
+	Player2Id     string                 `protobuf:"bytes,2,opt,name=player2_id,json=player2Id,proto3" json:"player2_id,omitempty"`
+	Rules         *engine.RuleSet        `protobuf:"bytes,3,opt,name=rules,proto3" json:"rules,omitempty"` // Unset for standard Kalah
+	GameType      engine.GameType        `protobuf:"varint,4,opt,name=game_type,json=gameType,proto3,enum=proto.engine.GameType" json:"game_type,omitempty"`
+	TimeControl   *TimeControl           `protobuf:"bytes,5,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"` // Unset for games without a clock
+	RequestId     string                 `protobuf:"bytes,6,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`       // Optional; creating again with the same ID returns the same game
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameRequest) Reset() {
+	*x = CreateGameRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameRequest) ProtoMessage() {}
+
+func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
+func (*CreateGameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateGameRequest) GetPlayer1Id() string {
+	if x != nil {
+		return x.Player1Id
+	}
+	return ""
+}
+
+func (x *CreateGameRequest) GetPlayer2Id() string {
+	if x != nil {
+		return x.Player2Id
+	}
+	return ""
+}
+
+func (x *CreateGameRequest) GetRules() *engine.RuleSet {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *CreateGameRequest) GetGameType() engine.GameType {
+	if x != nil {
+		return x.GameType
+	}
+	return engine.GameType(0)
+}
+
+func (x *CreateGameRequest) GetTimeControl() *TimeControl {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+func (x *CreateGameRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+type CreateGameResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateGameResponse) Reset() {
+	*x = CreateGameResponse{}
+	mi := &file_proto_games_games_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateGameResponse) ProtoMessage() {}
+
+func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
+func (*CreateGameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateGameResponse) GetGame() *Game {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+type MakeGameMoveRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PitIndex      uint32                 `protobuf:"varint,3,opt,name=pit_index,json=pitIndex,proto3" json:"pit_index,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MakeGameMoveRequest) Reset() {
+	*x = MakeGameMoveRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MakeGameMoveRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MakeGameMoveRequest) ProtoMessage() {}
+
+func (x *MakeGameMoveRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MakeGameMoveRequest.ProtoReflect.Descriptor instead.
+func (*MakeGameMoveRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MakeGameMoveRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MakeGameMoveRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *MakeGameMoveRequest) GetPitIndex() uint32 {
+	if x != nil {
+		return x.PitIndex
+	}
+	return 0
+}
+
+type MakeGameMoveResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*MakeGameMoveResponse_MoveResult
+	//	*MakeGameMoveResponse_Error
+	Result        isMakeGameMoveResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MakeGameMoveResponse) Reset() {
+	*x = MakeGameMoveResponse{}
+	mi := &file_proto_games_games_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MakeGameMoveResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MakeGameMoveResponse) ProtoMessage() {}
+
+func (x *MakeGameMoveResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MakeGameMoveResponse.ProtoReflect.Descriptor instead.
+func (*MakeGameMoveResponse) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MakeGameMoveResponse) GetResult() isMakeGameMoveResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *MakeGameMoveResponse) GetMoveResult() *engine.MoveResult {
+	if x != nil {
+		if x, ok := x.Result.(*MakeGameMoveResponse_MoveResult); ok {
+			return x.MoveResult
+		}
+	}
+	return nil
+}
+
+func (x *MakeGameMoveResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*MakeGameMoveResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isMakeGameMoveResponse_Result interface {
+	isMakeGameMoveResponse_Result()
+}
+
+type MakeGameMoveResponse_MoveResult struct {
+	MoveResult *engine.MoveResult `protobuf:"bytes,1,opt,name=move_result,json=moveResult,proto3,oneof"`
+}
+
+type MakeGameMoveResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*MakeGameMoveResponse_MoveResult) isMakeGameMoveResponse_Result() {}
+
+func (*MakeGameMoveResponse_Error) isMakeGameMoveResponse_Result() {}
+
+type GetGameRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGameRequest) Reset() {
+	*x = GetGameRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameRequest) ProtoMessage() {}
+
+func (x *GetGameRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameRequest.ProtoReflect.Descriptor instead.
+func (*GetGameRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetGameRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+// Request for a game in progress as spectators see it
+type SpectateRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpectateRequest) Reset() {
+	*x = SpectateRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpectateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpectateRequest) ProtoMessage() {}
+
+func (x *SpectateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpectateRequest.ProtoReflect.Descriptor instead.
+func (*SpectateRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *SpectateRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type GetGameResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GetGameResponse_Game
+	//	*GetGameResponse_Error
+	Result        isGetGameResponse_Result `protobuf_oneof:"result"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetGameResponse) Reset() {
+	*x = GetGameResponse{}
+	mi := &file_proto_games_games_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameResponse) ProtoMessage() {}
+
+func (x *GetGameResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameResponse.ProtoReflect.Descriptor instead.
+func (*GetGameResponse) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetGameResponse) GetResult() isGetGameResponse_Result {
+	if x != nil {
+		return x.Result
+	}
+	return nil
+}
+
+func (x *GetGameResponse) GetGame() *Game {
+	if x != nil {
+		if x, ok := x.Result.(*GetGameResponse_Game); ok {
+			return x.Game
+		}
+	}
+	return nil
+}
+
+func (x *GetGameResponse) GetError() *Error {
+	if x != nil {
+		if x, ok := x.Result.(*GetGameResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isGetGameResponse_Result interface {
+	isGetGameResponse_Result()
+}
+
+type GetGameResponse_Game struct {
+	Game *Game `protobuf:"bytes,1,opt,name=game,proto3,oneof"`
+}
+
+type GetGameResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*GetGameResponse_Game) isGetGameResponse_Result() {}
+
+func (*GetGameResponse_Error) isGetGameResponse_Result() {}
+
+type ListGamesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Status        GameStatus             `protobuf:"varint,2,opt,name=status,proto3,enum=proto.games.GameStatus" json:"status,omitempty"` // GAME_STATUS_UNSPECIFIED lists games of any status, finished games come from the archive
+	PageSize      int32                  `protobuf:"varint,3,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	PageToken     string                 `protobuf:"bytes,4,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGamesRequest) Reset() {
+	*x = ListGamesRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGamesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGamesRequest) ProtoMessage() {}
+
+func (x *ListGamesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGamesRequest.ProtoReflect.Descriptor instead.
+func (*ListGamesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListGamesRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *ListGamesRequest) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *ListGamesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListGamesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+type ListGamesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Games         []*Game                `protobuf:"bytes,1,rep,name=games,proto3" json:"games,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListGamesResponse) Reset() {
+	*x = ListGamesResponse{}
+	mi := &file_proto_games_games_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListGamesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListGamesResponse) ProtoMessage() {}
+
+func (x *ListGamesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListGamesResponse.ProtoReflect.Descriptor instead.
+func (*ListGamesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListGamesResponse) GetGames() []*Game {
+	if x != nil {
+		return x.Games
+	}
+	return nil
+}
+
+func (x *ListGamesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type GetReplayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetReplayRequest) Reset() {
+	*x = GetReplayRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetReplayRequest) ProtoMessage() {}
+
+func (x *GetReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetReplayRequest.ProtoReflect.Descriptor instead.
+func (*GetReplayRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetReplayRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+type ReplayPosition struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MoveNumber    int32                  `protobuf:"varint,1,opt,name=move_number,json=moveNumber,proto3" json:"move_number,omitempty"` // 0 for the initial position
+	Move          *GameMove              `protobuf:"bytes,2,opt,name=move,proto3" json:"move,omitempty"`                                // Move leading to this position, unset for the initial position
+	State         *engine.GameState      `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayPosition) Reset() {
+	*x = ReplayPosition{}
+	mi := &file_proto_games_games_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayPosition) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayPosition) ProtoMessage() {}
+
+func (x *ReplayPosition) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayPosition.ProtoReflect.Descriptor instead.
+func (*ReplayPosition) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ReplayPosition) GetMoveNumber() int32 {
+	if x != nil {
+		return x.MoveNumber
+	}
+	return 0
+}
+
+func (x *ReplayPosition) GetMove() *GameMove {
+	if x != nil {
+		return x.Move
+	}
+	return nil
+}
+
+func (x *ReplayPosition) GetState() *engine.GameState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+type Replay struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Player1Id     string                 `protobuf:"bytes,2,opt,name=player1_id,json=player1Id,proto3" json:"player1_id,omitempty"`
+	Player2Id     string                 `protobuf:"bytes,3,opt,name=player2_id,json=player2Id,proto3" json:"player2_id,omitempty"`
+	Positions     []*ReplayPosition      `protobuf:"bytes,4,rep,name=positions,proto3" json:"positions,omitempty"`
+	Status        GameStatus             `protobuf:"varint,5,opt,name=status,proto3,enum=proto.games.GameStatus" json:"status,omitempty"`
+	Winner        engine.Winner          `protobuf:"varint,6,opt,name=winner,proto3,enum=proto.engine.Winner" json:"winner,omitempty"`
+	WinnerId      string                 `protobuf:"bytes,7,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	EndReason     GameEndReason          `protobuf:"varint,8,opt,name=end_reason,json=endReason,proto3,enum=proto.games.GameEndReason" json:"end_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Replay) Reset() {
+	*x = Replay{}
+	mi := &file_proto_games_games_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Replay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Replay) ProtoMessage() {}
+
+func (x *Replay) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Replay.ProtoReflect.Descriptor instead.
+func (*Replay) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *Replay) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *Replay) GetPlayer1Id() string {
+	if x != nil {
+		return x.Player1Id
+	}
+	return ""
+}
+
+func (x *Replay) GetPlayer2Id() string {
+	if x != nil {
+		return x.Player2Id
+	}
+	return ""
+}
+
+func (x *Replay) GetPositions() []*ReplayPosition {
+	if x != nil {
+		return x.Positions
+	}
+	return nil
+}
+
+func (x *Replay) GetStatus() GameStatus {
+	if x != nil {
+		return x.Status
+	}
+	return GameStatus_GAME_STATUS_UNSPECIFIED
+}
+
+func (x *Replay) GetWinner() engine.Winner {
+	if x != nil {
+		return x.Winner
+	}
+	return engine.Winner(0)
+}
+
+func (x *Replay) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+func (x *Replay) GetEndReason() GameEndReason {
+	if x != nil {
+		return x.EndReason
+	}
+	return GameEndReason_GAME_END_REASON_UNSPECIFIED
+}
+
+type GetReplayResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Result:
+	//
+	//	*GetReplayResponse_Replay
+	//	*GetReplayResponse_Error
+	Result        isGetReplayResponse_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Game) Reset() {
-	*x = Game{}
-	mi := &file_proto_games_games_proto_msgTypes[0]
+func (x *GetReplayResponse) Reset() {
+	*x = GetReplayResponse{}
+	mi := &file_proto_games_games_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Game) String() string {
+func (x *GetReplayResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Game) ProtoMessage() {}
+func (*GetReplayResponse) ProtoMessage() {}
 
-func (x *Game) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_games_games_proto_msgTypes[0]
+func (x *GetReplayResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -57,62 +1376,127 @@ func (x *Game) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Game.ProtoReflect.Descriptor instead.
-func (*Game) Descriptor() ([]byte, []int) {
-	return file_proto_games_games_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use GetReplayResponse.ProtoReflect.Descriptor instead.
+func (*GetReplayResponse) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Game) GetId() string {
+func (x *GetReplayResponse) GetResult() isGetReplayResponse_Result {
 	if x != nil {
-		return x.Id
+		return x.Result
 	}
-	return ""
+	return nil
 }
 
-func (x *Game) GetState() *engine.GameState {
+func (x *GetReplayResponse) GetReplay() *Replay {
 	if x != nil {
-		return x.State
+		if x, ok := x.Result.(*GetReplayResponse_Replay); ok {
+			return x.Replay
+		}
 	}
 	return nil
 }
 
-func (x *Game) GetPlayer1Id() string {
+func (x *GetReplayResponse) GetError() *Error {
 	if x != nil {
-		return x.Player1Id
+		if x, ok := x.Result.(*GetReplayResponse_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+type isGetReplayResponse_Result interface {
+	isGetReplayResponse_Result()
+}
+
+type GetReplayResponse_Replay struct {
+	Replay *Replay `protobuf:"bytes,1,opt,name=replay,proto3,oneof"`
+}
+
+type GetReplayResponse_Error struct {
+	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
+}
+
+func (*GetReplayResponse_Replay) isGetReplayResponse_Result() {}
+
+func (*GetReplayResponse_Error) isGetReplayResponse_Result() {}
+
+type ResignRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResignRequest) Reset() {
+	*x = ResignRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResignRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResignRequest) ProtoMessage() {}
+
+func (x *ResignRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResignRequest.ProtoReflect.Descriptor instead.
+func (*ResignRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ResignRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
 	}
 	return ""
 }
 
-func (x *Game) GetPlayer2Id() string {
+func (x *ResignRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Player2Id
+		return x.PlayerId
 	}
 	return ""
 }
 
-type CreateGameRequest struct {
+type OfferDrawRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Player1Id     string                 `protobuf:"bytes,1,opt,name=player1_id,json=player1Id,proto3" json:"player1_id,omitempty"`
-	Player2Id     string                 `protobuf:"bytes,2,opt,name=player2_id,json=player2Id,proto3" json:"player2_id,omitempty"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateGameRequest) Reset() {
-	*x = CreateGameRequest{}
-	mi := &file_proto_games_games_proto_msgTypes[1]
+func (x *OfferDrawRequest) Reset() {
+	*x = OfferDrawRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGameRequest) String() string {
+func (x *OfferDrawRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGameRequest) ProtoMessage() {}
+func (*OfferDrawRequest) ProtoMessage() {}
 
-func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_games_games_proto_msgTypes[1]
+func (x *OfferDrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,47 +1507,49 @@ func (x *CreateGameRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGameRequest.ProtoReflect.Descriptor instead.
-func (*CreateGameRequest) Descriptor() ([]byte, []int) {
-	return file_proto_games_games_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use OfferDrawRequest.ProtoReflect.Descriptor instead.
+func (*OfferDrawRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{18}
 }
 
-func (x *CreateGameRequest) GetPlayer1Id() string {
+func (x *OfferDrawRequest) GetGameId() string {
 	if x != nil {
-		return x.Player1Id
+		return x.GameId
 	}
 	return ""
 }
 
-func (x *CreateGameRequest) GetPlayer2Id() string {
+func (x *OfferDrawRequest) GetPlayerId() string {
 	if x != nil {
-		return x.Player2Id
+		return x.PlayerId
 	}
 	return ""
 }
 
-type CreateGameResponse struct {
+type RespondDrawRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Game          *Game                  `protobuf:"bytes,1,opt,name=game,proto3" json:"game,omitempty"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateGameResponse) Reset() {
-	*x = CreateGameResponse{}
-	mi := &file_proto_games_games_proto_msgTypes[2]
+func (x *RespondDrawRequest) Reset() {
+	*x = RespondDrawRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateGameResponse) String() string {
+func (x *RespondDrawRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateGameResponse) ProtoMessage() {}
+func (*RespondDrawRequest) ProtoMessage() {}
 
-func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_games_games_proto_msgTypes[2]
+func (x *RespondDrawRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -174,42 +1560,55 @@ func (x *CreateGameResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateGameResponse.ProtoReflect.Descriptor instead.
-func (*CreateGameResponse) Descriptor() ([]byte, []int) {
-	return file_proto_games_games_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RespondDrawRequest.ProtoReflect.Descriptor instead.
+func (*RespondDrawRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{19}
 }
 
-func (x *CreateGameResponse) GetGame() *Game {
+func (x *RespondDrawRequest) GetGameId() string {
 	if x != nil {
-		return x.Game
+		return x.GameId
 	}
-	return nil
+	return ""
 }
 
-type MakeGameMoveRequest struct {
+func (x *RespondDrawRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *RespondDrawRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type AbortRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
-	PitIndex      uint32                 `protobuf:"varint,3,opt,name=pit_index,json=pitIndex,proto3" json:"pit_index,omitempty"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MakeGameMoveRequest) Reset() {
-	*x = MakeGameMoveRequest{}
-	mi := &file_proto_games_games_proto_msgTypes[3]
+func (x *AbortRequest) Reset() {
+	*x = AbortRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MakeGameMoveRequest) String() string {
+func (x *AbortRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MakeGameMoveRequest) ProtoMessage() {}
+func (*AbortRequest) ProtoMessage() {}
 
-func (x *MakeGameMoveRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_games_games_proto_msgTypes[3]
+func (x *AbortRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -220,58 +1619,113 @@ func (x *MakeGameMoveRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MakeGameMoveRequest.ProtoReflect.Descriptor instead.
-func (*MakeGameMoveRequest) Descriptor() ([]byte, []int) {
-	return file_proto_games_games_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use AbortRequest.ProtoReflect.Descriptor instead.
+func (*AbortRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *MakeGameMoveRequest) GetPlayerId() string {
+func (x *AbortRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *AbortRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *MakeGameMoveRequest) GetGameId() string {
+// ForceEndRequest ends a game in progress on a moderator's behalf
+type ForceEndRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	WinnerId      string                 `protobuf:"bytes,2,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"` // Optional, the game ends without a result when empty
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceEndRequest) Reset() {
+	*x = ForceEndRequest{}
+	mi := &file_proto_games_games_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceEndRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceEndRequest) ProtoMessage() {}
+
+func (x *ForceEndRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceEndRequest.ProtoReflect.Descriptor instead.
+func (*ForceEndRequest) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ForceEndRequest) GetGameId() string {
 	if x != nil {
 		return x.GameId
 	}
 	return ""
 }
 
-func (x *MakeGameMoveRequest) GetPitIndex() uint32 {
+func (x *ForceEndRequest) GetWinnerId() string {
 	if x != nil {
-		return x.PitIndex
+		return x.WinnerId
 	}
-	return 0
+	return ""
 }
 
-type MakeGameMoveResponse struct {
+func (x *ForceEndRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// GameActionResponse returns the game after a resign, draw, abort or force-end action
+type GameActionResponse struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Types that are valid to be assigned to Result:
 	//
-	//	*MakeGameMoveResponse_MoveResult
-	//	*MakeGameMoveResponse_Error
-	Result        isMakeGameMoveResponse_Result `protobuf_oneof:"result"`
+	//	*GameActionResponse_Game
+	//	*GameActionResponse_Error
+	Result        isGameActionResponse_Result `protobuf_oneof:"result"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MakeGameMoveResponse) Reset() {
-	*x = MakeGameMoveResponse{}
-	mi := &file_proto_games_games_proto_msgTypes[4]
+func (x *GameActionResponse) Reset() {
+	*x = GameActionResponse{}
+	mi := &file_proto_games_games_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MakeGameMoveResponse) String() string {
+func (x *GameActionResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MakeGameMoveResponse) ProtoMessage() {}
+func (*GameActionResponse) ProtoMessage() {}
 
-func (x *MakeGameMoveResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_games_games_proto_msgTypes[4]
+func (x *GameActionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_games_games_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -282,62 +1736,63 @@ func (x *MakeGameMoveResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MakeGameMoveResponse.ProtoReflect.Descriptor instead.
-func (*MakeGameMoveResponse) Descriptor() ([]byte, []int) {
-	return file_proto_games_games_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use GameActionResponse.ProtoReflect.Descriptor instead.
+func (*GameActionResponse) Descriptor() ([]byte, []int) {
+	return file_proto_games_games_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *MakeGameMoveResponse) GetResult() isMakeGameMoveResponse_Result {
+func (x *GameActionResponse) GetResult() isGameActionResponse_Result {
 	if x != nil {
 		return x.Result
 	}
 	return nil
 }
 
-func (x *MakeGameMoveResponse) GetMoveResult() *engine.MoveResult {
+func (x *GameActionResponse) GetGame() *Game {
 	if x != nil {
-		if x, ok := x.Result.(*MakeGameMoveResponse_MoveResult); ok {
-			return x.MoveResult
+		if x, ok := x.Result.(*GameActionResponse_Game); ok {
+			return x.Game
 		}
 	}
 	return nil
 }
 
-func (x *MakeGameMoveResponse) GetError() *Error {
+func (x *GameActionResponse) GetError() *Error {
 	if x != nil {
-		if x, ok := x.Result.(*MakeGameMoveResponse_Error); ok {
+		if x, ok := x.Result.(*GameActionResponse_Error); ok {
 			return x.Error
 		}
 	}
 	return nil
 }
 
-type isMakeGameMoveResponse_Result interface {
-	isMakeGameMoveResponse_Result()
+type isGameActionResponse_Result interface {
+	isGameActionResponse_Result()
 }
 
-type MakeGameMoveResponse_MoveResult struct {
-	MoveResult *engine.MoveResult `protobuf:"bytes,1,opt,name=move_result,json=moveResult,proto3,oneof"`
+type GameActionResponse_Game struct {
+	Game *Game `protobuf:"bytes,1,opt,name=game,proto3,oneof"`
 }
 
-type MakeGameMoveResponse_Error struct {
+type GameActionResponse_Error struct {
 	Error *Error `protobuf:"bytes,2,opt,name=error,proto3,oneof"`
 }
 
-func (*MakeGameMoveResponse_MoveResult) isMakeGameMoveResponse_Result() {}
+func (*GameActionResponse_Game) isGameActionResponse_Result() {}
 
-func (*MakeGameMoveResponse_Error) isMakeGameMoveResponse_Result() {}
+func (*GameActionResponse_Error) isGameActionResponse_Result() {}
 
 type Error struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       string                 `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	Code          ErrorCode              `protobuf:"varint,2,opt,name=code,proto3,enum=proto.games.ErrorCode" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Error) Reset() {
 	*x = Error{}
-	mi := &file_proto_games_games_proto_msgTypes[5]
+	mi := &file_proto_games_games_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -349,7 +1804,7 @@ func (x *Error) String() string {
 func (*Error) ProtoMessage() {}
 
 func (x *Error) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_games_games_proto_msgTypes[5]
+	mi := &file_proto_games_games_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -362,7 +1817,7 @@ func (x *Error) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Error.ProtoReflect.Descriptor instead.
 func (*Error) Descriptor() ([]byte, []int) {
-	return file_proto_games_games_proto_rawDescGZIP(), []int{5}
+	return file_proto_games_games_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Error) GetMessage() string {
@@ -372,23 +1827,69 @@ func (x *Error) GetMessage() string {
 	return ""
 }
 
+func (x *Error) GetCode() ErrorCode {
+	if x != nil {
+		return x.Code
+	}
+	return ErrorCode_ERROR_CODE_UNSPECIFIED
+}
+
 var File_proto_games_games_proto protoreflect.FileDescriptor
 
 const file_proto_games_games_proto_rawDesc = "" +
 	"\n" +
-	"\x17proto/games/games.proto\x12\vproto.games\x1a\x19proto/engine/engine.proto\"\x83\x01\n" +
+	"\x17proto/games/games.proto\x12\vproto.games\x1a\x19proto/engine/engine.proto\"i\n" +
+	"\vTimeControl\x12\x17\n" +
+	"\abase_ms\x18\x01 \x01(\x03R\x06baseMs\x12!\n" +
+	"\fincrement_ms\x18\x02 \x01(\x03R\vincrementMs\x12\x1e\n" +
+	"\vper_move_ms\x18\x03 \x01(\x03R\tperMoveMs\"\x98\x01\n" +
+	"\x05Clock\x120\n" +
+	"\x14player1_remaining_ms\x18\x01 \x01(\x03R\x12player1RemainingMs\x120\n" +
+	"\x14player2_remaining_ms\x18\x02 \x01(\x03R\x12player2RemainingMs\x12+\n" +
+	"\x12turn_started_at_ms\x18\x03 \x01(\x03R\x0fturnStartedAtMs\"\xff\x01\n" +
+	"\bGameMove\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
+	"\tpit_index\x18\x02 \x01(\rR\bpitIndex\x12\x1c\n" +
+	"\ttimestamp\x18\x03 \x01(\x03R\ttimestamp\x12)\n" +
+	"\x05board\x18\x04 \x01(\v2\x13.proto.engine.BoardR\x05board\x12\x1d\n" +
+	"\n" +
+	"extra_turn\x18\x05 \x01(\bR\textraTurn\x12\x1a\n" +
+	"\bcaptured\x18\x06 \x01(\bR\bcaptured\x125\n" +
+	"\vnext_player\x18\a \x01(\x0e2\x14.proto.engine.PlayerR\n" +
+	"nextPlayer\"\x8a\x05\n" +
 	"\x04Game\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12-\n" +
 	"\x05state\x18\x02 \x01(\v2\x17.proto.engine.GameStateR\x05state\x12\x1d\n" +
 	"\n" +
 	"player1_id\x18\x03 \x01(\tR\tplayer1Id\x12\x1d\n" +
 	"\n" +
-	"player2_id\x18\x04 \x01(\tR\tplayer2Id\"Q\n" +
+	"player2_id\x18\x04 \x01(\tR\tplayer2Id\x12/\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x17.proto.games.GameStatusR\x06status\x12+\n" +
+	"\x05moves\x18\x06 \x03(\v2\x15.proto.games.GameMoveR\x05moves\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\a \x01(\x03R\tcreatedAt\x12\x1f\n" +
+	"\vfinished_at\x18\b \x01(\x03R\n" +
+	"finishedAt\x12,\n" +
+	"\x06winner\x18\t \x01(\x0e2\x14.proto.engine.WinnerR\x06winner\x12\x1b\n" +
+	"\twinner_id\x18\n" +
+	" \x01(\tR\bwinnerId\x128\n" +
+	"\rinitial_board\x18\v \x01(\v2\x13.proto.engine.BoardR\finitialBoard\x129\n" +
+	"\n" +
+	"end_reason\x18\f \x01(\x0e2\x1a.proto.games.GameEndReasonR\tendReason\x12&\n" +
+	"\x0fdraw_offered_by\x18\r \x01(\tR\rdrawOfferedBy\x12;\n" +
+	"\ftime_control\x18\x0e \x01(\v2\x18.proto.games.TimeControlR\vtimeControl\x12(\n" +
+	"\x05clock\x18\x0f \x01(\v2\x12.proto.games.ClockR\x05clock\x12\x18\n" +
+	"\aversion\x18\x10 \x01(\x04R\aversion\"\x8f\x02\n" +
 	"\x11CreateGameRequest\x12\x1d\n" +
 	"\n" +
 	"player1_id\x18\x01 \x01(\tR\tplayer1Id\x12\x1d\n" +
 	"\n" +
-	"player2_id\x18\x02 \x01(\tR\tplayer2Id\";\n" +
+	"player2_id\x18\x02 \x01(\tR\tplayer2Id\x12+\n" +
+	"\x05rules\x18\x03 \x01(\v2\x15.proto.engine.RuleSetR\x05rules\x123\n" +
+	"\tgame_type\x18\x04 \x01(\x0e2\x16.proto.engine.GameTypeR\bgameType\x12;\n" +
+	"\ftime_control\x18\x05 \x01(\v2\x18.proto.games.TimeControlR\vtimeControl\x12\x1d\n" +
+	"\n" +
+	"request_id\x18\x06 \x01(\tR\trequestId\";\n" +
 	"\x12CreateGameResponse\x12%\n" +
 	"\x04game\x18\x01 \x01(\v2\x11.proto.games.GameR\x04game\"h\n" +
 	"\x13MakeGameMoveRequest\x12\x1b\n" +
@@ -399,12 +1900,104 @@ const file_proto_games_games_proto_rawDesc = "" +
 	"\vmove_result\x18\x01 \x01(\v2\x18.proto.engine.MoveResultH\x00R\n" +
 	"moveResult\x12*\n" +
 	"\x05error\x18\x02 \x01(\v2\x12.proto.games.ErrorH\x00R\x05errorB\b\n" +
-	"\x06result\"!\n" +
+	"\x06result\")\n" +
+	"\x0eGetGameRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"*\n" +
+	"\x0fSpectateRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"p\n" +
+	"\x0fGetGameResponse\x12'\n" +
+	"\x04game\x18\x01 \x01(\v2\x11.proto.games.GameH\x00R\x04game\x12*\n" +
+	"\x05error\x18\x02 \x01(\v2\x12.proto.games.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"\x9c\x01\n" +
+	"\x10ListGamesRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12/\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x17.proto.games.GameStatusR\x06status\x12\x1b\n" +
+	"\tpage_size\x18\x03 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x04 \x01(\tR\tpageToken\"d\n" +
+	"\x11ListGamesResponse\x12'\n" +
+	"\x05games\x18\x01 \x03(\v2\x11.proto.games.GameR\x05games\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"+\n" +
+	"\x10GetReplayRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"\x8b\x01\n" +
+	"\x0eReplayPosition\x12\x1f\n" +
+	"\vmove_number\x18\x01 \x01(\x05R\n" +
+	"moveNumber\x12)\n" +
+	"\x04move\x18\x02 \x01(\v2\x15.proto.games.GameMoveR\x04move\x12-\n" +
+	"\x05state\x18\x03 \x01(\v2\x17.proto.engine.GameStateR\x05state\"\xd1\x02\n" +
+	"\x06Replay\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1d\n" +
+	"\n" +
+	"player1_id\x18\x02 \x01(\tR\tplayer1Id\x12\x1d\n" +
+	"\n" +
+	"player2_id\x18\x03 \x01(\tR\tplayer2Id\x129\n" +
+	"\tpositions\x18\x04 \x03(\v2\x1b.proto.games.ReplayPositionR\tpositions\x12/\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x17.proto.games.GameStatusR\x06status\x12,\n" +
+	"\x06winner\x18\x06 \x01(\x0e2\x14.proto.engine.WinnerR\x06winner\x12\x1b\n" +
+	"\twinner_id\x18\a \x01(\tR\bwinnerId\x129\n" +
+	"\n" +
+	"end_reason\x18\b \x01(\x0e2\x1a.proto.games.GameEndReasonR\tendReason\"x\n" +
+	"\x11GetReplayResponse\x12-\n" +
+	"\x06replay\x18\x01 \x01(\v2\x13.proto.games.ReplayH\x00R\x06replay\x12*\n" +
+	"\x05error\x18\x02 \x01(\v2\x12.proto.games.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"E\n" +
+	"\rResignRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"H\n" +
+	"\x10OfferDrawRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"b\n" +
+	"\x12RespondDrawRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\"D\n" +
+	"\fAbortRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"_\n" +
+	"\x0fForceEndRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12\x1b\n" +
+	"\twinner_id\x18\x02 \x01(\tR\bwinnerId\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"s\n" +
+	"\x12GameActionResponse\x12'\n" +
+	"\x04game\x18\x01 \x01(\v2\x11.proto.games.GameH\x00R\x04game\x12*\n" +
+	"\x05error\x18\x02 \x01(\v2\x12.proto.games.ErrorH\x00R\x05errorB\b\n" +
+	"\x06result\"M\n" +
 	"\x05Error\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage2\x9f\x01\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\x12*\n" +
+	"\x04code\x18\x02 \x01(\x0e2\x16.proto.games.ErrorCodeR\x04code*`\n" +
+	"\n" +
+	"GameStatus\x12\x1b\n" +
+	"\x17GAME_STATUS_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17GAME_STATUS_IN_PROGRESS\x10\x01\x12\x18\n" +
+	"\x14GAME_STATUS_FINISHED\x10\x02*\xf3\x01\n" +
+	"\rGameEndReason\x12\x1f\n" +
+	"\x1bGAME_END_REASON_UNSPECIFIED\x10\x00\x12\x1d\n" +
+	"\x19GAME_END_REASON_COMPLETED\x10\x01\x12\x1f\n" +
+	"\x1bGAME_END_REASON_RESIGNATION\x10\x02\x12\x1f\n" +
+	"\x1bGAME_END_REASON_DRAW_AGREED\x10\x03\x12\x1b\n" +
+	"\x17GAME_END_REASON_ABORTED\x10\x04\x12\x1b\n" +
+	"\x17GAME_END_REASON_TIMEOUT\x10\x05\x12&\n" +
+	"\"GAME_END_REASON_ENDED_BY_MODERATOR\x10\x06*\xd9\x01\n" +
+	"\tErrorCode\x12\x1a\n" +
+	"\x16ERROR_CODE_UNSPECIFIED\x10\x00\x12\x18\n" +
+	"\x14ERROR_CODE_NOT_FOUND\x10\x01\x12 \n" +
+	"\x1cERROR_CODE_PERMISSION_DENIED\x10\x02\x12\x1c\n" +
+	"\x18ERROR_CODE_GAME_FINISHED\x10\x03\x12\x1f\n" +
+	"\x1bERROR_CODE_VERSION_CONFLICT\x10\x04\x12\x1c\n" +
+	"\x18ERROR_CODE_NOT_YOUR_TURN\x10\x05\x12\x17\n" +
+	"\x13ERROR_CODE_INTERNAL\x10\x062\xb6\x06\n" +
 	"\x05Games\x12I\n" +
 	"\x06Create\x12\x1e.proto.games.CreateGameRequest\x1a\x1f.proto.games.CreateGameResponse\x12K\n" +
-	"\x04Move\x12 .proto.games.MakeGameMoveRequest\x1a!.proto.games.MakeGameMoveResponseB0Z.github.com/laerson/mancala/proto/games;gamespbb\x06proto3"
+	"\x04Move\x12 .proto.games.MakeGameMoveRequest\x1a!.proto.games.MakeGameMoveResponse\x12@\n" +
+	"\x03Get\x12\x1b.proto.games.GetGameRequest\x1a\x1c.proto.games.GetGameResponse\x12F\n" +
+	"\bSpectate\x12\x1c.proto.games.SpectateRequest\x1a\x1c.proto.games.GetGameResponse\x12J\n" +
+	"\tListGames\x12\x1d.proto.games.ListGamesRequest\x1a\x1e.proto.games.ListGamesResponse\x12J\n" +
+	"\tGetReplay\x12\x1d.proto.games.GetReplayRequest\x1a\x1e.proto.games.GetReplayResponse\x12E\n" +
+	"\x06Resign\x12\x1a.proto.games.ResignRequest\x1a\x1f.proto.games.GameActionResponse\x12K\n" +
+	"\tOfferDraw\x12\x1d.proto.games.OfferDrawRequest\x1a\x1f.proto.games.GameActionResponse\x12O\n" +
+	"\vRespondDraw\x12\x1f.proto.games.RespondDrawRequest\x1a\x1f.proto.games.GameActionResponse\x12C\n" +
+	"\x05Abort\x12\x19.proto.games.AbortRequest\x1a\x1f.proto.games.GameActionResponse\x12I\n" +
+	"\bForceEnd\x12\x1c.proto.games.ForceEndRequest\x1a\x1f.proto.games.GameActionResponseB0Z.github.com/laerson/mancala/proto/games;gamespbb\x06proto3"
 
 var (
 	file_proto_games_games_proto_rawDescOnce sync.Once
@@ -418,31 +2011,103 @@ func file_proto_games_games_proto_rawDescGZIP() []byte {
 	return file_proto_games_games_proto_rawDescData
 }
 
-var file_proto_games_games_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_games_games_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_games_games_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
 var file_proto_games_games_proto_goTypes = []any{
-	(*Game)(nil),                 // 0: proto.games.Game
-	(*CreateGameRequest)(nil),    // 1: proto.games.CreateGameRequest
-	(*CreateGameResponse)(nil),   // 2: proto.games.CreateGameResponse
-	(*MakeGameMoveRequest)(nil),  // 3: proto.games.MakeGameMoveRequest
-	(*MakeGameMoveResponse)(nil), // 4: proto.games.MakeGameMoveResponse
-	(*Error)(nil),                // 5: proto.games.Error
-	(*engine.GameState)(nil),     // 6: proto.engine.GameState
-	(*engine.MoveResult)(nil),    // 7: proto.engine.MoveResult
+	(GameStatus)(0),              // 0: proto.games.GameStatus
+	(GameEndReason)(0),           // 1: proto.games.GameEndReason
+	(ErrorCode)(0),               // 2: proto.games.ErrorCode
+	(*TimeControl)(nil),          // 3: proto.games.TimeControl
+	(*Clock)(nil),                // 4: proto.games.Clock
+	(*GameMove)(nil),             // 5: proto.games.GameMove
+	(*Game)(nil),                 // 6: proto.games.Game
+	(*CreateGameRequest)(nil),    // 7: proto.games.CreateGameRequest
+	(*CreateGameResponse)(nil),   // 8: proto.games.CreateGameResponse
+	(*MakeGameMoveRequest)(nil),  // 9: proto.games.MakeGameMoveRequest
+	(*MakeGameMoveResponse)(nil), // 10: proto.games.MakeGameMoveResponse
+	(*GetGameRequest)(nil),       // 11: proto.games.GetGameRequest
+	(*SpectateRequest)(nil),      // 12: proto.games.SpectateRequest
+	(*GetGameResponse)(nil),      // 13: proto.games.GetGameResponse
+	(*ListGamesRequest)(nil),     // 14: proto.games.ListGamesRequest
+	(*ListGamesResponse)(nil),    // 15: proto.games.ListGamesResponse
+	(*GetReplayRequest)(nil),     // 16: proto.games.GetReplayRequest
+	(*ReplayPosition)(nil),       // 17: proto.games.ReplayPosition
+	(*Replay)(nil),               // 18: proto.games.Replay
+	(*GetReplayResponse)(nil),    // 19: proto.games.GetReplayResponse
+	(*ResignRequest)(nil),        // 20: proto.games.ResignRequest
+	(*OfferDrawRequest)(nil),     // 21: proto.games.OfferDrawRequest
+	(*RespondDrawRequest)(nil),   // 22: proto.games.RespondDrawRequest
+	(*AbortRequest)(nil),         // 23: proto.games.AbortRequest
+	(*ForceEndRequest)(nil),      // 24: proto.games.ForceEndRequest
+	(*GameActionResponse)(nil),   // 25: proto.games.GameActionResponse
+	(*Error)(nil),                // 26: proto.games.Error
+	(*engine.Board)(nil),         // 27: proto.engine.Board
+	(engine.Player)(0),           // 28: proto.engine.Player
+	(*engine.GameState)(nil),     // 29: proto.engine.GameState
+	(engine.Winner)(0),           // 30: proto.engine.Winner
+	(*engine.RuleSet)(nil),       // 31: proto.engine.RuleSet
+	(engine.GameType)(0),         // 32: proto.engine.GameType
+	(*engine.MoveResult)(nil),    // 33: proto.engine.MoveResult
 }
 var file_proto_games_games_proto_depIdxs = []int32{
-	6, // 0: proto.games.Game.state:type_name -> proto.engine.GameState
-	0, // 1: proto.games.CreateGameResponse.game:type_name -> proto.games.Game
-	7, // 2: proto.games.MakeGameMoveResponse.move_result:type_name -> proto.engine.MoveResult
-	5, // 3: proto.games.MakeGameMoveResponse.error:type_name -> proto.games.Error
-	1, // 4: proto.games.Games.Create:input_type -> proto.games.CreateGameRequest
-	3, // 5: proto.games.Games.Move:input_type -> proto.games.MakeGameMoveRequest
-	2, // 6: proto.games.Games.Create:output_type -> proto.games.CreateGameResponse
-	4, // 7: proto.games.Games.Move:output_type -> proto.games.MakeGameMoveResponse
-	6, // [6:8] is the sub-list for method output_type
-	4, // [4:6] is the sub-list for method input_type
-	4, // [4:4] is the sub-list for extension type_name
-	4, // [4:4] is the sub-list for extension extendee
-	0, // [0:4] is the sub-list for field type_name
+	27, // 0: proto.games.GameMove.board:type_name -> proto.engine.Board
+	28, // 1: proto.games.GameMove.next_player:type_name -> proto.engine.Player
+	29, // 2: proto.games.Game.state:type_name -> proto.engine.GameState
+	0,  // 3: proto.games.Game.status:type_name -> proto.games.GameStatus
+	5,  // 4: proto.games.Game.moves:type_name -> proto.games.GameMove
+	30, // 5: proto.games.Game.winner:type_name -> proto.engine.Winner
+	27, // 6: proto.games.Game.initial_board:type_name -> proto.engine.Board
+	1,  // 7: proto.games.Game.end_reason:type_name -> proto.games.GameEndReason
+	3,  // 8: proto.games.Game.time_control:type_name -> proto.games.TimeControl
+	4,  // 9: proto.games.Game.clock:type_name -> proto.games.Clock
+	31, // 10: proto.games.CreateGameRequest.rules:type_name -> proto.engine.RuleSet
+	32, // 11: proto.games.CreateGameRequest.game_type:type_name -> proto.engine.GameType
+	3,  // 12: proto.games.CreateGameRequest.time_control:type_name -> proto.games.TimeControl
+	6,  // 13: proto.games.CreateGameResponse.game:type_name -> proto.games.Game
+	33, // 14: proto.games.MakeGameMoveResponse.move_result:type_name -> proto.engine.MoveResult
+	26, // 15: proto.games.MakeGameMoveResponse.error:type_name -> proto.games.Error
+	6,  // 16: proto.games.GetGameResponse.game:type_name -> proto.games.Game
+	26, // 17: proto.games.GetGameResponse.error:type_name -> proto.games.Error
+	0,  // 18: proto.games.ListGamesRequest.status:type_name -> proto.games.GameStatus
+	6,  // 19: proto.games.ListGamesResponse.games:type_name -> proto.games.Game
+	5,  // 20: proto.games.ReplayPosition.move:type_name -> proto.games.GameMove
+	29, // 21: proto.games.ReplayPosition.state:type_name -> proto.engine.GameState
+	17, // 22: proto.games.Replay.positions:type_name -> proto.games.ReplayPosition
+	0,  // 23: proto.games.Replay.status:type_name -> proto.games.GameStatus
+	30, // 24: proto.games.Replay.winner:type_name -> proto.engine.Winner
+	1,  // 25: proto.games.Replay.end_reason:type_name -> proto.games.GameEndReason
+	18, // 26: proto.games.GetReplayResponse.replay:type_name -> proto.games.Replay
+	26, // 27: proto.games.GetReplayResponse.error:type_name -> proto.games.Error
+	6,  // 28: proto.games.GameActionResponse.game:type_name -> proto.games.Game
+	26, // 29: proto.games.GameActionResponse.error:type_name -> proto.games.Error
+	2,  // 30: proto.games.Error.code:type_name -> proto.games.ErrorCode
+	7,  // 31: proto.games.Games.Create:input_type -> proto.games.CreateGameRequest
+	9,  // 32: proto.games.Games.Move:input_type -> proto.games.MakeGameMoveRequest
+	11, // 33: proto.games.Games.Get:input_type -> proto.games.GetGameRequest
+	12, // 34: proto.games.Games.Spectate:input_type -> proto.games.SpectateRequest
+	14, // 35: proto.games.Games.ListGames:input_type -> proto.games.ListGamesRequest
+	16, // 36: proto.games.Games.GetReplay:input_type -> proto.games.GetReplayRequest
+	20, // 37: proto.games.Games.Resign:input_type -> proto.games.ResignRequest
+	21, // 38: proto.games.Games.OfferDraw:input_type -> proto.games.OfferDrawRequest
+	22, // 39: proto.games.Games.RespondDraw:input_type -> proto.games.RespondDrawRequest
+	23, // 40: proto.games.Games.Abort:input_type -> proto.games.AbortRequest
+	24, // 41: proto.games.Games.ForceEnd:input_type -> proto.games.ForceEndRequest
+	8,  // 42: proto.games.Games.Create:output_type -> proto.games.CreateGameResponse
+	10, // 43: proto.games.Games.Move:output_type -> proto.games.MakeGameMoveResponse
+	13, // 44: proto.games.Games.Get:output_type -> proto.games.GetGameResponse
+	13, // 45: proto.games.Games.Spectate:output_type -> proto.games.GetGameResponse
+	15, // 46: proto.games.Games.ListGames:output_type -> proto.games.ListGamesResponse
+	19, // 47: proto.games.Games.GetReplay:output_type -> proto.games.GetReplayResponse
+	25, // 48: proto.games.Games.Resign:output_type -> proto.games.GameActionResponse
+	25, // 49: proto.games.Games.OfferDraw:output_type -> proto.games.GameActionResponse
+	25, // 50: proto.games.Games.RespondDraw:output_type -> proto.games.GameActionResponse
+	25, // 51: proto.games.Games.Abort:output_type -> proto.games.GameActionResponse
+	25, // 52: proto.games.Games.ForceEnd:output_type -> proto.games.GameActionResponse
+	42, // [42:53] is the sub-list for method output_type
+	31, // [31:42] is the sub-list for method input_type
+	31, // [31:31] is the sub-list for extension type_name
+	31, // [31:31] is the sub-list for extension extendee
+	0,  // [0:31] is the sub-list for field type_name
 }
 
 func init() { file_proto_games_games_proto_init() }
@@ -450,22 +2115,35 @@ func file_proto_games_games_proto_init() {
 	if File_proto_games_games_proto != nil {
 		return
 	}
-	file_proto_games_games_proto_msgTypes[4].OneofWrappers = []any{
+	file_proto_games_games_proto_msgTypes[7].OneofWrappers = []any{
 		(*MakeGameMoveResponse_MoveResult)(nil),
 		(*MakeGameMoveResponse_Error)(nil),
 	}
+	file_proto_games_games_proto_msgTypes[10].OneofWrappers = []any{
+		(*GetGameResponse_Game)(nil),
+		(*GetGameResponse_Error)(nil),
+	}
+	file_proto_games_games_proto_msgTypes[16].OneofWrappers = []any{
+		(*GetReplayResponse_Replay)(nil),
+		(*GetReplayResponse_Error)(nil),
+	}
+	file_proto_games_games_proto_msgTypes[22].OneofWrappers = []any{
+		(*GameActionResponse_Game)(nil),
+		(*GameActionResponse_Error)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_games_games_proto_rawDesc), len(file_proto_games_games_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      3,
+			NumMessages:   24,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_games_games_proto_goTypes,
 		DependencyIndexes: file_proto_games_games_proto_depIdxs,
+		EnumInfos:         file_proto_games_games_proto_enumTypes,
 		MessageInfos:      file_proto_games_games_proto_msgTypes,
 	}.Build()
 	File_proto_games_games_proto = out.File
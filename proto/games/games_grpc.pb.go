@@ -19,8 +19,17 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Games_Create_FullMethodName = "/proto.games.Games/Create"
-	Games_Move_FullMethodName   = "/proto.games.Games/Move"
+	Games_Create_FullMethodName      = "/proto.games.Games/Create"
+	Games_Move_FullMethodName        = "/proto.games.Games/Move"
+	Games_Get_FullMethodName         = "/proto.games.Games/Get"
+	Games_Spectate_FullMethodName    = "/proto.games.Games/Spectate"
+	Games_ListGames_FullMethodName   = "/proto.games.Games/ListGames"
+	Games_GetReplay_FullMethodName   = "/proto.games.Games/GetReplay"
+	Games_Resign_FullMethodName      = "/proto.games.Games/Resign"
+	Games_OfferDraw_FullMethodName   = "/proto.games.Games/OfferDraw"
+	Games_RespondDraw_FullMethodName = "/proto.games.Games/RespondDraw"
+	Games_Abort_FullMethodName       = "/proto.games.Games/Abort"
+	Games_ForceEnd_FullMethodName    = "/proto.games.Games/ForceEnd"
 )
 
 // GamesClient is the client API for Games service.
@@ -29,6 +38,15 @@ const (
 type GamesClient interface {
 	Create(ctx context.Context, in *CreateGameRequest, opts ...grpc.CallOption) (*CreateGameResponse, error)
 	Move(ctx context.Context, in *MakeGameMoveRequest, opts ...grpc.CallOption) (*MakeGameMoveResponse, error)
+	Get(ctx context.Context, in *GetGameRequest, opts ...grpc.CallOption) (*GetGameResponse, error)
+	Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (*GetGameResponse, error)
+	ListGames(ctx context.Context, in *ListGamesRequest, opts ...grpc.CallOption) (*ListGamesResponse, error)
+	GetReplay(ctx context.Context, in *GetReplayRequest, opts ...grpc.CallOption) (*GetReplayResponse, error)
+	Resign(ctx context.Context, in *ResignRequest, opts ...grpc.CallOption) (*GameActionResponse, error)
+	OfferDraw(ctx context.Context, in *OfferDrawRequest, opts ...grpc.CallOption) (*GameActionResponse, error)
+	RespondDraw(ctx context.Context, in *RespondDrawRequest, opts ...grpc.CallOption) (*GameActionResponse, error)
+	Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*GameActionResponse, error)
+	ForceEnd(ctx context.Context, in *ForceEndRequest, opts ...grpc.CallOption) (*GameActionResponse, error)
 }
 
 type gamesClient struct {
@@ -59,12 +77,111 @@ func (c *gamesClient) Move(ctx context.Context, in *MakeGameMoveRequest, opts ..
 	return out, nil
 }
 
+func (c *gamesClient) Get(ctx context.Context, in *GetGameRequest, opts ...grpc.CallOption) (*GetGameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGameResponse)
+	err := c.cc.Invoke(ctx, Games_Get_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) Spectate(ctx context.Context, in *SpectateRequest, opts ...grpc.CallOption) (*GetGameResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetGameResponse)
+	err := c.cc.Invoke(ctx, Games_Spectate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) ListGames(ctx context.Context, in *ListGamesRequest, opts ...grpc.CallOption) (*ListGamesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListGamesResponse)
+	err := c.cc.Invoke(ctx, Games_ListGames_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) GetReplay(ctx context.Context, in *GetReplayRequest, opts ...grpc.CallOption) (*GetReplayResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetReplayResponse)
+	err := c.cc.Invoke(ctx, Games_GetReplay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) Resign(ctx context.Context, in *ResignRequest, opts ...grpc.CallOption) (*GameActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameActionResponse)
+	err := c.cc.Invoke(ctx, Games_Resign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) OfferDraw(ctx context.Context, in *OfferDrawRequest, opts ...grpc.CallOption) (*GameActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameActionResponse)
+	err := c.cc.Invoke(ctx, Games_OfferDraw_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) RespondDraw(ctx context.Context, in *RespondDrawRequest, opts ...grpc.CallOption) (*GameActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameActionResponse)
+	err := c.cc.Invoke(ctx, Games_RespondDraw_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) Abort(ctx context.Context, in *AbortRequest, opts ...grpc.CallOption) (*GameActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameActionResponse)
+	err := c.cc.Invoke(ctx, Games_Abort_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *gamesClient) ForceEnd(ctx context.Context, in *ForceEndRequest, opts ...grpc.CallOption) (*GameActionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GameActionResponse)
+	err := c.cc.Invoke(ctx, Games_ForceEnd_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GamesServer is the server API for Games service.
 // All implementations must embed UnimplementedGamesServer
 // for forward compatibility.
 type GamesServer interface {
 	Create(context.Context, *CreateGameRequest) (*CreateGameResponse, error)
 	Move(context.Context, *MakeGameMoveRequest) (*MakeGameMoveResponse, error)
+	Get(context.Context, *GetGameRequest) (*GetGameResponse, error)
+	Spectate(context.Context, *SpectateRequest) (*GetGameResponse, error)
+	ListGames(context.Context, *ListGamesRequest) (*ListGamesResponse, error)
+	GetReplay(context.Context, *GetReplayRequest) (*GetReplayResponse, error)
+	Resign(context.Context, *ResignRequest) (*GameActionResponse, error)
+	OfferDraw(context.Context, *OfferDrawRequest) (*GameActionResponse, error)
+	RespondDraw(context.Context, *RespondDrawRequest) (*GameActionResponse, error)
+	Abort(context.Context, *AbortRequest) (*GameActionResponse, error)
+	ForceEnd(context.Context, *ForceEndRequest) (*GameActionResponse, error)
 	mustEmbedUnimplementedGamesServer()
 }
 
@@ -81,6 +198,33 @@ func (UnimplementedGamesServer) Create(context.Context, *CreateGameRequest) (*Cr
 func (UnimplementedGamesServer) Move(context.Context, *MakeGameMoveRequest) (*MakeGameMoveResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Move not implemented")
 }
+func (UnimplementedGamesServer) Get(context.Context, *GetGameRequest) (*GetGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Get not implemented")
+}
+func (UnimplementedGamesServer) Spectate(context.Context, *SpectateRequest) (*GetGameResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Spectate not implemented")
+}
+func (UnimplementedGamesServer) ListGames(context.Context, *ListGamesRequest) (*ListGamesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListGames not implemented")
+}
+func (UnimplementedGamesServer) GetReplay(context.Context, *GetReplayRequest) (*GetReplayResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetReplay not implemented")
+}
+func (UnimplementedGamesServer) Resign(context.Context, *ResignRequest) (*GameActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resign not implemented")
+}
+func (UnimplementedGamesServer) OfferDraw(context.Context, *OfferDrawRequest) (*GameActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method OfferDraw not implemented")
+}
+func (UnimplementedGamesServer) RespondDraw(context.Context, *RespondDrawRequest) (*GameActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondDraw not implemented")
+}
+func (UnimplementedGamesServer) Abort(context.Context, *AbortRequest) (*GameActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Abort not implemented")
+}
+func (UnimplementedGamesServer) ForceEnd(context.Context, *ForceEndRequest) (*GameActionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceEnd not implemented")
+}
 func (UnimplementedGamesServer) mustEmbedUnimplementedGamesServer() {}
 func (UnimplementedGamesServer) testEmbeddedByValue()               {}
 
@@ -138,6 +282,168 @@ func _Games_Move_Handler(srv interface{}, ctx context.Context, dec func(interfac
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Games_Get_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetGameRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).Get(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_Get_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).Get(ctx, req.(*GetGameRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_Spectate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SpectateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).Spectate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_Spectate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).Spectate(ctx, req.(*SpectateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_ListGames_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListGamesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).ListGames(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_ListGames_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).ListGames(ctx, req.(*ListGamesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_GetReplay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetReplayRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).GetReplay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_GetReplay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).GetReplay(ctx, req.(*GetReplayRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_Resign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResignRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).Resign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_Resign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).Resign(ctx, req.(*ResignRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_OfferDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OfferDrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).OfferDraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_OfferDraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).OfferDraw(ctx, req.(*OfferDrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_RespondDraw_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondDrawRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).RespondDraw(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_RespondDraw_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).RespondDraw(ctx, req.(*RespondDrawRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_Abort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AbortRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).Abort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_Abort_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).Abort(ctx, req.(*AbortRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Games_ForceEnd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceEndRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GamesServer).ForceEnd(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Games_ForceEnd_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GamesServer).ForceEnd(ctx, req.(*ForceEndRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Games_ServiceDesc is the grpc.ServiceDesc for Games service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -153,6 +459,42 @@ var Games_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Move",
 			Handler:    _Games_Move_Handler,
 		},
+		{
+			MethodName: "Get",
+			Handler:    _Games_Get_Handler,
+		},
+		{
+			MethodName: "Spectate",
+			Handler:    _Games_Spectate_Handler,
+		},
+		{
+			MethodName: "ListGames",
+			Handler:    _Games_ListGames_Handler,
+		},
+		{
+			MethodName: "GetReplay",
+			Handler:    _Games_GetReplay_Handler,
+		},
+		{
+			MethodName: "Resign",
+			Handler:    _Games_Resign_Handler,
+		},
+		{
+			MethodName: "OfferDraw",
+			Handler:    _Games_OfferDraw_Handler,
+		},
+		{
+			MethodName: "RespondDraw",
+			Handler:    _Games_RespondDraw_Handler,
+		},
+		{
+			MethodName: "Abort",
+			Handler:    _Games_Abort_Handler,
+		},
+		{
+			MethodName: "ForceEnd",
+			Handler:    _Games_ForceEnd_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/games/games.proto",
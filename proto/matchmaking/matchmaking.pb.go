@@ -173,30 +173,815 @@ func (x *EnqueueRequest) GetPlayer() *Player {
 	return nil
 }
 
+// Bot match request
+type BotMatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	BotDifficulty string                 `protobuf:"bytes,2,opt,name=bot_difficulty,json=botDifficulty,proto3" json:"bot_difficulty,omitempty"` // "easy", "medium", "hard"
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BotMatchRequest) Reset() {
+	*x = BotMatchRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BotMatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotMatchRequest) ProtoMessage() {}
+
+func (x *BotMatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotMatchRequest.ProtoReflect.Descriptor instead.
+func (*BotMatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BotMatchRequest) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *BotMatchRequest) GetBotDifficulty() string {
+	if x != nil {
+		return x.BotDifficulty
+	}
+	return ""
+}
+
+type BotMatchResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	GameId        string                 `protobuf:"bytes,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	BotId         string                 `protobuf:"bytes,4,opt,name=bot_id,json=botId,proto3" json:"bot_id,omitempty"`
+	BotName       string                 `protobuf:"bytes,5,opt,name=bot_name,json=botName,proto3" json:"bot_name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BotMatchResponse) Reset() {
+	*x = BotMatchResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BotMatchResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BotMatchResponse) ProtoMessage() {}
+
+func (x *BotMatchResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BotMatchResponse.ProtoReflect.Descriptor instead.
+func (*BotMatchResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BotMatchResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BotMatchResponse) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *BotMatchResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BotMatchResponse) GetBotId() string {
+	if x != nil {
+		return x.BotId
+	}
+	return ""
+}
+
+func (x *BotMatchResponse) GetBotName() string {
+	if x != nil {
+		return x.BotName
+	}
+	return ""
+}
+
 type EnqueueResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
-	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnqueueResponse) Reset() {
+	*x = EnqueueResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnqueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnqueueResponse) ProtoMessage() {}
+
+func (x *EnqueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnqueueResponse.ProtoReflect.Descriptor instead.
+func (*EnqueueResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *EnqueueResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *EnqueueResponse) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+func (x *EnqueueResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Cancel queue request
+type CancelQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelQueueRequest) Reset() {
+	*x = CancelQueueRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueueRequest) ProtoMessage() {}
+
+func (x *CancelQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueueRequest.ProtoReflect.Descriptor instead.
+func (*CancelQueueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelQueueRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *CancelQueueRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+type CancelQueueResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelQueueResponse) Reset() {
+	*x = CancelQueueResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelQueueResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelQueueResponse) ProtoMessage() {}
+
+func (x *CancelQueueResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelQueueResponse.ProtoReflect.Descriptor instead.
+func (*CancelQueueResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelQueueResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *CancelQueueResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+// Remove a player from the queue on a moderator's behalf
+type RemoveFromQueueRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveFromQueueRequest) Reset() {
+	*x = RemoveFromQueueRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveFromQueueRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveFromQueueRequest) ProtoMessage() {}
+
+func (x *RemoveFromQueueRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveFromQueueRequest.ProtoReflect.Descriptor instead.
+func (*RemoveFromQueueRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RemoveFromQueueRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+// Queue length request
+type GetQueueLengthRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueueLengthRequest) Reset() {
+	*x = GetQueueLengthRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueLengthRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueLengthRequest) ProtoMessage() {}
+
+func (x *GetQueueLengthRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueLengthRequest.ProtoReflect.Descriptor instead.
+func (*GetQueueLengthRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{8}
+}
+
+type GetQueueLengthResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Length        int32                  `protobuf:"varint,1,opt,name=length,proto3" json:"length,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueueLengthResponse) Reset() {
+	*x = GetQueueLengthResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueLengthResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueLengthResponse) ProtoMessage() {}
+
+func (x *GetQueueLengthResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueLengthResponse.ProtoReflect.Descriptor instead.
+func (*GetQueueLengthResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetQueueLengthResponse) GetLength() int32 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
+// Queue status request
+type GetQueueStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueueStatusRequest) Reset() {
+	*x = GetQueueStatusRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueStatusRequest) ProtoMessage() {}
+
+func (x *GetQueueStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetQueueStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetQueueStatusRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GetQueueStatusRequest) GetQueueId() string {
+	if x != nil {
+		return x.QueueId
+	}
+	return ""
+}
+
+type GetQueueStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        QueueStatus            `protobuf:"varint,1,opt,name=status,proto3,enum=proto.matchmaking.QueueStatus" json:"status,omitempty"`
+	QueuePosition int32                  `protobuf:"varint,2,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	GameId        string                 `protobuf:"bytes,4,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"` // Set when status is GAME_CREATED
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQueueStatusResponse) Reset() {
+	*x = GetQueueStatusResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQueueStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQueueStatusResponse) ProtoMessage() {}
+
+func (x *GetQueueStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQueueStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetQueueStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetQueueStatusResponse) GetStatus() QueueStatus {
+	if x != nil {
+		return x.Status
+	}
+	return QueueStatus_QUEUED
+}
+
+func (x *GetQueueStatusResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *GetQueueStatusResponse) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+// A direct challenge to a specific player, or a private invite that any player can
+// redeem with its code. Invites have no target and use their code as ID.
+type Challenge struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Challenger    *Player                `protobuf:"bytes,2,opt,name=challenger,proto3" json:"challenger,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,3,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"` // Empty for invites
+	InviteCode    string                 `protobuf:"bytes,4,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`         // Set for invites
+	ExpiresAt     int64                  `protobuf:"varint,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`           // Unix timestamp
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Challenge) Reset() {
+	*x = Challenge{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Challenge) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Challenge) ProtoMessage() {}
+
+func (x *Challenge) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Challenge.ProtoReflect.Descriptor instead.
+func (*Challenge) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Challenge) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Challenge) GetChallenger() *Player {
+	if x != nil {
+		return x.Challenger
+	}
+	return nil
+}
+
+func (x *Challenge) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+func (x *Challenge) GetInviteCode() string {
+	if x != nil {
+		return x.InviteCode
+	}
+	return ""
+}
+
+func (x *Challenge) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+// Challenge a specific player
+type ChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenger    *Player                `protobuf:"bytes,1,opt,name=challenger,proto3" json:"challenger,omitempty"`
+	TargetUserId  string                 `protobuf:"bytes,2,opt,name=target_user_id,json=targetUserId,proto3" json:"target_user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChallengeRequest) Reset() {
+	*x = ChallengeRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeRequest) ProtoMessage() {}
+
+func (x *ChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeRequest.ProtoReflect.Descriptor instead.
+func (*ChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ChallengeRequest) GetChallenger() *Player {
+	if x != nil {
+		return x.Challenger
+	}
+	return nil
+}
+
+func (x *ChallengeRequest) GetTargetUserId() string {
+	if x != nil {
+		return x.TargetUserId
+	}
+	return ""
+}
+
+type ChallengeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Challenge     *Challenge             `protobuf:"bytes,3,opt,name=challenge,proto3" json:"challenge,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChallengeResponse) Reset() {
+	*x = ChallengeResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChallengeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeResponse) ProtoMessage() {}
+
+func (x *ChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeResponse.ProtoReflect.Descriptor instead.
+func (*ChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ChallengeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ChallengeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ChallengeResponse) GetChallenge() *Challenge {
+	if x != nil {
+		return x.Challenge
+	}
+	return nil
+}
+
+// Accept or decline a challenge. The challenger may decline to withdraw it.
+type RespondChallengeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	ChallengeId   string                 `protobuf:"bytes,2,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	Accept        bool                   `protobuf:"varint,3,opt,name=accept,proto3" json:"accept,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RespondChallengeRequest) Reset() {
+	*x = RespondChallengeRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RespondChallengeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RespondChallengeRequest) ProtoMessage() {}
+
+func (x *RespondChallengeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RespondChallengeRequest.ProtoReflect.Descriptor instead.
+func (*RespondChallengeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *RespondChallengeRequest) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *RespondChallengeRequest) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *RespondChallengeRequest) GetAccept() bool {
+	if x != nil {
+		return x.Accept
+	}
+	return false
+}
+
+type RespondChallengeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GameId        string                 `protobuf:"bytes,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"` // Set when the challenge was accepted
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EnqueueResponse) Reset() {
-	*x = EnqueueResponse{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[2]
+func (x *RespondChallengeResponse) Reset() {
+	*x = RespondChallengeResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EnqueueResponse) String() string {
+func (x *RespondChallengeResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EnqueueResponse) ProtoMessage() {}
+func (*RespondChallengeResponse) ProtoMessage() {}
 
-func (x *EnqueueResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[2]
+func (x *RespondChallengeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -207,56 +992,55 @@ func (x *EnqueueResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EnqueueResponse.ProtoReflect.Descriptor instead.
-func (*EnqueueResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{2}
+// Deprecated: Use RespondChallengeResponse.ProtoReflect.Descriptor instead.
+func (*RespondChallengeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *EnqueueResponse) GetSuccess() bool {
+func (x *RespondChallengeResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *EnqueueResponse) GetQueueId() string {
+func (x *RespondChallengeResponse) GetMessage() string {
 	if x != nil {
-		return x.QueueId
+		return x.Message
 	}
 	return ""
 }
 
-func (x *EnqueueResponse) GetMessage() string {
+func (x *RespondChallengeResponse) GetGameId() string {
 	if x != nil {
-		return x.Message
+		return x.GameId
 	}
 	return ""
 }
 
-// Cancel queue request
-type CancelQueueRequest struct {
+// List the pending challenges sent to a player
+type ListChallengesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelQueueRequest) Reset() {
-	*x = CancelQueueRequest{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[3]
+func (x *ListChallengesRequest) Reset() {
+	*x = ListChallengesRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelQueueRequest) String() string {
+func (x *ListChallengesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelQueueRequest) ProtoMessage() {}
+func (*ListChallengesRequest) ProtoMessage() {}
 
-func (x *CancelQueueRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[3]
+func (x *ListChallengesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -267,48 +1051,131 @@ func (x *CancelQueueRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelQueueRequest.ProtoReflect.Descriptor instead.
-func (*CancelQueueRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{3}
+// Deprecated: Use ListChallengesRequest.ProtoReflect.Descriptor instead.
+func (*ListChallengesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{17}
 }
 
-func (x *CancelQueueRequest) GetPlayerId() string {
+func (x *ListChallengesRequest) GetPlayerId() string {
 	if x != nil {
 		return x.PlayerId
 	}
 	return ""
 }
 
-func (x *CancelQueueRequest) GetQueueId() string {
+type ListChallengesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Challenges    []*Challenge           `protobuf:"bytes,1,rep,name=challenges,proto3" json:"challenges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListChallengesResponse) Reset() {
+	*x = ListChallengesResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListChallengesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListChallengesResponse) ProtoMessage() {}
+
+func (x *ListChallengesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[18]
 	if x != nil {
-		return x.QueueId
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return ""
+	return mi.MessageOf(x)
 }
 
-type CancelQueueResponse struct {
+// Deprecated: Use ListChallengesResponse.ProtoReflect.Descriptor instead.
+func (*ListChallengesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListChallengesResponse) GetChallenges() []*Challenge {
+	if x != nil {
+		return x.Challenges
+	}
+	return nil
+}
+
+// Create a private invite code
+type CreateInviteRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteRequest) Reset() {
+	*x = CreateInviteRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteRequest) ProtoMessage() {}
+
+func (x *CreateInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateInviteRequest) GetPlayer() *Player {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type CreateInviteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Invite        *Challenge             `protobuf:"bytes,3,opt,name=invite,proto3" json:"invite,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CancelQueueResponse) Reset() {
-	*x = CancelQueueResponse{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[4]
+func (x *CreateInviteResponse) Reset() {
+	*x = CreateInviteResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CancelQueueResponse) String() string {
+func (x *CreateInviteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CancelQueueResponse) ProtoMessage() {}
+func (*CreateInviteResponse) ProtoMessage() {}
 
-func (x *CancelQueueResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[4]
+func (x *CreateInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -319,49 +1186,56 @@ func (x *CancelQueueResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CancelQueueResponse.ProtoReflect.Descriptor instead.
-func (*CancelQueueResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use CreateInviteResponse.ProtoReflect.Descriptor instead.
+func (*CreateInviteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{20}
 }
 
-func (x *CancelQueueResponse) GetSuccess() bool {
+func (x *CreateInviteResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *CancelQueueResponse) GetMessage() string {
+func (x *CreateInviteResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-// Queue status request
-type GetQueueStatusRequest struct {
+func (x *CreateInviteResponse) GetInvite() *Challenge {
+	if x != nil {
+		return x.Invite
+	}
+	return nil
+}
+
+// Redeem an invite code and start the game
+type JoinInviteRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
-	QueueId       string                 `protobuf:"bytes,2,opt,name=queue_id,json=queueId,proto3" json:"queue_id,omitempty"`
+	Player        *Player                `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	InviteCode    string                 `protobuf:"bytes,2,opt,name=invite_code,json=inviteCode,proto3" json:"invite_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetQueueStatusRequest) Reset() {
-	*x = GetQueueStatusRequest{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[5]
+func (x *JoinInviteRequest) Reset() {
+	*x = JoinInviteRequest{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetQueueStatusRequest) String() string {
+func (x *JoinInviteRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetQueueStatusRequest) ProtoMessage() {}
+func (*JoinInviteRequest) ProtoMessage() {}
 
-func (x *GetQueueStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[5]
+func (x *JoinInviteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -372,49 +1246,49 @@ func (x *GetQueueStatusRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetQueueStatusRequest.ProtoReflect.Descriptor instead.
-func (*GetQueueStatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use JoinInviteRequest.ProtoReflect.Descriptor instead.
+func (*JoinInviteRequest) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{21}
 }
 
-func (x *GetQueueStatusRequest) GetPlayerId() string {
+func (x *JoinInviteRequest) GetPlayer() *Player {
 	if x != nil {
-		return x.PlayerId
+		return x.Player
 	}
-	return ""
+	return nil
 }
 
-func (x *GetQueueStatusRequest) GetQueueId() string {
+func (x *JoinInviteRequest) GetInviteCode() string {
 	if x != nil {
-		return x.QueueId
+		return x.InviteCode
 	}
 	return ""
 }
 
-type GetQueueStatusResponse struct {
+type JoinInviteResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        QueueStatus            `protobuf:"varint,1,opt,name=status,proto3,enum=proto.matchmaking.QueueStatus" json:"status,omitempty"`
-	QueuePosition int32                  `protobuf:"varint,2,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
-	GameId        string                 `protobuf:"bytes,4,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"` // Set when status is GAME_CREATED
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	GameId        string                 `protobuf:"bytes,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetQueueStatusResponse) Reset() {
-	*x = GetQueueStatusResponse{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[6]
+func (x *JoinInviteResponse) Reset() {
+	*x = JoinInviteResponse{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetQueueStatusResponse) String() string {
+func (x *JoinInviteResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetQueueStatusResponse) ProtoMessage() {}
+func (*JoinInviteResponse) ProtoMessage() {}
 
-func (x *GetQueueStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[6]
+func (x *JoinInviteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -425,26 +1299,26 @@ func (x *GetQueueStatusResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetQueueStatusResponse.ProtoReflect.Descriptor instead.
-func (*GetQueueStatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use JoinInviteResponse.ProtoReflect.Descriptor instead.
+func (*JoinInviteResponse) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{22}
 }
 
-func (x *GetQueueStatusResponse) GetStatus() QueueStatus {
+func (x *JoinInviteResponse) GetSuccess() bool {
 	if x != nil {
-		return x.Status
+		return x.Success
 	}
-	return QueueStatus_QUEUED
+	return false
 }
 
-func (x *GetQueueStatusResponse) GetQueuePosition() int32 {
+func (x *JoinInviteResponse) GetMessage() string {
 	if x != nil {
-		return x.QueuePosition
+		return x.Message
 	}
-	return 0
+	return ""
 }
 
-func (x *GetQueueStatusResponse) GetGameId() string {
+func (x *JoinInviteResponse) GetGameId() string {
 	if x != nil {
 		return x.GameId
 	}
@@ -465,7 +1339,7 @@ type MatchFoundEvent struct {
 
 func (x *MatchFoundEvent) Reset() {
 	*x = MatchFoundEvent{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[7]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -477,7 +1351,7 @@ func (x *MatchFoundEvent) String() string {
 func (*MatchFoundEvent) ProtoMessage() {}
 
 func (x *MatchFoundEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[7]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -490,7 +1364,7 @@ func (x *MatchFoundEvent) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchFoundEvent.ProtoReflect.Descriptor instead.
 func (*MatchFoundEvent) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{7}
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *MatchFoundEvent) GetMatchId() string {
@@ -539,6 +1413,7 @@ type MatchmakingUpdate struct {
 	//	*MatchmakingUpdate_MatchFound
 	//	*MatchmakingUpdate_QueueCancelled
 	//	*MatchmakingUpdate_GameCreated
+	//	*MatchmakingUpdate_MatchFailed
 	Update        isMatchmakingUpdate_Update `protobuf_oneof:"update"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -546,7 +1421,7 @@ type MatchmakingUpdate struct {
 
 func (x *MatchmakingUpdate) Reset() {
 	*x = MatchmakingUpdate{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[8]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -558,7 +1433,7 @@ func (x *MatchmakingUpdate) String() string {
 func (*MatchmakingUpdate) ProtoMessage() {}
 
 func (x *MatchmakingUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[8]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -571,7 +1446,7 @@ func (x *MatchmakingUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchmakingUpdate.ProtoReflect.Descriptor instead.
 func (*MatchmakingUpdate) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{8}
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *MatchmakingUpdate) GetQueueId() string {
@@ -631,6 +1506,15 @@ func (x *MatchmakingUpdate) GetGameCreated() *GameCreated {
 	return nil
 }
 
+func (x *MatchmakingUpdate) GetMatchFailed() *MatchFailed {
+	if x != nil {
+		if x, ok := x.Update.(*MatchmakingUpdate_MatchFailed); ok {
+			return x.MatchFailed
+		}
+	}
+	return nil
+}
+
 type isMatchmakingUpdate_Update interface {
 	isMatchmakingUpdate_Update()
 }
@@ -651,6 +1535,10 @@ type MatchmakingUpdate_GameCreated struct {
 	GameCreated *GameCreated `protobuf:"bytes,6,opt,name=game_created,json=gameCreated,proto3,oneof"`
 }
 
+type MatchmakingUpdate_MatchFailed struct {
+	MatchFailed *MatchFailed `protobuf:"bytes,7,opt,name=match_failed,json=matchFailed,proto3,oneof"`
+}
+
 func (*MatchmakingUpdate_QueuePosition) isMatchmakingUpdate_Update() {}
 
 func (*MatchmakingUpdate_MatchFound) isMatchmakingUpdate_Update() {}
@@ -659,6 +1547,8 @@ func (*MatchmakingUpdate_QueueCancelled) isMatchmakingUpdate_Update() {}
 
 func (*MatchmakingUpdate_GameCreated) isMatchmakingUpdate_Update() {}
 
+func (*MatchmakingUpdate_MatchFailed) isMatchmakingUpdate_Update() {}
+
 type QueuePositionUpdate struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Position      int32                  `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
@@ -668,7 +1558,7 @@ type QueuePositionUpdate struct {
 
 func (x *QueuePositionUpdate) Reset() {
 	*x = QueuePositionUpdate{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[9]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -680,7 +1570,7 @@ func (x *QueuePositionUpdate) String() string {
 func (*QueuePositionUpdate) ProtoMessage() {}
 
 func (x *QueuePositionUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[9]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -693,7 +1583,7 @@ func (x *QueuePositionUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueuePositionUpdate.ProtoReflect.Descriptor instead.
 func (*QueuePositionUpdate) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{9}
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *QueuePositionUpdate) GetPosition() int32 {
@@ -713,7 +1603,7 @@ type MatchFound struct {
 
 func (x *MatchFound) Reset() {
 	*x = MatchFound{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[10]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -725,7 +1615,7 @@ func (x *MatchFound) String() string {
 func (*MatchFound) ProtoMessage() {}
 
 func (x *MatchFound) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[10]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -738,7 +1628,7 @@ func (x *MatchFound) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchFound.ProtoReflect.Descriptor instead.
 func (*MatchFound) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{10}
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *MatchFound) GetMatchId() string {
@@ -764,7 +1654,7 @@ type QueueCancelled struct {
 
 func (x *QueueCancelled) Reset() {
 	*x = QueueCancelled{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[11]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -776,7 +1666,7 @@ func (x *QueueCancelled) String() string {
 func (*QueueCancelled) ProtoMessage() {}
 
 func (x *QueueCancelled) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[11]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -789,7 +1679,7 @@ func (x *QueueCancelled) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use QueueCancelled.ProtoReflect.Descriptor instead.
 func (*QueueCancelled) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{11}
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *QueueCancelled) GetReason() string {
@@ -809,7 +1699,7 @@ type GameCreated struct {
 
 func (x *GameCreated) Reset() {
 	*x = GameCreated{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[12]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -821,7 +1711,7 @@ func (x *GameCreated) String() string {
 func (*GameCreated) ProtoMessage() {}
 
 func (x *GameCreated) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[12]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -834,7 +1724,7 @@ func (x *GameCreated) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameCreated.ProtoReflect.Descriptor instead.
 func (*GameCreated) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{12}
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *GameCreated) GetGameId() string {
@@ -851,6 +1741,51 @@ func (x *GameCreated) GetGame() *games.Game {
 	return nil
 }
 
+// Sent when the game of a match could not be created; the player is back in the queue
+type MatchFailed struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchFailed) Reset() {
+	*x = MatchFailed{}
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchFailed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchFailed) ProtoMessage() {}
+
+func (x *MatchFailed) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchFailed.ProtoReflect.Descriptor instead.
+func (*MatchFailed) Descriptor() ([]byte, []int) {
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MatchFailed) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 // Streaming request
 type StreamUpdatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -862,7 +1797,7 @@ type StreamUpdatesRequest struct {
 
 func (x *StreamUpdatesRequest) Reset() {
 	*x = StreamUpdatesRequest{}
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[13]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -874,7 +1809,7 @@ func (x *StreamUpdatesRequest) String() string {
 func (*StreamUpdatesRequest) ProtoMessage() {}
 
 func (x *StreamUpdatesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[13]
+	mi := &file_proto_matchmaking_matchmaking_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -887,7 +1822,7 @@ func (x *StreamUpdatesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StreamUpdatesRequest.ProtoReflect.Descriptor instead.
 func (*StreamUpdatesRequest) Descriptor() ([]byte, []int) {
-	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{13}
+	return file_proto_matchmaking_matchmaking_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *StreamUpdatesRequest) GetPlayerId() string {
@@ -913,7 +1848,16 @@ const file_proto_matchmaking_matchmaking_proto_rawDesc = "" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"C\n" +
 	"\x0eEnqueueRequest\x121\n" +
-	"\x06player\x18\x01 \x01(\v2\x19.proto.matchmaking.PlayerR\x06player\"`\n" +
+	"\x06player\x18\x01 \x01(\v2\x19.proto.matchmaking.PlayerR\x06player\"k\n" +
+	"\x0fBotMatchRequest\x121\n" +
+	"\x06player\x18\x01 \x01(\v2\x19.proto.matchmaking.PlayerR\x06player\x12%\n" +
+	"\x0ebot_difficulty\x18\x02 \x01(\tR\rbotDifficulty\"\x91\x01\n" +
+	"\x10BotMatchResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\tR\x06gameId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x15\n" +
+	"\x06bot_id\x18\x04 \x01(\tR\x05botId\x12\x19\n" +
+	"\bbot_name\x18\x05 \x01(\tR\abotName\"`\n" +
 	"\x0fEnqueueResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x19\n" +
 	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\x12\x18\n" +
@@ -923,20 +1867,72 @@ const file_proto_matchmaking_matchmaking_proto_rawDesc = "" +
 	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\"I\n" +
 	"\x13CancelQueueResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage\"O\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"5\n" +
+	"\x16RemoveFromQueueRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"\x17\n" +
+	"\x15GetQueueLengthRequest\"0\n" +
+	"\x16GetQueueLengthResponse\x12\x16\n" +
+	"\x06length\x18\x01 \x01(\x05R\x06length\"O\n" +
 	"\x15GetQueueStatusRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
 	"\bqueue_id\x18\x02 \x01(\tR\aqueueId\"\x90\x01\n" +
 	"\x16GetQueueStatusResponse\x126\n" +
 	"\x06status\x18\x01 \x01(\x0e2\x1e.proto.matchmaking.QueueStatusR\x06status\x12%\n" +
 	"\x0equeue_position\x18\x02 \x01(\x05R\rqueuePosition\x12\x17\n" +
-	"\agame_id\x18\x04 \x01(\tR\x06gameId\"\xcd\x01\n" +
+	"\agame_id\x18\x04 \x01(\tR\x06gameId\"\xbc\x01\n" +
+	"\tChallenge\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
+	"\n" +
+	"challenger\x18\x02 \x01(\v2\x19.proto.matchmaking.PlayerR\n" +
+	"challenger\x12$\n" +
+	"\x0etarget_user_id\x18\x03 \x01(\tR\ftargetUserId\x12\x1f\n" +
+	"\vinvite_code\x18\x04 \x01(\tR\n" +
+	"inviteCode\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\x03R\texpiresAt\"s\n" +
+	"\x10ChallengeRequest\x129\n" +
+	"\n" +
+	"challenger\x18\x01 \x01(\v2\x19.proto.matchmaking.PlayerR\n" +
+	"challenger\x12$\n" +
+	"\x0etarget_user_id\x18\x02 \x01(\tR\ftargetUserId\"\x83\x01\n" +
+	"\x11ChallengeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12:\n" +
+	"\tchallenge\x18\x03 \x01(\v2\x1c.proto.matchmaking.ChallengeR\tchallenge\"\x87\x01\n" +
+	"\x17RespondChallengeRequest\x121\n" +
+	"\x06player\x18\x01 \x01(\v2\x19.proto.matchmaking.PlayerR\x06player\x12!\n" +
+	"\fchallenge_id\x18\x02 \x01(\tR\vchallengeId\x12\x16\n" +
+	"\x06accept\x18\x03 \x01(\bR\x06accept\"g\n" +
+	"\x18RespondChallengeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\agame_id\x18\x03 \x01(\tR\x06gameId\"4\n" +
+	"\x15ListChallengesRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"V\n" +
+	"\x16ListChallengesResponse\x12<\n" +
+	"\n" +
+	"challenges\x18\x01 \x03(\v2\x1c.proto.matchmaking.ChallengeR\n" +
+	"challenges\"H\n" +
+	"\x13CreateInviteRequest\x121\n" +
+	"\x06player\x18\x01 \x01(\v2\x19.proto.matchmaking.PlayerR\x06player\"\x80\x01\n" +
+	"\x14CreateInviteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\x06invite\x18\x03 \x01(\v2\x1c.proto.matchmaking.ChallengeR\x06invite\"g\n" +
+	"\x11JoinInviteRequest\x121\n" +
+	"\x06player\x18\x01 \x01(\v2\x19.proto.matchmaking.PlayerR\x06player\x12\x1f\n" +
+	"\vinvite_code\x18\x02 \x01(\tR\n" +
+	"inviteCode\"a\n" +
+	"\x12JoinInviteResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x17\n" +
+	"\agame_id\x18\x03 \x01(\tR\x06gameId\"\xcd\x01\n" +
 	"\x0fMatchFoundEvent\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x123\n" +
 	"\aplayer1\x18\x02 \x01(\v2\x19.proto.matchmaking.PlayerR\aplayer1\x123\n" +
 	"\aplayer2\x18\x03 \x01(\v2\x19.proto.matchmaking.PlayerR\aplayer2\x12\x17\n" +
 	"\agame_id\x18\x04 \x01(\tR\x06gameId\x12\x1c\n" +
-	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"\x96\x03\n" +
+	"\ttimestamp\x18\x05 \x01(\x03R\ttimestamp\"\xdb\x03\n" +
 	"\x11MatchmakingUpdate\x12\x19\n" +
 	"\bqueue_id\x18\x01 \x01(\tR\aqueueId\x126\n" +
 	"\x06status\x18\x02 \x01(\x0e2\x1e.proto.matchmaking.QueueStatusR\x06status\x12O\n" +
@@ -944,7 +1940,8 @@ const file_proto_matchmaking_matchmaking_proto_rawDesc = "" +
 	"\vmatch_found\x18\x04 \x01(\v2\x1d.proto.matchmaking.MatchFoundH\x00R\n" +
 	"matchFound\x12L\n" +
 	"\x0fqueue_cancelled\x18\x05 \x01(\v2!.proto.matchmaking.QueueCancelledH\x00R\x0equeueCancelled\x12C\n" +
-	"\fgame_created\x18\x06 \x01(\v2\x1e.proto.matchmaking.GameCreatedH\x00R\vgameCreatedB\b\n" +
+	"\fgame_created\x18\x06 \x01(\v2\x1e.proto.matchmaking.GameCreatedH\x00R\vgameCreated\x12C\n" +
+	"\fmatch_failed\x18\a \x01(\v2\x1e.proto.matchmaking.MatchFailedH\x00R\vmatchFailedB\b\n" +
 	"\x06update\"1\n" +
 	"\x13QueuePositionUpdate\x12\x1a\n" +
 	"\bposition\x18\x01 \x01(\x05R\bposition\"^\n" +
@@ -956,7 +1953,9 @@ const file_proto_matchmaking_matchmaking_proto_rawDesc = "" +
 	"\x06reason\x18\x01 \x01(\tR\x06reason\"M\n" +
 	"\vGameCreated\x12\x17\n" +
 	"\agame_id\x18\x01 \x01(\tR\x06gameId\x12%\n" +
-	"\x04game\x18\x02 \x01(\v2\x11.proto.games.GameR\x04game\"N\n" +
+	"\x04game\x18\x02 \x01(\v2\x11.proto.games.GameR\x04game\"%\n" +
+	"\vMatchFailed\x12\x16\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\"N\n" +
 	"\x14StreamUpdatesRequest\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x19\n" +
 	"\bqueue_id\x18\x02 \x01(\tR\aqueueId*G\n" +
@@ -965,12 +1964,21 @@ const file_proto_matchmaking_matchmaking_proto_rawDesc = "" +
 	"\x06QUEUED\x10\x00\x12\v\n" +
 	"\aMATCHED\x10\x01\x12\r\n" +
 	"\tCANCELLED\x10\x02\x12\x10\n" +
-	"\fGAME_CREATED\x10\x032\x86\x03\n" +
+	"\fGAME_CREATED\x10\x032\x90\t\n" +
 	"\vMatchmaking\x12P\n" +
-	"\aEnqueue\x12!.proto.matchmaking.EnqueueRequest\x1a\".proto.matchmaking.EnqueueResponse\x12\\\n" +
+	"\aEnqueue\x12!.proto.matchmaking.EnqueueRequest\x1a\".proto.matchmaking.EnqueueResponse\x12S\n" +
+	"\bBotMatch\x12\".proto.matchmaking.BotMatchRequest\x1a#.proto.matchmaking.BotMatchResponse\x12\\\n" +
 	"\vCancelQueue\x12%.proto.matchmaking.CancelQueueRequest\x1a&.proto.matchmaking.CancelQueueResponse\x12e\n" +
 	"\x0eGetQueueStatus\x12(.proto.matchmaking.GetQueueStatusRequest\x1a).proto.matchmaking.GetQueueStatusResponse\x12`\n" +
-	"\rStreamUpdates\x12'.proto.matchmaking.StreamUpdatesRequest\x1a$.proto.matchmaking.MatchmakingUpdate0\x01B<Z:github.com/laerson/mancala/proto/matchmaking;matchmakingpbb\x06proto3"
+	"\rStreamUpdates\x12'.proto.matchmaking.StreamUpdatesRequest\x1a$.proto.matchmaking.MatchmakingUpdate0\x01\x12V\n" +
+	"\tChallenge\x12#.proto.matchmaking.ChallengeRequest\x1a$.proto.matchmaking.ChallengeResponse\x12k\n" +
+	"\x10RespondChallenge\x12*.proto.matchmaking.RespondChallengeRequest\x1a+.proto.matchmaking.RespondChallengeResponse\x12e\n" +
+	"\x0eListChallenges\x12(.proto.matchmaking.ListChallengesRequest\x1a).proto.matchmaking.ListChallengesResponse\x12_\n" +
+	"\fCreateInvite\x12&.proto.matchmaking.CreateInviteRequest\x1a'.proto.matchmaking.CreateInviteResponse\x12Y\n" +
+	"\n" +
+	"JoinInvite\x12$.proto.matchmaking.JoinInviteRequest\x1a%.proto.matchmaking.JoinInviteResponse\x12d\n" +
+	"\x0fRemoveFromQueue\x12).proto.matchmaking.RemoveFromQueueRequest\x1a&.proto.matchmaking.CancelQueueResponse\x12e\n" +
+	"\x0eGetQueueLength\x12(.proto.matchmaking.GetQueueLengthRequest\x1a).proto.matchmaking.GetQueueLengthResponseB<Z:github.com/laerson/mancala/proto/matchmaking;matchmakingpbb\x06proto3"
 
 var (
 	file_proto_matchmaking_matchmaking_proto_rawDescOnce sync.Once
@@ -985,50 +1993,93 @@ func file_proto_matchmaking_matchmaking_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_matchmaking_matchmaking_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_matchmaking_matchmaking_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_proto_matchmaking_matchmaking_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
 var file_proto_matchmaking_matchmaking_proto_goTypes = []any{
-	(QueueStatus)(0),               // 0: proto.matchmaking.QueueStatus
-	(*Player)(nil),                 // 1: proto.matchmaking.Player
-	(*EnqueueRequest)(nil),         // 2: proto.matchmaking.EnqueueRequest
-	(*EnqueueResponse)(nil),        // 3: proto.matchmaking.EnqueueResponse
-	(*CancelQueueRequest)(nil),     // 4: proto.matchmaking.CancelQueueRequest
-	(*CancelQueueResponse)(nil),    // 5: proto.matchmaking.CancelQueueResponse
-	(*GetQueueStatusRequest)(nil),  // 6: proto.matchmaking.GetQueueStatusRequest
-	(*GetQueueStatusResponse)(nil), // 7: proto.matchmaking.GetQueueStatusResponse
-	(*MatchFoundEvent)(nil),        // 8: proto.matchmaking.MatchFoundEvent
-	(*MatchmakingUpdate)(nil),      // 9: proto.matchmaking.MatchmakingUpdate
-	(*QueuePositionUpdate)(nil),    // 10: proto.matchmaking.QueuePositionUpdate
-	(*MatchFound)(nil),             // 11: proto.matchmaking.MatchFound
-	(*QueueCancelled)(nil),         // 12: proto.matchmaking.QueueCancelled
-	(*GameCreated)(nil),            // 13: proto.matchmaking.GameCreated
-	(*StreamUpdatesRequest)(nil),   // 14: proto.matchmaking.StreamUpdatesRequest
-	(*games.Game)(nil),             // 15: proto.games.Game
+	(QueueStatus)(0),                 // 0: proto.matchmaking.QueueStatus
+	(*Player)(nil),                   // 1: proto.matchmaking.Player
+	(*EnqueueRequest)(nil),           // 2: proto.matchmaking.EnqueueRequest
+	(*BotMatchRequest)(nil),          // 3: proto.matchmaking.BotMatchRequest
+	(*BotMatchResponse)(nil),         // 4: proto.matchmaking.BotMatchResponse
+	(*EnqueueResponse)(nil),          // 5: proto.matchmaking.EnqueueResponse
+	(*CancelQueueRequest)(nil),       // 6: proto.matchmaking.CancelQueueRequest
+	(*CancelQueueResponse)(nil),      // 7: proto.matchmaking.CancelQueueResponse
+	(*RemoveFromQueueRequest)(nil),   // 8: proto.matchmaking.RemoveFromQueueRequest
+	(*GetQueueLengthRequest)(nil),    // 9: proto.matchmaking.GetQueueLengthRequest
+	(*GetQueueLengthResponse)(nil),   // 10: proto.matchmaking.GetQueueLengthResponse
+	(*GetQueueStatusRequest)(nil),    // 11: proto.matchmaking.GetQueueStatusRequest
+	(*GetQueueStatusResponse)(nil),   // 12: proto.matchmaking.GetQueueStatusResponse
+	(*Challenge)(nil),                // 13: proto.matchmaking.Challenge
+	(*ChallengeRequest)(nil),         // 14: proto.matchmaking.ChallengeRequest
+	(*ChallengeResponse)(nil),        // 15: proto.matchmaking.ChallengeResponse
+	(*RespondChallengeRequest)(nil),  // 16: proto.matchmaking.RespondChallengeRequest
+	(*RespondChallengeResponse)(nil), // 17: proto.matchmaking.RespondChallengeResponse
+	(*ListChallengesRequest)(nil),    // 18: proto.matchmaking.ListChallengesRequest
+	(*ListChallengesResponse)(nil),   // 19: proto.matchmaking.ListChallengesResponse
+	(*CreateInviteRequest)(nil),      // 20: proto.matchmaking.CreateInviteRequest
+	(*CreateInviteResponse)(nil),     // 21: proto.matchmaking.CreateInviteResponse
+	(*JoinInviteRequest)(nil),        // 22: proto.matchmaking.JoinInviteRequest
+	(*JoinInviteResponse)(nil),       // 23: proto.matchmaking.JoinInviteResponse
+	(*MatchFoundEvent)(nil),          // 24: proto.matchmaking.MatchFoundEvent
+	(*MatchmakingUpdate)(nil),        // 25: proto.matchmaking.MatchmakingUpdate
+	(*QueuePositionUpdate)(nil),      // 26: proto.matchmaking.QueuePositionUpdate
+	(*MatchFound)(nil),               // 27: proto.matchmaking.MatchFound
+	(*QueueCancelled)(nil),           // 28: proto.matchmaking.QueueCancelled
+	(*GameCreated)(nil),              // 29: proto.matchmaking.GameCreated
+	(*MatchFailed)(nil),              // 30: proto.matchmaking.MatchFailed
+	(*StreamUpdatesRequest)(nil),     // 31: proto.matchmaking.StreamUpdatesRequest
+	(*games.Game)(nil),               // 32: proto.games.Game
 }
 var file_proto_matchmaking_matchmaking_proto_depIdxs = []int32{
 	1,  // 0: proto.matchmaking.EnqueueRequest.player:type_name -> proto.matchmaking.Player
-	0,  // 1: proto.matchmaking.GetQueueStatusResponse.status:type_name -> proto.matchmaking.QueueStatus
-	1,  // 2: proto.matchmaking.MatchFoundEvent.player1:type_name -> proto.matchmaking.Player
-	1,  // 3: proto.matchmaking.MatchFoundEvent.player2:type_name -> proto.matchmaking.Player
-	0,  // 4: proto.matchmaking.MatchmakingUpdate.status:type_name -> proto.matchmaking.QueueStatus
-	10, // 5: proto.matchmaking.MatchmakingUpdate.queue_position:type_name -> proto.matchmaking.QueuePositionUpdate
-	11, // 6: proto.matchmaking.MatchmakingUpdate.match_found:type_name -> proto.matchmaking.MatchFound
-	12, // 7: proto.matchmaking.MatchmakingUpdate.queue_cancelled:type_name -> proto.matchmaking.QueueCancelled
-	13, // 8: proto.matchmaking.MatchmakingUpdate.game_created:type_name -> proto.matchmaking.GameCreated
-	1,  // 9: proto.matchmaking.MatchFound.opponent:type_name -> proto.matchmaking.Player
-	15, // 10: proto.matchmaking.GameCreated.game:type_name -> proto.games.Game
-	2,  // 11: proto.matchmaking.Matchmaking.Enqueue:input_type -> proto.matchmaking.EnqueueRequest
-	4,  // 12: proto.matchmaking.Matchmaking.CancelQueue:input_type -> proto.matchmaking.CancelQueueRequest
-	6,  // 13: proto.matchmaking.Matchmaking.GetQueueStatus:input_type -> proto.matchmaking.GetQueueStatusRequest
-	14, // 14: proto.matchmaking.Matchmaking.StreamUpdates:input_type -> proto.matchmaking.StreamUpdatesRequest
-	3,  // 15: proto.matchmaking.Matchmaking.Enqueue:output_type -> proto.matchmaking.EnqueueResponse
-	5,  // 16: proto.matchmaking.Matchmaking.CancelQueue:output_type -> proto.matchmaking.CancelQueueResponse
-	7,  // 17: proto.matchmaking.Matchmaking.GetQueueStatus:output_type -> proto.matchmaking.GetQueueStatusResponse
-	9,  // 18: proto.matchmaking.Matchmaking.StreamUpdates:output_type -> proto.matchmaking.MatchmakingUpdate
-	15, // [15:19] is the sub-list for method output_type
-	11, // [11:15] is the sub-list for method input_type
-	11, // [11:11] is the sub-list for extension type_name
-	11, // [11:11] is the sub-list for extension extendee
-	0,  // [0:11] is the sub-list for field type_name
+	1,  // 1: proto.matchmaking.BotMatchRequest.player:type_name -> proto.matchmaking.Player
+	0,  // 2: proto.matchmaking.GetQueueStatusResponse.status:type_name -> proto.matchmaking.QueueStatus
+	1,  // 3: proto.matchmaking.Challenge.challenger:type_name -> proto.matchmaking.Player
+	1,  // 4: proto.matchmaking.ChallengeRequest.challenger:type_name -> proto.matchmaking.Player
+	13, // 5: proto.matchmaking.ChallengeResponse.challenge:type_name -> proto.matchmaking.Challenge
+	1,  // 6: proto.matchmaking.RespondChallengeRequest.player:type_name -> proto.matchmaking.Player
+	13, // 7: proto.matchmaking.ListChallengesResponse.challenges:type_name -> proto.matchmaking.Challenge
+	1,  // 8: proto.matchmaking.CreateInviteRequest.player:type_name -> proto.matchmaking.Player
+	13, // 9: proto.matchmaking.CreateInviteResponse.invite:type_name -> proto.matchmaking.Challenge
+	1,  // 10: proto.matchmaking.JoinInviteRequest.player:type_name -> proto.matchmaking.Player
+	1,  // 11: proto.matchmaking.MatchFoundEvent.player1:type_name -> proto.matchmaking.Player
+	1,  // 12: proto.matchmaking.MatchFoundEvent.player2:type_name -> proto.matchmaking.Player
+	0,  // 13: proto.matchmaking.MatchmakingUpdate.status:type_name -> proto.matchmaking.QueueStatus
+	26, // 14: proto.matchmaking.MatchmakingUpdate.queue_position:type_name -> proto.matchmaking.QueuePositionUpdate
+	27, // 15: proto.matchmaking.MatchmakingUpdate.match_found:type_name -> proto.matchmaking.MatchFound
+	28, // 16: proto.matchmaking.MatchmakingUpdate.queue_cancelled:type_name -> proto.matchmaking.QueueCancelled
+	29, // 17: proto.matchmaking.MatchmakingUpdate.game_created:type_name -> proto.matchmaking.GameCreated
+	30, // 18: proto.matchmaking.MatchmakingUpdate.match_failed:type_name -> proto.matchmaking.MatchFailed
+	1,  // 19: proto.matchmaking.MatchFound.opponent:type_name -> proto.matchmaking.Player
+	32, // 20: proto.matchmaking.GameCreated.game:type_name -> proto.games.Game
+	2,  // 21: proto.matchmaking.Matchmaking.Enqueue:input_type -> proto.matchmaking.EnqueueRequest
+	3,  // 22: proto.matchmaking.Matchmaking.BotMatch:input_type -> proto.matchmaking.BotMatchRequest
+	6,  // 23: proto.matchmaking.Matchmaking.CancelQueue:input_type -> proto.matchmaking.CancelQueueRequest
+	11, // 24: proto.matchmaking.Matchmaking.GetQueueStatus:input_type -> proto.matchmaking.GetQueueStatusRequest
+	31, // 25: proto.matchmaking.Matchmaking.StreamUpdates:input_type -> proto.matchmaking.StreamUpdatesRequest
+	14, // 26: proto.matchmaking.Matchmaking.Challenge:input_type -> proto.matchmaking.ChallengeRequest
+	16, // 27: proto.matchmaking.Matchmaking.RespondChallenge:input_type -> proto.matchmaking.RespondChallengeRequest
+	18, // 28: proto.matchmaking.Matchmaking.ListChallenges:input_type -> proto.matchmaking.ListChallengesRequest
+	20, // 29: proto.matchmaking.Matchmaking.CreateInvite:input_type -> proto.matchmaking.CreateInviteRequest
+	22, // 30: proto.matchmaking.Matchmaking.JoinInvite:input_type -> proto.matchmaking.JoinInviteRequest
+	8,  // 31: proto.matchmaking.Matchmaking.RemoveFromQueue:input_type -> proto.matchmaking.RemoveFromQueueRequest
+	9,  // 32: proto.matchmaking.Matchmaking.GetQueueLength:input_type -> proto.matchmaking.GetQueueLengthRequest
+	5,  // 33: proto.matchmaking.Matchmaking.Enqueue:output_type -> proto.matchmaking.EnqueueResponse
+	4,  // 34: proto.matchmaking.Matchmaking.BotMatch:output_type -> proto.matchmaking.BotMatchResponse
+	7,  // 35: proto.matchmaking.Matchmaking.CancelQueue:output_type -> proto.matchmaking.CancelQueueResponse
+	12, // 36: proto.matchmaking.Matchmaking.GetQueueStatus:output_type -> proto.matchmaking.GetQueueStatusResponse
+	25, // 37: proto.matchmaking.Matchmaking.StreamUpdates:output_type -> proto.matchmaking.MatchmakingUpdate
+	15, // 38: proto.matchmaking.Matchmaking.Challenge:output_type -> proto.matchmaking.ChallengeResponse
+	17, // 39: proto.matchmaking.Matchmaking.RespondChallenge:output_type -> proto.matchmaking.RespondChallengeResponse
+	19, // 40: proto.matchmaking.Matchmaking.ListChallenges:output_type -> proto.matchmaking.ListChallengesResponse
+	21, // 41: proto.matchmaking.Matchmaking.CreateInvite:output_type -> proto.matchmaking.CreateInviteResponse
+	23, // 42: proto.matchmaking.Matchmaking.JoinInvite:output_type -> proto.matchmaking.JoinInviteResponse
+	7,  // 43: proto.matchmaking.Matchmaking.RemoveFromQueue:output_type -> proto.matchmaking.CancelQueueResponse
+	10, // 44: proto.matchmaking.Matchmaking.GetQueueLength:output_type -> proto.matchmaking.GetQueueLengthResponse
+	33, // [33:45] is the sub-list for method output_type
+	21, // [21:33] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_proto_matchmaking_matchmaking_proto_init() }
@@ -1036,11 +2087,12 @@ func file_proto_matchmaking_matchmaking_proto_init() {
 	if File_proto_matchmaking_matchmaking_proto != nil {
 		return
 	}
-	file_proto_matchmaking_matchmaking_proto_msgTypes[8].OneofWrappers = []any{
+	file_proto_matchmaking_matchmaking_proto_msgTypes[24].OneofWrappers = []any{
 		(*MatchmakingUpdate_QueuePosition)(nil),
 		(*MatchmakingUpdate_MatchFound)(nil),
 		(*MatchmakingUpdate_QueueCancelled)(nil),
 		(*MatchmakingUpdate_GameCreated)(nil),
+		(*MatchmakingUpdate_MatchFailed)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1048,7 +2100,7 @@ func file_proto_matchmaking_matchmaking_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_matchmaking_matchmaking_proto_rawDesc), len(file_proto_matchmaking_matchmaking_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   14,
+			NumMessages:   31,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
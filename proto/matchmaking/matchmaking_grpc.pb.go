@@ -19,10 +19,18 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	Matchmaking_Enqueue_FullMethodName        = "/proto.matchmaking.Matchmaking/Enqueue"
-	Matchmaking_CancelQueue_FullMethodName    = "/proto.matchmaking.Matchmaking/CancelQueue"
-	Matchmaking_GetQueueStatus_FullMethodName = "/proto.matchmaking.Matchmaking/GetQueueStatus"
-	Matchmaking_StreamUpdates_FullMethodName  = "/proto.matchmaking.Matchmaking/StreamUpdates"
+	Matchmaking_Enqueue_FullMethodName          = "/proto.matchmaking.Matchmaking/Enqueue"
+	Matchmaking_BotMatch_FullMethodName         = "/proto.matchmaking.Matchmaking/BotMatch"
+	Matchmaking_CancelQueue_FullMethodName      = "/proto.matchmaking.Matchmaking/CancelQueue"
+	Matchmaking_GetQueueStatus_FullMethodName   = "/proto.matchmaking.Matchmaking/GetQueueStatus"
+	Matchmaking_StreamUpdates_FullMethodName    = "/proto.matchmaking.Matchmaking/StreamUpdates"
+	Matchmaking_Challenge_FullMethodName        = "/proto.matchmaking.Matchmaking/Challenge"
+	Matchmaking_RespondChallenge_FullMethodName = "/proto.matchmaking.Matchmaking/RespondChallenge"
+	Matchmaking_ListChallenges_FullMethodName   = "/proto.matchmaking.Matchmaking/ListChallenges"
+	Matchmaking_CreateInvite_FullMethodName     = "/proto.matchmaking.Matchmaking/CreateInvite"
+	Matchmaking_JoinInvite_FullMethodName       = "/proto.matchmaking.Matchmaking/JoinInvite"
+	Matchmaking_RemoveFromQueue_FullMethodName  = "/proto.matchmaking.Matchmaking/RemoveFromQueue"
+	Matchmaking_GetQueueLength_FullMethodName   = "/proto.matchmaking.Matchmaking/GetQueueLength"
 )
 
 // MatchmakingClient is the client API for Matchmaking service.
@@ -31,12 +39,28 @@ const (
 type MatchmakingClient interface {
 	// Enqueue a player for matchmaking
 	Enqueue(ctx context.Context, in *EnqueueRequest, opts ...grpc.CallOption) (*EnqueueResponse, error)
+	// Create a bot match immediately
+	BotMatch(ctx context.Context, in *BotMatchRequest, opts ...grpc.CallOption) (*BotMatchResponse, error)
 	// Cancel matchmaking queue
 	CancelQueue(ctx context.Context, in *CancelQueueRequest, opts ...grpc.CallOption) (*CancelQueueResponse, error)
 	// Get current queue status
 	GetQueueStatus(ctx context.Context, in *GetQueueStatusRequest, opts ...grpc.CallOption) (*GetQueueStatusResponse, error)
 	// Stream matchmaking updates (optional real-time updates)
 	StreamUpdates(ctx context.Context, in *StreamUpdatesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[MatchmakingUpdate], error)
+	// Challenge a specific player to a game
+	Challenge(ctx context.Context, in *ChallengeRequest, opts ...grpc.CallOption) (*ChallengeResponse, error)
+	// Accept or decline a challenge
+	RespondChallenge(ctx context.Context, in *RespondChallengeRequest, opts ...grpc.CallOption) (*RespondChallengeResponse, error)
+	// List the pending challenges sent to a player
+	ListChallenges(ctx context.Context, in *ListChallengesRequest, opts ...grpc.CallOption) (*ListChallengesResponse, error)
+	// Create a private invite code for a friend to join
+	CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteResponse, error)
+	// Join the game of an invite code
+	JoinInvite(ctx context.Context, in *JoinInviteRequest, opts ...grpc.CallOption) (*JoinInviteResponse, error)
+	// Remove any player from the queue (moderators)
+	RemoveFromQueue(ctx context.Context, in *RemoveFromQueueRequest, opts ...grpc.CallOption) (*CancelQueueResponse, error)
+	// Get the number of queued players (moderators)
+	GetQueueLength(ctx context.Context, in *GetQueueLengthRequest, opts ...grpc.CallOption) (*GetQueueLengthResponse, error)
 }
 
 type matchmakingClient struct {
@@ -57,6 +81,16 @@ func (c *matchmakingClient) Enqueue(ctx context.Context, in *EnqueueRequest, opt
 	return out, nil
 }
 
+func (c *matchmakingClient) BotMatch(ctx context.Context, in *BotMatchRequest, opts ...grpc.CallOption) (*BotMatchResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BotMatchResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_BotMatch_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *matchmakingClient) CancelQueue(ctx context.Context, in *CancelQueueRequest, opts ...grpc.CallOption) (*CancelQueueResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(CancelQueueResponse)
@@ -96,18 +130,104 @@ func (c *matchmakingClient) StreamUpdates(ctx context.Context, in *StreamUpdates
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Matchmaking_StreamUpdatesClient = grpc.ServerStreamingClient[MatchmakingUpdate]
 
+func (c *matchmakingClient) Challenge(ctx context.Context, in *ChallengeRequest, opts ...grpc.CallOption) (*ChallengeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ChallengeResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_Challenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakingClient) RespondChallenge(ctx context.Context, in *RespondChallengeRequest, opts ...grpc.CallOption) (*RespondChallengeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RespondChallengeResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_RespondChallenge_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakingClient) ListChallenges(ctx context.Context, in *ListChallengesRequest, opts ...grpc.CallOption) (*ListChallengesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListChallengesResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_ListChallenges_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakingClient) CreateInvite(ctx context.Context, in *CreateInviteRequest, opts ...grpc.CallOption) (*CreateInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateInviteResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_CreateInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakingClient) JoinInvite(ctx context.Context, in *JoinInviteRequest, opts ...grpc.CallOption) (*JoinInviteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(JoinInviteResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_JoinInvite_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakingClient) RemoveFromQueue(ctx context.Context, in *RemoveFromQueueRequest, opts ...grpc.CallOption) (*CancelQueueResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelQueueResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_RemoveFromQueue_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *matchmakingClient) GetQueueLength(ctx context.Context, in *GetQueueLengthRequest, opts ...grpc.CallOption) (*GetQueueLengthResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQueueLengthResponse)
+	err := c.cc.Invoke(ctx, Matchmaking_GetQueueLength_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MatchmakingServer is the server API for Matchmaking service.
 // All implementations must embed UnimplementedMatchmakingServer
 // for forward compatibility.
 type MatchmakingServer interface {
 	// Enqueue a player for matchmaking
 	Enqueue(context.Context, *EnqueueRequest) (*EnqueueResponse, error)
+	// Create a bot match immediately
+	BotMatch(context.Context, *BotMatchRequest) (*BotMatchResponse, error)
 	// Cancel matchmaking queue
 	CancelQueue(context.Context, *CancelQueueRequest) (*CancelQueueResponse, error)
 	// Get current queue status
 	GetQueueStatus(context.Context, *GetQueueStatusRequest) (*GetQueueStatusResponse, error)
 	// Stream matchmaking updates (optional real-time updates)
 	StreamUpdates(*StreamUpdatesRequest, grpc.ServerStreamingServer[MatchmakingUpdate]) error
+	// Challenge a specific player to a game
+	Challenge(context.Context, *ChallengeRequest) (*ChallengeResponse, error)
+	// Accept or decline a challenge
+	RespondChallenge(context.Context, *RespondChallengeRequest) (*RespondChallengeResponse, error)
+	// List the pending challenges sent to a player
+	ListChallenges(context.Context, *ListChallengesRequest) (*ListChallengesResponse, error)
+	// Create a private invite code for a friend to join
+	CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteResponse, error)
+	// Join the game of an invite code
+	JoinInvite(context.Context, *JoinInviteRequest) (*JoinInviteResponse, error)
+	// Remove any player from the queue (moderators)
+	RemoveFromQueue(context.Context, *RemoveFromQueueRequest) (*CancelQueueResponse, error)
+	// Get the number of queued players (moderators)
+	GetQueueLength(context.Context, *GetQueueLengthRequest) (*GetQueueLengthResponse, error)
 	mustEmbedUnimplementedMatchmakingServer()
 }
 
@@ -121,6 +241,9 @@ type UnimplementedMatchmakingServer struct{}
 func (UnimplementedMatchmakingServer) Enqueue(context.Context, *EnqueueRequest) (*EnqueueResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Enqueue not implemented")
 }
+func (UnimplementedMatchmakingServer) BotMatch(context.Context, *BotMatchRequest) (*BotMatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BotMatch not implemented")
+}
 func (UnimplementedMatchmakingServer) CancelQueue(context.Context, *CancelQueueRequest) (*CancelQueueResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CancelQueue not implemented")
 }
@@ -130,6 +253,27 @@ func (UnimplementedMatchmakingServer) GetQueueStatus(context.Context, *GetQueueS
 func (UnimplementedMatchmakingServer) StreamUpdates(*StreamUpdatesRequest, grpc.ServerStreamingServer[MatchmakingUpdate]) error {
 	return status.Errorf(codes.Unimplemented, "method StreamUpdates not implemented")
 }
+func (UnimplementedMatchmakingServer) Challenge(context.Context, *ChallengeRequest) (*ChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Challenge not implemented")
+}
+func (UnimplementedMatchmakingServer) RespondChallenge(context.Context, *RespondChallengeRequest) (*RespondChallengeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RespondChallenge not implemented")
+}
+func (UnimplementedMatchmakingServer) ListChallenges(context.Context, *ListChallengesRequest) (*ListChallengesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListChallenges not implemented")
+}
+func (UnimplementedMatchmakingServer) CreateInvite(context.Context, *CreateInviteRequest) (*CreateInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInvite not implemented")
+}
+func (UnimplementedMatchmakingServer) JoinInvite(context.Context, *JoinInviteRequest) (*JoinInviteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinInvite not implemented")
+}
+func (UnimplementedMatchmakingServer) RemoveFromQueue(context.Context, *RemoveFromQueueRequest) (*CancelQueueResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RemoveFromQueue not implemented")
+}
+func (UnimplementedMatchmakingServer) GetQueueLength(context.Context, *GetQueueLengthRequest) (*GetQueueLengthResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQueueLength not implemented")
+}
 func (UnimplementedMatchmakingServer) mustEmbedUnimplementedMatchmakingServer() {}
 func (UnimplementedMatchmakingServer) testEmbeddedByValue()                     {}
 
@@ -169,6 +313,24 @@ func _Matchmaking_Enqueue_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Matchmaking_BotMatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BotMatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).BotMatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_BotMatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).BotMatch(ctx, req.(*BotMatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Matchmaking_CancelQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CancelQueueRequest)
 	if err := dec(in); err != nil {
@@ -216,6 +378,132 @@ func _Matchmaking_StreamUpdates_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Matchmaking_StreamUpdatesServer = grpc.ServerStreamingServer[MatchmakingUpdate]
 
+func _Matchmaking_Challenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).Challenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_Challenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).Challenge(ctx, req.(*ChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaking_RespondChallenge_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RespondChallengeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).RespondChallenge(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_RespondChallenge_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).RespondChallenge(ctx, req.(*RespondChallengeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaking_ListChallenges_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListChallengesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).ListChallenges(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_ListChallenges_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).ListChallenges(ctx, req.(*ListChallengesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaking_CreateInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).CreateInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_CreateInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).CreateInvite(ctx, req.(*CreateInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaking_JoinInvite_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinInviteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).JoinInvite(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_JoinInvite_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).JoinInvite(ctx, req.(*JoinInviteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaking_RemoveFromQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveFromQueueRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).RemoveFromQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_RemoveFromQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).RemoveFromQueue(ctx, req.(*RemoveFromQueueRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Matchmaking_GetQueueLength_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQueueLengthRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MatchmakingServer).GetQueueLength(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Matchmaking_GetQueueLength_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MatchmakingServer).GetQueueLength(ctx, req.(*GetQueueLengthRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Matchmaking_ServiceDesc is the grpc.ServiceDesc for Matchmaking service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -227,6 +515,10 @@ var Matchmaking_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Enqueue",
 			Handler:    _Matchmaking_Enqueue_Handler,
 		},
+		{
+			MethodName: "BotMatch",
+			Handler:    _Matchmaking_BotMatch_Handler,
+		},
 		{
 			MethodName: "CancelQueue",
 			Handler:    _Matchmaking_CancelQueue_Handler,
@@ -235,6 +527,34 @@ var Matchmaking_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetQueueStatus",
 			Handler:    _Matchmaking_GetQueueStatus_Handler,
 		},
+		{
+			MethodName: "Challenge",
+			Handler:    _Matchmaking_Challenge_Handler,
+		},
+		{
+			MethodName: "RespondChallenge",
+			Handler:    _Matchmaking_RespondChallenge_Handler,
+		},
+		{
+			MethodName: "ListChallenges",
+			Handler:    _Matchmaking_ListChallenges_Handler,
+		},
+		{
+			MethodName: "CreateInvite",
+			Handler:    _Matchmaking_CreateInvite_Handler,
+		},
+		{
+			MethodName: "JoinInvite",
+			Handler:    _Matchmaking_JoinInvite_Handler,
+		},
+		{
+			MethodName: "RemoveFromQueue",
+			Handler:    _Matchmaking_RemoveFromQueue_Handler,
+		},
+		{
+			MethodName: "GetQueueLength",
+			Handler:    _Matchmaking_GetQueueLength_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
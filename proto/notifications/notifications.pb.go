@@ -26,10 +26,12 @@ const (
 type NotificationType int32
 
 const (
-	NotificationType_NOTIFICATION_TYPE_UNSPECIFIED NotificationType = 0
-	NotificationType_NOTIFICATION_TYPE_MATCH_FOUND NotificationType = 1
-	NotificationType_NOTIFICATION_TYPE_MOVE_MADE   NotificationType = 2
-	NotificationType_NOTIFICATION_TYPE_GAME_OVER   NotificationType = 3
+	NotificationType_NOTIFICATION_TYPE_UNSPECIFIED  NotificationType = 0
+	NotificationType_NOTIFICATION_TYPE_MATCH_FOUND  NotificationType = 1
+	NotificationType_NOTIFICATION_TYPE_MOVE_MADE    NotificationType = 2
+	NotificationType_NOTIFICATION_TYPE_GAME_OVER    NotificationType = 3
+	NotificationType_NOTIFICATION_TYPE_MATCH_FAILED NotificationType = 4
+	NotificationType_NOTIFICATION_TYPE_CHALLENGE    NotificationType = 5
 )
 
 // Enum value maps for NotificationType.
@@ -39,12 +41,16 @@ var (
 		1: "NOTIFICATION_TYPE_MATCH_FOUND",
 		2: "NOTIFICATION_TYPE_MOVE_MADE",
 		3: "NOTIFICATION_TYPE_GAME_OVER",
+		4: "NOTIFICATION_TYPE_MATCH_FAILED",
+		5: "NOTIFICATION_TYPE_CHALLENGE",
 	}
 	NotificationType_value = map[string]int32{
-		"NOTIFICATION_TYPE_UNSPECIFIED": 0,
-		"NOTIFICATION_TYPE_MATCH_FOUND": 1,
-		"NOTIFICATION_TYPE_MOVE_MADE":   2,
-		"NOTIFICATION_TYPE_GAME_OVER":   3,
+		"NOTIFICATION_TYPE_UNSPECIFIED":  0,
+		"NOTIFICATION_TYPE_MATCH_FOUND":  1,
+		"NOTIFICATION_TYPE_MOVE_MADE":    2,
+		"NOTIFICATION_TYPE_GAME_OVER":    3,
+		"NOTIFICATION_TYPE_MATCH_FAILED": 4,
+		"NOTIFICATION_TYPE_CHALLENGE":    5,
 	}
 )
 
@@ -77,8 +83,11 @@ func (NotificationType) EnumDescriptor() ([]byte, []int) {
 
 // Request to subscribe to notifications
 type SubscribeRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	// Stream ID of the last notification the client received. The notifications
+	// published since then are replayed before live delivery starts.
+	LastEventId   string `protobuf:"bytes,2,opt,name=last_event_id,json=lastEventId,proto3" json:"last_event_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -120,6 +129,58 @@ func (x *SubscribeRequest) GetPlayerId() string {
 	return ""
 }
 
+func (x *SubscribeRequest) GetLastEventId() string {
+	if x != nil {
+		return x.LastEventId
+	}
+	return ""
+}
+
+// Request to watch a game. The stream ends after the game over notification.
+type WatchRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	GameId        string                 `protobuf:"bytes,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRequest) Reset() {
+	*x = WatchRequest{}
+	mi := &file_proto_notifications_notifications_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRequest) ProtoMessage() {}
+
+func (x *WatchRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notifications_notifications_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRequest.ProtoReflect.Descriptor instead.
+func (*WatchRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WatchRequest) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
 // Notification message sent to clients
 type Notification struct {
 	state     protoimpl.MessageState `protogen:"open.v1"`
@@ -127,11 +188,14 @@ type Notification struct {
 	Type      NotificationType       `protobuf:"varint,2,opt,name=type,proto3,enum=proto.notifications.NotificationType" json:"type,omitempty"`
 	GameId    string                 `protobuf:"bytes,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
 	Timestamp int64                  `protobuf:"varint,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	StreamId  string                 `protobuf:"bytes,10,opt,name=stream_id,json=streamId,proto3" json:"stream_id,omitempty"` // ID of the event in the Redis stream, increasing over time
 	// Types that are valid to be assigned to Data:
 	//
 	//	*Notification_MatchFound
 	//	*Notification_MoveMade
 	//	*Notification_GameOver
+	//	*Notification_MatchFailed
+	//	*Notification_Challenge
 	Data          isNotification_Data `protobuf_oneof:"data"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -139,7 +203,7 @@ type Notification struct {
 
 func (x *Notification) Reset() {
 	*x = Notification{}
-	mi := &file_proto_notifications_notifications_proto_msgTypes[1]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -151,7 +215,7 @@ func (x *Notification) String() string {
 func (*Notification) ProtoMessage() {}
 
 func (x *Notification) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notifications_notifications_proto_msgTypes[1]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -164,7 +228,7 @@ func (x *Notification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Notification.ProtoReflect.Descriptor instead.
 func (*Notification) Descriptor() ([]byte, []int) {
-	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{1}
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Notification) GetId() string {
@@ -195,6 +259,13 @@ func (x *Notification) GetTimestamp() int64 {
 	return 0
 }
 
+func (x *Notification) GetStreamId() string {
+	if x != nil {
+		return x.StreamId
+	}
+	return ""
+}
+
 func (x *Notification) GetData() isNotification_Data {
 	if x != nil {
 		return x.Data
@@ -229,6 +300,24 @@ func (x *Notification) GetGameOver() *GameOverNotification {
 	return nil
 }
 
+func (x *Notification) GetMatchFailed() *MatchFailedNotification {
+	if x != nil {
+		if x, ok := x.Data.(*Notification_MatchFailed); ok {
+			return x.MatchFailed
+		}
+	}
+	return nil
+}
+
+func (x *Notification) GetChallenge() *ChallengeNotification {
+	if x != nil {
+		if x, ok := x.Data.(*Notification_Challenge); ok {
+			return x.Challenge
+		}
+	}
+	return nil
+}
+
 type isNotification_Data interface {
 	isNotification_Data()
 }
@@ -245,12 +334,24 @@ type Notification_GameOver struct {
 	GameOver *GameOverNotification `protobuf:"bytes,7,opt,name=game_over,json=gameOver,proto3,oneof"`
 }
 
+type Notification_MatchFailed struct {
+	MatchFailed *MatchFailedNotification `protobuf:"bytes,8,opt,name=match_failed,json=matchFailed,proto3,oneof"`
+}
+
+type Notification_Challenge struct {
+	Challenge *ChallengeNotification `protobuf:"bytes,9,opt,name=challenge,proto3,oneof"`
+}
+
 func (*Notification_MatchFound) isNotification_Data() {}
 
 func (*Notification_MoveMade) isNotification_Data() {}
 
 func (*Notification_GameOver) isNotification_Data() {}
 
+func (*Notification_MatchFailed) isNotification_Data() {}
+
+func (*Notification_Challenge) isNotification_Data() {}
+
 // Match found notification data
 type MatchFoundNotification struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -265,7 +366,7 @@ type MatchFoundNotification struct {
 
 func (x *MatchFoundNotification) Reset() {
 	*x = MatchFoundNotification{}
-	mi := &file_proto_notifications_notifications_proto_msgTypes[2]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -277,7 +378,7 @@ func (x *MatchFoundNotification) String() string {
 func (*MatchFoundNotification) ProtoMessage() {}
 
 func (x *MatchFoundNotification) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notifications_notifications_proto_msgTypes[2]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -290,7 +391,7 @@ func (x *MatchFoundNotification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MatchFoundNotification.ProtoReflect.Descriptor instead.
 func (*MatchFoundNotification) Descriptor() ([]byte, []int) {
-	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{2}
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *MatchFoundNotification) GetMatchId() string {
@@ -335,13 +436,14 @@ type MoveMadeNotification struct {
 	PitIndex      uint32                 `protobuf:"varint,2,opt,name=pit_index,json=pitIndex,proto3" json:"pit_index,omitempty"`
 	GameState     *engine.GameState      `protobuf:"bytes,3,opt,name=game_state,json=gameState,proto3" json:"game_state,omitempty"`
 	MoveResult    *engine.MoveResult     `protobuf:"bytes,4,opt,name=move_result,json=moveResult,proto3" json:"move_result,omitempty"`
+	Clock         *ClockState            `protobuf:"bytes,5,opt,name=clock,proto3" json:"clock,omitempty"` // Unset for games without a time control
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *MoveMadeNotification) Reset() {
 	*x = MoveMadeNotification{}
-	mi := &file_proto_notifications_notifications_proto_msgTypes[3]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -353,7 +455,7 @@ func (x *MoveMadeNotification) String() string {
 func (*MoveMadeNotification) ProtoMessage() {}
 
 func (x *MoveMadeNotification) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notifications_notifications_proto_msgTypes[3]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -366,7 +468,7 @@ func (x *MoveMadeNotification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MoveMadeNotification.ProtoReflect.Descriptor instead.
 func (*MoveMadeNotification) Descriptor() ([]byte, []int) {
-	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{3}
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *MoveMadeNotification) GetPlayerId() string {
@@ -397,19 +499,88 @@ func (x *MoveMadeNotification) GetMoveResult() *engine.MoveResult {
 	return nil
 }
 
+func (x *MoveMadeNotification) GetClock() *ClockState {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+// Remaining time of both players after a move
+type ClockState struct {
+	state              protoimpl.MessageState `protogen:"open.v1"`
+	Player1RemainingMs int64                  `protobuf:"varint,1,opt,name=player1_remaining_ms,json=player1RemainingMs,proto3" json:"player1_remaining_ms,omitempty"`
+	Player2RemainingMs int64                  `protobuf:"varint,2,opt,name=player2_remaining_ms,json=player2RemainingMs,proto3" json:"player2_remaining_ms,omitempty"`
+	TurnStartedAtMs    int64                  `protobuf:"varint,3,opt,name=turn_started_at_ms,json=turnStartedAtMs,proto3" json:"turn_started_at_ms,omitempty"` // Unix milliseconds
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *ClockState) Reset() {
+	*x = ClockState{}
+	mi := &file_proto_notifications_notifications_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClockState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockState) ProtoMessage() {}
+
+func (x *ClockState) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notifications_notifications_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockState.ProtoReflect.Descriptor instead.
+func (*ClockState) Descriptor() ([]byte, []int) {
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ClockState) GetPlayer1RemainingMs() int64 {
+	if x != nil {
+		return x.Player1RemainingMs
+	}
+	return 0
+}
+
+func (x *ClockState) GetPlayer2RemainingMs() int64 {
+	if x != nil {
+		return x.Player2RemainingMs
+	}
+	return 0
+}
+
+func (x *ClockState) GetTurnStartedAtMs() int64 {
+	if x != nil {
+		return x.TurnStartedAtMs
+	}
+	return 0
+}
+
 // Game over notification data
 type GameOverNotification struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FinalState    *engine.GameState      `protobuf:"bytes,1,opt,name=final_state,json=finalState,proto3" json:"final_state,omitempty"`
 	WinnerId      string                 `protobuf:"bytes,2,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
 	IsDraw        bool                   `protobuf:"varint,3,opt,name=is_draw,json=isDraw,proto3" json:"is_draw,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"` // How the game ended, e.g. "COMPLETED", "RESIGNATION" or "TIMEOUT"
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GameOverNotification) Reset() {
 	*x = GameOverNotification{}
-	mi := &file_proto_notifications_notifications_proto_msgTypes[4]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -421,7 +592,7 @@ func (x *GameOverNotification) String() string {
 func (*GameOverNotification) ProtoMessage() {}
 
 func (x *GameOverNotification) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_notifications_notifications_proto_msgTypes[4]
+	mi := &file_proto_notifications_notifications_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -434,7 +605,7 @@ func (x *GameOverNotification) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameOverNotification.ProtoReflect.Descriptor instead.
 func (*GameOverNotification) Descriptor() ([]byte, []int) {
-	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{4}
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GameOverNotification) GetFinalState() *engine.GameState {
@@ -458,22 +629,183 @@ func (x *GameOverNotification) GetIsDraw() bool {
 	return false
 }
 
+func (x *GameOverNotification) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Match failed notification data. Both players are back in the queue.
+type MatchFailedNotification struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player1Id     string                 `protobuf:"bytes,1,opt,name=player1_id,json=player1Id,proto3" json:"player1_id,omitempty"`
+	Player2Id     string                 `protobuf:"bytes,2,opt,name=player2_id,json=player2Id,proto3" json:"player2_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchFailedNotification) Reset() {
+	*x = MatchFailedNotification{}
+	mi := &file_proto_notifications_notifications_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchFailedNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchFailedNotification) ProtoMessage() {}
+
+func (x *MatchFailedNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notifications_notifications_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchFailedNotification.ProtoReflect.Descriptor instead.
+func (*MatchFailedNotification) Descriptor() ([]byte, []int) {
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MatchFailedNotification) GetPlayer1Id() string {
+	if x != nil {
+		return x.Player1Id
+	}
+	return ""
+}
+
+func (x *MatchFailedNotification) GetPlayer2Id() string {
+	if x != nil {
+		return x.Player2Id
+	}
+	return ""
+}
+
+func (x *MatchFailedNotification) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+// Challenge notification data, sent to the challenger and the challenged player.
+// An accepted challenge is announced with a match found notification instead.
+type ChallengeNotification struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ChallengeId    string                 `protobuf:"bytes,1,opt,name=challenge_id,json=challengeId,proto3" json:"challenge_id,omitempty"`
+	ChallengerId   string                 `protobuf:"bytes,2,opt,name=challenger_id,json=challengerId,proto3" json:"challenger_id,omitempty"`
+	ChallengerName string                 `protobuf:"bytes,3,opt,name=challenger_name,json=challengerName,proto3" json:"challenger_name,omitempty"`
+	TargetId       string                 `protobuf:"bytes,4,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`     // Empty for invites
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`                         // "pending", "declined", "withdrawn" or "expired"
+	ExpiresAt      int64                  `protobuf:"varint,6,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // Unix timestamp
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ChallengeNotification) Reset() {
+	*x = ChallengeNotification{}
+	mi := &file_proto_notifications_notifications_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChallengeNotification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChallengeNotification) ProtoMessage() {}
+
+func (x *ChallengeNotification) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notifications_notifications_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChallengeNotification.ProtoReflect.Descriptor instead.
+func (*ChallengeNotification) Descriptor() ([]byte, []int) {
+	return file_proto_notifications_notifications_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ChallengeNotification) GetChallengeId() string {
+	if x != nil {
+		return x.ChallengeId
+	}
+	return ""
+}
+
+func (x *ChallengeNotification) GetChallengerId() string {
+	if x != nil {
+		return x.ChallengerId
+	}
+	return ""
+}
+
+func (x *ChallengeNotification) GetChallengerName() string {
+	if x != nil {
+		return x.ChallengerName
+	}
+	return ""
+}
+
+func (x *ChallengeNotification) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *ChallengeNotification) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ChallengeNotification) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
 var File_proto_notifications_notifications_proto protoreflect.FileDescriptor
 
 const file_proto_notifications_notifications_proto_rawDesc = "" +
 	"\n" +
-	"'proto/notifications/notifications.proto\x12\x13proto.notifications\x1a\x19proto/engine/engine.proto\"/\n" +
+	"'proto/notifications/notifications.proto\x12\x13proto.notifications\x1a\x19proto/engine/engine.proto\"S\n" +
 	"\x10SubscribeRequest\x12\x1b\n" +
-	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"\xfc\x02\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\"\n" +
+	"\rlast_event_id\x18\x02 \x01(\tR\vlastEventId\"'\n" +
+	"\fWatchRequest\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\tR\x06gameId\"\xb8\x04\n" +
 	"\fNotification\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x129\n" +
 	"\x04type\x18\x02 \x01(\x0e2%.proto.notifications.NotificationTypeR\x04type\x12\x17\n" +
 	"\agame_id\x18\x03 \x01(\tR\x06gameId\x12\x1c\n" +
-	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12N\n" +
+	"\ttimestamp\x18\x04 \x01(\x03R\ttimestamp\x12\x1b\n" +
+	"\tstream_id\x18\n" +
+	" \x01(\tR\bstreamId\x12N\n" +
 	"\vmatch_found\x18\x05 \x01(\v2+.proto.notifications.MatchFoundNotificationH\x00R\n" +
 	"matchFound\x12H\n" +
 	"\tmove_made\x18\x06 \x01(\v2).proto.notifications.MoveMadeNotificationH\x00R\bmoveMade\x12H\n" +
-	"\tgame_over\x18\a \x01(\v2).proto.notifications.GameOverNotificationH\x00R\bgameOverB\x06\n" +
+	"\tgame_over\x18\a \x01(\v2).proto.notifications.GameOverNotificationH\x00R\bgameOver\x12Q\n" +
+	"\fmatch_failed\x18\b \x01(\v2,.proto.notifications.MatchFailedNotificationH\x00R\vmatchFailed\x12J\n" +
+	"\tchallenge\x18\t \x01(\v2*.proto.notifications.ChallengeNotificationH\x00R\tchallengeB\x06\n" +
 	"\x04data\"\xb7\x01\n" +
 	"\x16MatchFoundNotification\x12\x19\n" +
 	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1d\n" +
@@ -482,26 +814,50 @@ const file_proto_notifications_notifications_proto_rawDesc = "" +
 	"\fplayer1_name\x18\x03 \x01(\tR\vplayer1Name\x12\x1d\n" +
 	"\n" +
 	"player2_id\x18\x04 \x01(\tR\tplayer2Id\x12!\n" +
-	"\fplayer2_name\x18\x05 \x01(\tR\vplayer2Name\"\xc3\x01\n" +
+	"\fplayer2_name\x18\x05 \x01(\tR\vplayer2Name\"\xfa\x01\n" +
 	"\x14MoveMadeNotification\x12\x1b\n" +
 	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x1b\n" +
 	"\tpit_index\x18\x02 \x01(\rR\bpitIndex\x126\n" +
 	"\n" +
 	"game_state\x18\x03 \x01(\v2\x17.proto.engine.GameStateR\tgameState\x129\n" +
 	"\vmove_result\x18\x04 \x01(\v2\x18.proto.engine.MoveResultR\n" +
-	"moveResult\"\x86\x01\n" +
+	"moveResult\x125\n" +
+	"\x05clock\x18\x05 \x01(\v2\x1f.proto.notifications.ClockStateR\x05clock\"\x9d\x01\n" +
+	"\n" +
+	"ClockState\x120\n" +
+	"\x14player1_remaining_ms\x18\x01 \x01(\x03R\x12player1RemainingMs\x120\n" +
+	"\x14player2_remaining_ms\x18\x02 \x01(\x03R\x12player2RemainingMs\x12+\n" +
+	"\x12turn_started_at_ms\x18\x03 \x01(\x03R\x0fturnStartedAtMs\"\x9e\x01\n" +
 	"\x14GameOverNotification\x128\n" +
 	"\vfinal_state\x18\x01 \x01(\v2\x17.proto.engine.GameStateR\n" +
 	"finalState\x12\x1b\n" +
 	"\twinner_id\x18\x02 \x01(\tR\bwinnerId\x12\x17\n" +
-	"\ais_draw\x18\x03 \x01(\bR\x06isDraw*\x9a\x01\n" +
+	"\ais_draw\x18\x03 \x01(\bR\x06isDraw\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"o\n" +
+	"\x17MatchFailedNotification\x12\x1d\n" +
+	"\n" +
+	"player1_id\x18\x01 \x01(\tR\tplayer1Id\x12\x1d\n" +
+	"\n" +
+	"player2_id\x18\x02 \x01(\tR\tplayer2Id\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"\xdc\x01\n" +
+	"\x15ChallengeNotification\x12!\n" +
+	"\fchallenge_id\x18\x01 \x01(\tR\vchallengeId\x12#\n" +
+	"\rchallenger_id\x18\x02 \x01(\tR\fchallengerId\x12'\n" +
+	"\x0fchallenger_name\x18\x03 \x01(\tR\x0echallengerName\x12\x1b\n" +
+	"\ttarget_id\x18\x04 \x01(\tR\btargetId\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x06 \x01(\x03R\texpiresAt*\xdf\x01\n" +
 	"\x10NotificationType\x12!\n" +
 	"\x1dNOTIFICATION_TYPE_UNSPECIFIED\x10\x00\x12!\n" +
 	"\x1dNOTIFICATION_TYPE_MATCH_FOUND\x10\x01\x12\x1f\n" +
 	"\x1bNOTIFICATION_TYPE_MOVE_MADE\x10\x02\x12\x1f\n" +
-	"\x1bNOTIFICATION_TYPE_GAME_OVER\x10\x032h\n" +
+	"\x1bNOTIFICATION_TYPE_GAME_OVER\x10\x03\x12\"\n" +
+	"\x1eNOTIFICATION_TYPE_MATCH_FAILED\x10\x04\x12\x1f\n" +
+	"\x1bNOTIFICATION_TYPE_CHALLENGE\x10\x052\xb9\x01\n" +
 	"\rNotifications\x12W\n" +
-	"\tSubscribe\x12%.proto.notifications.SubscribeRequest\x1a!.proto.notifications.Notification0\x01B@Z>github.com/laerson/mancala/proto/notifications;notificationspbb\x06proto3"
+	"\tSubscribe\x12%.proto.notifications.SubscribeRequest\x1a!.proto.notifications.Notification0\x01\x12O\n" +
+	"\x05Watch\x12!.proto.notifications.WatchRequest\x1a!.proto.notifications.Notification0\x01B@Z>github.com/laerson/mancala/proto/notifications;notificationspbb\x06proto3"
 
 var (
 	file_proto_notifications_notifications_proto_rawDescOnce sync.Once
@@ -516,32 +872,41 @@ func file_proto_notifications_notifications_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_notifications_notifications_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_notifications_notifications_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_notifications_notifications_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_proto_notifications_notifications_proto_goTypes = []any{
-	(NotificationType)(0),          // 0: proto.notifications.NotificationType
-	(*SubscribeRequest)(nil),       // 1: proto.notifications.SubscribeRequest
-	(*Notification)(nil),           // 2: proto.notifications.Notification
-	(*MatchFoundNotification)(nil), // 3: proto.notifications.MatchFoundNotification
-	(*MoveMadeNotification)(nil),   // 4: proto.notifications.MoveMadeNotification
-	(*GameOverNotification)(nil),   // 5: proto.notifications.GameOverNotification
-	(*engine.GameState)(nil),       // 6: proto.engine.GameState
-	(*engine.MoveResult)(nil),      // 7: proto.engine.MoveResult
+	(NotificationType)(0),           // 0: proto.notifications.NotificationType
+	(*SubscribeRequest)(nil),        // 1: proto.notifications.SubscribeRequest
+	(*WatchRequest)(nil),            // 2: proto.notifications.WatchRequest
+	(*Notification)(nil),            // 3: proto.notifications.Notification
+	(*MatchFoundNotification)(nil),  // 4: proto.notifications.MatchFoundNotification
+	(*MoveMadeNotification)(nil),    // 5: proto.notifications.MoveMadeNotification
+	(*ClockState)(nil),              // 6: proto.notifications.ClockState
+	(*GameOverNotification)(nil),    // 7: proto.notifications.GameOverNotification
+	(*MatchFailedNotification)(nil), // 8: proto.notifications.MatchFailedNotification
+	(*ChallengeNotification)(nil),   // 9: proto.notifications.ChallengeNotification
+	(*engine.GameState)(nil),        // 10: proto.engine.GameState
+	(*engine.MoveResult)(nil),       // 11: proto.engine.MoveResult
 }
 var file_proto_notifications_notifications_proto_depIdxs = []int32{
-	0, // 0: proto.notifications.Notification.type:type_name -> proto.notifications.NotificationType
-	3, // 1: proto.notifications.Notification.match_found:type_name -> proto.notifications.MatchFoundNotification
-	4, // 2: proto.notifications.Notification.move_made:type_name -> proto.notifications.MoveMadeNotification
-	5, // 3: proto.notifications.Notification.game_over:type_name -> proto.notifications.GameOverNotification
-	6, // 4: proto.notifications.MoveMadeNotification.game_state:type_name -> proto.engine.GameState
-	7, // 5: proto.notifications.MoveMadeNotification.move_result:type_name -> proto.engine.MoveResult
-	6, // 6: proto.notifications.GameOverNotification.final_state:type_name -> proto.engine.GameState
-	1, // 7: proto.notifications.Notifications.Subscribe:input_type -> proto.notifications.SubscribeRequest
-	2, // 8: proto.notifications.Notifications.Subscribe:output_type -> proto.notifications.Notification
-	8, // [8:9] is the sub-list for method output_type
-	7, // [7:8] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	0,  // 0: proto.notifications.Notification.type:type_name -> proto.notifications.NotificationType
+	4,  // 1: proto.notifications.Notification.match_found:type_name -> proto.notifications.MatchFoundNotification
+	5,  // 2: proto.notifications.Notification.move_made:type_name -> proto.notifications.MoveMadeNotification
+	7,  // 3: proto.notifications.Notification.game_over:type_name -> proto.notifications.GameOverNotification
+	8,  // 4: proto.notifications.Notification.match_failed:type_name -> proto.notifications.MatchFailedNotification
+	9,  // 5: proto.notifications.Notification.challenge:type_name -> proto.notifications.ChallengeNotification
+	10, // 6: proto.notifications.MoveMadeNotification.game_state:type_name -> proto.engine.GameState
+	11, // 7: proto.notifications.MoveMadeNotification.move_result:type_name -> proto.engine.MoveResult
+	6,  // 8: proto.notifications.MoveMadeNotification.clock:type_name -> proto.notifications.ClockState
+	10, // 9: proto.notifications.GameOverNotification.final_state:type_name -> proto.engine.GameState
+	1,  // 10: proto.notifications.Notifications.Subscribe:input_type -> proto.notifications.SubscribeRequest
+	2,  // 11: proto.notifications.Notifications.Watch:input_type -> proto.notifications.WatchRequest
+	3,  // 12: proto.notifications.Notifications.Subscribe:output_type -> proto.notifications.Notification
+	3,  // 13: proto.notifications.Notifications.Watch:output_type -> proto.notifications.Notification
+	12, // [12:14] is the sub-list for method output_type
+	10, // [10:12] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_proto_notifications_notifications_proto_init() }
@@ -549,10 +914,12 @@ func file_proto_notifications_notifications_proto_init() {
 	if File_proto_notifications_notifications_proto != nil {
 		return
 	}
-	file_proto_notifications_notifications_proto_msgTypes[1].OneofWrappers = []any{
+	file_proto_notifications_notifications_proto_msgTypes[2].OneofWrappers = []any{
 		(*Notification_MatchFound)(nil),
 		(*Notification_MoveMade)(nil),
 		(*Notification_GameOver)(nil),
+		(*Notification_MatchFailed)(nil),
+		(*Notification_Challenge)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -560,7 +927,7 @@ func file_proto_notifications_notifications_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_notifications_notifications_proto_rawDesc), len(file_proto_notifications_notifications_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
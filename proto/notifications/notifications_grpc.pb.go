@@ -20,6 +20,7 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	Notifications_Subscribe_FullMethodName = "/proto.notifications.Notifications/Subscribe"
+	Notifications_Watch_FullMethodName     = "/proto.notifications.Notifications/Watch"
 )
 
 // NotificationsClient is the client API for Notifications service.
@@ -30,6 +31,8 @@ const (
 type NotificationsClient interface {
 	// Subscribe to notifications for a specific player
 	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error)
+	// Watch the moves and the result of a live game as a spectator
+	Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error)
 }
 
 type notificationsClient struct {
@@ -59,6 +62,25 @@ func (c *notificationsClient) Subscribe(ctx context.Context, in *SubscribeReques
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Notifications_SubscribeClient = grpc.ServerStreamingClient[Notification]
 
+func (c *notificationsClient) Watch(ctx context.Context, in *WatchRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Notification], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Notifications_ServiceDesc.Streams[1], Notifications_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRequest, Notification]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Notifications_WatchClient = grpc.ServerStreamingClient[Notification]
+
 // NotificationsServer is the server API for Notifications service.
 // All implementations must embed UnimplementedNotificationsServer
 // for forward compatibility.
@@ -67,6 +89,8 @@ type Notifications_SubscribeClient = grpc.ServerStreamingClient[Notification]
 type NotificationsServer interface {
 	// Subscribe to notifications for a specific player
 	Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Notification]) error
+	// Watch the moves and the result of a live game as a spectator
+	Watch(*WatchRequest, grpc.ServerStreamingServer[Notification]) error
 	mustEmbedUnimplementedNotificationsServer()
 }
 
@@ -80,6 +104,9 @@ type UnimplementedNotificationsServer struct{}
 func (UnimplementedNotificationsServer) Subscribe(*SubscribeRequest, grpc.ServerStreamingServer[Notification]) error {
 	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
 }
+func (UnimplementedNotificationsServer) Watch(*WatchRequest, grpc.ServerStreamingServer[Notification]) error {
+	return status.Errorf(codes.Unimplemented, "method Watch not implemented")
+}
 func (UnimplementedNotificationsServer) mustEmbedUnimplementedNotificationsServer() {}
 func (UnimplementedNotificationsServer) testEmbeddedByValue()                       {}
 
@@ -112,6 +139,17 @@ func _Notifications_Subscribe_Handler(srv interface{}, stream grpc.ServerStream)
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type Notifications_SubscribeServer = grpc.ServerStreamingServer[Notification]
 
+func _Notifications_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(NotificationsServer).Watch(m, &grpc.GenericServerStream[WatchRequest, Notification]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Notifications_WatchServer = grpc.ServerStreamingServer[Notification]
+
 // Notifications_ServiceDesc is the grpc.ServiceDesc for Notifications service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -125,6 +163,11 @@ var Notifications_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _Notifications_Subscribe_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _Notifications_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "proto/notifications/notifications.proto",
 }
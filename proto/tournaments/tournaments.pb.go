@@ -0,0 +1,1406 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        v3.21.12
+// source: proto/tournaments/tournaments.proto
+
+package tournamentspb
+
+import (
+	games "github.com/laerson/mancala/proto/games"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TournamentFormat int32
+
+const (
+	TournamentFormat_TOURNAMENT_FORMAT_UNSPECIFIED TournamentFormat = 0
+	TournamentFormat_TOURNAMENT_FORMAT_SWISS       TournamentFormat = 1 // Players with equal scores meet, for a fixed number of rounds
+	TournamentFormat_TOURNAMENT_FORMAT_ROUND_ROBIN TournamentFormat = 2 // Everyone plays everyone once
+)
+
+// Enum value maps for TournamentFormat.
+var (
+	TournamentFormat_name = map[int32]string{
+		0: "TOURNAMENT_FORMAT_UNSPECIFIED",
+		1: "TOURNAMENT_FORMAT_SWISS",
+		2: "TOURNAMENT_FORMAT_ROUND_ROBIN",
+	}
+	TournamentFormat_value = map[string]int32{
+		"TOURNAMENT_FORMAT_UNSPECIFIED": 0,
+		"TOURNAMENT_FORMAT_SWISS":       1,
+		"TOURNAMENT_FORMAT_ROUND_ROBIN": 2,
+	}
+)
+
+func (x TournamentFormat) Enum() *TournamentFormat {
+	p := new(TournamentFormat)
+	*p = x
+	return p
+}
+
+func (x TournamentFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TournamentFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tournaments_tournaments_proto_enumTypes[0].Descriptor()
+}
+
+func (TournamentFormat) Type() protoreflect.EnumType {
+	return &file_proto_tournaments_tournaments_proto_enumTypes[0]
+}
+
+func (x TournamentFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TournamentFormat.Descriptor instead.
+func (TournamentFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{0}
+}
+
+type TournamentStatus int32
+
+const (
+	TournamentStatus_TOURNAMENT_STATUS_UNSPECIFIED TournamentStatus = 0
+	TournamentStatus_TOURNAMENT_STATUS_REGISTERING TournamentStatus = 1
+	TournamentStatus_TOURNAMENT_STATUS_IN_PROGRESS TournamentStatus = 2
+	TournamentStatus_TOURNAMENT_STATUS_FINISHED    TournamentStatus = 3
+)
+
+// Enum value maps for TournamentStatus.
+var (
+	TournamentStatus_name = map[int32]string{
+		0: "TOURNAMENT_STATUS_UNSPECIFIED",
+		1: "TOURNAMENT_STATUS_REGISTERING",
+		2: "TOURNAMENT_STATUS_IN_PROGRESS",
+		3: "TOURNAMENT_STATUS_FINISHED",
+	}
+	TournamentStatus_value = map[string]int32{
+		"TOURNAMENT_STATUS_UNSPECIFIED": 0,
+		"TOURNAMENT_STATUS_REGISTERING": 1,
+		"TOURNAMENT_STATUS_IN_PROGRESS": 2,
+		"TOURNAMENT_STATUS_FINISHED":    3,
+	}
+)
+
+func (x TournamentStatus) Enum() *TournamentStatus {
+	p := new(TournamentStatus)
+	*p = x
+	return p
+}
+
+func (x TournamentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TournamentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tournaments_tournaments_proto_enumTypes[1].Descriptor()
+}
+
+func (TournamentStatus) Type() protoreflect.EnumType {
+	return &file_proto_tournaments_tournaments_proto_enumTypes[1]
+}
+
+func (x TournamentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TournamentStatus.Descriptor instead.
+func (TournamentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{1}
+}
+
+type PairingResult int32
+
+const (
+	PairingResult_PAIRING_RESULT_PENDING     PairingResult = 0
+	PairingResult_PAIRING_RESULT_PLAYER1_WIN PairingResult = 1
+	PairingResult_PAIRING_RESULT_PLAYER2_WIN PairingResult = 2
+	PairingResult_PAIRING_RESULT_DRAW        PairingResult = 3
+	PairingResult_PAIRING_RESULT_BYE         PairingResult = 4 // Player one sits the round out and scores a win
+)
+
+// Enum value maps for PairingResult.
+var (
+	PairingResult_name = map[int32]string{
+		0: "PAIRING_RESULT_PENDING",
+		1: "PAIRING_RESULT_PLAYER1_WIN",
+		2: "PAIRING_RESULT_PLAYER2_WIN",
+		3: "PAIRING_RESULT_DRAW",
+		4: "PAIRING_RESULT_BYE",
+	}
+	PairingResult_value = map[string]int32{
+		"PAIRING_RESULT_PENDING":     0,
+		"PAIRING_RESULT_PLAYER1_WIN": 1,
+		"PAIRING_RESULT_PLAYER2_WIN": 2,
+		"PAIRING_RESULT_DRAW":        3,
+		"PAIRING_RESULT_BYE":         4,
+	}
+)
+
+func (x PairingResult) Enum() *PairingResult {
+	p := new(PairingResult)
+	*p = x
+	return p
+}
+
+func (x PairingResult) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PairingResult) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tournaments_tournaments_proto_enumTypes[2].Descriptor()
+}
+
+func (PairingResult) Type() protoreflect.EnumType {
+	return &file_proto_tournaments_tournaments_proto_enumTypes[2]
+}
+
+func (x PairingResult) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PairingResult.Descriptor instead.
+func (PairingResult) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{2}
+}
+
+type TournamentPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentPlayer) Reset() {
+	*x = TournamentPlayer{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentPlayer) ProtoMessage() {}
+
+func (x *TournamentPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentPlayer.ProtoReflect.Descriptor instead.
+func (*TournamentPlayer) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TournamentPlayer) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TournamentPlayer) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+// Pairing is one game of a round. Player one moves first.
+type Pairing struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player1Id     string                 `protobuf:"bytes,1,opt,name=player1_id,json=player1Id,proto3" json:"player1_id,omitempty"`
+	Player2Id     string                 `protobuf:"bytes,2,opt,name=player2_id,json=player2Id,proto3" json:"player2_id,omitempty"` // Empty for a bye
+	GameId        string                 `protobuf:"bytes,3,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`          // Empty until the game is created
+	Result        PairingResult          `protobuf:"varint,4,opt,name=result,proto3,enum=proto.tournaments.PairingResult" json:"result,omitempty"`
+	GameRequestId string                 `protobuf:"bytes,5,opt,name=game_request_id,json=gameRequestId,proto3" json:"game_request_id,omitempty"` // Request ID of the game being created, until game_id is recorded
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pairing) Reset() {
+	*x = Pairing{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pairing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pairing) ProtoMessage() {}
+
+func (x *Pairing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pairing.ProtoReflect.Descriptor instead.
+func (*Pairing) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Pairing) GetPlayer1Id() string {
+	if x != nil {
+		return x.Player1Id
+	}
+	return ""
+}
+
+func (x *Pairing) GetPlayer2Id() string {
+	if x != nil {
+		return x.Player2Id
+	}
+	return ""
+}
+
+func (x *Pairing) GetGameId() string {
+	if x != nil {
+		return x.GameId
+	}
+	return ""
+}
+
+func (x *Pairing) GetResult() PairingResult {
+	if x != nil {
+		return x.Result
+	}
+	return PairingResult_PAIRING_RESULT_PENDING
+}
+
+func (x *Pairing) GetGameRequestId() string {
+	if x != nil {
+		return x.GameRequestId
+	}
+	return ""
+}
+
+type Round struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        int32                  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"` // 1-based
+	Pairings      []*Pairing             `protobuf:"bytes,2,rep,name=pairings,proto3" json:"pairings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Round) Reset() {
+	*x = Round{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Round) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Round) ProtoMessage() {}
+
+func (x *Round) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Round.ProtoReflect.Descriptor instead.
+func (*Round) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Round) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *Round) GetPairings() []*Pairing {
+	if x != nil {
+		return x.Pairings
+	}
+	return nil
+}
+
+type Tournament struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Format        TournamentFormat       `protobuf:"varint,3,opt,name=format,proto3,enum=proto.tournaments.TournamentFormat" json:"format,omitempty"`
+	Status        TournamentStatus       `protobuf:"varint,4,opt,name=status,proto3,enum=proto.tournaments.TournamentStatus" json:"status,omitempty"`
+	OrganizerId   string                 `protobuf:"bytes,5,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	TotalRounds   int32                  `protobuf:"varint,6,opt,name=total_rounds,json=totalRounds,proto3" json:"total_rounds,omitempty"`    // Fixed when the tournament starts
+	CurrentRound  int32                  `protobuf:"varint,7,opt,name=current_round,json=currentRound,proto3" json:"current_round,omitempty"` // 0 before the first round
+	Players       []*TournamentPlayer    `protobuf:"bytes,8,rep,name=players,proto3" json:"players,omitempty"`
+	Rounds        []*Round               `protobuf:"bytes,9,rep,name=rounds,proto3" json:"rounds,omitempty"`
+	TimeControl   *games.TimeControl     `protobuf:"bytes,10,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"` // Unset for games without a clock
+	CreatedAt     int64                  `protobuf:"varint,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`      // Unix timestamp
+	Version       uint64                 `protobuf:"varint,12,opt,name=version,proto3" json:"version,omitempty"`                           // Bumped on every save, which fails if the tournament changed since it was read
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Tournament) Reset() {
+	*x = Tournament{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Tournament) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Tournament) ProtoMessage() {}
+
+func (x *Tournament) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Tournament.ProtoReflect.Descriptor instead.
+func (*Tournament) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Tournament) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Tournament) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Tournament) GetFormat() TournamentFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TournamentFormat_TOURNAMENT_FORMAT_UNSPECIFIED
+}
+
+func (x *Tournament) GetStatus() TournamentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TournamentStatus_TOURNAMENT_STATUS_UNSPECIFIED
+}
+
+func (x *Tournament) GetOrganizerId() string {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return ""
+}
+
+func (x *Tournament) GetTotalRounds() int32 {
+	if x != nil {
+		return x.TotalRounds
+	}
+	return 0
+}
+
+func (x *Tournament) GetCurrentRound() int32 {
+	if x != nil {
+		return x.CurrentRound
+	}
+	return 0
+}
+
+func (x *Tournament) GetPlayers() []*TournamentPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *Tournament) GetRounds() []*Round {
+	if x != nil {
+		return x.Rounds
+	}
+	return nil
+}
+
+func (x *Tournament) GetTimeControl() *games.TimeControl {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+func (x *Tournament) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *Tournament) GetVersion() uint64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+// Standing is a player's place in the tournament. Ties on score are broken by
+// Buchholz, then Sonneborn-Berger.
+type Standing struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Rank            int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	PlayerId        string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	PlayerName      string                 `protobuf:"bytes,3,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Score           float64                `protobuf:"fixed64,4,opt,name=score,proto3" json:"score,omitempty"`                                            // 1 per win or bye, 0.5 per draw
+	Buchholz        float64                `protobuf:"fixed64,5,opt,name=buchholz,proto3" json:"buchholz,omitempty"`                                      // Sum of the opponents' scores
+	SonnebornBerger float64                `protobuf:"fixed64,6,opt,name=sonneborn_berger,json=sonnebornBerger,proto3" json:"sonneborn_berger,omitempty"` // Scores of beaten opponents plus half the scores of drawn ones
+	Wins            int32                  `protobuf:"varint,7,opt,name=wins,proto3" json:"wins,omitempty"`
+	Draws           int32                  `protobuf:"varint,8,opt,name=draws,proto3" json:"draws,omitempty"`
+	Losses          int32                  `protobuf:"varint,9,opt,name=losses,proto3" json:"losses,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Standing) Reset() {
+	*x = Standing{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Standing) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Standing) ProtoMessage() {}
+
+func (x *Standing) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Standing.ProtoReflect.Descriptor instead.
+func (*Standing) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Standing) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *Standing) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *Standing) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *Standing) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Standing) GetBuchholz() float64 {
+	if x != nil {
+		return x.Buchholz
+	}
+	return 0
+}
+
+func (x *Standing) GetSonnebornBerger() float64 {
+	if x != nil {
+		return x.SonnebornBerger
+	}
+	return 0
+}
+
+func (x *Standing) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *Standing) GetDraws() int32 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
+}
+
+func (x *Standing) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+type CreateTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrganizerId   string                 `protobuf:"bytes,1,opt,name=organizer_id,json=organizerId,proto3" json:"organizer_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Format        TournamentFormat       `protobuf:"varint,3,opt,name=format,proto3,enum=proto.tournaments.TournamentFormat" json:"format,omitempty"`
+	Rounds        int32                  `protobuf:"varint,4,opt,name=rounds,proto3" json:"rounds,omitempty"` // Swiss only, 0 picks enough rounds to find a winner
+	TimeControl   *games.TimeControl     `protobuf:"bytes,5,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTournamentRequest) Reset() {
+	*x = CreateTournamentRequest{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentRequest) ProtoMessage() {}
+
+func (x *CreateTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentRequest.ProtoReflect.Descriptor instead.
+func (*CreateTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CreateTournamentRequest) GetOrganizerId() string {
+	if x != nil {
+		return x.OrganizerId
+	}
+	return ""
+}
+
+func (x *CreateTournamentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTournamentRequest) GetFormat() TournamentFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TournamentFormat_TOURNAMENT_FORMAT_UNSPECIFIED
+}
+
+func (x *CreateTournamentRequest) GetRounds() int32 {
+	if x != nil {
+		return x.Rounds
+	}
+	return 0
+}
+
+func (x *CreateTournamentRequest) GetTimeControl() *games.TimeControl {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+type CreateTournamentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    *Tournament            `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTournamentResponse) Reset() {
+	*x = CreateTournamentResponse{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentResponse) ProtoMessage() {}
+
+func (x *CreateTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentResponse.ProtoReflect.Descriptor instead.
+func (*CreateTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateTournamentResponse) GetTournament() *Tournament {
+	if x != nil {
+		return x.Tournament
+	}
+	return nil
+}
+
+type JoinTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	Player        *TournamentPlayer      `protobuf:"bytes,2,opt,name=player,proto3" json:"player,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinTournamentRequest) Reset() {
+	*x = JoinTournamentRequest{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinTournamentRequest) ProtoMessage() {}
+
+func (x *JoinTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinTournamentRequest.ProtoReflect.Descriptor instead.
+func (*JoinTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *JoinTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *JoinTournamentRequest) GetPlayer() *TournamentPlayer {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+type JoinTournamentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    *Tournament            `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinTournamentResponse) Reset() {
+	*x = JoinTournamentResponse{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinTournamentResponse) ProtoMessage() {}
+
+func (x *JoinTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinTournamentResponse.ProtoReflect.Descriptor instead.
+func (*JoinTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *JoinTournamentResponse) GetTournament() *Tournament {
+	if x != nil {
+		return x.Tournament
+	}
+	return nil
+}
+
+type LeaveTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveTournamentRequest) Reset() {
+	*x = LeaveTournamentRequest{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTournamentRequest) ProtoMessage() {}
+
+func (x *LeaveTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTournamentRequest.ProtoReflect.Descriptor instead.
+func (*LeaveTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LeaveTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *LeaveTournamentRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type LeaveTournamentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    *Tournament            `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveTournamentResponse) Reset() {
+	*x = LeaveTournamentResponse{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveTournamentResponse) ProtoMessage() {}
+
+func (x *LeaveTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveTournamentResponse.ProtoReflect.Descriptor instead.
+func (*LeaveTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LeaveTournamentResponse) GetTournament() *Tournament {
+	if x != nil {
+		return x.Tournament
+	}
+	return nil
+}
+
+// StartTournament closes registration and pairs the first round. Only the organizer can start it.
+type StartTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTournamentRequest) Reset() {
+	*x = StartTournamentRequest{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTournamentRequest) ProtoMessage() {}
+
+func (x *StartTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTournamentRequest.ProtoReflect.Descriptor instead.
+func (*StartTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *StartTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *StartTournamentRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type StartTournamentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    *Tournament            `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StartTournamentResponse) Reset() {
+	*x = StartTournamentResponse{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StartTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StartTournamentResponse) ProtoMessage() {}
+
+func (x *StartTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StartTournamentResponse.ProtoReflect.Descriptor instead.
+func (*StartTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *StartTournamentResponse) GetTournament() *Tournament {
+	if x != nil {
+		return x.Tournament
+	}
+	return nil
+}
+
+type GetTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTournamentRequest) Reset() {
+	*x = GetTournamentRequest{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTournamentRequest) ProtoMessage() {}
+
+func (x *GetTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTournamentRequest.ProtoReflect.Descriptor instead.
+func (*GetTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetTournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+type GetTournamentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournament    *Tournament            `protobuf:"bytes,1,opt,name=tournament,proto3" json:"tournament,omitempty"`
+	Standings     []*Standing            `protobuf:"bytes,2,rep,name=standings,proto3" json:"standings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTournamentResponse) Reset() {
+	*x = GetTournamentResponse{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTournamentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTournamentResponse) ProtoMessage() {}
+
+func (x *GetTournamentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTournamentResponse.ProtoReflect.Descriptor instead.
+func (*GetTournamentResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetTournamentResponse) GetTournament() *Tournament {
+	if x != nil {
+		return x.Tournament
+	}
+	return nil
+}
+
+func (x *GetTournamentResponse) GetStandings() []*Standing {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+type ListTournamentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        TournamentStatus       `protobuf:"varint,1,opt,name=status,proto3,enum=proto.tournaments.TournamentStatus" json:"status,omitempty"` // TOURNAMENT_STATUS_UNSPECIFIED lists tournaments of any status
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTournamentsRequest) Reset() {
+	*x = ListTournamentsRequest{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsRequest) ProtoMessage() {}
+
+func (x *ListTournamentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsRequest.ProtoReflect.Descriptor instead.
+func (*ListTournamentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListTournamentsRequest) GetStatus() TournamentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TournamentStatus_TOURNAMENT_STATUS_UNSPECIFIED
+}
+
+type ListTournamentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournaments   []*Tournament          `protobuf:"bytes,1,rep,name=tournaments,proto3" json:"tournaments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTournamentsResponse) Reset() {
+	*x = ListTournamentsResponse{}
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsResponse) ProtoMessage() {}
+
+func (x *ListTournamentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournaments_tournaments_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsResponse.ProtoReflect.Descriptor instead.
+func (*ListTournamentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tournaments_tournaments_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListTournamentsResponse) GetTournaments() []*Tournament {
+	if x != nil {
+		return x.Tournaments
+	}
+	return nil
+}
+
+var File_proto_tournaments_tournaments_proto protoreflect.FileDescriptor
+
+const file_proto_tournaments_tournaments_proto_rawDesc = "" +
+	"\n" +
+	"#proto/tournaments/tournaments.proto\x12\x11proto.tournaments\x1a\x17proto/games/games.proto\"6\n" +
+	"\x10TournamentPlayer\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"\xc2\x01\n" +
+	"\aPairing\x12\x1d\n" +
+	"\n" +
+	"player1_id\x18\x01 \x01(\tR\tplayer1Id\x12\x1d\n" +
+	"\n" +
+	"player2_id\x18\x02 \x01(\tR\tplayer2Id\x12\x17\n" +
+	"\agame_id\x18\x03 \x01(\tR\x06gameId\x128\n" +
+	"\x06result\x18\x04 \x01(\x0e2 .proto.tournaments.PairingResultR\x06result\x12&\n" +
+	"\x0fgame_request_id\x18\x05 \x01(\tR\rgameRequestId\"W\n" +
+	"\x05Round\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x126\n" +
+	"\bpairings\x18\x02 \x03(\v2\x1a.proto.tournaments.PairingR\bpairings\"\xfc\x03\n" +
+	"\n" +
+	"Tournament\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12;\n" +
+	"\x06format\x18\x03 \x01(\x0e2#.proto.tournaments.TournamentFormatR\x06format\x12;\n" +
+	"\x06status\x18\x04 \x01(\x0e2#.proto.tournaments.TournamentStatusR\x06status\x12!\n" +
+	"\forganizer_id\x18\x05 \x01(\tR\vorganizerId\x12!\n" +
+	"\ftotal_rounds\x18\x06 \x01(\x05R\vtotalRounds\x12#\n" +
+	"\rcurrent_round\x18\a \x01(\x05R\fcurrentRound\x12=\n" +
+	"\aplayers\x18\b \x03(\v2#.proto.tournaments.TournamentPlayerR\aplayers\x120\n" +
+	"\x06rounds\x18\t \x03(\v2\x18.proto.tournaments.RoundR\x06rounds\x12;\n" +
+	"\ftime_control\x18\n" +
+	" \x01(\v2\x18.proto.games.TimeControlR\vtimeControl\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\v \x01(\x03R\tcreatedAt\x12\x18\n" +
+	"\aversion\x18\f \x01(\x04R\aversion\"\xfb\x01\n" +
+	"\bStanding\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12\x1f\n" +
+	"\vplayer_name\x18\x03 \x01(\tR\n" +
+	"playerName\x12\x14\n" +
+	"\x05score\x18\x04 \x01(\x01R\x05score\x12\x1a\n" +
+	"\bbuchholz\x18\x05 \x01(\x01R\bbuchholz\x12)\n" +
+	"\x10sonneborn_berger\x18\x06 \x01(\x01R\x0fsonnebornBerger\x12\x12\n" +
+	"\x04wins\x18\a \x01(\x05R\x04wins\x12\x14\n" +
+	"\x05draws\x18\b \x01(\x05R\x05draws\x12\x16\n" +
+	"\x06losses\x18\t \x01(\x05R\x06losses\"\xe2\x01\n" +
+	"\x17CreateTournamentRequest\x12!\n" +
+	"\forganizer_id\x18\x01 \x01(\tR\vorganizerId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12;\n" +
+	"\x06format\x18\x03 \x01(\x0e2#.proto.tournaments.TournamentFormatR\x06format\x12\x16\n" +
+	"\x06rounds\x18\x04 \x01(\x05R\x06rounds\x12;\n" +
+	"\ftime_control\x18\x05 \x01(\v2\x18.proto.games.TimeControlR\vtimeControl\"Y\n" +
+	"\x18CreateTournamentResponse\x12=\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\v2\x1d.proto.tournaments.TournamentR\n" +
+	"tournament\"y\n" +
+	"\x15JoinTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12;\n" +
+	"\x06player\x18\x02 \x01(\v2#.proto.tournaments.TournamentPlayerR\x06player\"W\n" +
+	"\x16JoinTournamentResponse\x12=\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\v2\x1d.proto.tournaments.TournamentR\n" +
+	"tournament\"Z\n" +
+	"\x16LeaveTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"X\n" +
+	"\x17LeaveTournamentResponse\x12=\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\v2\x1d.proto.tournaments.TournamentR\n" +
+	"tournament\"Z\n" +
+	"\x16StartTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\"X\n" +
+	"\x17StartTournamentResponse\x12=\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\v2\x1d.proto.tournaments.TournamentR\n" +
+	"tournament\";\n" +
+	"\x14GetTournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\"\x91\x01\n" +
+	"\x15GetTournamentResponse\x12=\n" +
+	"\n" +
+	"tournament\x18\x01 \x01(\v2\x1d.proto.tournaments.TournamentR\n" +
+	"tournament\x129\n" +
+	"\tstandings\x18\x02 \x03(\v2\x1b.proto.tournaments.StandingR\tstandings\"U\n" +
+	"\x16ListTournamentsRequest\x12;\n" +
+	"\x06status\x18\x01 \x01(\x0e2#.proto.tournaments.TournamentStatusR\x06status\"Z\n" +
+	"\x17ListTournamentsResponse\x12?\n" +
+	"\vtournaments\x18\x01 \x03(\v2\x1d.proto.tournaments.TournamentR\vtournaments*u\n" +
+	"\x10TournamentFormat\x12!\n" +
+	"\x1dTOURNAMENT_FORMAT_UNSPECIFIED\x10\x00\x12\x1b\n" +
+	"\x17TOURNAMENT_FORMAT_SWISS\x10\x01\x12!\n" +
+	"\x1dTOURNAMENT_FORMAT_ROUND_ROBIN\x10\x02*\x9b\x01\n" +
+	"\x10TournamentStatus\x12!\n" +
+	"\x1dTOURNAMENT_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dTOURNAMENT_STATUS_REGISTERING\x10\x01\x12!\n" +
+	"\x1dTOURNAMENT_STATUS_IN_PROGRESS\x10\x02\x12\x1e\n" +
+	"\x1aTOURNAMENT_STATUS_FINISHED\x10\x03*\x9c\x01\n" +
+	"\rPairingResult\x12\x1a\n" +
+	"\x16PAIRING_RESULT_PENDING\x10\x00\x12\x1e\n" +
+	"\x1aPAIRING_RESULT_PLAYER1_WIN\x10\x01\x12\x1e\n" +
+	"\x1aPAIRING_RESULT_PLAYER2_WIN\x10\x02\x12\x17\n" +
+	"\x13PAIRING_RESULT_DRAW\x10\x03\x12\x16\n" +
+	"\x12PAIRING_RESULT_BYE\x10\x042\x83\x05\n" +
+	"\vTournaments\x12k\n" +
+	"\x10CreateTournament\x12*.proto.tournaments.CreateTournamentRequest\x1a+.proto.tournaments.CreateTournamentResponse\x12e\n" +
+	"\x0eJoinTournament\x12(.proto.tournaments.JoinTournamentRequest\x1a).proto.tournaments.JoinTournamentResponse\x12h\n" +
+	"\x0fLeaveTournament\x12).proto.tournaments.LeaveTournamentRequest\x1a*.proto.tournaments.LeaveTournamentResponse\x12h\n" +
+	"\x0fStartTournament\x12).proto.tournaments.StartTournamentRequest\x1a*.proto.tournaments.StartTournamentResponse\x12b\n" +
+	"\rGetTournament\x12'.proto.tournaments.GetTournamentRequest\x1a(.proto.tournaments.GetTournamentResponse\x12h\n" +
+	"\x0fListTournaments\x12).proto.tournaments.ListTournamentsRequest\x1a*.proto.tournaments.ListTournamentsResponseB<Z:github.com/laerson/mancala/proto/tournaments;tournamentspbb\x06proto3"
+
+var (
+	file_proto_tournaments_tournaments_proto_rawDescOnce sync.Once
+	file_proto_tournaments_tournaments_proto_rawDescData []byte
+)
+
+func file_proto_tournaments_tournaments_proto_rawDescGZIP() []byte {
+	file_proto_tournaments_tournaments_proto_rawDescOnce.Do(func() {
+		file_proto_tournaments_tournaments_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_tournaments_tournaments_proto_rawDesc), len(file_proto_tournaments_tournaments_proto_rawDesc)))
+	})
+	return file_proto_tournaments_tournaments_proto_rawDescData
+}
+
+var file_proto_tournaments_tournaments_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_proto_tournaments_tournaments_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
+var file_proto_tournaments_tournaments_proto_goTypes = []any{
+	(TournamentFormat)(0),            // 0: proto.tournaments.TournamentFormat
+	(TournamentStatus)(0),            // 1: proto.tournaments.TournamentStatus
+	(PairingResult)(0),               // 2: proto.tournaments.PairingResult
+	(*TournamentPlayer)(nil),         // 3: proto.tournaments.TournamentPlayer
+	(*Pairing)(nil),                  // 4: proto.tournaments.Pairing
+	(*Round)(nil),                    // 5: proto.tournaments.Round
+	(*Tournament)(nil),               // 6: proto.tournaments.Tournament
+	(*Standing)(nil),                 // 7: proto.tournaments.Standing
+	(*CreateTournamentRequest)(nil),  // 8: proto.tournaments.CreateTournamentRequest
+	(*CreateTournamentResponse)(nil), // 9: proto.tournaments.CreateTournamentResponse
+	(*JoinTournamentRequest)(nil),    // 10: proto.tournaments.JoinTournamentRequest
+	(*JoinTournamentResponse)(nil),   // 11: proto.tournaments.JoinTournamentResponse
+	(*LeaveTournamentRequest)(nil),   // 12: proto.tournaments.LeaveTournamentRequest
+	(*LeaveTournamentResponse)(nil),  // 13: proto.tournaments.LeaveTournamentResponse
+	(*StartTournamentRequest)(nil),   // 14: proto.tournaments.StartTournamentRequest
+	(*StartTournamentResponse)(nil),  // 15: proto.tournaments.StartTournamentResponse
+	(*GetTournamentRequest)(nil),     // 16: proto.tournaments.GetTournamentRequest
+	(*GetTournamentResponse)(nil),    // 17: proto.tournaments.GetTournamentResponse
+	(*ListTournamentsRequest)(nil),   // 18: proto.tournaments.ListTournamentsRequest
+	(*ListTournamentsResponse)(nil),  // 19: proto.tournaments.ListTournamentsResponse
+	(*games.TimeControl)(nil),        // 20: proto.games.TimeControl
+}
+var file_proto_tournaments_tournaments_proto_depIdxs = []int32{
+	2,  // 0: proto.tournaments.Pairing.result:type_name -> proto.tournaments.PairingResult
+	4,  // 1: proto.tournaments.Round.pairings:type_name -> proto.tournaments.Pairing
+	0,  // 2: proto.tournaments.Tournament.format:type_name -> proto.tournaments.TournamentFormat
+	1,  // 3: proto.tournaments.Tournament.status:type_name -> proto.tournaments.TournamentStatus
+	3,  // 4: proto.tournaments.Tournament.players:type_name -> proto.tournaments.TournamentPlayer
+	5,  // 5: proto.tournaments.Tournament.rounds:type_name -> proto.tournaments.Round
+	20, // 6: proto.tournaments.Tournament.time_control:type_name -> proto.games.TimeControl
+	0,  // 7: proto.tournaments.CreateTournamentRequest.format:type_name -> proto.tournaments.TournamentFormat
+	20, // 8: proto.tournaments.CreateTournamentRequest.time_control:type_name -> proto.games.TimeControl
+	6,  // 9: proto.tournaments.CreateTournamentResponse.tournament:type_name -> proto.tournaments.Tournament
+	3,  // 10: proto.tournaments.JoinTournamentRequest.player:type_name -> proto.tournaments.TournamentPlayer
+	6,  // 11: proto.tournaments.JoinTournamentResponse.tournament:type_name -> proto.tournaments.Tournament
+	6,  // 12: proto.tournaments.LeaveTournamentResponse.tournament:type_name -> proto.tournaments.Tournament
+	6,  // 13: proto.tournaments.StartTournamentResponse.tournament:type_name -> proto.tournaments.Tournament
+	6,  // 14: proto.tournaments.GetTournamentResponse.tournament:type_name -> proto.tournaments.Tournament
+	7,  // 15: proto.tournaments.GetTournamentResponse.standings:type_name -> proto.tournaments.Standing
+	1,  // 16: proto.tournaments.ListTournamentsRequest.status:type_name -> proto.tournaments.TournamentStatus
+	6,  // 17: proto.tournaments.ListTournamentsResponse.tournaments:type_name -> proto.tournaments.Tournament
+	8,  // 18: proto.tournaments.Tournaments.CreateTournament:input_type -> proto.tournaments.CreateTournamentRequest
+	10, // 19: proto.tournaments.Tournaments.JoinTournament:input_type -> proto.tournaments.JoinTournamentRequest
+	12, // 20: proto.tournaments.Tournaments.LeaveTournament:input_type -> proto.tournaments.LeaveTournamentRequest
+	14, // 21: proto.tournaments.Tournaments.StartTournament:input_type -> proto.tournaments.StartTournamentRequest
+	16, // 22: proto.tournaments.Tournaments.GetTournament:input_type -> proto.tournaments.GetTournamentRequest
+	18, // 23: proto.tournaments.Tournaments.ListTournaments:input_type -> proto.tournaments.ListTournamentsRequest
+	9,  // 24: proto.tournaments.Tournaments.CreateTournament:output_type -> proto.tournaments.CreateTournamentResponse
+	11, // 25: proto.tournaments.Tournaments.JoinTournament:output_type -> proto.tournaments.JoinTournamentResponse
+	13, // 26: proto.tournaments.Tournaments.LeaveTournament:output_type -> proto.tournaments.LeaveTournamentResponse
+	15, // 27: proto.tournaments.Tournaments.StartTournament:output_type -> proto.tournaments.StartTournamentResponse
+	17, // 28: proto.tournaments.Tournaments.GetTournament:output_type -> proto.tournaments.GetTournamentResponse
+	19, // 29: proto.tournaments.Tournaments.ListTournaments:output_type -> proto.tournaments.ListTournamentsResponse
+	24, // [24:30] is the sub-list for method output_type
+	18, // [18:24] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
+}
+
+func init() { file_proto_tournaments_tournaments_proto_init() }
+func file_proto_tournaments_tournaments_proto_init() {
+	if File_proto_tournaments_tournaments_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tournaments_tournaments_proto_rawDesc), len(file_proto_tournaments_tournaments_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   17,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_tournaments_tournaments_proto_goTypes,
+		DependencyIndexes: file_proto_tournaments_tournaments_proto_depIdxs,
+		EnumInfos:         file_proto_tournaments_tournaments_proto_enumTypes,
+		MessageInfos:      file_proto_tournaments_tournaments_proto_msgTypes,
+	}.Build()
+	File_proto_tournaments_tournaments_proto = out.File
+	file_proto_tournaments_tournaments_proto_goTypes = nil
+	file_proto_tournaments_tournaments_proto_depIdxs = nil
+}